@@ -28,6 +28,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/localsql"
 	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/smeshing"
 )
 
 // PoetConfig is the configuration to interact with the poet server.
@@ -38,12 +39,22 @@ type PoetConfig struct {
 	RequestTimeout    time.Duration `mapstructure:"poet-request-timeout"`
 	RequestRetryDelay time.Duration `mapstructure:"retry-delay"`
 	MaxRequestRetries int           `mapstructure:"retry-max"`
+	// MinSubmissions is the number of PoET services that the challenge must be submitted to.
+	// Services are tried in the order of observed reliability until this number of submissions succeeds.
+	// If zero the challenge is submitted to every configured service.
+	MinSubmissions int `mapstructure:"poet-min-submissions"`
+	// FailureBackoff is how long the service is tried last after a failed request.
+	// It doubles with every consecutive failure up to MaxFailureBackoff. If zero backoff is disabled.
+	FailureBackoff    time.Duration `mapstructure:"poet-failure-backoff"`
+	MaxFailureBackoff time.Duration `mapstructure:"poet-max-failure-backoff"`
 }
 
 func DefaultPoetConfig() PoetConfig {
 	return PoetConfig{
 		RequestRetryDelay: 400 * time.Millisecond,
 		MaxRequestRetries: 10,
+		FailureBackoff:    time.Hour,
+		MaxFailureBackoff: 7 * 24 * time.Hour,
 	}
 }
 
@@ -92,8 +103,16 @@ type Builder struct {
 	// since they (can) modify the fields below.
 	smeshingMutex sync.Mutex
 	signers       map[types.NodeID]*signing.EdSigner
-	eg            errgroup.Group
-	stop          context.CancelFunc
+	// workers of the identities that are smeshing
+	workers map[types.NodeID]*worker
+	ctx     context.Context
+	stop    context.CancelFunc
+}
+
+// worker builds atxs for a single identity until it is stopped.
+type worker struct {
+	stop context.CancelFunc
+	eg   errgroup.Group
 }
 
 type BuilderOption func(*Builder)
@@ -153,6 +172,7 @@ func NewBuilder(
 	b := &Builder{
 		parentCtx:         context.Background(),
 		signers:           make(map[types.NodeID]*signing.EdSigner),
+		workers:           make(map[types.NodeID]*worker),
 		conf:              conf,
 		db:                db,
 		localDB:           localDB,
@@ -183,9 +203,33 @@ func (b *Builder) Register(sig *signing.EdSigner) {
 	b.signers[sig.NodeID()] = sig
 	b.postStates.Set(sig.NodeID(), types.PostStateIdle)
 
-	if b.stop != nil {
-		b.startID(b.parentCtx, sig)
+	if b.stop == nil {
+		return
+	}
+	identity, err := smeshing.Get(b.localDB, sig.NodeID())
+	if err != nil {
+		b.log.Error("failed to load smeshing settings", log.ZShortStringer("id", sig.NodeID()), zap.Error(err))
+	} else if identity.Stopped {
+		b.log.Info("identity is stopped", log.ZShortStringer("id", sig.NodeID()))
+		return
 	}
+	b.startID(sig)
+}
+
+// Unregister stops smeshing with the identity and removes its signing key. Local NIPoST state of the
+// identity is kept, so that it continues where it stopped if it is registered again.
+func (b *Builder) Unregister(id types.NodeID) {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	if _, exists := b.signers[id]; !exists {
+		return
+	}
+	if err := b.stopID(id); err != nil {
+		b.log.Error("failed to stop identity", log.ZShortStringer("id", id), zap.Error(err))
+	}
+	delete(b.signers, id)
+	b.postStates.Remove(id)
+	b.log.Info("unregistered signing key", log.ZShortStringer("id", id))
 }
 
 // Smeshing returns true if atx builder is smeshing.
@@ -215,6 +259,8 @@ func (b *Builder) PostStates() map[types.IdentityDescriptor]types.PostState {
 // or missing, data creation session will be preceded. Changing of the post
 // options (e.g., number of labels), after initial setup, is supported. If data
 // creation fails for any reason then the go-routine will panic.
+//
+// Identities that were stopped with StopSmeshingIdentity are not started.
 func (b *Builder) StartSmeshing(coinbase types.Address) error {
 	b.smeshingMutex.Lock()
 	defer b.smeshingMutex.Unlock()
@@ -222,26 +268,43 @@ func (b *Builder) StartSmeshing(coinbase types.Address) error {
 	if b.stop != nil {
 		return errors.New("already started")
 	}
+	identities, err := smeshing.All(b.localDB)
+	if err != nil {
+		return fmt.Errorf("load smeshing settings: %w", err)
+	}
+	stopped := make(map[types.NodeID]struct{}, len(identities))
+	for _, identity := range identities {
+		if identity.Stopped {
+			stopped[identity.ID] = struct{}{}
+		}
+	}
 
-	b.coinbaseAccount = coinbase
-	ctx, stop := context.WithCancel(b.parentCtx)
-	b.stop = stop
+	b.SetCoinbase(coinbase)
+	b.ctx, b.stop = context.WithCancel(b.parentCtx)
 
-	for _, sig := range b.signers {
-		b.startID(ctx, sig)
+	for id, sig := range b.signers {
+		if _, exist := stopped[id]; exist {
+			b.log.Info("identity is stopped", log.ZShortStringer("id", id))
+			continue
+		}
+		b.startID(sig)
 	}
 	return nil
 }
 
-func (b *Builder) startID(ctx context.Context, sig *signing.EdSigner) {
-	b.eg.Go(func() error {
+// startID starts the worker for the identity. Must be called with smeshingMutex held.
+func (b *Builder) startID(sig *signing.EdSigner) {
+	ctx, stop := context.WithCancel(b.ctx)
+	w := &worker{stop: stop}
+	b.workers[sig.NodeID()] = w
+	w.eg.Go(func() error {
 		b.run(ctx, sig)
 		return nil
 	})
 	if b.conf.RegossipInterval == 0 {
 		return
 	}
-	b.eg.Go(func() error {
+	w.eg.Go(func() error {
 		ticker := time.NewTicker(b.conf.RegossipInterval)
 		defer ticker.Stop()
 		for {
@@ -267,33 +330,51 @@ func (b *Builder) StopSmeshing(deleteFiles bool) error {
 	}
 
 	b.stop()
-	err := b.eg.Wait()
-	b.eg = errgroup.Group{}
+	var err error
+	for id := range b.workers {
+		err = errors.Join(err, b.stopID(id))
+	}
+	b.ctx = nil
 	b.stop = nil
-	switch {
-	case err == nil || errors.Is(err, context.Canceled):
-		if !deleteFiles {
-			return nil
-		}
-		var resetErr error
-		for _, sig := range b.signers {
-			b.postStates.Set(sig.NodeID(), types.PostStateIdle)
-			if err := b.nipostBuilder.ResetState(sig.NodeID()); err != nil {
-				b.log.Error("failed to reset builder state", log.ZShortStringer("id", sig.NodeID()), zap.Error(err))
-				err = fmt.Errorf("reset builder state for id %s: %w", sig.NodeID().ShortString(), err)
-				resetErr = errors.Join(resetErr, err)
-				continue
-			}
-			if err := nipost.RemoveChallenge(b.localDB, sig.NodeID()); err != nil {
-				b.log.Error("failed to remove nipost challenge", zap.Error(err))
-				err = fmt.Errorf("remove nipost challenge for id %s: %w", sig.NodeID().ShortString(), err)
-				resetErr = errors.Join(resetErr, err)
-			}
-		}
-		return resetErr
-	default:
+	if err != nil {
 		return fmt.Errorf("failed to stop smeshing: %w", err)
 	}
+	if !deleteFiles {
+		return nil
+	}
+	var resetErr error
+	for _, sig := range b.signers {
+		resetErr = errors.Join(resetErr, b.resetState(sig.NodeID()))
+	}
+	return resetErr
+}
+
+// stopID stops the worker of the identity if it is running. Must be called with smeshingMutex held.
+func (b *Builder) stopID(id types.NodeID) error {
+	w, exist := b.workers[id]
+	if !exist {
+		return nil
+	}
+	delete(b.workers, id)
+	w.stop()
+	if err := w.eg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("stop identity %s: %w", id.ShortString(), err)
+	}
+	return nil
+}
+
+// resetState discards the local NIPoST state of the identity.
+func (b *Builder) resetState(id types.NodeID) error {
+	b.postStates.Set(id, types.PostStateIdle)
+	if err := b.nipostBuilder.ResetState(id); err != nil {
+		b.log.Error("failed to reset builder state", log.ZShortStringer("id", id), zap.Error(err))
+		return fmt.Errorf("reset builder state for id %s: %w", id.ShortString(), err)
+	}
+	if err := nipost.RemoveChallenge(b.localDB, id); err != nil {
+		b.log.Error("failed to remove nipost challenge", zap.Error(err))
+		return fmt.Errorf("remove nipost challenge for id %s: %w", id.ShortString(), err)
+	}
+	return nil
 }
 
 // SmesherID returns the ID of the smesher that created this activation.
@@ -586,6 +667,13 @@ func (b *Builder) PublishActivationTx(ctx context.Context, sig *signing.EdSigner
 	if err != nil {
 		return err
 	}
+	identity, err := smeshing.Get(b.localDB, sig.NodeID())
+	if err != nil {
+		return fmt.Errorf("load smeshing settings: %w", err)
+	}
+	if identity.PauseEpoch == challenge.PublishEpoch {
+		return b.skipPausedEpoch(ctx, sig.NodeID(), challenge.PublishEpoch)
+	}
 
 	b.log.Info("atx challenge is ready",
 		log.ZShortStringer("smesherID", sig.NodeID()),
@@ -645,6 +733,27 @@ func (b *Builder) PublishActivationTx(ctx context.Context, sig *signing.EdSigner
 	return nil
 }
 
+// skipPausedEpoch discards the challenge of the paused identity and waits until the publish epoch,
+// so that the next challenge is built for the following epoch.
+func (b *Builder) skipPausedEpoch(ctx context.Context, id types.NodeID, epoch types.EpochID) error {
+	b.log.Info("identity is paused, skipping atx",
+		log.ZShortStringer("smesherID", id),
+		zap.Uint32("pub_epoch", epoch.Uint32()),
+	)
+	if err := b.nipostBuilder.ResetState(id); err != nil {
+		return fmt.Errorf("reset nipost builder state: %w", err)
+	}
+	if err := nipost.RemoveChallenge(b.localDB, id); err != nil {
+		return fmt.Errorf("discarding challenge of paused identity: %w", err)
+	}
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("wait for paused epoch: %w", ctx.Err())
+	case <-b.layerClock.AwaitLayer(epoch.FirstLayer()):
+	}
+	return nil
+}
+
 func (b *Builder) poetRoundStart(epoch types.EpochID) time.Time {
 	return b.layerClock.LayerToTime(epoch.FirstLayer()).Add(b.poetCfg.PhaseShift)
 }
@@ -688,7 +797,7 @@ func (b *Builder) createAtx(
 	atx := wire.ActivationTxV1{
 		InnerActivationTxV1: wire.InnerActivationTxV1{
 			NIPostChallengeV1: *challenge,
-			Coinbase:          b.Coinbase(),
+			Coinbase:          b.identityCoinbase(sig.NodeID()),
 			NumUnits:          nipostState.NumUnits,
 			NIPost:            wire.NiPostToWireV1(nipostState.NIPost),
 		},
@@ -696,7 +805,9 @@ func (b *Builder) createAtx(
 	if nonce != nil {
 		atx.VRFNonce = (*uint64)(nonce)
 	}
-	atx.Sign(sig)
+	if err := atx.SignOnce(sig); err != nil {
+		return nil, fmt.Errorf("sign atx: %w", err)
+	}
 
 	return &atx, nil
 }
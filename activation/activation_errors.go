@@ -12,6 +12,8 @@ var (
 	ErrPoetServiceUnstable = &PoetSvcUnstableError{}
 	// ErrPoetProofNotReceived is returned when no poet proof was received.
 	ErrPoetProofNotReceived = errors.New("builder: didn't receive any poet proof")
+	// ErrUnknownIdentity is returned when the identity is not registered in the builder.
+	ErrUnknownIdentity = errors.New("builder: unknown identity")
 )
 
 // PoetSvcUnstableError means there was a problem communicating
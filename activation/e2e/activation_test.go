@@ -14,6 +14,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/activation/poettest"
 	"github.com/spacemeshos/go-spacemesh/activation/wire"
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
 	"github.com/spacemeshos/go-spacemesh/codec"
@@ -222,3 +223,149 @@ func Test_BuilderWithMultipleClients(t *testing.T) {
 		require.Equal(t, sig.NodeID(), *atx.NodeID)
 	}
 }
+
+func Test_BuilderWithMockPoet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+
+	logger := zaptest.NewLogger(t)
+	goldenATX := types.ATXID{2, 3, 4}
+	cfg := activation.DefaultPostConfig()
+	db := sql.InMemory()
+	cdb := datastore.NewCachedDB(db, log.NewFromLog(logger))
+
+	syncer := activation.NewMocksyncer(ctrl)
+	syncer.EXPECT().RegisterForATXSynced().DoAndReturn(func() <-chan struct{} {
+		synced := make(chan struct{})
+		close(synced)
+		return synced
+	}).AnyTimes()
+
+	svc := grpcserver.NewPostService(logger)
+	svc.AllowConnections(true)
+	grpcCfg, cleanup := launchServer(t, svc)
+	t.Cleanup(cleanup)
+
+	opts := activation.DefaultPostSetupOpts()
+	opts.DataDir = t.TempDir()
+	opts.ProviderID.SetUint32(initialization.CPUProviderID())
+	opts.Scrypt.N = 2 // Speedup initialization in tests.
+
+	mgr, err := activation.NewPostSetupManager(cfg, logger, cdb, goldenATX, syncer,
+		activation.NewMocknipostValidator(ctrl))
+	require.NoError(t, err)
+	initPost(t, mgr, opts, sig.NodeID())
+	t.Cleanup(launchPostSupervisor(t, logger, mgr, sig, grpcCfg, opts))
+	require.Eventually(t, func() bool {
+		_, err := svc.Client(sig.NodeID())
+		return err == nil
+	}, 10*time.Second, 100*time.Millisecond, "timed out waiting for connection")
+
+	// ensure that genesis aligns with layer timings
+	genesis := time.Now().Add(layerDuration).Round(layerDuration)
+	layerDuration := 3 * time.Second
+	epoch := layersPerEpoch * layerDuration
+	poetCfg := activation.PoetConfig{
+		PhaseShift:        epoch,
+		CycleGap:          epoch / 2,
+		GracePeriod:       epoch / 5,
+		RequestTimeout:    epoch / 5,
+		RequestRetryDelay: epoch / 50,
+		MaxRequestRetries: 10,
+	}
+	poet, err := poettest.New(poettest.Config{
+		Genesis:       genesis,
+		EpochDuration: epoch,
+		PhaseShift:    poetCfg.PhaseShift,
+		CycleGap:      poetCfg.CycleGap,
+		ProofDuration: time.Second,
+		Prover:        poettest.WorkProver(t.TempDir()),
+		PowDifficulty: 8,
+	}, logger.Named("poet"))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var eg errgroup.Group
+	eg.Go(func() error {
+		return poet.Run(ctx)
+	})
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, eg.Wait())
+	})
+
+	clock, err := timesync.NewClock(
+		timesync.WithGenesisTime(genesis),
+		timesync.WithLayerDuration(layerDuration),
+		timesync.WithTickInterval(100*time.Millisecond),
+		timesync.WithLogger(logger),
+	)
+	require.NoError(t, err)
+	t.Cleanup(clock.Close)
+
+	poetDb := activation.NewPoetDb(db, log.NewFromLog(logger).Named("poetDb"))
+	localDB := localsql.InMemory()
+	nb, err := activation.NewNIPostBuilder(
+		localDB,
+		poetDb,
+		svc,
+		[]types.PoetServer{poet.GRPC()},
+		logger.Named("nipostBuilder"),
+		poetCfg,
+		clock,
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, nb.Close()) })
+
+	published := make(chan wire.ActivationTxV1, 1)
+	mpub := mocks.NewMockPublisher(ctrl)
+	mpub.EXPECT().Publish(gomock.Any(), pubsub.AtxProtocol, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, topic string, got []byte) error {
+			var atx wire.ActivationTxV1
+			codec.MustDecode(got, &atx)
+			published <- atx
+			return nil
+		},
+	)
+
+	verifier, err := activation.NewPostVerifier(cfg, logger.Named("verifier"))
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, verifier.Close()) })
+	v := activation.NewValidator(nil, poetDb, cfg, opts.Scrypt, verifier)
+	tab := activation.NewBuilder(
+		activation.Config{GoldenATXID: goldenATX},
+		cdb,
+		localDB,
+		mpub,
+		nb,
+		clock,
+		syncer,
+		logger,
+		activation.WithPoetConfig(poetCfg),
+		activation.WithValidator(v),
+	)
+	tab.Register(sig)
+
+	require.NoError(t, tab.StartSmeshing(types.Address{}))
+	atx := <-published
+	require.NoError(t, tab.StopSmeshing(false))
+
+	require.Equal(t, sig.NodeID(), atx.SmesherID)
+	require.Equal(t, postGenesisEpoch, atx.PublishEpoch+1)
+	_, err = v.NIPost(
+		context.Background(),
+		sig.NodeID(),
+		*atx.CommitmentATXID,
+		wire.NiPostFromWireV1(atx.NIPost),
+		atx.NIPostChallengeV1.Hash(),
+		atx.NumUnits,
+	)
+	require.NoError(t, err)
+
+	stats, err := nb.PoetStats(sig.NodeID())
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	require.Equal(t, poet.GRPC().Address, stats[0].Address)
+	require.Equal(t, uint32(1), stats[0].SubmitSuccess)
+	require.Equal(t, uint32(1), stats[0].ProofSuccess)
+}
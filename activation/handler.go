@@ -108,6 +108,16 @@ func (h *Handler) Register(sig *signing.EdSigner) {
 	h.signers[sig.NodeID()] = sig
 }
 
+// Unregister removes the signing key of the identity that was registered with Register.
+func (h *Handler) Unregister(id types.NodeID) {
+	h.signerMtx.Lock()
+	defer h.signerMtx.Unlock()
+	if _, exists := h.signers[id]; exists {
+		h.log.With().Info("unregistered signing key", log.ShortStringer("id", id))
+		delete(h.signers, id)
+	}
+}
+
 func (h *Handler) syntacticallyValidate(ctx context.Context, atx *wire.ActivationTxV1) error {
 	if atx.NIPost == nil {
 		return fmt.Errorf("nil nipost for atx %s", atx.ID())
@@ -0,0 +1,145 @@
+package activation
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/smeshing"
+)
+
+// IdentityStatus is the smeshing status of a registered identity.
+type IdentityStatus struct {
+	smeshing.Identity
+	// Smeshing is true if the builder is running for the identity.
+	Smeshing bool
+}
+
+// StartSmeshingIdentity starts smeshing with a single identity that was previously stopped or paused.
+// If the node isn't smeshing the identity will start together with the other identities.
+func (b *Builder) StartSmeshingIdentity(id types.NodeID) error {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	sig, exist := b.signers[id]
+	if !exist {
+		return fmt.Errorf("%w: %s", ErrUnknownIdentity, id.ShortString())
+	}
+	if err := smeshing.SetStopped(b.localDB, id, false); err != nil {
+		return err
+	}
+	if err := smeshing.Pause(b.localDB, id, 0); err != nil {
+		return err
+	}
+	if _, running := b.workers[id]; running || b.stop == nil {
+		return nil
+	}
+	b.log.Info("starting identity", log.ZShortStringer("id", id))
+	b.startID(sig)
+	return nil
+}
+
+// StopSmeshingIdentity stops smeshing with a single identity. The identity stays stopped
+// across restarts of the node until it is started with StartSmeshingIdentity.
+func (b *Builder) StopSmeshingIdentity(id types.NodeID) error {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	if _, exist := b.signers[id]; !exist {
+		return fmt.Errorf("%w: %s", ErrUnknownIdentity, id.ShortString())
+	}
+	if err := smeshing.SetStopped(b.localDB, id, true); err != nil {
+		return err
+	}
+	b.log.Info("stopping identity", log.ZShortStringer("id", id))
+	return b.stopID(id)
+}
+
+// SetIdentityCoinbase sets the coinbase that is used in ATXs of the identity.
+// Empty address resets it to the coinbase of the node.
+func (b *Builder) SetIdentityCoinbase(id types.NodeID, coinbase types.Address) error {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	if _, exist := b.signers[id]; !exist {
+		return fmt.Errorf("%w: %s", ErrUnknownIdentity, id.ShortString())
+	}
+	return smeshing.SetCoinbase(b.localDB, id, coinbase)
+}
+
+// PauseIdentity makes the identity skip the ATX that is published in the epoch.
+// Zero epoch resumes the identity.
+func (b *Builder) PauseIdentity(id types.NodeID, epoch types.EpochID) error {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	if _, exist := b.signers[id]; !exist {
+		return fmt.Errorf("%w: %s", ErrUnknownIdentity, id.ShortString())
+	}
+	return smeshing.Pause(b.localDB, id, epoch)
+}
+
+// ResetIdentity stops the identity and discards its local NIPoST state. The identity stays registered
+// and stopped, so that it is not used to build ATXs until it is started with StartSmeshingIdentity,
+// which then begins with a new initial post.
+func (b *Builder) ResetIdentity(id types.NodeID) error {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	if _, exist := b.signers[id]; !exist {
+		return fmt.Errorf("%w: %s", ErrUnknownIdentity, id.ShortString())
+	}
+	if err := smeshing.SetStopped(b.localDB, id, true); err != nil {
+		return err
+	}
+	if err := b.stopID(id); err != nil {
+		return err
+	}
+	if err := b.resetState(id); err != nil {
+		return err
+	}
+	if err := nipost.RemoveInitialPost(b.localDB, id); err != nil {
+		return fmt.Errorf("remove initial post for id %s: %w", id.ShortString(), err)
+	}
+	b.log.Info("reset identity", log.ZShortStringer("id", id))
+	return nil
+}
+
+// Identities returns the smeshing status of every registered identity ordered by id.
+func (b *Builder) Identities() ([]IdentityStatus, error) {
+	b.smeshingMutex.Lock()
+	defer b.smeshingMutex.Unlock()
+	identities, err := smeshing.All(b.localDB)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[types.NodeID]smeshing.Identity, len(identities))
+	for _, identity := range identities {
+		settings[identity.ID] = identity
+	}
+	rst := make([]IdentityStatus, 0, len(b.signers))
+	for id := range b.signers {
+		identity, exist := settings[id]
+		if !exist {
+			identity = smeshing.Identity{ID: id}
+		}
+		_, running := b.workers[id]
+		rst = append(rst, IdentityStatus{Identity: identity, Smeshing: running})
+	}
+	slices.SortFunc(rst, func(x, y IdentityStatus) int {
+		return bytes.Compare(x.ID.Bytes(), y.ID.Bytes())
+	})
+	return rst, nil
+}
+
+// identityCoinbase returns the coinbase of the identity, or the coinbase of the node if the
+// identity doesn't have one.
+func (b *Builder) identityCoinbase(id types.NodeID) types.Address {
+	identity, err := smeshing.Get(b.localDB, id)
+	if err != nil {
+		b.log.Warn("failed to load identity coinbase", log.ZShortStringer("id", id), zap.Error(err))
+	} else if identity.Coinbase != (types.Address{}) {
+		return identity.Coinbase
+	}
+	return b.Coinbase()
+}
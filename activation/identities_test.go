@@ -0,0 +1,182 @@
+package activation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spacemeshos/post/shared"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/exp/maps"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/smeshing"
+)
+
+func expectBlockedProof(tab *testAtxBuilder, id types.NodeID) {
+	tab.mnipost.EXPECT().Proof(gomock.Any(), id, shared.ZeroChallenge).AnyTimes().DoAndReturn(
+		func(ctx context.Context, _ types.NodeID, _ []byte) (*types.Post, *types.PostInfo, error) {
+			<-ctx.Done()
+			return nil, nil, ctx.Err()
+		})
+}
+
+func running(tb testing.TB, b *Builder) map[types.NodeID]bool {
+	tb.Helper()
+	identities, err := b.Identities()
+	require.NoError(tb, err)
+	rst := make(map[types.NodeID]bool, len(identities))
+	for _, identity := range identities {
+		rst[identity.ID] = identity.Smeshing
+	}
+	return rst
+}
+
+func Test_Builder_Identities_StartStop(t *testing.T) {
+	tab := newTestBuilder(t, 3)
+	tab.mclock.EXPECT().CurrentLayer().Return(types.LayerID(0)).AnyTimes()
+	tab.mclock.EXPECT().AwaitLayer(gomock.Any()).Return(make(chan struct{})).AnyTimes()
+	ids := make([]types.NodeID, 0, len(tab.signers))
+	for _, sig := range tab.signers {
+		expectBlockedProof(tab, sig.NodeID())
+		ids = append(ids, sig.NodeID())
+	}
+	stopped := ids[0]
+
+	require.ErrorIs(t, tab.StopSmeshingIdentity(types.RandomNodeID()), ErrUnknownIdentity)
+	require.ErrorIs(t, tab.StartSmeshingIdentity(types.RandomNodeID()), ErrUnknownIdentity)
+
+	require.NoError(t, tab.StopSmeshingIdentity(stopped))
+	require.NoError(t, tab.StartSmeshing(types.Address{1}))
+	require.Equal(t, map[types.NodeID]bool{ids[0]: false, ids[1]: true, ids[2]: true}, running(t, tab.Builder))
+
+	require.NoError(t, tab.StopSmeshingIdentity(ids[1]))
+	require.Equal(t, map[types.NodeID]bool{ids[0]: false, ids[1]: false, ids[2]: true}, running(t, tab.Builder))
+
+	require.NoError(t, tab.StartSmeshingIdentity(stopped))
+	require.Equal(t, map[types.NodeID]bool{ids[0]: true, ids[1]: false, ids[2]: true}, running(t, tab.Builder))
+	require.NoError(t, tab.StopSmeshing(false))
+	require.Equal(t, map[types.NodeID]bool{ids[0]: false, ids[1]: false, ids[2]: false}, running(t, tab.Builder))
+
+	// stopped identity stays stopped after restart
+	require.NoError(t, tab.StartSmeshing(types.Address{1}))
+	require.Equal(t, map[types.NodeID]bool{ids[0]: true, ids[1]: false, ids[2]: true}, running(t, tab.Builder))
+
+	// stopped identity registered while smeshing is not started
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	require.NoError(t, smeshing.SetStopped(tab.localDb, sig.NodeID(), true))
+	tab.Register(sig)
+	require.False(t, running(t, tab.Builder)[sig.NodeID()])
+	require.NoError(t, tab.StopSmeshing(false))
+}
+
+func Test_Builder_Identities_Coinbase(t *testing.T) {
+	tab := newTestBuilder(t, 2)
+	nodeCoinbase := types.GenerateAddress([]byte("node"))
+	tab.SetCoinbase(nodeCoinbase)
+	var ids []types.NodeID
+	for _, sig := range tab.signers {
+		ids = append(ids, sig.NodeID())
+	}
+
+	coinbase := types.GenerateAddress([]byte("identity"))
+	require.ErrorIs(t, tab.SetIdentityCoinbase(types.RandomNodeID(), coinbase), ErrUnknownIdentity)
+	require.NoError(t, tab.SetIdentityCoinbase(ids[0], coinbase))
+	require.Equal(t, coinbase, tab.identityCoinbase(ids[0]))
+	require.Equal(t, nodeCoinbase, tab.identityCoinbase(ids[1]))
+
+	require.NoError(t, tab.SetIdentityCoinbase(ids[0], types.Address{}))
+	require.Equal(t, nodeCoinbase, tab.identityCoinbase(ids[0]))
+}
+
+func Test_Builder_Identities_Pause(t *testing.T) {
+	tab := newTestBuilder(t, 1)
+	sig := maps.Values(tab.signers)[0]
+	publish := postGenesisEpoch + 2
+	atx := types.RandomATXID()
+	require.NoError(t, nipost.AddChallenge(tab.localDb, sig.NodeID(), &types.NIPostChallenge{
+		PublishEpoch:  publish,
+		CommitmentATX: &atx,
+	}))
+
+	require.ErrorIs(t, tab.PauseIdentity(types.RandomNodeID(), publish), ErrUnknownIdentity)
+	require.NoError(t, tab.PauseIdentity(sig.NodeID(), publish))
+
+	tab.mclock.EXPECT().CurrentLayer().Return((postGenesisEpoch + 1).FirstLayer()).AnyTimes()
+	tab.mnipost.EXPECT().ResetState(sig.NodeID())
+	published := make(chan struct{})
+	close(published)
+	tab.mclock.EXPECT().AwaitLayer(publish.FirstLayer()).Return(published)
+	require.NoError(t, tab.PublishActivationTx(context.Background(), sig))
+
+	_, err := nipost.Challenge(tab.localDb, sig.NodeID())
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	// starting the identity clears the pause
+	require.NoError(t, tab.StartSmeshingIdentity(sig.NodeID()))
+	identity, err := smeshing.Get(tab.localDb, sig.NodeID())
+	require.NoError(t, err)
+	require.Zero(t, identity.PauseEpoch)
+}
+
+func Test_Builder_Identities_Reset(t *testing.T) {
+	tab := newTestBuilder(t, 3)
+	tab.mclock.EXPECT().CurrentLayer().Return(types.LayerID(0)).AnyTimes()
+	tab.mclock.EXPECT().AwaitLayer(gomock.Any()).Return(make(chan struct{})).AnyTimes()
+	var ids []types.NodeID
+	for _, sig := range tab.signers {
+		expectBlockedProof(tab, sig.NodeID())
+		ids = append(ids, sig.NodeID())
+	}
+
+	// identity with local state is stopped so that the builder doesn't try to use that state
+	reset := ids[0]
+	require.NoError(t, tab.StopSmeshingIdentity(reset))
+	atx := types.RandomATXID()
+	require.NoError(t, nipost.AddChallenge(tab.localDb, reset, &types.NIPostChallenge{
+		PublishEpoch:  postGenesisEpoch + 2,
+		CommitmentATX: &atx,
+	}))
+	require.NoError(t, nipost.AddInitialPost(tab.localDb, reset, nipost.Post{
+		Indices:       types.RandomBytes(10),
+		CommitmentATX: atx,
+	}))
+	coinbase := types.Address{1}
+	require.NoError(t, tab.SetIdentityCoinbase(reset, coinbase))
+	require.NoError(t, tab.StartSmeshing(types.Address{}))
+
+	require.ErrorIs(t, tab.ResetIdentity(types.RandomNodeID()), ErrUnknownIdentity)
+	tab.mnipost.EXPECT().ResetState(reset)
+	require.NoError(t, tab.ResetIdentity(reset))
+	_, err := nipost.Challenge(tab.localDb, reset)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	_, err = nipost.InitialPost(tab.localDb, reset)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	identity, err := smeshing.Get(tab.localDb, reset)
+	require.NoError(t, err)
+	require.True(t, identity.Stopped)
+	require.Equal(t, coinbase, identity.Coinbase)
+
+	// identity that is smeshing is stopped and stays registered
+	tab.mnipost.EXPECT().ResetState(ids[1])
+	require.NoError(t, tab.ResetIdentity(ids[1]))
+
+	require.ElementsMatch(t, ids, tab.SmesherIDs())
+	require.Equal(t, map[types.NodeID]bool{ids[0]: false, ids[1]: false, ids[2]: true}, running(t, tab.Builder))
+	require.NoError(t, tab.StopSmeshing(false))
+}
+
+func Test_Builder_Unregister(t *testing.T) {
+	tab := newTestBuilder(t, 2)
+	ids := tab.SmesherIDs()
+	require.Len(t, tab.PostStates(), 2)
+
+	tab.Unregister(ids[0])
+	require.Equal(t, ids[1:], tab.SmesherIDs())
+	require.NotContains(t, tab.postStates.Get(), ids[0])
+	require.Contains(t, tab.postStates.Get(), ids[1])
+}
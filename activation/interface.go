@@ -115,11 +115,18 @@ type SmeshingProvider interface {
 	SmesherIDs() []types.NodeID
 	Coinbase() types.Address
 	SetCoinbase(coinbase types.Address)
+
+	StartSmeshingIdentity(id types.NodeID) error
+	StopSmeshingIdentity(id types.NodeID) error
+	SetIdentityCoinbase(id types.NodeID, coinbase types.Address) error
+	PauseIdentity(id types.NodeID, epoch types.EpochID) error
+	ResetIdentity(id types.NodeID) error
+	Identities() ([]IdentityStatus, error)
 }
 
-// poetClient servers as an interface to communicate with a PoET server.
+// PoetClient servers as an interface to communicate with a PoET server.
 // It is used to submit challenges and fetch proofs.
-type poetClient interface {
+type PoetClient interface {
 	Address() string
 
 	PowParams(ctx context.Context) (*PoetPowParams, error)
@@ -164,4 +171,5 @@ type PostClient interface {
 type PostStates interface {
 	Set(id types.NodeID, state types.PostState)
 	Get() map[types.NodeID]types.PostState
+	Remove(id types.NodeID)
 }
@@ -1262,6 +1262,121 @@ func (c *MockSmeshingProviderCoinbaseCall) DoAndReturn(f func() types.Address) *
 	return c
 }
 
+// Identities mocks base method.
+func (m *MockSmeshingProvider) Identities() ([]IdentityStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Identities")
+	ret0, _ := ret[0].([]IdentityStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Identities indicates an expected call of Identities.
+func (mr *MockSmeshingProviderMockRecorder) Identities() *MockSmeshingProviderIdentitiesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Identities", reflect.TypeOf((*MockSmeshingProvider)(nil).Identities))
+	return &MockSmeshingProviderIdentitiesCall{Call: call}
+}
+
+// MockSmeshingProviderIdentitiesCall wrap *gomock.Call
+type MockSmeshingProviderIdentitiesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSmeshingProviderIdentitiesCall) Return(arg0 []IdentityStatus, arg1 error) *MockSmeshingProviderIdentitiesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSmeshingProviderIdentitiesCall) Do(f func() ([]IdentityStatus, error)) *MockSmeshingProviderIdentitiesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSmeshingProviderIdentitiesCall) DoAndReturn(f func() ([]IdentityStatus, error)) *MockSmeshingProviderIdentitiesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PauseIdentity mocks base method.
+func (m *MockSmeshingProvider) PauseIdentity(id types.NodeID, epoch types.EpochID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseIdentity", id, epoch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseIdentity indicates an expected call of PauseIdentity.
+func (mr *MockSmeshingProviderMockRecorder) PauseIdentity(id, epoch any) *MockSmeshingProviderPauseIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseIdentity", reflect.TypeOf((*MockSmeshingProvider)(nil).PauseIdentity), id, epoch)
+	return &MockSmeshingProviderPauseIdentityCall{Call: call}
+}
+
+// MockSmeshingProviderPauseIdentityCall wrap *gomock.Call
+type MockSmeshingProviderPauseIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSmeshingProviderPauseIdentityCall) Return(arg0 error) *MockSmeshingProviderPauseIdentityCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSmeshingProviderPauseIdentityCall) Do(f func(types.NodeID, types.EpochID) error) *MockSmeshingProviderPauseIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSmeshingProviderPauseIdentityCall) DoAndReturn(f func(types.NodeID, types.EpochID) error) *MockSmeshingProviderPauseIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ResetIdentity mocks base method.
+func (m *MockSmeshingProvider) ResetIdentity(id types.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetIdentity", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetIdentity indicates an expected call of ResetIdentity.
+func (mr *MockSmeshingProviderMockRecorder) ResetIdentity(id any) *MockSmeshingProviderResetIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetIdentity", reflect.TypeOf((*MockSmeshingProvider)(nil).ResetIdentity), id)
+	return &MockSmeshingProviderResetIdentityCall{Call: call}
+}
+
+// MockSmeshingProviderResetIdentityCall wrap *gomock.Call
+type MockSmeshingProviderResetIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSmeshingProviderResetIdentityCall) Return(arg0 error) *MockSmeshingProviderResetIdentityCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSmeshingProviderResetIdentityCall) Do(f func(types.NodeID) error) *MockSmeshingProviderResetIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSmeshingProviderResetIdentityCall) DoAndReturn(f func(types.NodeID) error) *MockSmeshingProviderResetIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SetCoinbase mocks base method.
 func (m *MockSmeshingProvider) SetCoinbase(coinbase types.Address) {
 	m.ctrl.T.Helper()
@@ -1298,6 +1413,44 @@ func (c *MockSmeshingProviderSetCoinbaseCall) DoAndReturn(f func(types.Address))
 	return c
 }
 
+// SetIdentityCoinbase mocks base method.
+func (m *MockSmeshingProvider) SetIdentityCoinbase(id types.NodeID, coinbase types.Address) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIdentityCoinbase", id, coinbase)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIdentityCoinbase indicates an expected call of SetIdentityCoinbase.
+func (mr *MockSmeshingProviderMockRecorder) SetIdentityCoinbase(id, coinbase any) *MockSmeshingProviderSetIdentityCoinbaseCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIdentityCoinbase", reflect.TypeOf((*MockSmeshingProvider)(nil).SetIdentityCoinbase), id, coinbase)
+	return &MockSmeshingProviderSetIdentityCoinbaseCall{Call: call}
+}
+
+// MockSmeshingProviderSetIdentityCoinbaseCall wrap *gomock.Call
+type MockSmeshingProviderSetIdentityCoinbaseCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSmeshingProviderSetIdentityCoinbaseCall) Return(arg0 error) *MockSmeshingProviderSetIdentityCoinbaseCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSmeshingProviderSetIdentityCoinbaseCall) Do(f func(types.NodeID, types.Address) error) *MockSmeshingProviderSetIdentityCoinbaseCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSmeshingProviderSetIdentityCoinbaseCall) DoAndReturn(f func(types.NodeID, types.Address) error) *MockSmeshingProviderSetIdentityCoinbaseCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SmesherIDs mocks base method.
 func (m *MockSmeshingProvider) SmesherIDs() []types.NodeID {
 	m.ctrl.T.Helper()
@@ -1412,6 +1565,44 @@ func (c *MockSmeshingProviderStartSmeshingCall) DoAndReturn(f func(types.Address
 	return c
 }
 
+// StartSmeshingIdentity mocks base method.
+func (m *MockSmeshingProvider) StartSmeshingIdentity(id types.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSmeshingIdentity", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartSmeshingIdentity indicates an expected call of StartSmeshingIdentity.
+func (mr *MockSmeshingProviderMockRecorder) StartSmeshingIdentity(id any) *MockSmeshingProviderStartSmeshingIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSmeshingIdentity", reflect.TypeOf((*MockSmeshingProvider)(nil).StartSmeshingIdentity), id)
+	return &MockSmeshingProviderStartSmeshingIdentityCall{Call: call}
+}
+
+// MockSmeshingProviderStartSmeshingIdentityCall wrap *gomock.Call
+type MockSmeshingProviderStartSmeshingIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSmeshingProviderStartSmeshingIdentityCall) Return(arg0 error) *MockSmeshingProviderStartSmeshingIdentityCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSmeshingProviderStartSmeshingIdentityCall) Do(f func(types.NodeID) error) *MockSmeshingProviderStartSmeshingIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSmeshingProviderStartSmeshingIdentityCall) DoAndReturn(f func(types.NodeID) error) *MockSmeshingProviderStartSmeshingIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // StopSmeshing mocks base method.
 func (m *MockSmeshingProvider) StopSmeshing(arg0 bool) error {
 	m.ctrl.T.Helper()
@@ -1450,31 +1641,69 @@ func (c *MockSmeshingProviderStopSmeshingCall) DoAndReturn(f func(bool) error) *
 	return c
 }
 
-// MockpoetClient is a mock of poetClient interface.
-type MockpoetClient struct {
+// StopSmeshingIdentity mocks base method.
+func (m *MockSmeshingProvider) StopSmeshingIdentity(id types.NodeID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopSmeshingIdentity", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopSmeshingIdentity indicates an expected call of StopSmeshingIdentity.
+func (mr *MockSmeshingProviderMockRecorder) StopSmeshingIdentity(id any) *MockSmeshingProviderStopSmeshingIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopSmeshingIdentity", reflect.TypeOf((*MockSmeshingProvider)(nil).StopSmeshingIdentity), id)
+	return &MockSmeshingProviderStopSmeshingIdentityCall{Call: call}
+}
+
+// MockSmeshingProviderStopSmeshingIdentityCall wrap *gomock.Call
+type MockSmeshingProviderStopSmeshingIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSmeshingProviderStopSmeshingIdentityCall) Return(arg0 error) *MockSmeshingProviderStopSmeshingIdentityCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSmeshingProviderStopSmeshingIdentityCall) Do(f func(types.NodeID) error) *MockSmeshingProviderStopSmeshingIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSmeshingProviderStopSmeshingIdentityCall) DoAndReturn(f func(types.NodeID) error) *MockSmeshingProviderStopSmeshingIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockPoetClient is a mock of PoetClient interface.
+type MockPoetClient struct {
 	ctrl     *gomock.Controller
-	recorder *MockpoetClientMockRecorder
+	recorder *MockPoetClientMockRecorder
 }
 
-// MockpoetClientMockRecorder is the mock recorder for MockpoetClient.
-type MockpoetClientMockRecorder struct {
-	mock *MockpoetClient
+// MockPoetClientMockRecorder is the mock recorder for MockPoetClient.
+type MockPoetClientMockRecorder struct {
+	mock *MockPoetClient
 }
 
-// NewMockpoetClient creates a new mock instance.
-func NewMockpoetClient(ctrl *gomock.Controller) *MockpoetClient {
-	mock := &MockpoetClient{ctrl: ctrl}
-	mock.recorder = &MockpoetClientMockRecorder{mock}
+// NewMockPoetClient creates a new mock instance.
+func NewMockPoetClient(ctrl *gomock.Controller) *MockPoetClient {
+	mock := &MockPoetClient{ctrl: ctrl}
+	mock.recorder = &MockPoetClientMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockpoetClient) EXPECT() *MockpoetClientMockRecorder {
+func (m *MockPoetClient) EXPECT() *MockPoetClientMockRecorder {
 	return m.recorder
 }
 
 // Address mocks base method.
-func (m *MockpoetClient) Address() string {
+func (m *MockPoetClient) Address() string {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Address")
 	ret0, _ := ret[0].(string)
@@ -1482,37 +1711,37 @@ func (m *MockpoetClient) Address() string {
 }
 
 // Address indicates an expected call of Address.
-func (mr *MockpoetClientMockRecorder) Address() *MockpoetClientAddressCall {
+func (mr *MockPoetClientMockRecorder) Address() *MockPoetClientAddressCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockpoetClient)(nil).Address))
-	return &MockpoetClientAddressCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockPoetClient)(nil).Address))
+	return &MockPoetClientAddressCall{Call: call}
 }
 
-// MockpoetClientAddressCall wrap *gomock.Call
-type MockpoetClientAddressCall struct {
+// MockPoetClientAddressCall wrap *gomock.Call
+type MockPoetClientAddressCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockpoetClientAddressCall) Return(arg0 string) *MockpoetClientAddressCall {
+func (c *MockPoetClientAddressCall) Return(arg0 string) *MockPoetClientAddressCall {
 	c.Call = c.Call.Return(arg0)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockpoetClientAddressCall) Do(f func() string) *MockpoetClientAddressCall {
+func (c *MockPoetClientAddressCall) Do(f func() string) *MockPoetClientAddressCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockpoetClientAddressCall) DoAndReturn(f func() string) *MockpoetClientAddressCall {
+func (c *MockPoetClientAddressCall) DoAndReturn(f func() string) *MockPoetClientAddressCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
 // PowParams mocks base method.
-func (m *MockpoetClient) PowParams(ctx context.Context) (*PoetPowParams, error) {
+func (m *MockPoetClient) PowParams(ctx context.Context) (*PoetPowParams, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PowParams", ctx)
 	ret0, _ := ret[0].(*PoetPowParams)
@@ -1521,37 +1750,37 @@ func (m *MockpoetClient) PowParams(ctx context.Context) (*PoetPowParams, error)
 }
 
 // PowParams indicates an expected call of PowParams.
-func (mr *MockpoetClientMockRecorder) PowParams(ctx any) *MockpoetClientPowParamsCall {
+func (mr *MockPoetClientMockRecorder) PowParams(ctx any) *MockPoetClientPowParamsCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowParams", reflect.TypeOf((*MockpoetClient)(nil).PowParams), ctx)
-	return &MockpoetClientPowParamsCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PowParams", reflect.TypeOf((*MockPoetClient)(nil).PowParams), ctx)
+	return &MockPoetClientPowParamsCall{Call: call}
 }
 
-// MockpoetClientPowParamsCall wrap *gomock.Call
-type MockpoetClientPowParamsCall struct {
+// MockPoetClientPowParamsCall wrap *gomock.Call
+type MockPoetClientPowParamsCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockpoetClientPowParamsCall) Return(arg0 *PoetPowParams, arg1 error) *MockpoetClientPowParamsCall {
+func (c *MockPoetClientPowParamsCall) Return(arg0 *PoetPowParams, arg1 error) *MockPoetClientPowParamsCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockpoetClientPowParamsCall) Do(f func(context.Context) (*PoetPowParams, error)) *MockpoetClientPowParamsCall {
+func (c *MockPoetClientPowParamsCall) Do(f func(context.Context) (*PoetPowParams, error)) *MockPoetClientPowParamsCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockpoetClientPowParamsCall) DoAndReturn(f func(context.Context) (*PoetPowParams, error)) *MockpoetClientPowParamsCall {
+func (c *MockPoetClientPowParamsCall) DoAndReturn(f func(context.Context) (*PoetPowParams, error)) *MockPoetClientPowParamsCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
 // Proof mocks base method.
-func (m *MockpoetClient) Proof(ctx context.Context, roundID string) (*types.PoetProofMessage, []types.Hash32, error) {
+func (m *MockPoetClient) Proof(ctx context.Context, roundID string) (*types.PoetProofMessage, []types.Hash32, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Proof", ctx, roundID)
 	ret0, _ := ret[0].(*types.PoetProofMessage)
@@ -1561,37 +1790,37 @@ func (m *MockpoetClient) Proof(ctx context.Context, roundID string) (*types.Poet
 }
 
 // Proof indicates an expected call of Proof.
-func (mr *MockpoetClientMockRecorder) Proof(ctx, roundID any) *MockpoetClientProofCall {
+func (mr *MockPoetClientMockRecorder) Proof(ctx, roundID any) *MockPoetClientProofCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proof", reflect.TypeOf((*MockpoetClient)(nil).Proof), ctx, roundID)
-	return &MockpoetClientProofCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Proof", reflect.TypeOf((*MockPoetClient)(nil).Proof), ctx, roundID)
+	return &MockPoetClientProofCall{Call: call}
 }
 
-// MockpoetClientProofCall wrap *gomock.Call
-type MockpoetClientProofCall struct {
+// MockPoetClientProofCall wrap *gomock.Call
+type MockPoetClientProofCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockpoetClientProofCall) Return(arg0 *types.PoetProofMessage, arg1 []types.Hash32, arg2 error) *MockpoetClientProofCall {
+func (c *MockPoetClientProofCall) Return(arg0 *types.PoetProofMessage, arg1 []types.Hash32, arg2 error) *MockPoetClientProofCall {
 	c.Call = c.Call.Return(arg0, arg1, arg2)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockpoetClientProofCall) Do(f func(context.Context, string) (*types.PoetProofMessage, []types.Hash32, error)) *MockpoetClientProofCall {
+func (c *MockPoetClientProofCall) Do(f func(context.Context, string) (*types.PoetProofMessage, []types.Hash32, error)) *MockPoetClientProofCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockpoetClientProofCall) DoAndReturn(f func(context.Context, string) (*types.PoetProofMessage, []types.Hash32, error)) *MockpoetClientProofCall {
+func (c *MockPoetClientProofCall) DoAndReturn(f func(context.Context, string) (*types.PoetProofMessage, []types.Hash32, error)) *MockPoetClientProofCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
 
 // Submit mocks base method.
-func (m *MockpoetClient) Submit(ctx context.Context, deadline time.Time, prefix, challenge []byte, signature types.EdSignature, nodeID types.NodeID, pow PoetPoW) (*types.PoetRound, error) {
+func (m *MockPoetClient) Submit(ctx context.Context, deadline time.Time, prefix, challenge []byte, signature types.EdSignature, nodeID types.NodeID, pow PoetPoW) (*types.PoetRound, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Submit", ctx, deadline, prefix, challenge, signature, nodeID, pow)
 	ret0, _ := ret[0].(*types.PoetRound)
@@ -1600,31 +1829,31 @@ func (m *MockpoetClient) Submit(ctx context.Context, deadline time.Time, prefix,
 }
 
 // Submit indicates an expected call of Submit.
-func (mr *MockpoetClientMockRecorder) Submit(ctx, deadline, prefix, challenge, signature, nodeID, pow any) *MockpoetClientSubmitCall {
+func (mr *MockPoetClientMockRecorder) Submit(ctx, deadline, prefix, challenge, signature, nodeID, pow any) *MockPoetClientSubmitCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockpoetClient)(nil).Submit), ctx, deadline, prefix, challenge, signature, nodeID, pow)
-	return &MockpoetClientSubmitCall{Call: call}
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockPoetClient)(nil).Submit), ctx, deadline, prefix, challenge, signature, nodeID, pow)
+	return &MockPoetClientSubmitCall{Call: call}
 }
 
-// MockpoetClientSubmitCall wrap *gomock.Call
-type MockpoetClientSubmitCall struct {
+// MockPoetClientSubmitCall wrap *gomock.Call
+type MockPoetClientSubmitCall struct {
 	*gomock.Call
 }
 
 // Return rewrite *gomock.Call.Return
-func (c *MockpoetClientSubmitCall) Return(arg0 *types.PoetRound, arg1 error) *MockpoetClientSubmitCall {
+func (c *MockPoetClientSubmitCall) Return(arg0 *types.PoetRound, arg1 error) *MockPoetClientSubmitCall {
 	c.Call = c.Call.Return(arg0, arg1)
 	return c
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockpoetClientSubmitCall) Do(f func(context.Context, time.Time, []byte, []byte, types.EdSignature, types.NodeID, PoetPoW) (*types.PoetRound, error)) *MockpoetClientSubmitCall {
+func (c *MockPoetClientSubmitCall) Do(f func(context.Context, time.Time, []byte, []byte, types.EdSignature, types.NodeID, PoetPoW) (*types.PoetRound, error)) *MockPoetClientSubmitCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockpoetClientSubmitCall) DoAndReturn(f func(context.Context, time.Time, []byte, []byte, types.EdSignature, types.NodeID, PoetPoW) (*types.PoetRound, error)) *MockpoetClientSubmitCall {
+func (c *MockPoetClientSubmitCall) DoAndReturn(f func(context.Context, time.Time, []byte, []byte, types.EdSignature, types.NodeID, PoetPoW) (*types.PoetRound, error)) *MockPoetClientSubmitCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -2014,6 +2243,42 @@ func (c *MockPostStatesGetCall) DoAndReturn(f func() map[types.NodeID]types.Post
 	return c
 }
 
+// Remove mocks base method.
+func (m *MockPostStates) Remove(id types.NodeID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Remove", id)
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockPostStatesMockRecorder) Remove(id any) *MockPostStatesRemoveCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockPostStates)(nil).Remove), id)
+	return &MockPostStatesRemoveCall{Call: call}
+}
+
+// MockPostStatesRemoveCall wrap *gomock.Call
+type MockPostStatesRemoveCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockPostStatesRemoveCall) Return() *MockPostStatesRemoveCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockPostStatesRemoveCall) Do(f func(types.NodeID)) *MockPostStatesRemoveCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockPostStatesRemoveCall) DoAndReturn(f func(types.NodeID)) *MockPostStatesRemoveCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Set mocks base method.
 func (m *MockPostStates) Set(id types.NodeID, state types.PostState) {
 	m.ctrl.T.Helper()
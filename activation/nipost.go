@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand/v2"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/localsql"
 	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/poetstats"
 )
 
 const (
@@ -46,7 +48,7 @@ const (
 type NIPostBuilder struct {
 	localDB *localsql.Database
 
-	poetProvers map[string]poetClient
+	poetProvers map[string]PoetClient
 	poetDB      poetDbAPI
 	postService postService
 	log         *zap.Logger
@@ -58,9 +60,9 @@ type NIPostBuilder struct {
 type NIPostBuilderOption func(*NIPostBuilder)
 
 // withPoetClients allows to pass in clients directly (for testing purposes).
-func withPoetClients(clients []poetClient) NIPostBuilderOption {
+func withPoetClients(clients []PoetClient) NIPostBuilderOption {
 	return func(nb *NIPostBuilder) {
-		nb.poetProvers = make(map[string]poetClient, len(clients))
+		nb.poetProvers = make(map[string]PoetClient, len(clients))
 		for _, client := range clients {
 			nb.poetProvers[client.Address()] = client
 		}
@@ -84,10 +86,11 @@ func NewNIPostBuilder(
 	layerClock layerClock,
 	opts ...NIPostBuilderOption,
 ) (*NIPostBuilder, error) {
-	poetClients := make(map[string]poetClient, len(poetServers))
+	poetClients := make(map[string]PoetClient, len(poetServers))
 	for _, server := range poetServers {
-		client, err := NewHTTPPoetClient(server, poetCfg, WithLogger(lg.Named("poet")))
+		client, err := NewPoetClient(server, poetCfg, lg.Named("poet"))
 		if err != nil {
+			closePoetClients(poetClients)
 			return nil, fmt.Errorf("cannot create poet client: %w", err)
 		}
 		poetClients[client.Address()] = client
@@ -111,6 +114,23 @@ func NewNIPostBuilder(
 	return b, nil
 }
 
+// Close releases connections to the PoET services.
+func (nb *NIPostBuilder) Close() error {
+	return closePoetClients(nb.poetProvers)
+}
+
+func closePoetClients(clients map[string]PoetClient) error {
+	var errs []error
+	for address, client := range clients {
+		if closer, ok := client.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("close poet client %s: %w", address, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (nb *NIPostBuilder) ResetState(nodeId types.NodeID) error {
 	if err := nipost.ClearPoetRegistrations(nb.localDB, nodeId); err != nil {
 		return fmt.Errorf("clear poet registrations: %w", err)
@@ -345,7 +365,7 @@ func (nb *NIPostBuilder) submitPoetChallenge(
 	ctx context.Context,
 	nodeID types.NodeID,
 	deadline time.Time,
-	client poetClient,
+	client PoetClient,
 	prefix, challenge []byte,
 	signature types.EdSignature,
 ) error {
@@ -360,6 +380,7 @@ func (nb *NIPostBuilder) submitPoetChallenge(
 	defer cancel()
 	powParams, err := client.PowParams(powCtx)
 	if err != nil {
+		nb.recordPoetRequest(poetstats.AddSubmit, nodeID, client.Address(), err, 0)
 		return &PoetSvcUnstableError{msg: "failed to get PoW params", source: err}
 	}
 
@@ -381,10 +402,12 @@ func (nb *NIPostBuilder) submitPoetChallenge(
 
 	submitCtx, cancel := withConditionalTimeout(ctx, nb.poetCfg.RequestTimeout)
 	defer cancel()
+	startTime = time.Now()
 	round, err := client.Submit(submitCtx, deadline, prefix, challenge, signature, nodeID, PoetPoW{
 		Nonce:  nonce,
 		Params: *powParams,
 	})
+	nb.recordPoetRequest(poetstats.AddSubmit, nodeID, client.Address(), err, time.Since(startTime))
 	if err != nil {
 		return &PoetSvcUnstableError{msg: "failed to submit challenge to poet service", source: err}
 	}
@@ -398,7 +421,10 @@ func (nb *NIPostBuilder) submitPoetChallenge(
 	})
 }
 
-// Submit the challenge to all registered PoETs.
+// Submit the challenge to registered PoETs.
+//
+// PoETs are tried in the order of preference until the challenge is submitted to PoetConfig.MinSubmissions
+// of them, or to all of them if it is not set.
 func (nb *NIPostBuilder) submitPoetChallenges(
 	ctx context.Context,
 	signer *signing.EdSigner,
@@ -408,37 +434,57 @@ func (nb *NIPostBuilder) submitPoetChallenges(
 	signature := signer.Sign(signing.POET, challenge)
 	prefix := bytes.Join([][]byte{signer.Prefix(), {byte(signing.POET)}}, nil)
 	nodeID := signer.NodeID()
-	g, ctx := errgroup.WithContext(ctx)
-	errChan := make(chan error, len(nb.poetProvers))
-	for _, client := range nb.poetProvers {
-		g.Go(func() error {
-			errChan <- nb.submitPoetChallenge(ctx, nodeID, deadline, client, prefix, challenge, signature)
-			return nil
-		})
+	candidates := nb.orderedPoets(time.Now())
+	quorum := nb.poetCfg.MinSubmissions
+	if quorum <= 0 || quorum > len(candidates) {
+		quorum = len(candidates)
 	}
-	g.Wait()
-	close(errChan)
 
+	submitted := 0
 	allInvalid := true
-	for err := range errChan {
-		if err == nil {
-			allInvalid = false
-			continue
+	for next := 0; submitted < quorum && next < len(candidates) && ctx.Err() == nil; {
+		batch := candidates[next:min(next+quorum-submitted, len(candidates))]
+		next += len(batch)
+
+		var eg errgroup.Group
+		errChan := make(chan error, len(batch))
+		for _, client := range batch {
+			eg.Go(func() error {
+				errChan <- nb.submitPoetChallenge(ctx, nodeID, deadline, client, prefix, challenge, signature)
+				return nil
+			})
 		}
+		eg.Wait()
+		close(errChan)
+
+		for err := range errChan {
+			if err == nil {
+				submitted++
+				allInvalid = false
+				continue
+			}
 
-		nb.log.Warn("failed to submit challenge to poet", zap.Error(err), log.ZShortStringer("smesherID", nodeID))
-		if !errors.Is(err, ErrInvalidRequest) {
-			allInvalid = false
+			nb.log.Warn("failed to submit challenge to poet", zap.Error(err), log.ZShortStringer("smesherID", nodeID))
+			if !errors.Is(err, ErrInvalidRequest) {
+				allInvalid = false
+			}
 		}
 	}
 	if allInvalid {
 		nb.log.Warn("all poet submits were too late. ATX challenge expires", log.ZShortStringer("smesherID", nodeID))
 		return ErrATXChallengeExpired
 	}
+	if submitted < quorum {
+		nb.log.Warn("challenge submitted to fewer poets than required",
+			zap.Int("submitted", submitted),
+			zap.Int("required", quorum),
+			log.ZShortStringer("smesherID", nodeID),
+		)
+	}
 	return nil
 }
 
-func (nb *NIPostBuilder) getPoetClient(ctx context.Context, address string) poetClient {
+func (nb *NIPostBuilder) getPoetClient(ctx context.Context, address string) PoetClient {
 	for _, client := range nb.poetProvers {
 		if address == client.Address() {
 			return client
@@ -498,18 +544,23 @@ func (nb *NIPostBuilder) getBestProof(
 
 			getProofsCtx, cancel := withConditionalTimeout(ctx, nb.poetCfg.RequestTimeout)
 			defer cancel()
+			startTime := time.Now()
 			proof, members, err := client.Proof(getProofsCtx, round)
+			latency := time.Since(startTime)
 			if err != nil {
+				nb.recordPoetRequest(poetstats.AddProof, nodeID, client.Address(), err, latency)
 				logger.Warn("failed to get proof from poet", zap.Error(err))
 				return nil
 			}
 
 			if err := nb.poetDB.ValidateAndStore(ctx, proof); err != nil && !errors.Is(err, ErrObjectExists) {
+				nb.recordPoetRequest(poetstats.AddProof, nodeID, client.Address(), err, latency)
 				logger.Warn("failed to validate and store proof", zap.Error(err), zap.Object("proof", proof))
 				return nil
 			}
 
 			membership, err := constructMerkleProof(challenge, members)
+			nb.recordPoetRequest(poetstats.AddProof, nodeID, client.Address(), err, latency)
 			if err != nil {
 				logger.Warn("failed to construct merkle proof", zap.Error(err))
 				return nil
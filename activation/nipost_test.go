@@ -24,15 +24,15 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
 )
 
-func defaultPoetServiceMock(ctrl *gomock.Controller, id []byte, address string) *MockpoetClient {
-	poetClient := NewMockpoetClient(ctrl)
-	poetClient.EXPECT().
+func defaultPoetServiceMock(ctrl *gomock.Controller, id []byte, address string) *MockPoetClient {
+	PoetClient := NewMockPoetClient(ctrl)
+	PoetClient.EXPECT().
 		Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		AnyTimes().
 		Return(&types.PoetRound{}, nil)
-	poetClient.EXPECT().PowParams(gomock.Any()).AnyTimes().Return(&PoetPowParams{}, nil)
-	poetClient.EXPECT().Address().AnyTimes().Return(address).AnyTimes()
-	return poetClient
+	PoetClient.EXPECT().PowParams(gomock.Any()).AnyTimes().Return(&PoetPowParams{}, nil)
+	PoetClient.EXPECT().Address().AnyTimes().Return(address).AnyTimes()
+	return PoetClient
 }
 
 func defaultLayerClockMock(ctrl *gomock.Controller) *MocklayerClock {
@@ -407,7 +407,7 @@ func Test_NIPostBuilder_WithMocks(t *testing.T) {
 		zaptest.NewLogger(t),
 		PoetConfig{},
 		mclock,
-		withPoetClients([]poetClient{poetProvider}),
+		withPoetClients([]PoetClient{poetProvider}),
 	)
 	require.NoError(t, err)
 
@@ -447,7 +447,7 @@ func TestPostSetup(t *testing.T) {
 		zaptest.NewLogger(t),
 		PoetConfig{},
 		mclock,
-		withPoetClients([]poetClient{poetProvider}),
+		withPoetClients([]PoetClient{poetProvider}),
 	)
 	require.NoError(t, err)
 
@@ -504,7 +504,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 		zaptest.NewLogger(t),
 		PoetConfig{},
 		mclock,
-		withPoetClients([]poetClient{poetProver}),
+		withPoetClients([]PoetClient{poetProver}),
 	)
 	require.NoError(t, err)
 
@@ -524,7 +524,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 		zaptest.NewLogger(t),
 		PoetConfig{},
 		mclock,
-		withPoetClients([]poetClient{poetProver}),
+		withPoetClients([]PoetClient{poetProver}),
 	)
 	require.NoError(t, err)
 
@@ -544,7 +544,7 @@ func TestNIPostBuilder_BuildNIPost(t *testing.T) {
 		zaptest.NewLogger(t),
 		PoetConfig{},
 		mclock,
-		withPoetClients([]poetClient{poetProver}),
+		withPoetClients([]PoetClient{poetProver}),
 	)
 	require.NoError(t, err)
 	postClient.EXPECT().Proof(gomock.Any(), gomock.Any()).Return(
@@ -586,9 +586,9 @@ func TestNIPostBuilder_ManyPoETs_SubmittingChallenge_DeadlineReached(t *testing.
 	poetDb.EXPECT().ValidateAndStore(gomock.Any(), gomock.Any()).Return(nil)
 	mclock := defaultLayerClockMock(ctrl)
 
-	poets := make([]poetClient, 0, 2)
+	poets := make([]PoetClient, 0, 2)
 	{
-		poet := NewMockpoetClient(ctrl)
+		poet := NewMockPoetClient(ctrl)
 		poet.EXPECT().
 			Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			DoAndReturn(func(
@@ -607,7 +607,7 @@ func TestNIPostBuilder_ManyPoETs_SubmittingChallenge_DeadlineReached(t *testing.
 		poets = append(poets, poet)
 	}
 	{
-		poet := NewMockpoetClient(ctrl)
+		poet := NewMockPoetClient(ctrl)
 		poet.EXPECT().
 			Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(&types.PoetRound{}, nil)
@@ -676,7 +676,7 @@ func TestNIPostBuilder_ManyPoETs_AllFinished(t *testing.T) {
 	poetDb.EXPECT().ValidateAndStore(gomock.Any(), gomock.Any()).Times(2).Return(nil)
 	mclock := defaultLayerClockMock(ctrl)
 
-	poets := make([]poetClient, 0, 2)
+	poets := make([]PoetClient, 0, 2)
 	{
 		poet := defaultPoetServiceMock(ctrl, []byte("poet0"), "http://localhost:9999")
 		poet.EXPECT().Proof(gomock.Any(), "").Return(proofWorse, []types.Hash32{challenge}, nil)
@@ -735,7 +735,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		poetDb := NewMockpoetDbAPI(ctrl)
 		mclock := defaultLayerClockMock(ctrl)
-		poetProver := NewMockpoetClient(ctrl)
+		poetProver := NewMockPoetClient(ctrl)
 		poetProver.EXPECT().
 			Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(nil, errors.New("test"))
@@ -751,7 +751,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 			zaptest.NewLogger(t),
 			poetCfg,
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 
@@ -764,7 +764,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		poetDb := NewMockpoetDbAPI(ctrl)
 		mclock := defaultLayerClockMock(ctrl)
-		poetProver := NewMockpoetClient(ctrl)
+		poetProver := NewMockPoetClient(ctrl)
 		poetProver.EXPECT().
 			Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			DoAndReturn(func(
@@ -790,7 +790,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 			zaptest.NewLogger(t),
 			poetCfg,
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 		nipst, err := nb.BuildNIPost(context.Background(), sig, postGenesisEpoch+2, challenge)
@@ -814,7 +814,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 			zaptest.NewLogger(t),
 			poetCfg,
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 		nipst, err := nb.BuildNIPost(context.Background(), sig, postGenesisEpoch+2, challenge)
@@ -841,7 +841,7 @@ func TestNIPSTBuilder_PoetUnstable(t *testing.T) {
 			zaptest.NewLogger(t),
 			poetCfg,
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 		nipst, err := nb.BuildNIPost(context.Background(), sig, postGenesisEpoch+2, challenge)
@@ -866,7 +866,7 @@ func TestNIPoSTBuilder_StaleChallenge(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		poetDb := NewMockpoetDbAPI(ctrl)
 		mclock := NewMocklayerClock(ctrl)
-		poetProver := NewMockpoetClient(ctrl)
+		poetProver := NewMockPoetClient(ctrl)
 		poetProver.EXPECT().Address().Return("http://localhost:9999")
 		mclock.EXPECT().LayerToTime(gomock.Any()).DoAndReturn(
 			func(got types.LayerID) time.Time {
@@ -883,7 +883,7 @@ func TestNIPoSTBuilder_StaleChallenge(t *testing.T) {
 			zaptest.NewLogger(t),
 			PoetConfig{},
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 
@@ -896,7 +896,7 @@ func TestNIPoSTBuilder_StaleChallenge(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		poetDb := NewMockpoetDbAPI(ctrl)
 		mclock := NewMocklayerClock(ctrl)
-		poetProver := NewMockpoetClient(ctrl)
+		poetProver := NewMockPoetClient(ctrl)
 		poetProver.EXPECT().Address().Return("http://localhost:9999")
 		mclock.EXPECT().LayerToTime(gomock.Any()).DoAndReturn(
 			func(got types.LayerID) time.Time {
@@ -913,7 +913,7 @@ func TestNIPoSTBuilder_StaleChallenge(t *testing.T) {
 			zaptest.NewLogger(t),
 			PoetConfig{},
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 
@@ -940,7 +940,7 @@ func TestNIPoSTBuilder_StaleChallenge(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		poetDb := NewMockpoetDbAPI(ctrl)
 		mclock := NewMocklayerClock(ctrl)
-		poetProver := NewMockpoetClient(ctrl)
+		poetProver := NewMockPoetClient(ctrl)
 		poetProver.EXPECT().Address().Return("http://localhost:9999")
 		mclock.EXPECT().LayerToTime(gomock.Any()).DoAndReturn(
 			func(got types.LayerID) time.Time {
@@ -957,7 +957,7 @@ func TestNIPoSTBuilder_StaleChallenge(t *testing.T) {
 			zaptest.NewLogger(t),
 			PoetConfig{},
 			mclock,
-			withPoetClients([]poetClient{poetProver}),
+			withPoetClients([]PoetClient{poetProver}),
 		)
 		require.NoError(t, err)
 
@@ -1006,7 +1006,7 @@ func TestNIPoSTBuilder_Continues_After_Interrupted(t *testing.T) {
 
 	buildCtx, cancel := context.WithCancel(context.Background())
 
-	poet := NewMockpoetClient(ctrl)
+	poet := NewMockPoetClient(ctrl)
 	poet.EXPECT().
 		Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(func(
@@ -1047,7 +1047,7 @@ func TestNIPoSTBuilder_Continues_After_Interrupted(t *testing.T) {
 		zaptest.NewLogger(t),
 		poetCfg,
 		mclock,
-		withPoetClients([]poetClient{poet}),
+		withPoetClients([]PoetClient{poet}),
 	)
 	require.NoError(t, err)
 
@@ -1129,9 +1129,9 @@ func TestNIPostBuilder_Mainnet_Poet_Workaround(t *testing.T) {
 
 			challenge := types.RandomHash()
 			ctrl := gomock.NewController(t)
-			poets := make([]poetClient, 0, 2)
+			poets := make([]PoetClient, 0, 2)
 			{
-				poetProvider := NewMockpoetClient(ctrl)
+				poetProvider := NewMockPoetClient(ctrl)
 				poetProvider.EXPECT().Address().Return(tc.from)
 				poetProvider.EXPECT().PowParams(gomock.Any()).AnyTimes().Return(&PoetPowParams{}, nil)
 
@@ -1149,7 +1149,7 @@ func TestNIPostBuilder_Mainnet_Poet_Workaround(t *testing.T) {
 
 			{
 				// PoET fails submission
-				poetProvider := NewMockpoetClient(ctrl)
+				poetProvider := NewMockPoetClient(ctrl)
 				poetProvider.EXPECT().Address().Return(tc.to)
 
 				// proof is still fetched from PoET
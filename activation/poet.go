@@ -160,28 +160,12 @@ func (c *HTTPPoetClient) Submit(
 	nodeID types.NodeID,
 	pow PoetPoW,
 ) (*types.PoetRound, error) {
-	request := rpcapi.SubmitRequest{
-		Prefix:    prefix,
-		Challenge: challenge,
-		Signature: signature.Bytes(),
-		Pubkey:    nodeID.Bytes(),
-		Nonce:     pow.Nonce,
-		PowParams: &rpcapi.PowParams{
-			Challenge:  pow.Params.Challenge,
-			Difficulty: uint32(pow.Params.Difficulty),
-		},
-		Deadline: timestamppb.New(deadline),
-	}
+	request := submitRequest(deadline, prefix, challenge, signature, nodeID, pow)
 	resBody := rpcapi.SubmitResponse{}
-	if err := c.req(ctx, http.MethodPost, "/v1/submit", &request, &resBody); err != nil {
+	if err := c.req(ctx, http.MethodPost, "/v1/submit", request, &resBody); err != nil {
 		return nil, fmt.Errorf("submitting challenge: %w", err)
 	}
-	roundEnd := time.Time{}
-	if resBody.RoundEnd != nil {
-		roundEnd = time.Now().Add(resBody.RoundEnd.AsDuration())
-	}
-
-	return &types.PoetRound{ID: resBody.RoundId, End: roundEnd}, nil
+	return submitRound(&resBody), nil
 }
 
 // PoetServiceID returns the public key of the PoET proving service.
@@ -196,9 +180,42 @@ func (c *HTTPPoetClient) Proof(ctx context.Context, roundID string) (*types.Poet
 		return nil, nil, fmt.Errorf("getting proof: %w", err)
 	}
 
-	p := resBody.Proof.GetProof()
+	return proofFromResponse(roundID, &resBody)
+}
+
+func submitRequest(
+	deadline time.Time,
+	prefix, challenge []byte,
+	signature types.EdSignature,
+	nodeID types.NodeID,
+	pow PoetPoW,
+) *rpcapi.SubmitRequest {
+	return &rpcapi.SubmitRequest{
+		Prefix:    prefix,
+		Challenge: challenge,
+		Signature: signature.Bytes(),
+		Pubkey:    nodeID.Bytes(),
+		Nonce:     pow.Nonce,
+		PowParams: &rpcapi.PowParams{
+			Challenge:  pow.Params.Challenge,
+			Difficulty: uint32(pow.Params.Difficulty),
+		},
+		Deadline: timestamppb.New(deadline),
+	}
+}
+
+func submitRound(res *rpcapi.SubmitResponse) *types.PoetRound {
+	roundEnd := time.Time{}
+	if res.RoundEnd != nil {
+		roundEnd = time.Now().Add(res.RoundEnd.AsDuration())
+	}
+	return &types.PoetRound{ID: res.RoundId, End: roundEnd}
+}
+
+func proofFromResponse(roundID string, res *rpcapi.ProofResponse) (*types.PoetProofMessage, []types.Hash32, error) {
+	p := res.Proof.GetProof()
 
-	pMembers := resBody.Proof.GetMembers()
+	pMembers := res.Proof.GetMembers()
 	members := make([]types.Hash32, len(pMembers))
 	for i, m := range pMembers {
 		copy(members[i][:], m)
@@ -215,9 +232,9 @@ func (c *HTTPPoetClient) Proof(ctx context.Context, roundID string) (*types.Poet
 				ProvenLeaves: p.GetProvenLeaves(),
 				ProofNodes:   p.GetProofNodes(),
 			},
-			LeafCount: resBody.Proof.GetLeaves(),
+			LeafCount: res.Proof.GetLeaves(),
 		},
-		PoetServiceID: resBody.Pubkey,
+		PoetServiceID: res.Pubkey,
 		RoundID:       roundID,
 		Statement:     types.BytesToHash(statement),
 	}
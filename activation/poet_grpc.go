@@ -0,0 +1,184 @@
+package activation
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	rpcapi "github.com/spacemeshos/poet/release/proto/go/rpc/api/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// GRPCPoetClient is a PoetClient that uses the native gRPC api of the PoET service.
+//
+// Addresses are formatted as grpc://host:port for plaintext connections and grpcs://host:port for TLS.
+type GRPCPoetClient struct {
+	address string
+	conn    *grpc.ClientConn
+	client  rpcapi.PoetServiceClient
+	logger  *zap.Logger
+
+	retryMax     int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+// NewGRPCPoetClient returns new instance of GRPCPoetClient connecting to the specified address.
+// The connection is established lazily on the first request.
+func NewGRPCPoetClient(
+	server types.PoetServer,
+	cfg PoetConfig,
+	logger *zap.Logger,
+	opts ...grpc.DialOption,
+) (*GRPCPoetClient, error) {
+	scheme, target, ok := strings.Cut(server.Address, "://")
+	if !ok || target == "" {
+		return nil, fmt.Errorf("invalid grpc address %q", server.Address)
+	}
+	switch scheme {
+	case "grpc":
+		opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	case "grpcs":
+		opts = append([]grpc.DialOption{
+			grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})),
+		}, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q for grpc poet client", scheme)
+	}
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating grpc client for %s: %w", server.Address, err)
+	}
+	logger.Info(
+		"created grpc poet client",
+		zap.String("address", server.Address),
+		zap.Binary("pubkey", server.Pubkey.Bytes()),
+		zap.Int("max retries", cfg.MaxRequestRetries),
+		zap.Duration("min retry wait", cfg.RequestRetryDelay),
+		zap.Duration("max retry wait", 2*cfg.RequestRetryDelay),
+	)
+	return &GRPCPoetClient{
+		address:      server.Address,
+		conn:         conn,
+		client:       rpcapi.NewPoetServiceClient(conn),
+		logger:       logger,
+		retryMax:     cfg.MaxRequestRetries,
+		retryWaitMin: cfg.RequestRetryDelay,
+		retryWaitMax: 2 * cfg.RequestRetryDelay,
+	}, nil
+}
+
+func (c *GRPCPoetClient) Address() string {
+	return c.address
+}
+
+// Close closes the connection to the PoET service.
+func (c *GRPCPoetClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *GRPCPoetClient) PowParams(ctx context.Context) (*PoetPowParams, error) {
+	var res *rpcapi.PowParamsResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		res, err = c.client.PowParams(ctx, &rpcapi.PowParamsRequest{})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying PoW params: %w", err)
+	}
+	return &PoetPowParams{
+		Challenge:  res.GetPowParams().GetChallenge(),
+		Difficulty: uint(res.GetPowParams().GetDifficulty()),
+	}, nil
+}
+
+// Submit registers a challenge in the proving service current open round.
+func (c *GRPCPoetClient) Submit(
+	ctx context.Context,
+	deadline time.Time,
+	prefix, challenge []byte,
+	signature types.EdSignature,
+	nodeID types.NodeID,
+	pow PoetPoW,
+) (*types.PoetRound, error) {
+	request := submitRequest(deadline, prefix, challenge, signature, nodeID, pow)
+	var res *rpcapi.SubmitResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		res, err = c.client.Submit(ctx, request)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("submitting challenge: %w", err)
+	}
+	return submitRound(res), nil
+}
+
+// Proof returns the proof for the given round ID.
+func (c *GRPCPoetClient) Proof(ctx context.Context, roundID string) (*types.PoetProofMessage, []types.Hash32, error) {
+	var res *rpcapi.ProofResponse
+	err := c.call(ctx, func(ctx context.Context) (err error) {
+		res, err = c.client.Proof(ctx, &rpcapi.ProofRequest{RoundId: roundID})
+		return err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting proof: %w", err)
+	}
+	return proofFromResponse(roundID, res)
+}
+
+// call executes the request and retries it with the same policy as the http client:
+// requests are retried if the service is unavailable, failed internally or the resource is not found yet.
+func (c *GRPCPoetClient) call(ctx context.Context, request func(context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := request(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return errors.Join(ctx.Err(), err)
+		}
+		code := status.Code(err)
+		retry := code == codes.Unavailable || code == codes.NotFound ||
+			code == codes.Internal || code == codes.Unknown
+		if !retry || attempt >= c.retryMax {
+			return grpcPoetError(err)
+		}
+		wait := retryablehttp.LinearJitterBackoff(c.retryWaitMin, c.retryWaitMax, attempt+1, nil)
+		c.logger.Debug("retrying poet request",
+			zap.String("address", c.address),
+			zap.Int("attempt", attempt+1),
+			zap.Duration("wait", wait),
+			zap.Error(err),
+		)
+		select {
+		case <-ctx.Done():
+			return errors.Join(ctx.Err(), err)
+		case <-time.After(wait):
+		}
+	}
+}
+
+// grpcPoetError maps the status of the response to the same errors that are returned by the http client.
+func grpcPoetError(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %w", ErrNotFound, err)
+	case codes.Unavailable:
+		return fmt.Errorf("%w: %w", ErrUnavailable, err)
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	default:
+		return err
+	}
+}
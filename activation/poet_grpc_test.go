@@ -0,0 +1,214 @@
+package activation
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/spacemeshos/poet/shared"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/activation/poettest"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func startMockPoet(tb testing.TB, cfg poettest.Config) *poettest.Server {
+	tb.Helper()
+	srv, err := poettest.New(cfg, zaptest.NewLogger(tb))
+	require.NoError(tb, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var eg errgroup.Group
+	eg.Go(func() error {
+		return srv.Run(ctx)
+	})
+	tb.Cleanup(func() {
+		cancel()
+		require.NoError(tb, eg.Wait())
+	})
+	return srv
+}
+
+func mockPoetConfig() poettest.Config {
+	return poettest.Config{
+		EpochDuration: 500 * time.Millisecond,
+		CycleGap:      100 * time.Millisecond,
+		ProofDuration: 100 * time.Millisecond,
+		PowDifficulty: 4,
+	}
+}
+
+func TestNewPoetClient(t *testing.T) {
+	cfg := DefaultPoetConfig()
+	for _, tc := range []struct {
+		address string
+		grpc    bool
+		err     bool
+	}{
+		{address: "http://poet:8080"},
+		{address: "https://poet"},
+		{address: "poet"},
+		{address: "grpc://poet:9090", grpc: true},
+		{address: "grpcs://poet:443", grpc: true},
+		{address: "grpc://", err: true},
+		{address: "udp://poet:8080", err: true},
+	} {
+		t.Run(tc.address, func(t *testing.T) {
+			client, err := NewPoetClient(types.PoetServer{Address: tc.address}, cfg, zap.NewNop())
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tc.grpc {
+				require.IsType(t, &GRPCPoetClient{}, client)
+				require.Equal(t, tc.address, client.Address())
+				require.NoError(t, client.(*GRPCPoetClient).Close())
+			} else {
+				require.IsType(t, &HTTPPoetClient{}, client)
+			}
+		})
+	}
+
+	t.Run("registered transport", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		expected := NewMockPoetClient(ctrl)
+		RegisterPoetTransport("test", func(server types.PoetServer, _ PoetConfig, _ *zap.Logger) (PoetClient, error) {
+			require.Equal(t, "test://poet", server.Address)
+			return expected, nil
+		})
+		client, err := NewPoetClient(types.PoetServer{Address: "test://poet"}, cfg, zap.NewNop())
+		require.NoError(t, err)
+		require.Equal(t, expected, client)
+	})
+}
+
+func TestPoetClient_MockServer(t *testing.T) {
+	srv := startMockPoet(t, mockPoetConfig())
+	cfg := PoetConfig{RequestRetryDelay: 50 * time.Millisecond, MaxRequestRetries: 40}
+
+	for _, server := range []types.PoetServer{srv.GRPC(), srv.REST()} {
+		t.Run(server.Address, func(t *testing.T) {
+			t.Parallel()
+			client, err := NewPoetClient(server, cfg, zaptest.NewLogger(t))
+			require.NoError(t, err)
+			if closer, ok := client.(io.Closer); ok {
+				t.Cleanup(func() { require.NoError(t, closer.Close()) })
+			}
+
+			params, err := client.PowParams(context.Background())
+			require.NoError(t, err)
+			require.EqualValues(t, 4, params.Difficulty)
+
+			sig, err := signing.NewEdSigner()
+			require.NoError(t, err)
+			challenge := types.RandomHash()
+			prefix := bytes.Join([][]byte{sig.Prefix(), {byte(signing.POET)}}, nil)
+			nonce, err := shared.FindSubmitPowNonce(
+				context.Background(),
+				params.Challenge,
+				challenge.Bytes(),
+				sig.NodeID().Bytes(),
+				params.Difficulty,
+			)
+			require.NoError(t, err)
+			_, err = client.Submit(
+				context.Background(),
+				time.Now().Add(time.Minute),
+				prefix,
+				challenge.Bytes(),
+				sig.Sign(signing.POET, challenge.Bytes()),
+				sig.NodeID(),
+				PoetPoW{Params: PoetPowParams{Challenge: params.Challenge}},
+			)
+			require.ErrorIs(t, err, ErrInvalidRequest, "pow params don't match")
+
+			round, err := client.Submit(
+				context.Background(),
+				time.Now().Add(time.Minute),
+				prefix,
+				challenge.Bytes(),
+				sig.Sign(signing.POET, challenge.Bytes()),
+				sig.NodeID(),
+				PoetPoW{Nonce: nonce, Params: *params},
+			)
+			require.NoError(t, err)
+
+			_, err = client.Submit(
+				context.Background(),
+				time.Now().Add(time.Minute),
+				prefix,
+				challenge.Bytes(),
+				types.EmptyEdSignature,
+				sig.NodeID(),
+				PoetPoW{Nonce: nonce, Params: *params},
+			)
+			require.ErrorIs(t, err, ErrInvalidRequest)
+
+			proof, members, err := client.Proof(context.Background(), round.ID)
+			require.NoError(t, err)
+			require.Equal(t, []types.Hash32{challenge}, members)
+			require.Equal(t, srv.PublicKey(), proof.PoetServiceID)
+
+			db := NewPoetDb(sql.InMemory(), logtest.New(t))
+			require.NoError(t, db.Validate(proof.Statement[:], proof.PoetProof, proof.PoetServiceID, round.ID,
+				types.EmptyEdSignature))
+		})
+	}
+}
+
+func TestGRPCPoetClient_NotFound(t *testing.T) {
+	srv := startMockPoet(t, mockPoetConfig())
+	client, err := NewGRPCPoetClient(srv.GRPC(), PoetConfig{}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	_, _, err = client.Proof(context.Background(), "100")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestNIPostBuilder_MockPoet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	grpcPoet := startMockPoet(t, mockPoetConfig())
+	restPoet := startMockPoet(t, mockPoetConfig())
+	db := localsql.InMemory()
+	poetDb := NewPoetDb(sql.InMemory(), logtest.New(t))
+
+	nb, err := NewNIPostBuilder(
+		db,
+		poetDb,
+		NewMockpostService(ctrl),
+		[]types.PoetServer{grpcPoet.GRPC(), restPoet.REST()},
+		zaptest.NewLogger(t),
+		PoetConfig{RequestRetryDelay: 50 * time.Millisecond, MaxRequestRetries: 40},
+		defaultLayerClockMock(ctrl),
+	)
+	require.NoError(t, err)
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	challenge := types.RandomHash()
+	require.NoError(t, nb.submitPoetChallenges(context.Background(), sig, time.Now().Add(time.Minute), challenge.Bytes()))
+
+	ref, membership, err := nb.getBestProof(context.Background(), sig.NodeID(), challenge, postGenesisEpoch)
+	require.NoError(t, err)
+	require.NotNil(t, membership)
+	_, statement, err := poetDb.GetProof(ref)
+	require.NoError(t, err)
+	require.NoError(t, validateMerkleProof(challenge[:], membership, statement[:]))
+
+	require.NoError(t, nb.Close())
+	_, err = nb.getPoetClient(context.Background(), grpcPoet.GRPC().Address).PowParams(context.Background())
+	require.Equal(t, codes.Canceled, status.Code(err), "connection is closed")
+}
@@ -0,0 +1,130 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/poetstats"
+)
+
+// backoff returns for how long the PoET service is tried last after the number of consecutive failures.
+func (c PoetConfig) backoff(failures uint32) time.Duration {
+	if c.FailureBackoff == 0 || failures == 0 {
+		return 0
+	}
+	backoff := c.FailureBackoff
+	for i := uint32(1); i < failures; i++ {
+		if c.MaxFailureBackoff != 0 && backoff >= c.MaxFailureBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if c.MaxFailureBackoff != 0 {
+		backoff = min(backoff, c.MaxFailureBackoff)
+	}
+	return backoff
+}
+
+// PoetStats returns stats of requests made for the identity to every configured PoET service, ordered by address.
+func (nb *NIPostBuilder) PoetStats(nodeID types.NodeID) ([]poetstats.Stats, error) {
+	recorded, err := poetstats.ByIdentity(nb.localDB, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	byAddress := make(map[string]poetstats.Stats, len(recorded))
+	for _, stats := range recorded {
+		byAddress[stats.Address] = stats
+	}
+	rst := make([]poetstats.Stats, 0, len(nb.poetProvers))
+	for address := range nb.poetProvers {
+		stats, exists := byAddress[address]
+		if !exists {
+			stats = poetstats.Stats{NodeID: nodeID, Address: address}
+		}
+		rst = append(rst, stats)
+	}
+	sort.Slice(rst, func(i, j int) bool {
+		return rst[i].Address < rst[j].Address
+	})
+	return rst, nil
+}
+
+// aggregatedPoetStats returns stats of requests to every configured PoET service aggregated over identities.
+func (nb *NIPostBuilder) aggregatedPoetStats() ([]poetstats.Stats, error) {
+	rst := make([]poetstats.Stats, 0, len(nb.poetProvers))
+	for address := range nb.poetProvers {
+		stats, err := poetstats.Get(nb.localDB, address)
+		switch {
+		case errors.Is(err, sql.ErrNotFound):
+			rst = append(rst, poetstats.Stats{Address: address})
+		case err != nil:
+			return nil, err
+		default:
+			rst = append(rst, *stats)
+		}
+	}
+	return rst, nil
+}
+
+// orderedPoets returns PoET clients in the order of preference.
+// Services that are backing off after failures go last, otherwise services are ordered by reliability
+// and by the average latency of submits.
+func (nb *NIPostBuilder) orderedPoets(now time.Time) []PoetClient {
+	stats, err := nb.aggregatedPoetStats()
+	if err != nil {
+		nb.log.Warn("failed to load poet stats", zap.Error(err))
+		stats = stats[:0]
+		for address := range nb.poetProvers {
+			stats = append(stats, poetstats.Stats{Address: address})
+		}
+	}
+	backingOff := func(s *poetstats.Stats) bool {
+		return now.Before(s.LastFailure.Add(nb.poetCfg.backoff(s.ConsecutiveFailures)))
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Address < stats[j].Address
+	})
+	sort.SliceStable(stats, func(i, j int) bool {
+		left, right := &stats[i], &stats[j]
+		if lb, rb := backingOff(left), backingOff(right); lb != rb {
+			return rb
+		}
+		if lr, rr := left.Reliability(), right.Reliability(); lr != rr {
+			return lr > rr
+		}
+		return left.AvgSubmitLatency() < right.AvgSubmitLatency()
+	})
+	clients := make([]PoetClient, 0, len(stats))
+	for _, s := range stats {
+		clients = append(clients, nb.poetProvers[s.Address])
+	}
+	return clients
+}
+
+// recordPoetRequest persists the outcome of the request to the PoET service made for the identity.
+// Requests that were cancelled by the node or rejected as invalid are not caused by the service and are ignored.
+func (nb *NIPostBuilder) recordPoetRequest(
+	add func(sql.Executor, types.NodeID, string, bool, time.Duration, time.Time) error,
+	nodeID types.NodeID,
+	address string,
+	err error,
+	latency time.Duration,
+) {
+	if errors.Is(err, ErrInvalidRequest) || errors.Is(err, context.Canceled) {
+		return
+	}
+	if err := add(nb.localDB, nodeID, address, err == nil, latency, time.Now()); err != nil {
+		nb.log.Warn("failed to record poet stats",
+			zap.String("poet", address),
+			log.ZShortStringer("smesherID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
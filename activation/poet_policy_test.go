@@ -0,0 +1,141 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/poetstats"
+)
+
+func TestPoetConfig_Backoff(t *testing.T) {
+	cfg := PoetConfig{FailureBackoff: time.Minute, MaxFailureBackoff: 5 * time.Minute}
+	require.Zero(t, cfg.backoff(0))
+	require.Equal(t, time.Minute, cfg.backoff(1))
+	require.Equal(t, 2*time.Minute, cfg.backoff(2))
+	require.Equal(t, 4*time.Minute, cfg.backoff(3))
+	require.Equal(t, 5*time.Minute, cfg.backoff(4))
+	require.Equal(t, 5*time.Minute, cfg.backoff(100))
+	require.Zero(t, PoetConfig{}.backoff(3))
+}
+
+func TestNIPostBuilder_SubmitQuorum(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := localsql.InMemory()
+	now := time.Now()
+
+	// a failed recently and backs off, b is reliable, c and d are unknown
+	other := types.RandomNodeID()
+	for range 3 {
+		require.NoError(t, poetstats.AddSubmit(db, other, "a", false, 0, now))
+	}
+	require.NoError(t, poetstats.AddSubmit(db, other, "b", true, time.Second, now))
+	require.NoError(t, poetstats.AddProof(db, other, "b", true, time.Second, now))
+
+	a := defaultPoetServiceMock(ctrl, nil, "a")
+	b := defaultPoetServiceMock(ctrl, nil, "b")
+	c := NewMockPoetClient(ctrl)
+	c.EXPECT().Address().Return("c").AnyTimes()
+	c.EXPECT().PowParams(gomock.Any()).Return(&PoetPowParams{}, nil)
+	c.EXPECT().
+		Submit(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("unavailable"))
+	d := defaultPoetServiceMock(ctrl, nil, "d")
+
+	nb, err := NewNIPostBuilder(
+		db,
+		NewMockpoetDbAPI(ctrl),
+		NewMockpostService(ctrl),
+		nil,
+		zaptest.NewLogger(t),
+		PoetConfig{MinSubmissions: 2, FailureBackoff: time.Hour},
+		defaultLayerClockMock(ctrl),
+		withPoetClients([]PoetClient{a, b, c, d}),
+	)
+	require.NoError(t, err)
+
+	ordered := nb.orderedPoets(now)
+	require.Equal(t, b, ordered[0])
+	require.ElementsMatch(t, []PoetClient{c, d}, ordered[1:3])
+	require.Equal(t, a, ordered[3])
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	challenge := types.RandomHash()
+	require.NoError(t, nb.submitPoetChallenges(context.Background(), sig, now.Add(time.Hour), challenge.Bytes()))
+
+	registrations, err := nipost.PoetRegistrations(db, sig.NodeID())
+	require.NoError(t, err)
+	require.Len(t, registrations, 2)
+
+	stats, err := nb.PoetStats(sig.NodeID())
+	require.NoError(t, err)
+	require.Len(t, stats, 4)
+	byAddress := map[string]poetstats.Stats{}
+	for _, s := range stats {
+		require.Equal(t, sig.NodeID(), s.NodeID)
+		byAddress[s.Address] = s
+	}
+	require.Equal(t, uint32(1), byAddress["b"].SubmitSuccess)
+	require.Equal(t, uint32(1), byAddress["c"].SubmitFailure)
+	require.Equal(t, uint32(1), byAddress["c"].ConsecutiveFailures)
+	// d is tried either in the first batch with c, or after c failed
+	require.Equal(t, uint32(1), byAddress["d"].SubmitSuccess)
+	// a is not needed to reach quorum
+	require.Zero(t, byAddress["a"].SubmitSuccess)
+	require.Zero(t, byAddress["a"].SubmitFailure)
+
+	aggregated, err := poetstats.Get(db, "b")
+	require.NoError(t, err)
+	require.Equal(t, uint32(2), aggregated.SubmitSuccess)
+}
+
+func TestNIPostBuilder_SubmitQuorum_Failover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := localsql.InMemory()
+
+	failing := NewMockPoetClient(ctrl)
+	failing.EXPECT().Address().Return("failing").AnyTimes()
+	failing.EXPECT().PowParams(gomock.Any()).Return(nil, errors.New("unavailable"))
+	backup := defaultPoetServiceMock(ctrl, nil, "backup")
+	failed := time.Now().Add(-2 * time.Hour)
+	require.NoError(t, poetstats.AddSubmit(db, types.RandomNodeID(), "backup", false, 0, failed))
+
+	nb, err := NewNIPostBuilder(
+		db,
+		NewMockpoetDbAPI(ctrl),
+		NewMockpostService(ctrl),
+		nil,
+		zaptest.NewLogger(t),
+		PoetConfig{MinSubmissions: 1, FailureBackoff: time.Hour},
+		defaultLayerClockMock(ctrl),
+		withPoetClients([]PoetClient{failing, backup}),
+	)
+	require.NoError(t, err)
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	challenge := types.RandomHash()
+	require.NoError(t, nb.submitPoetChallenges(context.Background(), sig, time.Now().Add(time.Hour), challenge.Bytes()))
+
+	registrations, err := nipost.PoetRegistrations(db, sig.NodeID())
+	require.NoError(t, err)
+	require.Len(t, registrations, 1)
+	require.Equal(t, "backup", registrations[0].Address)
+
+	stats, err := poetstats.Get(db, "failing")
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), stats.SubmitFailure)
+	stats, err = poetstats.Get(db, "backup")
+	require.NoError(t, err)
+	require.Zero(t, stats.ConsecutiveFailures)
+}
@@ -0,0 +1,60 @@
+package activation
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// PoetClientFactory creates a client of the PoET service for the server.
+type PoetClientFactory func(server types.PoetServer, cfg PoetConfig, logger *zap.Logger) (PoetClient, error)
+
+var poetTransports = struct {
+	mu        sync.RWMutex
+	factories map[string]PoetClientFactory
+}{
+	factories: map[string]PoetClientFactory{
+		"http":  newHTTPPoetClient,
+		"https": newHTTPPoetClient,
+		"grpc":  newGRPCPoetClient,
+		"grpcs": newGRPCPoetClient,
+	},
+}
+
+func newHTTPPoetClient(server types.PoetServer, cfg PoetConfig, logger *zap.Logger) (PoetClient, error) {
+	return NewHTTPPoetClient(server, cfg, WithLogger(logger))
+}
+
+func newGRPCPoetClient(server types.PoetServer, cfg PoetConfig, logger *zap.Logger) (PoetClient, error) {
+	return NewGRPCPoetClient(server, cfg, logger)
+}
+
+// RegisterPoetTransport registers the factory for the PoET servers with the scheme in the address,
+// replacing the factory that was registered for the scheme before.
+//
+// Transports for http(s) and grpc(s) schemes are registered by default.
+func RegisterPoetTransport(scheme string, factory PoetClientFactory) {
+	poetTransports.mu.Lock()
+	defer poetTransports.mu.Unlock()
+	poetTransports.factories[scheme] = factory
+}
+
+// NewPoetClient creates a client for the server with the transport that is registered for the scheme
+// of the server address. Addresses without a scheme use http.
+func NewPoetClient(server types.PoetServer, cfg PoetConfig, logger *zap.Logger) (PoetClient, error) {
+	scheme := "http"
+	if prefix, _, ok := strings.Cut(server.Address, "://"); ok {
+		scheme = prefix
+	}
+	poetTransports.mu.RLock()
+	factory, exist := poetTransports.factories[scheme]
+	poetTransports.mu.RUnlock()
+	if !exist {
+		return nil, fmt.Errorf("no poet transport for scheme %q in %s", scheme, server.Address)
+	}
+	return factory(server, cfg, logger)
+}
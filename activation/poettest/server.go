@@ -0,0 +1,388 @@
+// Package poettest implements an in-memory PoET service for tests and local networks.
+//
+// The server follows the round schedule of the PoET service: round N accepts registrations until
+// Genesis + PhaseShift + N*EpochDuration, and then executes for EpochDuration - CycleGap. Proof of the round
+// is available after the execution finished. The api is served over gRPC and over REST, so that nodes can use
+// either transport.
+package poettest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/spacemeshos/merkle-tree"
+	"github.com/spacemeshos/poet/hash"
+	"github.com/spacemeshos/poet/prover"
+	rpcapi "github.com/spacemeshos/poet/release/proto/go/rpc/api/v1"
+	"github.com/spacemeshos/poet/shared"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// Prover generates the proof of sequential work for the membership root of the round.
+// The proof must be generated before the deadline.
+type Prover func(ctx context.Context, root []byte, deadline time.Time) (*shared.MerkleProof, uint64, error)
+
+// WorkProver executes the sequential work in the same way as the PoET service until the deadline.
+// Temporary files are created in the directory, or in the default directory for temporary files if it is empty.
+func WorkProver(dir string) Prover {
+	return func(ctx context.Context, root []byte, deadline time.Time) (*shared.MerkleProof, uint64, error) {
+		datadir, err := os.MkdirTemp(dir, "poettest")
+		if err != nil {
+			return nil, 0, err
+		}
+		defer os.RemoveAll(datadir)
+		leaves, proof, err := prover.GenerateProofWithoutPersistency(
+			ctx,
+			prover.TreeConfig{Datadir: datadir},
+			hash.GenLabelHashFunc(root),
+			hash.GenMerkleHashFunc(root),
+			deadline,
+			shared.T,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		return proof, leaves, nil
+	}
+}
+
+// Config of the server.
+type Config struct {
+	// Genesis is the reference time for the rounds, usually the genesis of the network.
+	Genesis       time.Time
+	EpochDuration time.Duration
+	PhaseShift    time.Duration
+	CycleGap      time.Duration
+
+	// ProofDuration limits the execution of the round, so that proofs are available earlier and are cheaper
+	// to generate. If zero the round executes until its end.
+	ProofDuration time.Duration
+	// Prover generates proofs, WorkProver by default.
+	Prover Prover
+	// PowDifficulty is the number of leading zero bits required in the PoW hash of the registration.
+	PowDifficulty uint
+
+	// GRPCListener and RESTListener are addresses for the apis, 127.0.0.1:0 by default.
+	GRPCListener string
+	RESTListener string
+}
+
+func (c *Config) roundStart(round uint) time.Time {
+	return c.Genesis.Add(c.PhaseShift).Add(c.EpochDuration * time.Duration(round))
+}
+
+func (c *Config) roundEnd(round uint) time.Time {
+	return c.roundStart(round).Add(c.EpochDuration).Add(-c.CycleGap)
+}
+
+// openRound is the round that accepts registrations at the time.
+func (c *Config) openRound(now time.Time) uint {
+	since := now.Sub(c.Genesis)
+	if since < c.PhaseShift {
+		return 0
+	}
+	return uint((since-c.PhaseShift)/c.EpochDuration) + 1
+}
+
+type registrations struct {
+	challenges map[string][]byte
+	members    [][]byte
+}
+
+// Server is an in-memory PoET service.
+type Server struct {
+	cfg    Config
+	logger *zap.Logger
+	key    ed25519.PrivateKey
+	pow    []byte
+
+	grpcListener net.Listener
+	restListener net.Listener
+
+	mu sync.Mutex
+	// next is the first round that wasn't closed for registrations.
+	next   uint
+	rounds map[uint]*registrations
+	proofs map[string]*rpcapi.ProofResponse
+}
+
+// New creates the server and starts listening for connections. Requests are served after Run is called.
+func New(cfg Config, logger *zap.Logger) (*Server, error) {
+	if cfg.EpochDuration <= 0 {
+		return nil, errors.New("epoch duration must be positive")
+	}
+	if cfg.CycleGap < 0 || cfg.CycleGap >= cfg.EpochDuration {
+		return nil, fmt.Errorf("cycle gap %v must be in [0, %v)", cfg.CycleGap, cfg.EpochDuration)
+	}
+	if cfg.Genesis.IsZero() {
+		cfg.Genesis = time.Now()
+	}
+	if cfg.Prover == nil {
+		cfg.Prover = WorkProver("")
+	}
+	if cfg.GRPCListener == "" {
+		cfg.GRPCListener = "127.0.0.1:0"
+	}
+	if cfg.RESTListener == "" {
+		cfg.RESTListener = "127.0.0.1:0"
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate key: %w", err)
+	}
+	pow := make([]byte, 32)
+	if _, err := rand.Read(pow); err != nil {
+		return nil, fmt.Errorf("generate pow challenge: %w", err)
+	}
+	grpcListener, err := net.Listen("tcp", cfg.GRPCListener)
+	if err != nil {
+		return nil, fmt.Errorf("listen grpc: %w", err)
+	}
+	restListener, err := net.Listen("tcp", cfg.RESTListener)
+	if err != nil {
+		grpcListener.Close()
+		return nil, fmt.Errorf("listen rest: %w", err)
+	}
+	return &Server{
+		cfg:          cfg,
+		logger:       logger,
+		key:          key,
+		pow:          pow,
+		grpcListener: grpcListener,
+		restListener: restListener,
+		next:         cfg.openRound(time.Now()),
+		rounds:       map[uint]*registrations{},
+		proofs:       map[string]*rpcapi.ProofResponse{},
+	}, nil
+}
+
+// PublicKey of the service.
+func (s *Server) PublicKey() []byte {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+// GRPC returns the server that is reachable with the gRPC client.
+func (s *Server) GRPC() types.PoetServer {
+	return types.PoetServer{
+		Address: "grpc://" + s.grpcListener.Addr().String(),
+		Pubkey:  types.NewBase64Enc(s.PublicKey()),
+	}
+}
+
+// REST returns the server that is reachable with the http client.
+func (s *Server) REST() types.PoetServer {
+	return types.PoetServer{
+		Address: "http://" + s.restListener.Addr().String(),
+		Pubkey:  types.NewBase64Enc(s.PublicKey()),
+	}
+}
+
+// RoundEnd is the time when the proof of the round is generated.
+func (s *Server) RoundEnd(round uint) time.Time {
+	return s.cfg.roundEnd(round)
+}
+
+// Run serves requests and executes rounds until the context is canceled.
+func (s *Server) Run(ctx context.Context) error {
+	svc := &service{server: s}
+	grpcServer := grpc.NewServer()
+	rpcapi.RegisterPoetServiceServer(grpcServer, svc)
+	mux := runtime.NewServeMux()
+	if err := rpcapi.RegisterPoetServiceHandlerServer(ctx, mux, svc); err != nil {
+		return fmt.Errorf("register rest handler: %w", err)
+	}
+	restServer := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return grpcServer.Serve(s.grpcListener)
+	})
+	eg.Go(func() error {
+		if err := restServer.Serve(s.restListener); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		s.executeRounds(ctx)
+		return nil
+	})
+	eg.Go(func() error {
+		<-ctx.Done()
+		grpcServer.Stop()
+		return restServer.Close()
+	})
+	return eg.Wait()
+}
+
+func (s *Server) executeRounds(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	s.mu.Lock()
+	next := s.next
+	s.mu.Unlock()
+	for round := next; ; round++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(s.cfg.roundStart(round))):
+		}
+		s.mu.Lock()
+		reg := s.rounds[round]
+		delete(s.rounds, round)
+		s.next = round + 1
+		s.mu.Unlock()
+		if reg == nil {
+			s.logger.Debug("skipping round without members", zap.Uint("round", round))
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.execute(ctx, round, reg.members); err != nil && ctx.Err() == nil {
+				s.logger.Error("failed to execute round", zap.Uint("round", round), zap.Error(err))
+			}
+		}()
+	}
+}
+
+func (s *Server) execute(ctx context.Context, round uint, members [][]byte) error {
+	tree, err := merkle.NewTreeBuilder().WithHashFunc(shared.HashMembershipTreeNode).Build()
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		if err := tree.AddLeaf(member); err != nil {
+			return err
+		}
+	}
+	deadline := s.cfg.roundEnd(round)
+	if s.cfg.ProofDuration != 0 {
+		deadline = s.cfg.roundStart(round).Add(s.cfg.ProofDuration)
+	}
+	s.logger.Debug("executing round",
+		zap.Uint("round", round),
+		zap.Int("members", len(members)),
+		zap.Time("deadline", deadline),
+	)
+	proof, leaves, err := s.cfg.Prover(ctx, tree.Root(), deadline)
+	if err != nil {
+		return fmt.Errorf("generate proof: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.proofs[strconv.FormatUint(uint64(round), 10)] = &rpcapi.ProofResponse{
+		Proof: &rpcapi.PoetProof{
+			Proof: &rpcapi.MerkleProof{
+				Root:         proof.Root,
+				ProvenLeaves: proof.ProvenLeaves,
+				ProofNodes:   proof.ProofNodes,
+			},
+			Members: members,
+			Leaves:  leaves,
+		},
+		Pubkey: s.PublicKey(),
+	}
+	s.logger.Debug("round executed", zap.Uint("round", round), zap.Uint64("leaves", leaves))
+	return nil
+}
+
+type service struct {
+	rpcapi.UnimplementedPoetServiceServer
+
+	server *Server
+}
+
+func (s *service) PowParams(context.Context, *rpcapi.PowParamsRequest) (*rpcapi.PowParamsResponse, error) {
+	return &rpcapi.PowParamsResponse{
+		PowParams: &rpcapi.PowParams{
+			Challenge:  s.server.pow,
+			Difficulty: uint32(s.server.cfg.PowDifficulty),
+		},
+	}, nil
+}
+
+// verifyPow checks the PoW of the registration in the same way as the PoET service.
+func (s *service) verifyPow(req *rpcapi.SubmitRequest) error {
+	params := req.GetPowParams()
+	if !bytes.Equal(params.GetChallenge(), s.server.pow) || uint(params.GetDifficulty()) != s.server.cfg.PowDifficulty {
+		return status.Error(codes.InvalidArgument, "invalid proof of work parameters")
+	}
+	hash := shared.NewPowHasher(s.server.pow, req.Pubkey, req.Challenge).Hash(req.Nonce, nil)
+	if !shared.CheckLeadingZeroBits(hash, s.server.cfg.PowDifficulty) {
+		return status.Error(codes.InvalidArgument, "invalid proof of work")
+	}
+	return nil
+}
+
+func (s *service) Submit(ctx context.Context, req *rpcapi.SubmitRequest) (*rpcapi.SubmitResponse, error) {
+	if len(req.Pubkey) != ed25519.PublicKeySize {
+		return nil, status.Error(codes.InvalidArgument, "invalid public key")
+	}
+	if !ed25519.Verify(req.Pubkey, bytes.Join([][]byte{req.Prefix, req.Challenge}, nil), req.Signature) {
+		return nil, status.Error(codes.InvalidArgument, "invalid signature")
+	}
+	if err := s.verifyPow(req); err != nil {
+		return nil, err
+	}
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+	// the open round may be closed slightly earlier than scheduled
+	round := max(s.server.cfg.openRound(time.Now()), s.server.next)
+	end := s.server.cfg.roundEnd(round)
+	if req.Deadline != nil && end.After(req.Deadline.AsTime()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "round %d ends after the deadline", round)
+	}
+	reg, exist := s.server.rounds[round]
+	if !exist {
+		reg = &registrations{challenges: map[string][]byte{}}
+		s.server.rounds[round] = reg
+	}
+	switch prev, exist := reg.challenges[string(req.Pubkey)]; {
+	case !exist:
+		reg.challenges[string(req.Pubkey)] = req.Challenge
+		reg.members = append(reg.members, req.Challenge)
+	case !bytes.Equal(prev, req.Challenge):
+		return nil, status.Error(codes.AlreadyExists, "conflicting registration")
+	}
+	return &rpcapi.SubmitResponse{
+		RoundId:  strconv.FormatUint(uint64(round), 10),
+		RoundEnd: durationpb.New(time.Until(end)),
+	}, nil
+}
+
+func (s *service) Info(context.Context, *rpcapi.InfoRequest) (*rpcapi.InfoResponse, error) {
+	return &rpcapi.InfoResponse{
+		ServicePubkey: s.server.PublicKey(),
+		PhaseShift:    durationpb.New(s.server.cfg.PhaseShift),
+		CycleGap:      durationpb.New(s.server.cfg.CycleGap),
+	}, nil
+}
+
+func (s *service) Proof(ctx context.Context, req *rpcapi.ProofRequest) (*rpcapi.ProofResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+	proof, exist := s.server.proofs[req.RoundId]
+	if !exist {
+		return nil, status.Error(codes.NotFound, "proof not found")
+	}
+	return proof, nil
+}
@@ -0,0 +1,150 @@
+package poettest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	rpcapi "github.com/spacemeshos/poet/release/proto/go/rpc/api/v1"
+	"github.com/spacemeshos/poet/shared"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func start(t *testing.T, cfg Config) (*Server, rpcapi.PoetServiceClient) {
+	srv, err := New(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var eg errgroup.Group
+	eg.Go(func() error {
+		return srv.Run(ctx)
+	})
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, eg.Wait())
+	})
+	conn, err := grpc.NewClient(
+		strings.TrimPrefix(srv.GRPC().Address, "grpc://"),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return srv, rpcapi.NewPoetServiceClient(conn)
+}
+
+func submitRequest(t *testing.T, params *rpcapi.PowParams, challenge []byte) *rpcapi.SubmitRequest {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	nonce, err := shared.FindSubmitPowNonce(
+		context.Background(),
+		params.Challenge,
+		challenge,
+		pub,
+		uint(params.Difficulty),
+	)
+	require.NoError(t, err)
+	prefix := []byte("prefix")
+	return &rpcapi.SubmitRequest{
+		Prefix:    prefix,
+		Challenge: challenge,
+		Pubkey:    pub,
+		Signature: ed25519.Sign(key, bytes.Join([][]byte{prefix, challenge}, nil)),
+		Nonce:     nonce,
+		PowParams: params,
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{}, zaptest.NewLogger(t))
+	require.Error(t, err)
+	_, err = New(Config{EpochDuration: time.Second, CycleGap: time.Second}, zaptest.NewLogger(t))
+	require.Error(t, err)
+}
+
+func TestServer_Rounds(t *testing.T) {
+	var proved [][]byte
+	prover := func(ctx context.Context, root []byte, deadline time.Time) (*shared.MerkleProof, uint64, error) {
+		proved = append(proved, root)
+		return &shared.MerkleProof{Root: root}, 1, nil
+	}
+	srv, client := start(t, Config{
+		EpochDuration: 200 * time.Millisecond,
+		CycleGap:      50 * time.Millisecond,
+		Prover:        prover,
+		PowDifficulty: 8,
+	})
+	ctx := context.Background()
+
+	info, err := client.Info(ctx, &rpcapi.InfoRequest{})
+	require.NoError(t, err)
+	require.Equal(t, srv.PublicKey(), info.ServicePubkey)
+	params, err := client.PowParams(ctx, &rpcapi.PowParamsRequest{})
+	require.NoError(t, err)
+	require.EqualValues(t, 8, params.PowParams.Difficulty)
+
+	first := submitRequest(t, params.PowParams, []byte("first"))
+	res, err := client.Submit(ctx, first)
+	require.NoError(t, err)
+	require.Equal(t, "1", res.RoundId)
+	_, err = client.Submit(ctx, first)
+	require.NoError(t, err, "same challenge can be submitted twice")
+
+	conflicting := &rpcapi.SubmitRequest{
+		Prefix:    first.Prefix,
+		Challenge: []byte("second"),
+		Pubkey:    first.Pubkey,
+		Signature: first.Signature,
+	}
+	_, err = client.Submit(ctx, conflicting)
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "signature doesn't match challenge")
+
+	second := submitRequest(t, params.PowParams, []byte("second"))
+	noPow := &rpcapi.SubmitRequest{
+		Prefix:    second.Prefix,
+		Challenge: second.Challenge,
+		Pubkey:    second.Pubkey,
+		Signature: second.Signature,
+		PowParams: second.PowParams,
+	}
+	hasher := shared.NewPowHasher(params.PowParams.Challenge, second.Pubkey, second.Challenge)
+	for shared.CheckLeadingZeroBits(hasher.Hash(noPow.Nonce, nil), uint(params.PowParams.Difficulty)) {
+		noPow.Nonce++
+	}
+	_, err = client.Submit(ctx, noPow)
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "invalid pow")
+	noPow.Nonce = second.Nonce
+	noPow.PowParams = &rpcapi.PowParams{Challenge: params.PowParams.Challenge}
+	_, err = client.Submit(ctx, noPow)
+	require.Equal(t, codes.InvalidArgument, status.Code(err), "pow params don't match")
+
+	second.Deadline = timestamppb.New(time.Now())
+	_, err = client.Submit(ctx, second)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	second.Deadline = nil
+	_, err = client.Submit(ctx, second)
+	require.NoError(t, err)
+
+	_, err = client.Proof(ctx, &rpcapi.ProofRequest{RoundId: res.RoundId})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.Eventually(t, func() bool {
+		_, err := client.Proof(ctx, &rpcapi.ProofRequest{RoundId: res.RoundId})
+		return err == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	proof, err := client.Proof(ctx, &rpcapi.ProofRequest{RoundId: res.RoundId})
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("first"), []byte("second")}, proof.Proof.Members)
+	require.Equal(t, srv.PublicKey(), proof.Pubkey)
+	require.Len(t, proved, 1)
+	require.Equal(t, proved[0], proof.Proof.Proof.Root)
+}
@@ -30,6 +30,12 @@ func (s *postStates) Set(id types.NodeID, state types.PostState) {
 	s.log.Info("post state changed", zap.Stringer("id", id), zap.Stringer("state", state))
 }
 
+func (s *postStates) Remove(id types.NodeID) {
+	s.mu.Lock()
+	delete(s.states, id)
+	s.mu.Unlock()
+}
+
 func (s *postStates) Get() map[types.NodeID]types.PostState {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
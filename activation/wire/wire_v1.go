@@ -94,6 +94,21 @@ func (atx *ActivationTxV1) Sign(signer *signing.EdSigner) {
 	atx.SmesherID = signer.NodeID()
 }
 
+// SignOnce signs the ATX unless the identity already signed a different ATX for the same publish epoch.
+func (atx *ActivationTxV1) SignOnce(signer *signing.EdSigner) error {
+	if atx.PrevATXID == types.EmptyATXID {
+		nodeID := signer.NodeID()
+		atx.NodeID = &nodeID
+	}
+	sig, err := signer.SignOnce(signing.ATX, atx.PublishEpoch.FirstLayer(), 0, atx.SignedBytes())
+	if err != nil {
+		return err
+	}
+	atx.Signature = sig
+	atx.SmesherID = signer.NodeID()
+	return nil
+}
+
 func (atx *ActivationTxV1) SignedBytes() []byte {
 	data := codec.MustEncode(&ATXMetadataV1{
 		Publish: atx.PublishEpoch,
@@ -6,41 +6,65 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/spf13/afero"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
 	"github.com/spacemeshos/go-spacemesh/checkpoint"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
 const (
 	chunksize      = 1024
 	defaultNumAtxs = 4
+
+	reputationTagPrefix = "reputation:"
 )
 
+var peerRuleActions = map[p2p.RuleAction]nodev1.PeerRuleAction{
+	p2p.RuleBlock: nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK,
+	p2p.RuleAllow: nodev1.PeerRuleAction_PEER_RULE_ACTION_ALLOW,
+}
+
 // AdminService exposes endpoints for node administration.
 type AdminService struct {
 	db      *sql.Database
 	dataDir string
 	recover func()
 	p       peers
+	signer  *signing.EdSigner
+}
+
+// AdminServiceOpt configures AdminService.
+type AdminServiceOpt func(*AdminService)
+
+// WithCheckpointSigner sets the dedicated key that signs generated checkpoints.
+func WithCheckpointSigner(signer *signing.EdSigner) AdminServiceOpt {
+	return func(a *AdminService) {
+		a.signer = signer
+	}
 }
 
 // NewAdminService creates a new admin grpc service.
-func NewAdminService(db *sql.Database, dataDir string, p peers) *AdminService {
-	return &AdminService{
+func NewAdminService(db *sql.Database, dataDir string, p peers, opts ...AdminServiceOpt) *AdminService {
+	a := &AdminService{
 		db:      db,
 		dataDir: dataDir,
 		recover: func() {
@@ -52,11 +76,16 @@ func NewAdminService(db *sql.Database, dataDir string, p peers) *AdminService {
 		},
 		p: p,
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // RegisterService registers this service with a grpc server instance.
 func (a AdminService) RegisterService(server *grpc.Server) {
 	pb.RegisterAdminServiceServer(server, a)
+	nodev1.RegisterAdminServiceServer(server, a)
 }
 
 func (s AdminService) RegisterHandlerService(mux *runtime.ServeMux) error {
@@ -80,7 +109,11 @@ func (a AdminService) CheckpointStream(
 	if numAtxs < defaultNumAtxs {
 		numAtxs = defaultNumAtxs
 	}
-	err := checkpoint.Generate(stream.Context(), afero.NewOsFs(), a.db, a.dataDir, snapshot, numAtxs)
+	var opts []checkpoint.GenerateOpt
+	if a.signer != nil {
+		opts = append(opts, checkpoint.WithSigner(a.signer))
+	}
+	err := checkpoint.Generate(stream.Context(), afero.NewOsFs(), a.db, a.dataDir, snapshot, numAtxs, opts...)
 	if err != nil {
 		return status.Errorf(codes.Internal, fmt.Sprintf("failed to create checkpoint: %s", err.Error()))
 	}
@@ -154,6 +187,11 @@ func (a AdminService) EventsStream(req *pb.EventStreamRequest, stream pb.AdminSe
 	}
 }
 
+// reputationTag is the tag of the peer info with the reputation score of the peer.
+func reputationTag(score float64) string {
+	return reputationTagPrefix + strconv.FormatFloat(score, 'g', -1, 64)
+}
+
 func (a AdminService) PeerInfoStream(_ *emptypb.Empty, stream pb.AdminService_PeerInfoStreamServer) error {
 	for _, p := range a.p.GetPeers() {
 		select {
@@ -177,7 +215,8 @@ func (a AdminService) PeerInfoStream(_ *emptypb.Empty, stream pb.AdminService_Pe
 			err := stream.Send(&pb.PeerInfo{
 				Id:          info.ID.String(),
 				Connections: connections,
-				Tags:        info.Tags,
+				// pb.PeerInfo has no field for the reputation, it is reported with a tag
+				Tags: append(info.Tags, reputationTag(info.Reputation)),
 			})
 			if err != nil {
 				return fmt.Errorf("send to stream: %w", err)
@@ -187,3 +226,86 @@ func (a AdminService) PeerInfoStream(_ *emptypb.Empty, stream pb.AdminService_Pe
 
 	return nil
 }
+
+// ListPeerRules returns peer rules that are not expired.
+func (a AdminService) ListPeerRules(
+	context.Context,
+	*nodev1.ListPeerRulesRequest,
+) (*nodev1.ListPeerRulesResponse, error) {
+	rules := a.p.PeerRules()
+	res := &nodev1.ListPeerRulesResponse{Rules: make([]*nodev1.PeerRule, 0, len(rules))}
+	for _, rule := range rules {
+		rst := &nodev1.PeerRule{
+			Action: peerRuleActions[rule.Action],
+			Target: rule.Target,
+		}
+		if !rule.Expires.IsZero() {
+			rst.Expires = timestamppb.New(rule.Expires)
+		}
+		res.Rules = append(res.Rules, rst)
+	}
+	return res, nil
+}
+
+// AddPeerRule adds the rule, replacing the rule with the same action and target.
+// Open connections that match the block rule are closed.
+func (a AdminService) AddPeerRule(
+	ctx context.Context,
+	in *nodev1.AddPeerRuleRequest,
+) (*nodev1.AddPeerRuleResponse, error) {
+	rule, err := parsePeerRule(in.Rule)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.p.AddPeerRule(rule); err != nil {
+		ctxzap.Error(ctx, "failed to add peer rule", zap.Stringer("rule", rule), zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to add peer rule: %v", err))
+	}
+	ctxzap.Info(ctx, "peer rule added", zap.Stringer("rule", rule))
+	return &nodev1.AddPeerRuleResponse{}, nil
+}
+
+// RemovePeerRule removes the rule with the same action and target.
+func (a AdminService) RemovePeerRule(
+	ctx context.Context,
+	in *nodev1.RemovePeerRuleRequest,
+) (*nodev1.RemovePeerRuleResponse, error) {
+	rule, err := parsePeerRule(in.Rule)
+	if err != nil {
+		return nil, err
+	}
+	err = a.p.RemovePeerRule(rule)
+	switch {
+	case errors.Is(err, p2p.ErrRuleNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		ctxzap.Error(ctx, "failed to remove peer rule", zap.Stringer("rule", rule), zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to remove peer rule: %v", err))
+	}
+	ctxzap.Info(ctx, "peer rule removed", zap.Stringer("rule", rule))
+	return &nodev1.RemovePeerRuleResponse{}, nil
+}
+
+func parsePeerRule(in *nodev1.PeerRule) (p2p.Rule, error) {
+	if in == nil {
+		return p2p.Rule{}, status.Error(codes.InvalidArgument, "`Rule` must be provided")
+	}
+	var action p2p.RuleAction
+	for a, value := range peerRuleActions {
+		if value == in.Action {
+			action = a
+		}
+	}
+	if action == "" {
+		return p2p.Rule{}, status.Errorf(codes.InvalidArgument, "unknown peer rule action %s", in.Action)
+	}
+	var expires time.Time
+	if in.Expires != nil {
+		expires = in.Expires.AsTime()
+	}
+	rule, err := p2p.NewRule(action, in.Target, expires)
+	if err != nil {
+		return p2p.Rule{}, status.Errorf(codes.InvalidArgument, "invalid peer rule target %q: %v", in.Target, err)
+	}
+	return rule, nil
+}
@@ -8,10 +8,18 @@ import (
 	"testing"
 	"time"
 
+	ma "github.com/multiformats/go-multiaddr"
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
@@ -129,3 +137,115 @@ func TestAdminService_Recovery(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, recoveryCalled.Load())
 }
+
+func TestAdminService_PeerInfoStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	peers := NewMockpeers(ctrl)
+	cfg, cleanup := launchServer(t, NewAdminService(sql.InMemory(), t.TempDir(), peers))
+	t.Cleanup(cleanup)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+	c := pb.NewAdminServiceClient(conn)
+
+	addr, err := ma.NewMultiaddr("/ip4/10.0.0.1/tcp/7513")
+	require.NoError(t, err)
+	peers.EXPECT().GetPeers().Return([]p2p.Peer{"a", "b"})
+	peers.EXPECT().ConnectedPeerInfo(p2p.Peer("a")).Return(&p2p.PeerInfo{
+		ID:          "a",
+		Connections: []p2p.ConnectionInfo{{Address: addr, Uptime: time.Minute, Outbound: true}},
+		Tags:        []string{"bootnode"},
+		Reputation:  0.75,
+	})
+	// disconnected after GetPeers
+	peers.EXPECT().ConnectedPeerInfo(p2p.Peer("b")).Return(nil)
+
+	stream, err := c.PeerInfoStream(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	info, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, p2p.Peer("a").String(), info.Id)
+	require.Equal(t, []string{"bootnode", "reputation:0.75"}, info.Tags)
+	require.Len(t, info.Connections, 1)
+	require.Equal(t, addr.String(), info.Connections[0].Address)
+	require.Equal(t, time.Minute, info.Connections[0].Uptime.AsDuration())
+	require.True(t, info.Connections[0].Outbound)
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestAdminService_PeerRules(t *testing.T) {
+	const target = "12D3KooWJuP7Pc1dnXE6pX9b6XmEUbJmgPQCXKDxLQdDw9Ww3ePe"
+	ctrl := gomock.NewController(t)
+	peers := NewMockpeers(ctrl)
+	cfg, cleanup := launchServer(t, NewAdminService(sql.InMemory(), t.TempDir(), peers))
+	t.Cleanup(cleanup)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn := dialGrpc(ctx, t, cfg)
+	c := nodev1.NewAdminServiceClient(conn)
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	block, err := p2p.NewRule(p2p.RuleBlock, target, time.Time{})
+	require.NoError(t, err)
+	allow, err := p2p.NewRule(p2p.RuleAllow, "10.0.0.0/8", expires)
+	require.NoError(t, err)
+
+	peers.EXPECT().AddPeerRule(block).Return(nil)
+	_, err = c.AddPeerRule(ctx, &nodev1.AddPeerRuleRequest{Rule: &nodev1.PeerRule{
+		Action: nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK,
+		Target: target,
+	}})
+	require.NoError(t, err)
+
+	peers.EXPECT().AddPeerRule(allow).Return(nil)
+	_, err = c.AddPeerRule(ctx, &nodev1.AddPeerRuleRequest{Rule: &nodev1.PeerRule{
+		Action:  nodev1.PeerRuleAction_PEER_RULE_ACTION_ALLOW,
+		Target:  "10.0.0.0/8",
+		Expires: timestamppb.New(expires),
+	}})
+	require.NoError(t, err)
+
+	peers.EXPECT().PeerRules().Return([]p2p.Rule{block, allow})
+	res, err := c.ListPeerRules(ctx, &nodev1.ListPeerRulesRequest{})
+	require.NoError(t, err)
+	require.Len(t, res.Rules, 2)
+	require.Equal(t, nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK, res.Rules[0].Action)
+	require.Equal(t, target, res.Rules[0].Target)
+	require.Nil(t, res.Rules[0].Expires)
+	require.Equal(t, nodev1.PeerRuleAction_PEER_RULE_ACTION_ALLOW, res.Rules[1].Action)
+	require.Equal(t, expires, res.Rules[1].Expires.AsTime().Local())
+
+	peers.EXPECT().RemovePeerRule(block).Return(nil)
+	_, err = c.RemovePeerRule(ctx, &nodev1.RemovePeerRuleRequest{Rule: &nodev1.PeerRule{
+		Action: nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK,
+		Target: target,
+	}})
+	require.NoError(t, err)
+
+	peers.EXPECT().RemovePeerRule(block).Return(p2p.ErrRuleNotFound)
+	_, err = c.RemovePeerRule(ctx, &nodev1.RemovePeerRuleRequest{Rule: &nodev1.PeerRule{
+		Action: nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK,
+		Target: target,
+	}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	peers.EXPECT().AddPeerRule(block).Return(errors.New("test"))
+	_, err = c.AddPeerRule(ctx, &nodev1.AddPeerRuleRequest{Rule: &nodev1.PeerRule{
+		Action: nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK,
+		Target: target,
+	}})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	for _, rule := range []*nodev1.PeerRule{
+		nil,
+		{Target: target},
+		{Action: nodev1.PeerRuleAction_PEER_RULE_ACTION_BLOCK, Target: "peer"},
+		{Action: nodev1.PeerRuleAction_PEER_RULE_ACTION_ALLOW, Target: "1.2.3/24"},
+	} {
+		_, err = c.AddPeerRule(ctx, &nodev1.AddPeerRuleRequest{Rule: rule})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), rule.String())
+	}
+}
@@ -0,0 +1,165 @@
+package grpcserver
+
+import (
+	"fmt"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
+)
+
+var appEventKinds = map[string]nodev1.AppEventKind{
+	core.EventSpawn.String():      nodev1.AppEventKind_APP_EVENT_KIND_SPAWN,
+	core.EventTransfer.String():   nodev1.AppEventKind_APP_EVENT_KIND_TRANSFER,
+	core.EventRelay.String():      nodev1.AppEventKind_APP_EVENT_KIND_RELAY,
+	core.EventDrainVault.String(): nodev1.AppEventKind_APP_EVENT_KIND_DRAIN_VAULT,
+}
+
+// AppEventService exposes account level events emitted by the vm.
+type AppEventService struct{}
+
+// NewAppEventService creates a new AppEventService.
+func NewAppEventService() *AppEventService {
+	return &AppEventService{}
+}
+
+// RegisterService registers this service with a grpc server instance.
+func (s *AppEventService) RegisterService(server *grpc.Server) {
+	nodev1.RegisterAppEventServiceServer(server, s)
+}
+
+// RegisterHandlerService is a no-op, json gateway is not generated for the node api.
+func (s *AppEventService) RegisterHandlerService(*runtime.ServeMux) error {
+	return nil
+}
+
+// String returns the name of this service.
+func (s *AppEventService) String() string {
+	return "AppEventService"
+}
+
+type appEventFilter struct {
+	addresses map[types.Address]struct{}
+	kinds     map[nodev1.AppEventKind]struct{}
+}
+
+func newAppEventFilter(req *nodev1.AppEventStreamRequest) (*appEventFilter, error) {
+	filter := &appEventFilter{}
+	if len(req.Addresses) > 0 {
+		filter.addresses = make(map[types.Address]struct{}, len(req.Addresses))
+		for _, value := range req.Addresses {
+			address, err := types.StringToAddress(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid address %s: %w", value, err)
+			}
+			filter.addresses[address] = struct{}{}
+		}
+	}
+	if len(req.Kinds) > 0 {
+		filter.kinds = make(map[nodev1.AppEventKind]struct{}, len(req.Kinds))
+		for _, kind := range req.Kinds {
+			if _, exists := nodev1.AppEventKind_name[int32(kind)]; !exists ||
+				kind == nodev1.AppEventKind_APP_EVENT_KIND_UNSPECIFIED {
+				return nil, fmt.Errorf("unknown event kind %d", kind)
+			}
+			filter.kinds[kind] = struct{}{}
+		}
+	}
+	return filter, nil
+}
+
+func (f *appEventFilter) match(ev *events.AppEvent) bool {
+	if ev.Revert {
+		return true
+	}
+	if f.kinds != nil {
+		if _, exists := f.kinds[appEventKinds[ev.Kind]]; !exists {
+			return false
+		}
+	}
+	if f.addresses == nil {
+		return true
+	}
+	for address := range f.addresses {
+		if ev.Involves(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func appEventToPb(ev *events.AppEvent) *nodev1.AppEventStreamResponse {
+	if ev.Revert {
+		return &nodev1.AppEventStreamResponse{
+			Reverted: &nodev1.AppEventsReverted{Layer: ev.Layer.Uint32()},
+		}
+	}
+	event := &nodev1.AppEvent{
+		Layer:         ev.Layer.Uint32(),
+		TransactionId: ev.Transaction[:],
+		Kind:          appEventKinds[ev.Kind],
+		Principal:     ev.Principal.String(),
+		Account:       ev.Account.String(),
+		Amount:        ev.Amount,
+	}
+	if ev.Destination != (types.Address{}) {
+		event.Destination = ev.Destination.String()
+	}
+	if ev.Template != (types.Address{}) {
+		event.Template = ev.Template.String()
+	}
+	return &nodev1.AppEventStreamResponse{Event: event}
+}
+
+// Stream streams events of the transactions applied after the stream was opened.
+func (s *AppEventService) Stream(
+	req *nodev1.AppEventStreamRequest,
+	stream nodev1.AppEventService_StreamServer,
+) error {
+	filter, err := newAppEventFilter(req)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return streamAppEvents(stream, filter, func(ev *events.AppEvent) error {
+		return stream.Send(appEventToPb(ev))
+	})
+}
+
+// streamAppEvents sends events that match the filter until the client disconnects.
+func streamAppEvents(stream grpc.ServerStream, filter *appEventFilter, send func(*events.AppEvent) error) error {
+	var (
+		eventsCh      <-chan events.AppEvent
+		eventsBufFull <-chan struct{}
+	)
+	if sub := events.SubscribeAppEvents(); sub != nil {
+		eventsCh, eventsBufFull = consumeEvents[events.AppEvent](stream.Context(), sub)
+	}
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return status.Errorf(codes.Unavailable, "can't send header")
+	}
+
+	for {
+		select {
+		case <-eventsBufFull:
+			ctxzap.Info(stream.Context(), "app events buffer is full, shutting down")
+			return status.Error(codes.Canceled, errAppEventsBufferFull)
+		case ev := <-eventsCh:
+			if !filter.match(&ev) {
+				continue
+			}
+			if err := send(&ev); err != nil {
+				return fmt.Errorf("send to stream: %w", err)
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package grpcserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
+)
+
+func setupAppEventService(t *testing.T) (nodev1.AppEventServiceClient, context.Context) {
+	cfg, cleanup := launchServer(t, NewAppEventService())
+	t.Cleanup(cleanup)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return nodev1.NewAppEventServiceClient(dialGrpc(ctx, t, cfg)), ctx
+}
+
+func TestAppEventService_InvalidFilter(t *testing.T) {
+	c, ctx := setupAppEventService(t)
+	for _, req := range []*nodev1.AppEventStreamRequest{
+		{Kinds: []nodev1.AppEventKind{nodev1.AppEventKind_APP_EVENT_KIND_UNSPECIFIED}},
+		{Kinds: []nodev1.AppEventKind{100}},
+		{Addresses: []string{"invalid"}},
+	} {
+		stream, err := c.Stream(ctx, req)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestAppEventService_Stream(t *testing.T) {
+	events.CloseEventReporter()
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+
+	c, ctx := setupAppEventService(t)
+	principal := types.GenerateAddress([]byte{1})
+	destination := types.GenerateAddress([]byte{2})
+	stream, err := c.Stream(ctx, &nodev1.AppEventStreamRequest{
+		Addresses: []string{destination.String()},
+		Kinds:     []nodev1.AppEventKind{nodev1.AppEventKind_APP_EVENT_KIND_TRANSFER},
+	})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	spawn := events.AppEvent{
+		Layer:       10,
+		Transaction: types.TransactionID{1},
+		Kind:        core.EventSpawn.String(),
+		Principal:   principal,
+		Account:     destination,
+	}
+	transfer := events.AppEvent{
+		Layer:       10,
+		Transaction: types.TransactionID{2},
+		Kind:        core.EventTransfer.String(),
+		Principal:   principal,
+		Account:     principal,
+		Destination: destination,
+		Amount:      100,
+	}
+	other := transfer
+	other.Transaction = types.TransactionID{3}
+	other.Destination = principal
+	events.ReportAppEvent(spawn)
+	events.ReportAppEvent(other)
+	events.ReportAppEvent(transfer)
+	events.ReportAppEvent(events.AppEvent{Layer: 9, Revert: true})
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, res.Reverted)
+	require.Equal(t, transfer.Transaction[:], res.Event.TransactionId)
+	require.Equal(t, uint32(10), res.Event.Layer)
+	require.Equal(t, nodev1.AppEventKind_APP_EVENT_KIND_TRANSFER, res.Event.Kind)
+	require.Equal(t, principal.String(), res.Event.Principal)
+	require.Equal(t, principal.String(), res.Event.Account)
+	require.Equal(t, destination.String(), res.Event.Destination)
+	require.Empty(t, res.Event.Template)
+	require.EqualValues(t, 100, res.Event.Amount)
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, res.Event)
+	require.Equal(t, uint32(9), res.Reverted.Layer)
+}
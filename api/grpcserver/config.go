@@ -43,6 +43,8 @@ const (
 	RewardStreamV2Alpha1     Service = "reward_stream_v2alpha1"
 	NetworkV2Alpha1          Service = "network_v2alpha1"
 	NodeV2Alpha1             Service = "node_v2alpha1"
+	IdentityV1               Service = "identity_v1"
+	AppEventV1               Service = "app_event_v1"
 )
 
 // DefaultConfig defines the default configuration options for api.
@@ -50,12 +52,12 @@ func DefaultConfig() Config {
 	return Config{
 		PublicServices: []Service{
 			GlobalState, Mesh, Transaction, Node, Activation, ActivationV2Alpha1,
-			RewardV2Alpha1, NetworkV2Alpha1, NodeV2Alpha1,
+			RewardV2Alpha1, NetworkV2Alpha1, NodeV2Alpha1, AppEventV1,
 		},
 		PublicListener: "0.0.0.0:9092",
 		PrivateServices: []Service{
 			Admin, Smesher, Debug, ActivationStreamV2Alpha1,
-			RewardStreamV2Alpha1,
+			RewardStreamV2Alpha1, IdentityV1,
 		},
 		PrivateListener:       "127.0.0.1:9093",
 		PostServices:          []Service{Post, PostInfo},
@@ -16,6 +16,7 @@ var (
 	errLayerBufferFull       = "layer buffer is full"
 	errAccountBufferFull     = "account buffer is full"
 	errRewardsBufferFull     = "rewards buffer is full"
+	errAppEventsBufferFull   = "app events buffer is full"
 	errActivationsBufferFull = "activations buffer is full"
 	errStatusBufferFull      = "status buffer is full"
 	errErrorsBufferFull      = "errors buffer is full"
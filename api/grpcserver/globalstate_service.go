@@ -12,6 +12,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/events"
@@ -336,14 +337,26 @@ func (s GlobalStateService) SmesherRewardStream(
 }
 
 // AppEventStream exposes a stream of emitted app events.
+//
+// Events are emitted by the vm for every successfully applied transaction, and a single event without
+// transaction is emitted when layers are reverted. Message of the event is the json encoding of
+// the AppEventStreamResponse of the node api (api/node/v1/app_event.proto).
+// Events are not filtered, AppEventService.Stream streams events filtered by address and kind.
 func (s GlobalStateService) AppEventStream(
-	*pb.AppEventStreamRequest,
-	pb.GlobalStateService_AppEventStreamServer,
+	_ *pb.AppEventStreamRequest,
+	stream pb.GlobalStateService_AppEventStreamServer,
 ) error {
-	// TODO: implement me! We don't currently have any app events
-	// See https://github.com/spacemeshos/go-spacemesh/issues/2074
-
-	return status.Errorf(codes.Unimplemented, "this endpoint has not yet been implemented")
+	return streamAppEvents(stream, &appEventFilter{}, func(ev *events.AppEvent) error {
+		msg, err := protojson.Marshal(appEventToPb(ev))
+		if err != nil {
+			return err
+		}
+		event := &pb.AppEvent{Message: string(msg)}
+		if !ev.Revert {
+			event.TransactionId = &pb.TransactionId{Id: ev.Transaction[:]}
+		}
+		return stream.Send(&pb.AppEventStreamResponse{Event: event})
+	})
 }
 
 // GlobalStateStream exposes a stream of global data data items: rewards, receipts, account info, global state hash.
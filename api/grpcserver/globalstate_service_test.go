@@ -11,8 +11,12 @@ import (
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
+	"github.com/spacemeshos/go-spacemesh/genvm/core"
 )
 
 type globalStateServiceConn struct {
@@ -216,17 +220,6 @@ func TestGlobalStateService(t *testing.T) {
 		checkAccountDataQueryItemReward(t, res.AccountItem[0].Datum)
 		checkAccountDataQueryItemAccount(t, res.AccountItem[1].Datum)
 	})
-	t.Run("AppEventStream", func(t *testing.T) {
-		t.Parallel()
-		c, ctx := setupGlobalStateService(t)
-
-		stream, err := c.AppEventStream(ctx, &pb.AppEventStreamRequest{})
-		// We expect to be able to open the stream but for it to fail upon the first request
-		require.NoError(t, err)
-		_, err = stream.Recv()
-		statusCode := status.Code(err)
-		require.Equal(t, codes.Unimplemented, statusCode)
-	})
 	t.Run("AccountDataStream_emptyAddress", func(t *testing.T) {
 		t.Parallel()
 		c, ctx := setupGlobalStateService(t)
@@ -368,3 +361,50 @@ func TestGlobalStateService(t *testing.T) {
 		})
 	})
 }
+
+func TestGlobalStateService_AppEventStream(t *testing.T) {
+	events.CloseEventReporter()
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+
+	c, ctx := setupGlobalStateService(t)
+	stream, err := c.AppEventStream(ctx, &pb.AppEventStreamRequest{})
+	require.NoError(t, err)
+	_, err = stream.Header()
+	require.NoError(t, err)
+
+	principal := types.GenerateAddress([]byte{1})
+	destination := types.GenerateAddress([]byte{2})
+	transfer := events.AppEvent{
+		Layer:       10,
+		Transaction: types.TransactionID{1},
+		Kind:        core.EventTransfer.String(),
+		Principal:   principal,
+		Account:     principal,
+		Destination: destination,
+		Amount:      100,
+	}
+	events.ReportAppEvent(transfer)
+	events.ReportAppEvent(events.AppEvent{Layer: 9, Revert: true})
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, transfer.Transaction[:], res.Event.TransactionId.Id)
+	var msg nodev1.AppEventStreamResponse
+	require.NoError(t, protojson.Unmarshal([]byte(res.Event.Message), &msg))
+	require.Nil(t, msg.Reverted)
+	require.Equal(t, transfer.Transaction[:], msg.Event.TransactionId)
+	require.Equal(t, uint32(10), msg.Event.Layer)
+	require.Equal(t, nodev1.AppEventKind_APP_EVENT_KIND_TRANSFER, msg.Event.Kind)
+	require.Equal(t, principal.String(), msg.Event.Principal)
+	require.Equal(t, destination.String(), msg.Event.Destination)
+	require.EqualValues(t, 100, msg.Event.Amount)
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	require.Nil(t, res.Event.TransactionId)
+	msg.Reset()
+	require.NoError(t, protojson.Unmarshal([]byte(res.Event.Message), &msg))
+	require.Nil(t, msg.Event)
+	require.Equal(t, uint32(9), msg.Reverted.Layer)
+}
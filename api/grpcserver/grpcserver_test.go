@@ -42,6 +42,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk/wallet"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/miner"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
 	"github.com/spacemeshos/go-spacemesh/signing"
@@ -518,6 +519,8 @@ type smesherServiceConn struct {
 	smeshingProvider *activation.MockSmeshingProvider
 	postSupervisor   *MockpostSupervisor
 	grpcPostService  *MockgrpcPostService
+	minGas           *MockminGasProvider
+	rewards          *MockrewardEstimator
 }
 
 func setupSmesherService(t *testing.T, sig *signing.EdSigner) (*smesherServiceConn, context.Context) {
@@ -525,10 +528,14 @@ func setupSmesherService(t *testing.T, sig *signing.EdSigner) (*smesherServiceCo
 	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
 	postSupervisor := NewMockpostSupervisor(ctrl)
 	grpcPostService := NewMockgrpcPostService(ctrl)
+	minGas := NewMockminGasProvider(ctrl)
+	rewards := NewMockrewardEstimator(ctrl)
 	svc := NewSmesherService(
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		minGas,
+		rewards,
 		10*time.Millisecond,
 		activation.DefaultPostSetupOpts(),
 		sig,
@@ -548,6 +555,8 @@ func setupSmesherService(t *testing.T, sig *signing.EdSigner) (*smesherServiceCo
 		smeshingProvider: smeshingProvider,
 		postSupervisor:   postSupervisor,
 		grpcPostService:  grpcPostService,
+		minGas:           minGas,
+		rewards:          rewards,
 	}, mockCtx
 }
 
@@ -684,19 +693,58 @@ func TestSmesherService(t *testing.T) {
 	t.Run("MinGas", func(t *testing.T) {
 		t.Parallel()
 		c, ctx := setupSmesherService(t, nil)
-		_, err := c.MinGas(ctx, &emptypb.Empty{})
-		require.Error(t, err)
-		statusCode := status.Code(err)
-		require.Equal(t, codes.Unimplemented, statusCode)
+		c.minGas.EXPECT().MinGas().Return(uint64(7))
+		res, err := c.MinGas(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		require.EqualValues(t, 7, res.Mingas.Value)
 	})
 
 	t.Run("SetMinGas", func(t *testing.T) {
+		t.Parallel()
+		c, ctx := setupSmesherService(t, nil)
+		c.minGas.EXPECT().SetMinGas(uint64(11)).Return(nil)
+		res, err := c.SetMinGas(ctx, &pb.SetMinGasRequest{Mingas: &pb.SimpleInt{Value: 11}})
+		require.NoError(t, err)
+		require.EqualValues(t, code.Code_OK, res.Status.Code)
+	})
+
+	t.Run("SetMinGas missing value", func(t *testing.T) {
 		t.Parallel()
 		c, ctx := setupSmesherService(t, nil)
 		_, err := c.SetMinGas(ctx, &pb.SetMinGasRequest{})
-		require.Error(t, err)
-		statusCode := status.Code(err)
-		require.Equal(t, codes.Unimplemented, statusCode)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("SetMinGas fails to persist", func(t *testing.T) {
+		t.Parallel()
+		c, ctx := setupSmesherService(t, nil)
+		c.minGas.EXPECT().SetMinGas(uint64(11)).Return(errors.New("test"))
+		_, err := c.SetMinGas(ctx, &pb.SetMinGasRequest{Mingas: &pb.SimpleInt{Value: 11}})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("EstimatedRewards", func(t *testing.T) {
+		t.Parallel()
+		c, ctx := setupSmesherService(t, nil)
+		ids := []types.NodeID{{1}, {2}}
+		c.smeshingProvider.EXPECT().SmesherIDs().Return(ids)
+		c.rewards.EXPECT().EstimateRewards(ids).Return([]miner.EstimatedReward{
+			{NodeID: ids[0], NumUnits: 4, Eligibilities: 2, Amount: 100},
+			{NodeID: ids[1], NumUnits: 8, Eligibilities: 4, Amount: 200},
+		}, nil)
+		res, err := c.EstimatedRewards(ctx, &pb.EstimatedRewardsRequest{})
+		require.NoError(t, err)
+		require.EqualValues(t, 300, res.Amount.Value)
+		require.EqualValues(t, 12, res.NumUnits)
+	})
+
+	t.Run("EstimatedRewards fails", func(t *testing.T) {
+		t.Parallel()
+		c, ctx := setupSmesherService(t, nil)
+		c.smeshingProvider.EXPECT().SmesherIDs().Return(nil)
+		c.rewards.EXPECT().EstimateRewards(gomock.Any()).Return(nil, errors.New("test"))
+		_, err := c.EstimatedRewards(ctx, &pb.EstimatedRewardsRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
 	})
 
 	t.Run("PostSetupComputeProviders", func(t *testing.T) {
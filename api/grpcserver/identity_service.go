@@ -0,0 +1,196 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// IdentityService exposes the state of every smeshing identity managed by the node.
+type IdentityService struct {
+	smeshingProvider activation.SmeshingProvider
+	rewards          rewardEstimator
+	poetStats        poetStatsProvider
+	identities       identityManager
+}
+
+// NewIdentityService creates a new IdentityService.
+func NewIdentityService(
+	smeshing activation.SmeshingProvider,
+	rewards rewardEstimator,
+	poetStats poetStatsProvider,
+	identities identityManager,
+) *IdentityService {
+	return &IdentityService{
+		smeshingProvider: smeshing,
+		rewards:          rewards,
+		poetStats:        poetStats,
+		identities:       identities,
+	}
+}
+
+// RegisterService registers this service with a grpc server instance.
+func (s *IdentityService) RegisterService(server *grpc.Server) {
+	nodev1.RegisterIdentityServiceServer(server, s)
+}
+
+// RegisterHandlerService is a no-op, json gateway is not generated for the node api.
+func (s *IdentityService) RegisterHandlerService(*runtime.ServeMux) error {
+	return nil
+}
+
+// String returns the name of this service.
+func (s *IdentityService) String() string {
+	return "IdentityService"
+}
+
+// EstimatedRewards returns estimated rewards of every smeshing identity in the next epoch.
+// Identities that are not eligible in the next epoch are returned with zero eligibilities.
+func (s *IdentityService) EstimatedRewards(
+	ctx context.Context,
+	_ *nodev1.EstimatedRewardsRequest,
+) (*nodev1.EstimatedRewardsResponse, error) {
+	estimates, err := s.rewards.EstimateRewards(s.smeshingProvider.SmesherIDs())
+	if err != nil {
+		ctxzap.Error(ctx, "failed to estimate rewards", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to estimate rewards: %v", err))
+	}
+	res := &nodev1.EstimatedRewardsResponse{
+		Identities: make([]*nodev1.IdentityRewards, 0, len(estimates)),
+	}
+	for _, estimate := range estimates {
+		rewards := &nodev1.IdentityRewards{
+			NodeId:        estimate.NodeID.Bytes(),
+			NumUnits:      estimate.NumUnits,
+			Weight:        estimate.Weight,
+			Eligibilities: estimate.Eligibilities,
+			Amount:        estimate.Amount,
+		}
+		if estimate.ATX != types.EmptyATXID {
+			rewards.AtxId = estimate.ATX.Bytes()
+		}
+		res.Identities = append(res.Identities, rewards)
+	}
+	return res, nil
+}
+
+// PoetStats returns stats of the requests made for every smeshing identity to the configured PoET services.
+func (s *IdentityService) PoetStats(
+	ctx context.Context,
+	_ *nodev1.PoetStatsRequest,
+) (*nodev1.PoetStatsResponse, error) {
+	ids := s.smeshingProvider.SmesherIDs()
+	res := &nodev1.PoetStatsResponse{
+		Identities: make([]*nodev1.IdentityPoetStats, 0, len(ids)),
+	}
+	for _, id := range ids {
+		stats, err := s.poetStats.PoetStats(id)
+		if err != nil {
+			ctxzap.Error(ctx, "failed to get poet stats", zap.Stringer("id", id), zap.Error(err))
+			return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get poet stats for %s: %v", id, err))
+		}
+		identity := &nodev1.IdentityPoetStats{
+			NodeId: id.Bytes(),
+			Poets:  make([]*nodev1.PoetStats, 0, len(stats)),
+		}
+		for _, poet := range stats {
+			rst := &nodev1.PoetStats{
+				Address:             poet.Address,
+				SubmitSuccess:       poet.SubmitSuccess,
+				SubmitFailure:       poet.SubmitFailure,
+				AvgSubmitLatencyMs:  uint64(poet.AvgSubmitLatency().Milliseconds()),
+				ProofSuccess:        poet.ProofSuccess,
+				ProofFailure:        poet.ProofFailure,
+				AvgProofLatencyMs:   uint64(poet.AvgProofLatency().Milliseconds()),
+				ConsecutiveFailures: poet.ConsecutiveFailures,
+			}
+			if !poet.LastFailure.IsZero() {
+				rst.LastFailure = poet.LastFailure.Unix()
+			}
+			identity.Poets = append(identity.Poets, rst)
+		}
+		res.Identities = append(res.Identities, identity)
+	}
+	return res, nil
+}
+
+// Identities returns the smeshing state of every identity managed by the node.
+func (s *IdentityService) Identities(
+	ctx context.Context,
+	_ *nodev1.IdentitiesRequest,
+) (*nodev1.IdentitiesResponse, error) {
+	identities, err := s.smeshingProvider.Identities()
+	if err != nil {
+		ctxzap.Error(ctx, "failed to get smeshing state", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to get smeshing state: %v", err))
+	}
+	res := &nodev1.IdentitiesResponse{
+		Identities: make([]*nodev1.IdentityState, 0, len(identities)),
+	}
+	for _, identity := range identities {
+		state := &nodev1.IdentityState{
+			NodeId:     identity.ID.Bytes(),
+			Smeshing:   identity.Smeshing,
+			Stopped:    identity.Stopped,
+			PauseEpoch: identity.PauseEpoch.Uint32(),
+		}
+		if identity.Coinbase != (types.Address{}) {
+			state.Coinbase = identity.Coinbase.String()
+		}
+		res.Identities = append(res.Identities, state)
+	}
+	return res, nil
+}
+
+// AddIdentity loads an identity file from the identities directory of the node and registers it
+// with every component of the running node.
+func (s *IdentityService) AddIdentity(
+	ctx context.Context,
+	in *nodev1.AddIdentityRequest,
+) (*nodev1.AddIdentityResponse, error) {
+	id, err := s.identities.AddIdentity(in.File)
+	if err != nil {
+		return nil, identityFileError(ctx, "failed to add identity", in.File, err)
+	}
+	return &nodev1.AddIdentityResponse{NodeId: id.Bytes()}, nil
+}
+
+// RemoveIdentity unregisters the identity loaded from an identity file from every component of the
+// running node. The identity file and local state of the identity are kept.
+func (s *IdentityService) RemoveIdentity(
+	ctx context.Context,
+	in *nodev1.RemoveIdentityRequest,
+) (*nodev1.RemoveIdentityResponse, error) {
+	id, err := s.identities.RemoveIdentity(in.File)
+	if err != nil {
+		return nil, identityFileError(ctx, "failed to remove identity", in.File, err)
+	}
+	return &nodev1.RemoveIdentityResponse{NodeId: id.Bytes()}, nil
+}
+
+func identityFileError(ctx context.Context, msg, file string, err error) error {
+	switch {
+	case errors.Is(err, fs.ErrInvalid):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, fs.ErrExist):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, fs.ErrNotExist):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errors.ErrUnsupported):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	ctxzap.Error(ctx, msg, zap.String("file", file), zap.Error(err))
+	return status.Error(codes.Internal, fmt.Sprintf("%s: %v", msg, err))
+}
@@ -0,0 +1,205 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/spacemeshos/go-spacemesh/activation"
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/miner"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/poetstats"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/smeshing"
+)
+
+type identityServiceConn struct {
+	nodev1.IdentityServiceClient
+
+	smeshingProvider *activation.MockSmeshingProvider
+	rewards          *MockrewardEstimator
+	poetStats        *MockpoetStatsProvider
+	identities       *MockidentityManager
+}
+
+func setupIdentityService(t *testing.T) (*identityServiceConn, context.Context) {
+	ctrl := gomock.NewController(t)
+	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
+	rewards := NewMockrewardEstimator(ctrl)
+	poetStats := NewMockpoetStatsProvider(ctrl)
+	identities := NewMockidentityManager(ctrl)
+	svc := NewIdentityService(smeshingProvider, rewards, poetStats, identities)
+	cfg, cleanup := launchServer(t, svc)
+	t.Cleanup(cleanup)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	conn := dialGrpc(ctx, t, cfg)
+	return &identityServiceConn{
+		IdentityServiceClient: nodev1.NewIdentityServiceClient(conn),
+		smeshingProvider:      smeshingProvider,
+		rewards:               rewards,
+		poetStats:             poetStats,
+		identities:            identities,
+	}, ctx
+}
+
+func TestIdentityService_EstimatedRewards(t *testing.T) {
+	t.Run("per identity", func(t *testing.T) {
+		c, ctx := setupIdentityService(t)
+		ids := []types.NodeID{{1}, {2}}
+		c.smeshingProvider.EXPECT().SmesherIDs().Return(ids)
+		c.rewards.EXPECT().EstimateRewards(ids).Return([]miner.EstimatedReward{
+			{NodeID: ids[0], ATX: types.ATXID{1}, NumUnits: 4, Weight: 40, Eligibilities: 2, Amount: 100},
+			{NodeID: ids[1]},
+		}, nil)
+		res, err := c.EstimatedRewards(ctx, &nodev1.EstimatedRewardsRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Identities, 2)
+
+		require.Equal(t, ids[0].Bytes(), res.Identities[0].NodeId)
+		require.Equal(t, types.ATXID{1}.Bytes(), res.Identities[0].AtxId)
+		require.EqualValues(t, 4, res.Identities[0].NumUnits)
+		require.EqualValues(t, 40, res.Identities[0].Weight)
+		require.EqualValues(t, 2, res.Identities[0].Eligibilities)
+		require.EqualValues(t, 100, res.Identities[0].Amount)
+
+		require.Equal(t, ids[1].Bytes(), res.Identities[1].NodeId)
+		require.Empty(t, res.Identities[1].AtxId)
+		require.Zero(t, res.Identities[1].Eligibilities)
+		require.Zero(t, res.Identities[1].Amount)
+	})
+	t.Run("fails", func(t *testing.T) {
+		c, ctx := setupIdentityService(t)
+		c.smeshingProvider.EXPECT().SmesherIDs().Return(nil)
+		c.rewards.EXPECT().EstimateRewards(gomock.Any()).Return(nil, errors.New("test"))
+		_, err := c.EstimatedRewards(ctx, &nodev1.EstimatedRewardsRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestIdentityService_PoetStats(t *testing.T) {
+	t.Run("per identity", func(t *testing.T) {
+		c, ctx := setupIdentityService(t)
+		ids := []types.NodeID{{1}, {2}}
+		failed := time.Unix(1000, 0)
+		c.smeshingProvider.EXPECT().SmesherIDs().Return(ids)
+		c.poetStats.EXPECT().PoetStats(ids[0]).Return([]poetstats.Stats{
+			{
+				NodeID:              ids[0],
+				Address:             "http://poet1:80",
+				SubmitSuccess:       2,
+				SubmitFailure:       1,
+				SubmitLatency:       4 * time.Second,
+				ProofSuccess:        1,
+				ProofLatency:        time.Second,
+				ConsecutiveFailures: 1,
+				LastFailure:         failed,
+			},
+			{NodeID: ids[0], Address: "http://poet2:80"},
+		}, nil)
+		c.poetStats.EXPECT().PoetStats(ids[1]).Return([]poetstats.Stats{
+			{NodeID: ids[1], Address: "http://poet1:80", ProofSuccess: 1},
+		}, nil)
+		res, err := c.PoetStats(ctx, &nodev1.PoetStatsRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Identities, 2)
+
+		require.Equal(t, ids[0].Bytes(), res.Identities[0].NodeId)
+		require.Len(t, res.Identities[0].Poets, 2)
+		poet := res.Identities[0].Poets[0]
+		require.Equal(t, "http://poet1:80", poet.Address)
+		require.EqualValues(t, 2, poet.SubmitSuccess)
+		require.EqualValues(t, 1, poet.SubmitFailure)
+		require.EqualValues(t, 2000, poet.AvgSubmitLatencyMs)
+		require.EqualValues(t, 1, poet.ProofSuccess)
+		require.Zero(t, poet.ProofFailure)
+		require.EqualValues(t, 1000, poet.AvgProofLatencyMs)
+		require.EqualValues(t, 1, poet.ConsecutiveFailures)
+		require.Equal(t, failed.Unix(), poet.LastFailure)
+		require.Equal(t, "http://poet2:80", res.Identities[0].Poets[1].Address)
+		require.Zero(t, res.Identities[0].Poets[1].LastFailure)
+
+		require.Equal(t, ids[1].Bytes(), res.Identities[1].NodeId)
+		require.Len(t, res.Identities[1].Poets, 1)
+		require.EqualValues(t, 1, res.Identities[1].Poets[0].ProofSuccess)
+	})
+	t.Run("fails", func(t *testing.T) {
+		c, ctx := setupIdentityService(t)
+		c.smeshingProvider.EXPECT().SmesherIDs().Return([]types.NodeID{{1}})
+		c.poetStats.EXPECT().PoetStats(types.NodeID{1}).Return(nil, errors.New("test"))
+		_, err := c.PoetStats(ctx, &nodev1.PoetStatsRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestIdentityService_Identities(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		c, ctx := setupIdentityService(t)
+		ids := []types.NodeID{types.RandomNodeID(), types.RandomNodeID()}
+		coinbase := types.GenerateAddress([]byte("coinbase"))
+		c.smeshingProvider.EXPECT().Identities().Return([]activation.IdentityStatus{
+			{Identity: smeshing.Identity{ID: ids[0], Coinbase: coinbase}, Smeshing: true},
+			{Identity: smeshing.Identity{ID: ids[1], Stopped: true, PauseEpoch: 3}},
+		}, nil)
+		res, err := c.Identities(ctx, &nodev1.IdentitiesRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Identities, 2)
+		require.Equal(t, ids[0].Bytes(), res.Identities[0].NodeId)
+		require.True(t, res.Identities[0].Smeshing)
+		require.Equal(t, coinbase.String(), res.Identities[0].Coinbase)
+		require.Equal(t, ids[1].Bytes(), res.Identities[1].NodeId)
+		require.True(t, res.Identities[1].Stopped)
+		require.Empty(t, res.Identities[1].Coinbase)
+		require.EqualValues(t, 3, res.Identities[1].PauseEpoch)
+	})
+	t.Run("fails", func(t *testing.T) {
+		c, ctx := setupIdentityService(t)
+		c.smeshingProvider.EXPECT().Identities().Return(nil, errors.New("test"))
+		_, err := c.Identities(ctx, &nodev1.IdentitiesRequest{})
+		require.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestIdentityService_IdentityFiles(t *testing.T) {
+	c, ctx := setupIdentityService(t)
+	id := types.RandomNodeID()
+
+	c.identities.EXPECT().AddIdentity("a.key").Return(id, nil)
+	added, err := c.AddIdentity(ctx, &nodev1.AddIdentityRequest{File: "a.key"})
+	require.NoError(t, err)
+	require.Equal(t, id.Bytes(), added.NodeId)
+
+	c.identities.EXPECT().AddIdentity("a.key").Return(types.EmptyNodeID, fmt.Errorf("%w: test", fs.ErrExist))
+	_, err = c.AddIdentity(ctx, &nodev1.AddIdentityRequest{File: "a.key"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	c.identities.EXPECT().AddIdentity("../a.key").Return(types.EmptyNodeID, fmt.Errorf("%w: test", fs.ErrInvalid))
+	_, err = c.AddIdentity(ctx, &nodev1.AddIdentityRequest{File: "../a.key"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	c.identities.EXPECT().RemoveIdentity("a.key").Return(id, nil)
+	removed, err := c.RemoveIdentity(ctx, &nodev1.RemoveIdentityRequest{File: "a.key"})
+	require.NoError(t, err)
+	require.Equal(t, id.Bytes(), removed.NodeId)
+
+	c.identities.EXPECT().RemoveIdentity("a.key").Return(types.EmptyNodeID, fmt.Errorf("%w: test", fs.ErrNotExist))
+	_, err = c.RemoveIdentity(ctx, &nodev1.RemoveIdentityRequest{File: "a.key"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	c.identities.EXPECT().RemoveIdentity("b.key").Return(types.EmptyNodeID, errors.ErrUnsupported)
+	_, err = c.RemoveIdentity(ctx, &nodev1.RemoveIdentityRequest{File: "b.key"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	c.identities.EXPECT().RemoveIdentity("b.key").Return(types.EmptyNodeID, errors.New("test"))
+	_, err = c.RemoveIdentity(ctx, &nodev1.RemoveIdentityRequest{File: "b.key"})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
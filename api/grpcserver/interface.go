@@ -10,8 +10,10 @@ import (
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/malfeasance/wire"
+	"github.com/spacemeshos/go-spacemesh/miner"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/poetstats"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -77,6 +79,28 @@ type grpcPostService interface {
 	AllowConnections(allow bool)
 }
 
+// rewardEstimator is an api to estimate rewards of the smeshing identities in the next epoch.
+type rewardEstimator interface {
+	EstimateRewards(ids []types.NodeID) ([]miner.EstimatedReward, error)
+}
+
+// poetStatsProvider is an api to get stats of requests made for the identity to the PoET services.
+type poetStatsProvider interface {
+	PoetStats(nodeID types.NodeID) ([]poetstats.Stats, error)
+}
+
+// identityManager is an api to add and remove identities of the running node.
+type identityManager interface {
+	AddIdentity(name string) (types.NodeID, error)
+	RemoveIdentity(name string) (types.NodeID, error)
+}
+
+// minGasProvider is an api to get and update the min gas price for the mempool.
+type minGasProvider interface {
+	MinGas() uint64
+	SetMinGas(price uint64) error
+}
+
 // peerCounter is an api to get amount of connected peers.
 type peerCounter interface {
 	PeerCount() uint64
@@ -86,6 +110,9 @@ type peerCounter interface {
 type peers interface {
 	ConnectedPeerInfo(p2p.Peer) *p2p.PeerInfo
 	GetPeers() []p2p.Peer
+	PeerRules() []p2p.Rule
+	AddPeerRule(p2p.Rule) error
+	RemovePeerRule(p2p.Rule) error
 }
 
 // genesisTimeAPI is an API to get genesis time and current layer of the system.
@@ -19,8 +19,10 @@ import (
 	activation "github.com/spacemeshos/go-spacemesh/activation"
 	types "github.com/spacemeshos/go-spacemesh/common/types"
 	wire "github.com/spacemeshos/go-spacemesh/malfeasance/wire"
+	miner "github.com/spacemeshos/go-spacemesh/miner"
 	p2p "github.com/spacemeshos/go-spacemesh/p2p"
 	signing "github.com/spacemeshos/go-spacemesh/signing"
+	poetstats "github.com/spacemeshos/go-spacemesh/sql/localsql/poetstats"
 	system "github.com/spacemeshos/go-spacemesh/system"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -1324,6 +1326,330 @@ func (c *MockgrpcPostServiceAllowConnectionsCall) DoAndReturn(f func(bool)) *Moc
 	return c
 }
 
+// MockrewardEstimator is a mock of rewardEstimator interface.
+type MockrewardEstimator struct {
+	ctrl     *gomock.Controller
+	recorder *MockrewardEstimatorMockRecorder
+}
+
+// MockrewardEstimatorMockRecorder is the mock recorder for MockrewardEstimator.
+type MockrewardEstimatorMockRecorder struct {
+	mock *MockrewardEstimator
+}
+
+// NewMockrewardEstimator creates a new mock instance.
+func NewMockrewardEstimator(ctrl *gomock.Controller) *MockrewardEstimator {
+	mock := &MockrewardEstimator{ctrl: ctrl}
+	mock.recorder = &MockrewardEstimatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrewardEstimator) EXPECT() *MockrewardEstimatorMockRecorder {
+	return m.recorder
+}
+
+// EstimateRewards mocks base method.
+func (m *MockrewardEstimator) EstimateRewards(ids []types.NodeID) ([]miner.EstimatedReward, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EstimateRewards", ids)
+	ret0, _ := ret[0].([]miner.EstimatedReward)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EstimateRewards indicates an expected call of EstimateRewards.
+func (mr *MockrewardEstimatorMockRecorder) EstimateRewards(ids any) *MockrewardEstimatorEstimateRewardsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EstimateRewards", reflect.TypeOf((*MockrewardEstimator)(nil).EstimateRewards), ids)
+	return &MockrewardEstimatorEstimateRewardsCall{Call: call}
+}
+
+// MockrewardEstimatorEstimateRewardsCall wrap *gomock.Call
+type MockrewardEstimatorEstimateRewardsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockrewardEstimatorEstimateRewardsCall) Return(arg0 []miner.EstimatedReward, arg1 error) *MockrewardEstimatorEstimateRewardsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockrewardEstimatorEstimateRewardsCall) Do(f func([]types.NodeID) ([]miner.EstimatedReward, error)) *MockrewardEstimatorEstimateRewardsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockrewardEstimatorEstimateRewardsCall) DoAndReturn(f func([]types.NodeID) ([]miner.EstimatedReward, error)) *MockrewardEstimatorEstimateRewardsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockpoetStatsProvider is a mock of poetStatsProvider interface.
+type MockpoetStatsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockpoetStatsProviderMockRecorder
+}
+
+// MockpoetStatsProviderMockRecorder is the mock recorder for MockpoetStatsProvider.
+type MockpoetStatsProviderMockRecorder struct {
+	mock *MockpoetStatsProvider
+}
+
+// NewMockpoetStatsProvider creates a new mock instance.
+func NewMockpoetStatsProvider(ctrl *gomock.Controller) *MockpoetStatsProvider {
+	mock := &MockpoetStatsProvider{ctrl: ctrl}
+	mock.recorder = &MockpoetStatsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockpoetStatsProvider) EXPECT() *MockpoetStatsProviderMockRecorder {
+	return m.recorder
+}
+
+// PoetStats mocks base method.
+func (m *MockpoetStatsProvider) PoetStats(nodeID types.NodeID) ([]poetstats.Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PoetStats", nodeID)
+	ret0, _ := ret[0].([]poetstats.Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PoetStats indicates an expected call of PoetStats.
+func (mr *MockpoetStatsProviderMockRecorder) PoetStats(nodeID any) *MockpoetStatsProviderPoetStatsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PoetStats", reflect.TypeOf((*MockpoetStatsProvider)(nil).PoetStats), nodeID)
+	return &MockpoetStatsProviderPoetStatsCall{Call: call}
+}
+
+// MockpoetStatsProviderPoetStatsCall wrap *gomock.Call
+type MockpoetStatsProviderPoetStatsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpoetStatsProviderPoetStatsCall) Return(arg0 []poetstats.Stats, arg1 error) *MockpoetStatsProviderPoetStatsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpoetStatsProviderPoetStatsCall) Do(f func(types.NodeID) ([]poetstats.Stats, error)) *MockpoetStatsProviderPoetStatsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpoetStatsProviderPoetStatsCall) DoAndReturn(f func(types.NodeID) ([]poetstats.Stats, error)) *MockpoetStatsProviderPoetStatsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockidentityManager is a mock of identityManager interface.
+type MockidentityManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockidentityManagerMockRecorder
+}
+
+// MockidentityManagerMockRecorder is the mock recorder for MockidentityManager.
+type MockidentityManagerMockRecorder struct {
+	mock *MockidentityManager
+}
+
+// NewMockidentityManager creates a new mock instance.
+func NewMockidentityManager(ctrl *gomock.Controller) *MockidentityManager {
+	mock := &MockidentityManager{ctrl: ctrl}
+	mock.recorder = &MockidentityManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockidentityManager) EXPECT() *MockidentityManagerMockRecorder {
+	return m.recorder
+}
+
+// AddIdentity mocks base method.
+func (m *MockidentityManager) AddIdentity(name string) (types.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIdentity", name)
+	ret0, _ := ret[0].(types.NodeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIdentity indicates an expected call of AddIdentity.
+func (mr *MockidentityManagerMockRecorder) AddIdentity(name any) *MockidentityManagerAddIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIdentity", reflect.TypeOf((*MockidentityManager)(nil).AddIdentity), name)
+	return &MockidentityManagerAddIdentityCall{Call: call}
+}
+
+// MockidentityManagerAddIdentityCall wrap *gomock.Call
+type MockidentityManagerAddIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockidentityManagerAddIdentityCall) Return(arg0 types.NodeID, arg1 error) *MockidentityManagerAddIdentityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockidentityManagerAddIdentityCall) Do(f func(string) (types.NodeID, error)) *MockidentityManagerAddIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockidentityManagerAddIdentityCall) DoAndReturn(f func(string) (types.NodeID, error)) *MockidentityManagerAddIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveIdentity mocks base method.
+func (m *MockidentityManager) RemoveIdentity(name string) (types.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveIdentity", name)
+	ret0, _ := ret[0].(types.NodeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveIdentity indicates an expected call of RemoveIdentity.
+func (mr *MockidentityManagerMockRecorder) RemoveIdentity(name any) *MockidentityManagerRemoveIdentityCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveIdentity", reflect.TypeOf((*MockidentityManager)(nil).RemoveIdentity), name)
+	return &MockidentityManagerRemoveIdentityCall{Call: call}
+}
+
+// MockidentityManagerRemoveIdentityCall wrap *gomock.Call
+type MockidentityManagerRemoveIdentityCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockidentityManagerRemoveIdentityCall) Return(arg0 types.NodeID, arg1 error) *MockidentityManagerRemoveIdentityCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockidentityManagerRemoveIdentityCall) Do(f func(string) (types.NodeID, error)) *MockidentityManagerRemoveIdentityCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockidentityManagerRemoveIdentityCall) DoAndReturn(f func(string) (types.NodeID, error)) *MockidentityManagerRemoveIdentityCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// MockminGasProvider is a mock of minGasProvider interface.
+type MockminGasProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockminGasProviderMockRecorder
+}
+
+// MockminGasProviderMockRecorder is the mock recorder for MockminGasProvider.
+type MockminGasProviderMockRecorder struct {
+	mock *MockminGasProvider
+}
+
+// NewMockminGasProvider creates a new mock instance.
+func NewMockminGasProvider(ctrl *gomock.Controller) *MockminGasProvider {
+	mock := &MockminGasProvider{ctrl: ctrl}
+	mock.recorder = &MockminGasProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockminGasProvider) EXPECT() *MockminGasProviderMockRecorder {
+	return m.recorder
+}
+
+// MinGas mocks base method.
+func (m *MockminGasProvider) MinGas() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinGas")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MinGas indicates an expected call of MinGas.
+func (mr *MockminGasProviderMockRecorder) MinGas() *MockminGasProviderMinGasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinGas", reflect.TypeOf((*MockminGasProvider)(nil).MinGas))
+	return &MockminGasProviderMinGasCall{Call: call}
+}
+
+// MockminGasProviderMinGasCall wrap *gomock.Call
+type MockminGasProviderMinGasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockminGasProviderMinGasCall) Return(arg0 uint64) *MockminGasProviderMinGasCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockminGasProviderMinGasCall) Do(f func() uint64) *MockminGasProviderMinGasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockminGasProviderMinGasCall) DoAndReturn(f func() uint64) *MockminGasProviderMinGasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetMinGas mocks base method.
+func (m *MockminGasProvider) SetMinGas(price uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMinGas", price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMinGas indicates an expected call of SetMinGas.
+func (mr *MockminGasProviderMockRecorder) SetMinGas(price any) *MockminGasProviderSetMinGasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMinGas", reflect.TypeOf((*MockminGasProvider)(nil).SetMinGas), price)
+	return &MockminGasProviderSetMinGasCall{Call: call}
+}
+
+// MockminGasProviderSetMinGasCall wrap *gomock.Call
+type MockminGasProviderSetMinGasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockminGasProviderSetMinGasCall) Return(arg0 error) *MockminGasProviderSetMinGasCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockminGasProviderSetMinGasCall) Do(f func(uint64) error) *MockminGasProviderSetMinGasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockminGasProviderSetMinGasCall) DoAndReturn(f func(uint64) error) *MockminGasProviderSetMinGasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MockpeerCounter is a mock of peerCounter interface.
 type MockpeerCounter struct {
 	ctrl     *gomock.Controller
@@ -1408,6 +1734,44 @@ func (m *Mockpeers) EXPECT() *MockpeersMockRecorder {
 	return m.recorder
 }
 
+// AddPeerRule mocks base method.
+func (m *Mockpeers) AddPeerRule(arg0 p2p.Rule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPeerRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddPeerRule indicates an expected call of AddPeerRule.
+func (mr *MockpeersMockRecorder) AddPeerRule(arg0 any) *MockpeersAddPeerRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeerRule", reflect.TypeOf((*Mockpeers)(nil).AddPeerRule), arg0)
+	return &MockpeersAddPeerRuleCall{Call: call}
+}
+
+// MockpeersAddPeerRuleCall wrap *gomock.Call
+type MockpeersAddPeerRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpeersAddPeerRuleCall) Return(arg0 error) *MockpeersAddPeerRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpeersAddPeerRuleCall) Do(f func(p2p.Rule) error) *MockpeersAddPeerRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpeersAddPeerRuleCall) DoAndReturn(f func(p2p.Rule) error) *MockpeersAddPeerRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // ConnectedPeerInfo mocks base method.
 func (m *Mockpeers) ConnectedPeerInfo(arg0 p2p.Peer) *p2p.PeerInfo {
 	m.ctrl.T.Helper()
@@ -1484,6 +1848,82 @@ func (c *MockpeersGetPeersCall) DoAndReturn(f func() []p2p.Peer) *MockpeersGetPe
 	return c
 }
 
+// PeerRules mocks base method.
+func (m *Mockpeers) PeerRules() []p2p.Rule {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerRules")
+	ret0, _ := ret[0].([]p2p.Rule)
+	return ret0
+}
+
+// PeerRules indicates an expected call of PeerRules.
+func (mr *MockpeersMockRecorder) PeerRules() *MockpeersPeerRulesCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerRules", reflect.TypeOf((*Mockpeers)(nil).PeerRules))
+	return &MockpeersPeerRulesCall{Call: call}
+}
+
+// MockpeersPeerRulesCall wrap *gomock.Call
+type MockpeersPeerRulesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpeersPeerRulesCall) Return(arg0 []p2p.Rule) *MockpeersPeerRulesCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpeersPeerRulesCall) Do(f func() []p2p.Rule) *MockpeersPeerRulesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpeersPeerRulesCall) DoAndReturn(f func() []p2p.Rule) *MockpeersPeerRulesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemovePeerRule mocks base method.
+func (m *Mockpeers) RemovePeerRule(arg0 p2p.Rule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePeerRule", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemovePeerRule indicates an expected call of RemovePeerRule.
+func (mr *MockpeersMockRecorder) RemovePeerRule(arg0 any) *MockpeersRemovePeerRuleCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeerRule", reflect.TypeOf((*Mockpeers)(nil).RemovePeerRule), arg0)
+	return &MockpeersRemovePeerRuleCall{Call: call}
+}
+
+// MockpeersRemovePeerRuleCall wrap *gomock.Call
+type MockpeersRemovePeerRuleCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockpeersRemovePeerRuleCall) Return(arg0 error) *MockpeersRemovePeerRuleCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockpeersRemovePeerRuleCall) Do(f func(p2p.Rule) error) *MockpeersRemovePeerRuleCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockpeersRemovePeerRuleCall) DoAndReturn(f func(p2p.Rule) error) *MockpeersRemovePeerRuleCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // MockgenesisTimeAPI is a mock of genesisTimeAPI interface.
 type MockgenesisTimeAPI struct {
 	ctrl     *gomock.Controller
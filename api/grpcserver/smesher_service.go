@@ -19,6 +19,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/spacemeshos/go-spacemesh/activation"
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/signing"
 )
@@ -28,6 +29,8 @@ type SmesherService struct {
 	smeshingProvider activation.SmeshingProvider
 	postSupervisor   postSupervisor
 	grpcPostService  grpcPostService
+	minGas           minGasProvider
+	rewards          rewardEstimator
 
 	streamInterval time.Duration
 	cmdCfg         *activation.PostSupervisorConfig
@@ -38,6 +41,7 @@ type SmesherService struct {
 // RegisterService registers this service with a grpc server instance.
 func (s SmesherService) RegisterService(server *grpc.Server) {
 	pb.RegisterSmesherServiceServer(server, s)
+	nodev1.RegisterSmesherServiceServer(server, s)
 }
 
 func (s SmesherService) RegisterHandlerService(mux *runtime.ServeMux) error {
@@ -54,6 +58,8 @@ func NewSmesherService(
 	smeshing activation.SmeshingProvider,
 	postSupervisor postSupervisor,
 	grpcPostService grpcPostService,
+	minGas minGasProvider,
+	rewards rewardEstimator,
 	streamInterval time.Duration,
 	postOpts activation.PostSetupOpts,
 	sig *signing.EdSigner,
@@ -62,6 +68,8 @@ func NewSmesherService(
 		smeshingProvider: smeshing,
 		postSupervisor:   postSupervisor,
 		grpcPostService:  grpcPostService,
+		minGas:           minGas,
+		rewards:          rewards,
 		streamInterval:   streamInterval,
 		postOpts:         postOpts,
 		sig:              sig,
@@ -168,6 +176,7 @@ func (s SmesherService) SmesherID(context.Context, *emptypb.Empty) (*pb.SmesherI
 	return nil, status.Errorf(codes.Unimplemented, "this endpoint has been deprecated, use `SmesherIDs` instead")
 }
 
+// SmesherIDs returns identities managed by the node.
 func (s SmesherService) SmesherIDs(context.Context, *emptypb.Empty) (*pb.SmesherIDsResponse, error) {
 	ids := s.smeshingProvider.SmesherIDs()
 	res := &pb.SmesherIDsResponse{}
@@ -201,20 +210,48 @@ func (s SmesherService) SetCoinbase(_ context.Context, in *pb.SetCoinbaseRequest
 
 // MinGas returns the current mingas setting of this node.
 func (s SmesherService) MinGas(context.Context, *emptypb.Empty) (*pb.MinGasResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+	return &pb.MinGasResponse{Mingas: &pb.SimpleInt{Value: s.minGas.MinGas()}}, nil
 }
 
 // SetMinGas sets the mingas setting of this node.
-func (s SmesherService) SetMinGas(context.Context, *pb.SetMinGasRequest) (*pb.SetMinGasResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+func (s SmesherService) SetMinGas(ctx context.Context, in *pb.SetMinGasRequest) (*pb.SetMinGasResponse, error) {
+	if in.Mingas == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "`Mingas` must be provided")
+	}
+	if err := s.minGas.SetMinGas(in.Mingas.Value); err != nil {
+		ctxzap.Error(ctx, "failed to set min gas", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to set min gas: %v", err))
+	}
+	return &pb.SetMinGasResponse{
+		Status: &rpcstatus.Status{Code: int32(code.Code_OK)},
+	}, nil
 }
 
 // EstimatedRewards returns estimated smeshing rewards over the next epoch.
+//
+// The response contains the total for all identities managed by the node. Breakdown per identity
+// is available in IdentityService.EstimatedRewards.
 func (s SmesherService) EstimatedRewards(
-	context.Context,
-	*pb.EstimatedRewardsRequest,
+	ctx context.Context,
+	_ *pb.EstimatedRewardsRequest,
 ) (*pb.EstimatedRewardsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "this endpoint is not implemented")
+	estimates, err := s.rewards.EstimateRewards(s.smeshingProvider.SmesherIDs())
+	if err != nil {
+		ctxzap.Error(ctx, "failed to estimate rewards", zap.Error(err))
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to estimate rewards: %v", err))
+	}
+	var (
+		total uint64
+		units uint32
+	)
+	for _, estimate := range estimates {
+		total += estimate.Amount
+		units += estimate.NumUnits
+	}
+	return &pb.EstimatedRewardsResponse{
+		Amount:   &pb.Amount{Value: total},
+		NumUnits: units,
+	}, nil
 }
 
 // PostSetupStatus returns post data status.
@@ -292,6 +329,104 @@ func (s SmesherService) PostConfig(context.Context, *emptypb.Empty) (*pb.PostCon
 	}, nil
 }
 
+// StartIdentity starts smeshing with an identity that was stopped or paused.
+func (s SmesherService) StartIdentity(
+	ctx context.Context,
+	in *nodev1.StartIdentityRequest,
+) (*nodev1.StartIdentityResponse, error) {
+	id, err := parseNodeID(in.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.smeshingProvider.StartSmeshingIdentity(id); err != nil {
+		return nil, identityError(ctx, "failed to start identity", err)
+	}
+	return &nodev1.StartIdentityResponse{}, nil
+}
+
+// StopIdentity stops smeshing with an identity until it is started again.
+func (s SmesherService) StopIdentity(
+	ctx context.Context,
+	in *nodev1.StopIdentityRequest,
+) (*nodev1.StopIdentityResponse, error) {
+	id, err := parseNodeID(in.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.smeshingProvider.StopSmeshingIdentity(id); err != nil {
+		return nil, identityError(ctx, "failed to stop identity", err)
+	}
+	return &nodev1.StopIdentityResponse{}, nil
+}
+
+// PauseIdentity makes an identity skip the ATX that is published in the epoch.
+func (s SmesherService) PauseIdentity(
+	ctx context.Context,
+	in *nodev1.PauseIdentityRequest,
+) (*nodev1.PauseIdentityResponse, error) {
+	id, err := parseNodeID(in.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.smeshingProvider.PauseIdentity(id, types.EpochID(in.Epoch)); err != nil {
+		return nil, identityError(ctx, "failed to pause identity", err)
+	}
+	return &nodev1.PauseIdentityResponse{}, nil
+}
+
+// SetIdentityCoinbase sets the coinbase that is used in ATXs of an identity.
+func (s SmesherService) SetIdentityCoinbase(
+	ctx context.Context,
+	in *nodev1.SetIdentityCoinbaseRequest,
+) (*nodev1.SetIdentityCoinbaseResponse, error) {
+	id, err := parseNodeID(in.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	var coinbase types.Address
+	if in.Coinbase != "" {
+		coinbase, err = types.StringToAddress(in.Coinbase)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to parse coinbase `%s`: %v", in.Coinbase, err)
+		}
+	}
+	if err := s.smeshingProvider.SetIdentityCoinbase(id, coinbase); err != nil {
+		return nil, identityError(ctx, "failed to set coinbase", err)
+	}
+	return &nodev1.SetIdentityCoinbaseResponse{}, nil
+}
+
+// ResetIdentity stops smeshing with an identity and discards its local NIPoST state.
+func (s SmesherService) ResetIdentity(
+	ctx context.Context,
+	in *nodev1.ResetIdentityRequest,
+) (*nodev1.ResetIdentityResponse, error) {
+	id, err := parseNodeID(in.NodeId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.smeshingProvider.ResetIdentity(id); err != nil {
+		return nil, identityError(ctx, "failed to reset identity", err)
+	}
+	return &nodev1.ResetIdentityResponse{}, nil
+}
+
+func parseNodeID(buf []byte) (types.NodeID, error) {
+	if len(buf) != types.NodeIDSize {
+		return types.EmptyNodeID, status.Errorf(codes.InvalidArgument,
+			"invalid node id length (%d), expected (%d)", len(buf), types.NodeIDSize)
+	}
+	return types.BytesToNodeID(buf), nil
+}
+
+func identityError(ctx context.Context, msg string, err error) error {
+	if errors.Is(err, activation.ErrUnknownIdentity) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	ctxzap.Error(ctx, msg, zap.Error(err))
+	return status.Error(codes.Internal, fmt.Sprintf("%s: %v", msg, err))
+}
+
 func statusToPbStatus(status *activation.PostSetupStatus) *pb.PostSetupStatus {
 	pbStatus := &pb.PostSetupStatus{}
 
@@ -2,6 +2,7 @@ package grpcserver_test
 
 import (
 	"context"
+	"errors"
 	"math/rand/v2"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/activation"
 	"github.com/spacemeshos/go-spacemesh/api/grpcserver"
+	nodev1 "github.com/spacemeshos/go-spacemesh/api/node/v1"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/signing"
 )
@@ -29,6 +31,8 @@ func TestPostConfig(t *testing.T) {
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
 		time.Second,
 		activation.DefaultPostSetupOpts(),
 		nil,
@@ -65,6 +69,8 @@ func TestStartSmeshingPassesCorrectSmeshingOpts(t *testing.T) {
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
 		time.Second,
 		activation.DefaultPostSetupOpts(),
 		sig,
@@ -112,6 +118,8 @@ func TestStartSmeshing_ErrorOnMissingPostServiceConfig(t *testing.T) {
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
 		time.Second,
 		activation.DefaultPostSetupOpts(),
 		sig,
@@ -151,6 +159,8 @@ func TestStartSmeshing_ErrorOnMultiSmeshingSetup(t *testing.T) {
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
 		time.Second,
 		activation.DefaultPostSetupOpts(),
 		nil, // no nodeID in multi smesher setup
@@ -192,6 +202,8 @@ func TestSmesherService_PostSetupProviders(t *testing.T) {
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
 		time.Second,
 		activation.DefaultPostSetupOpts(),
 		nil, // no nodeID in multi smesher setup
@@ -241,6 +253,8 @@ func TestSmesherService_PostSetupStatus(t *testing.T) {
 			smeshingProvider,
 			postSupervisor,
 			grpcPostService,
+			grpcserver.NewMockminGasProvider(ctrl),
+			grpcserver.NewMockrewardEstimator(ctrl),
 			time.Second,
 			activation.DefaultPostSetupOpts(),
 			nil,
@@ -267,6 +281,8 @@ func TestSmesherService_PostSetupStatus(t *testing.T) {
 			smeshingProvider,
 			postSupervisor,
 			grpcPostService,
+			grpcserver.NewMockminGasProvider(ctrl),
+			grpcserver.NewMockrewardEstimator(ctrl),
 			time.Second,
 			activation.DefaultPostSetupOpts(),
 			nil,
@@ -306,6 +322,8 @@ func TestSmesherService_PostSetupStatus(t *testing.T) {
 			smeshingProvider,
 			postSupervisor,
 			grpcPostService,
+			grpcserver.NewMockminGasProvider(ctrl),
+			grpcserver.NewMockrewardEstimator(ctrl),
 			time.Second,
 			activation.DefaultPostSetupOpts(),
 			nil,
@@ -346,6 +364,8 @@ func TestSmesherService_SmesherID(t *testing.T) {
 		smeshingProvider,
 		postSupervisor,
 		grpcPostService,
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
 		time.Second,
 		activation.DefaultPostSetupOpts(),
 		nil,
@@ -358,3 +378,62 @@ func TestSmesherService_SmesherID(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, codes.Unimplemented, statusErr.Code())
 }
+
+func TestSmesherService_ManageIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	smeshingProvider := activation.NewMockSmeshingProvider(ctrl)
+	svc := grpcserver.NewSmesherService(
+		smeshingProvider,
+		grpcserver.NewMockpostSupervisor(ctrl),
+		grpcserver.NewMockgrpcPostService(ctrl),
+		grpcserver.NewMockminGasProvider(ctrl),
+		grpcserver.NewMockrewardEstimator(ctrl),
+		time.Second,
+		activation.DefaultPostSetupOpts(),
+		nil,
+	)
+	ctx := context.Background()
+	id := types.RandomNodeID()
+
+	smeshingProvider.EXPECT().StartSmeshingIdentity(id).Return(nil)
+	_, err := svc.StartIdentity(ctx, &nodev1.StartIdentityRequest{NodeId: id.Bytes()})
+	require.NoError(t, err)
+
+	smeshingProvider.EXPECT().StopSmeshingIdentity(id).Return(nil)
+	_, err = svc.StopIdentity(ctx, &nodev1.StopIdentityRequest{NodeId: id.Bytes()})
+	require.NoError(t, err)
+
+	smeshingProvider.EXPECT().PauseIdentity(id, types.EpochID(5)).Return(nil)
+	_, err = svc.PauseIdentity(ctx, &nodev1.PauseIdentityRequest{NodeId: id.Bytes(), Epoch: 5})
+	require.NoError(t, err)
+
+	coinbase := types.GenerateAddress([]byte("coinbase"))
+	smeshingProvider.EXPECT().SetIdentityCoinbase(id, coinbase).Return(nil)
+	_, err = svc.SetIdentityCoinbase(ctx, &nodev1.SetIdentityCoinbaseRequest{
+		NodeId:   id.Bytes(),
+		Coinbase: coinbase.String(),
+	})
+	require.NoError(t, err)
+
+	smeshingProvider.EXPECT().SetIdentityCoinbase(id, types.Address{}).Return(nil)
+	_, err = svc.SetIdentityCoinbase(ctx, &nodev1.SetIdentityCoinbaseRequest{NodeId: id.Bytes()})
+	require.NoError(t, err)
+
+	_, err = svc.SetIdentityCoinbase(ctx, &nodev1.SetIdentityCoinbaseRequest{NodeId: id.Bytes(), Coinbase: "x"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	smeshingProvider.EXPECT().ResetIdentity(id).Return(nil)
+	_, err = svc.ResetIdentity(ctx, &nodev1.ResetIdentityRequest{NodeId: id.Bytes()})
+	require.NoError(t, err)
+
+	_, err = svc.StartIdentity(ctx, &nodev1.StartIdentityRequest{NodeId: []byte{1, 2}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	smeshingProvider.EXPECT().StartSmeshingIdentity(id).Return(activation.ErrUnknownIdentity)
+	_, err = svc.StartIdentity(ctx, &nodev1.StartIdentityRequest{NodeId: id.Bytes()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	smeshingProvider.EXPECT().StopSmeshingIdentity(id).Return(errors.New("test"))
+	_, err = svc.StopIdentity(ctx, &nodev1.StopIdentityRequest{NodeId: id.Bytes()})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,618 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: node/v1/admin.proto
+
+package nodev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PeerRuleAction int32
+
+const (
+	PeerRuleAction_PEER_RULE_ACTION_UNSPECIFIED PeerRuleAction = 0
+	// Refuse connections to and from the matching peers.
+	PeerRuleAction_PEER_RULE_ACTION_BLOCK PeerRuleAction = 1
+	// Accept connections to and from the matching peers regardless of other rules and limits.
+	PeerRuleAction_PEER_RULE_ACTION_ALLOW PeerRuleAction = 2
+)
+
+// Enum value maps for PeerRuleAction.
+var (
+	PeerRuleAction_name = map[int32]string{
+		0: "PEER_RULE_ACTION_UNSPECIFIED",
+		1: "PEER_RULE_ACTION_BLOCK",
+		2: "PEER_RULE_ACTION_ALLOW",
+	}
+	PeerRuleAction_value = map[string]int32{
+		"PEER_RULE_ACTION_UNSPECIFIED": 0,
+		"PEER_RULE_ACTION_BLOCK":       1,
+		"PEER_RULE_ACTION_ALLOW":       2,
+	}
+)
+
+func (x PeerRuleAction) Enum() *PeerRuleAction {
+	p := new(PeerRuleAction)
+	*p = x
+	return p
+}
+
+func (x PeerRuleAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PeerRuleAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_node_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (PeerRuleAction) Type() protoreflect.EnumType {
+	return &file_node_v1_admin_proto_enumTypes[0]
+}
+
+func (x PeerRuleAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PeerRuleAction.Descriptor instead.
+func (PeerRuleAction) EnumDescriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type PeerRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action PeerRuleAction `protobuf:"varint,1,opt,name=action,proto3,enum=spacemesh.node.v1.PeerRuleAction" json:"action,omitempty"`
+	// Peer id or an address range in CIDR notation.
+	Target string `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
+	// Rule without expiry never expires.
+	Expires *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires,proto3" json:"expires,omitempty"`
+}
+
+func (x *PeerRule) Reset() {
+	*x = PeerRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerRule) ProtoMessage() {}
+
+func (x *PeerRule) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerRule.ProtoReflect.Descriptor instead.
+func (*PeerRule) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PeerRule) GetAction() PeerRuleAction {
+	if x != nil {
+		return x.Action
+	}
+	return PeerRuleAction_PEER_RULE_ACTION_UNSPECIFIED
+}
+
+func (x *PeerRule) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *PeerRule) GetExpires() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expires
+	}
+	return nil
+}
+
+type ListPeerRulesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListPeerRulesRequest) Reset() {
+	*x = ListPeerRulesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPeerRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPeerRulesRequest) ProtoMessage() {}
+
+func (x *ListPeerRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPeerRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListPeerRulesRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+type ListPeerRulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rules []*PeerRule `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *ListPeerRulesResponse) Reset() {
+	*x = ListPeerRulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListPeerRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPeerRulesResponse) ProtoMessage() {}
+
+func (x *ListPeerRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPeerRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListPeerRulesResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPeerRulesResponse) GetRules() []*PeerRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type AddPeerRuleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rule *PeerRule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *AddPeerRuleRequest) Reset() {
+	*x = AddPeerRuleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPeerRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPeerRuleRequest) ProtoMessage() {}
+
+func (x *AddPeerRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPeerRuleRequest.ProtoReflect.Descriptor instead.
+func (*AddPeerRuleRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddPeerRuleRequest) GetRule() *PeerRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type AddPeerRuleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AddPeerRuleResponse) Reset() {
+	*x = AddPeerRuleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPeerRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPeerRuleResponse) ProtoMessage() {}
+
+func (x *AddPeerRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPeerRuleResponse.ProtoReflect.Descriptor instead.
+func (*AddPeerRuleResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+type RemovePeerRuleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Rule with the same action and target is removed, expiry is ignored.
+	Rule *PeerRule `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *RemovePeerRuleRequest) Reset() {
+	*x = RemovePeerRuleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemovePeerRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePeerRuleRequest) ProtoMessage() {}
+
+func (x *RemovePeerRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePeerRuleRequest.ProtoReflect.Descriptor instead.
+func (*RemovePeerRuleRequest) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemovePeerRuleRequest) GetRule() *PeerRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type RemovePeerRuleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemovePeerRuleResponse) Reset() {
+	*x = RemovePeerRuleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_node_v1_admin_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemovePeerRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemovePeerRuleResponse) ProtoMessage() {}
+
+func (x *RemovePeerRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_node_v1_admin_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemovePeerRuleResponse.ProtoReflect.Descriptor instead.
+func (*RemovePeerRuleResponse) Descriptor() ([]byte, []int) {
+	return file_node_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+var File_node_v1_admin_proto protoreflect.FileDescriptor
+
+var file_node_v1_admin_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x11, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68,
+	0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x93, 0x01, 0x0a, 0x08, 0x50, 0x65,
+	0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x75, 0x6c, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x34, 0x0a, 0x07, 0x65, 0x78, 0x70,
+	0x69, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x22,
+	0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4a, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x31, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75,
+	0x6c, 0x65, 0x73, 0x22, 0x45, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x75, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0x15, 0x0a, 0x13, 0x41, 0x64,
+	0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x48, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x22, 0x18, 0x0a, 0x16, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2a, 0x6a, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c,
+	0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x1c, 0x50, 0x45, 0x45, 0x52, 0x5f,
+	0x52, 0x55, 0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x45, 0x45,
+	0x52, 0x5f, 0x52, 0x55, 0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x42, 0x4c,
+	0x4f, 0x43, 0x4b, 0x10, 0x01, 0x12, 0x1a, 0x0a, 0x16, 0x50, 0x45, 0x45, 0x52, 0x5f, 0x52, 0x55,
+	0x4c, 0x45, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x4c, 0x4c, 0x4f, 0x57, 0x10,
+	0x02, 0x32, 0xb7, 0x02, 0x0a, 0x0c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x62, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75,
+	0x6c, 0x65, 0x73, 0x12, 0x27, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e,
+	0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72,
+	0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x25, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73,
+	0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65,
+	0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x41, 0x64, 0x64, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x65,
+	0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x28, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x29, 0x2e, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x73, 0x68, 0x2e, 0x6e, 0x6f, 0x64,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x38, 0x5a, 0x36, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x73, 0x68, 0x6f, 0x73, 0x2f, 0x67, 0x6f, 0x2d, 0x73, 0x70, 0x61, 0x63, 0x65, 0x6d, 0x65,
+	0x73, 0x68, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6e, 0x6f, 0x64, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x6e,
+	0x6f, 0x64, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_node_v1_admin_proto_rawDescOnce sync.Once
+	file_node_v1_admin_proto_rawDescData = file_node_v1_admin_proto_rawDesc
+)
+
+func file_node_v1_admin_proto_rawDescGZIP() []byte {
+	file_node_v1_admin_proto_rawDescOnce.Do(func() {
+		file_node_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(file_node_v1_admin_proto_rawDescData)
+	})
+	return file_node_v1_admin_proto_rawDescData
+}
+
+var file_node_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_node_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_node_v1_admin_proto_goTypes = []interface{}{
+	(PeerRuleAction)(0),            // 0: spacemesh.node.v1.PeerRuleAction
+	(*PeerRule)(nil),               // 1: spacemesh.node.v1.PeerRule
+	(*ListPeerRulesRequest)(nil),   // 2: spacemesh.node.v1.ListPeerRulesRequest
+	(*ListPeerRulesResponse)(nil),  // 3: spacemesh.node.v1.ListPeerRulesResponse
+	(*AddPeerRuleRequest)(nil),     // 4: spacemesh.node.v1.AddPeerRuleRequest
+	(*AddPeerRuleResponse)(nil),    // 5: spacemesh.node.v1.AddPeerRuleResponse
+	(*RemovePeerRuleRequest)(nil),  // 6: spacemesh.node.v1.RemovePeerRuleRequest
+	(*RemovePeerRuleResponse)(nil), // 7: spacemesh.node.v1.RemovePeerRuleResponse
+	(*timestamppb.Timestamp)(nil),  // 8: google.protobuf.Timestamp
+}
+var file_node_v1_admin_proto_depIdxs = []int32{
+	0, // 0: spacemesh.node.v1.PeerRule.action:type_name -> spacemesh.node.v1.PeerRuleAction
+	8, // 1: spacemesh.node.v1.PeerRule.expires:type_name -> google.protobuf.Timestamp
+	1, // 2: spacemesh.node.v1.ListPeerRulesResponse.rules:type_name -> spacemesh.node.v1.PeerRule
+	1, // 3: spacemesh.node.v1.AddPeerRuleRequest.rule:type_name -> spacemesh.node.v1.PeerRule
+	1, // 4: spacemesh.node.v1.RemovePeerRuleRequest.rule:type_name -> spacemesh.node.v1.PeerRule
+	2, // 5: spacemesh.node.v1.AdminService.ListPeerRules:input_type -> spacemesh.node.v1.ListPeerRulesRequest
+	4, // 6: spacemesh.node.v1.AdminService.AddPeerRule:input_type -> spacemesh.node.v1.AddPeerRuleRequest
+	6, // 7: spacemesh.node.v1.AdminService.RemovePeerRule:input_type -> spacemesh.node.v1.RemovePeerRuleRequest
+	3, // 8: spacemesh.node.v1.AdminService.ListPeerRules:output_type -> spacemesh.node.v1.ListPeerRulesResponse
+	5, // 9: spacemesh.node.v1.AdminService.AddPeerRule:output_type -> spacemesh.node.v1.AddPeerRuleResponse
+	7, // 10: spacemesh.node.v1.AdminService.RemovePeerRule:output_type -> spacemesh.node.v1.RemovePeerRuleResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_node_v1_admin_proto_init() }
+func file_node_v1_admin_proto_init() {
+	if File_node_v1_admin_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_node_v1_admin_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_v1_admin_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPeerRulesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_v1_admin_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListPeerRulesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_v1_admin_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddPeerRuleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_v1_admin_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddPeerRuleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_v1_admin_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemovePeerRuleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_node_v1_admin_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemovePeerRuleResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_node_v1_admin_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_node_v1_admin_proto_goTypes,
+		DependencyIndexes: file_node_v1_admin_proto_depIdxs,
+		EnumInfos:         file_node_v1_admin_proto_enumTypes,
+		MessageInfos:      file_node_v1_admin_proto_msgTypes,
+	}.Build()
+	File_node_v1_admin_proto = out.File
+	file_node_v1_admin_proto_rawDesc = nil
+	file_node_v1_admin_proto_goTypes = nil
+	file_node_v1_admin_proto_depIdxs = nil
+}
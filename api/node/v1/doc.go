@@ -0,0 +1,5 @@
+// Package nodev1 is the local API of the node, for services that are specific to go-spacemesh
+// and are not part of github.com/spacemeshos/api.
+package nodev1
+
+//go:generate buf generate --template ../../buf.gen.yaml --output ../.. ../..
@@ -26,6 +26,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/beaconstate"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
@@ -70,6 +71,14 @@ func WithConfig(cfg Config) Opt {
 	}
 }
 
+// WithLocalDB defines the local database used to persist the state of the protocol,
+// so that the node can rejoin the protocol in progress after restart.
+func WithLocalDB(db sql.Executor) Opt {
+	return func(pd *ProtocolDriver) {
+		pd.localDB = db
+	}
+}
+
 func withWeakCoin(wc coin) Opt {
 	return func(pd *ProtocolDriver) {
 		pd.weakCoin = wc
@@ -139,6 +148,16 @@ func (pd *ProtocolDriver) Register(sig *signing.EdSigner) {
 	pd.signers[sig.NodeID()] = sig
 }
 
+// Unregister removes the signing key of the identity that was registered with Register.
+func (pd *ProtocolDriver) Unregister(id types.NodeID) {
+	pd.mu.Lock()
+	defer pd.mu.Unlock()
+	if _, exists := pd.signers[id]; exists {
+		pd.logger.With().Info("unregistered signing key", log.ShortStringer("id", id))
+		delete(pd.signers, id)
+	}
+}
+
 type participant struct {
 	signer *signing.EdSigner
 	nonce  types.VRFPostIndex
@@ -177,6 +196,7 @@ type ProtocolDriver struct {
 	clock    layerClock
 	msgTimes *messageTimes
 	cdb      *datastore.CachedDB
+	localDB  sql.Executor
 
 	mu sync.RWMutex
 
@@ -231,6 +251,12 @@ func (pd *ProtocolDriver) Start(ctx context.Context) {
 		}
 		pd.logger.With().Info("starting beacon protocol", log.Any("config", pd.config))
 		pd.setProposalTimeForNextEpoch()
+		if pd.localDB != nil {
+			pd.eg.Go(func() error {
+				pd.resume(ctx)
+				return nil
+			})
+		}
 		pd.eg.Go(func() error {
 			pd.listenEpochs(ctx)
 			return nil
@@ -685,6 +711,12 @@ func (pd *ProtocolDriver) cleanupEpoch(epoch types.EpochID) {
 	}
 	delete(pd.states, epoch)
 
+	if pd.localDB != nil {
+		if err := beaconstate.DeleteBefore(pd.localDB, epoch); err != nil {
+			pd.logger.With().Error("failed to delete persisted beacon state", epoch, log.Err(err))
+		}
+	}
+
 	if epoch <= numEpochsToKeep {
 		return
 	}
@@ -787,11 +819,16 @@ func (pd *ProtocolDriver) runProtocol(ctx context.Context, epoch types.EpochID,
 		logger.With().Warning("proposal phase failed", log.Err(err))
 		return
 	}
-	lastRoundOwnVotes, err := pd.runConsensusPhase(ctx, epoch, st)
+	lastRoundOwnVotes, err := pd.runConsensusPhase(ctx, epoch, st, pd.config.FirstVotingRoundDuration)
 	if err != nil {
 		logger.With().Warning("consensus phase failed", log.Err(err))
 		return
 	}
+	pd.setBeaconFromVotes(logger, targetEpoch, lastRoundOwnVotes)
+}
+
+// setBeaconFromVotes calculates the beacon from own votes after the last round.
+func (pd *ProtocolDriver) setBeaconFromVotes(logger log.Log, targetEpoch types.EpochID, lastRoundOwnVotes allVotes) {
 	if len(lastRoundOwnVotes.support) == 0 {
 		logger.With().Warning("consensus phase failed", log.Err(errNoProposals))
 		return
@@ -801,7 +838,7 @@ func (pd *ProtocolDriver) runProtocol(ctx context.Context, epoch types.EpochID,
 	// After K rounds had passed, tally up votes for proposals using simple tortoise vote counting
 	beacon := calcBeacon(logger, lastRoundOwnVotes.support)
 
-	if err = pd.setBeacon(targetEpoch, beacon); err != nil {
+	if err := pd.setBeacon(targetEpoch, beacon); err != nil {
 		logger.With().Error("failed to set beacon", log.Err(err))
 		return
 	}
@@ -846,6 +883,7 @@ func (pd *ProtocolDriver) runProposalPhase(ctx context.Context, epoch types.Epoc
 	finished := time.Now()
 	pd.markProposalPhaseFinished(st, finished)
 	logger.With().Info("proposal phase finished", log.Time("finished_at", finished))
+	pd.persistState(logger, epoch, st, types.FirstRound, allVotes{})
 	return nil
 }
 
@@ -887,7 +925,13 @@ func (pd *ProtocolDriver) sendProposal(
 }
 
 // runConsensusPhase runs K voting rounds and returns result from last weak coin round.
-func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.EpochID, st *state) (allVotes, error) {
+// The first voting round lasts for firstRound, it is shorter if the node rejoins the protocol in progress.
+func (pd *ProtocolDriver) runConsensusPhase(
+	ctx context.Context,
+	epoch types.EpochID,
+	st *state,
+	firstRound time.Duration,
+) (allVotes, error) {
 	logger := pd.logger.WithContext(ctx).WithFields(epoch)
 	logger.Info("starting consensus phase")
 
@@ -895,14 +939,9 @@ func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.Epo
 	// For next rounds,
 	// wait for δ time, and construct a message that points to all messages from previous round received by δ.
 	// rounds 1 to K
-	timer := time.NewTimer(pd.config.FirstVotingRoundDuration)
+	timer := time.NewTimer(firstRound)
 	defer timer.Stop()
 
-	var (
-		ownVotes  allVotes
-		undecided proposalList
-	)
-
 	// First round
 	round := types.FirstRound
 	pd.setRoundInProgress(round)
@@ -927,13 +966,33 @@ func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.Epo
 	case <-ctx.Done():
 		return allVotes{}, fmt.Errorf("context done: %w", ctx.Err())
 	}
-	ownVotes, _ = pd.calcVotesBeforeWeakCoin(logger, st)
+	ownVotes, _ := pd.calcVotesBeforeWeakCoin(logger, st)
+	pd.persistState(logger, epoch, st, types.FirstRound+1, ownVotes)
+
+	return pd.runFollowingRounds(ctx, epoch, st, types.FirstRound+1, ownVotes, pd.config.VotingRoundDuration)
+}
+
+// runFollowingRounds runs voting rounds starting from the given one, ownVotes are votes from the previous round.
+// The voting in the first of these rounds lasts for voting, it is shorter if the node rejoins the protocol in progress.
+func (pd *ProtocolDriver) runFollowingRounds(
+	ctx context.Context,
+	epoch types.EpochID,
+	st *state,
+	from types.RoundID,
+	ownVotes allVotes,
+	voting time.Duration,
+) (allVotes, error) {
+	logger := pd.logger.WithContext(ctx).WithFields(epoch)
+	timer := time.NewTimer(voting)
+	defer timer.Stop()
 
-	// Subsequent rounds
-	for round := types.FirstRound + 1; round < pd.config.RoundsNumber; round++ {
+	var undecided proposalList
+	for round := from; round < pd.config.RoundsNumber; round++ {
 		pd.setRoundInProgress(round)
 		rLogger := logger.WithFields(round)
-		timer.Reset(pd.config.VotingRoundDuration)
+		if round != from {
+			timer.Reset(pd.config.VotingRoundDuration)
+		}
 
 		votes := ownVotes
 		for _, session := range st.active {
@@ -985,6 +1044,7 @@ func (pd *ProtocolDriver) runConsensusPhase(ctx context.Context, epoch types.Epo
 		}
 
 		tallyUndecided(&ownVotes, undecided, flip)
+		pd.persistState(rLogger, epoch, st, round+1, ownVotes)
 	}
 
 	logger.Info("consensus phase finished")
@@ -1008,10 +1068,14 @@ func (pd *ProtocolDriver) sendFirstRoundVote(
 	msg FirstVotingMessageBody,
 	signer *signing.EdSigner,
 ) error {
+	signature, err := signer.SignOnce(signing.BEACON_FIRST_MSG, msg.EpochID.FirstLayer(), 0, codec.MustEncode(&msg))
+	if err != nil {
+		return fmt.Errorf("sign first round vote: %w", err)
+	}
 	m := FirstVotingMessage{
 		FirstVotingMessageBody: msg,
 		SmesherID:              signer.NodeID(),
-		Signature:              signer.Sign(signing.BEACON_FIRST_MSG, codec.MustEncode(&msg)),
+		Signature:              signature,
 	}
 
 	pd.logger.WithContext(ctx).
@@ -1051,10 +1115,16 @@ func (pd *ProtocolDriver) sendFollowingVote(
 		VotesBitVector: bitVector,
 	}
 
+	signature, err := signer.SignOnce(
+		signing.BEACON_FOLLOWUP_MSG, epoch.FirstLayer(), uint32(round), codec.MustEncode(&mb),
+	)
+	if err != nil {
+		return fmt.Errorf("sign following round vote: %w", err)
+	}
 	m := FollowingVotingMessage{
 		FollowingVotingMessageBody: mb,
 		SmesherID:                  signer.NodeID(),
-		Signature:                  signer.Sign(signing.BEACON_FOLLOWUP_MSG, codec.MustEncode(&mb)),
+		Signature:                  signature,
 	}
 
 	pd.logger.WithContext(ctx).
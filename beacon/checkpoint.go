@@ -0,0 +1,329 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/beaconstate"
+)
+
+//go:generate scalegen
+
+// EpochCheckpoint is the state of the beacon protocol for an epoch.
+// It is persisted after every phase of the protocol, so that the node can rejoin the protocol after restart.
+type EpochCheckpoint struct {
+	// ProposalPhaseFinished is the time when the proposal phase finished, in unix nanoseconds.
+	ProposalPhaseFinished uint64
+	Valid                 []Proposal     `scale:"max=100000"`
+	PotentiallyValid      []Proposal     `scale:"max=100000"`
+	Proposed              []types.NodeID `scale:"max=100000"`
+	// number of voters is bounded by the number of identities with atxs in the epoch
+	Voted   []VotedRounds `scale:"max=16777216"`
+	Margins []VoteMargin  `scale:"max=200000"`
+	// Support and Against are own votes that will be sent in the next round.
+	Support []Proposal `scale:"max=200000"`
+	Against []Proposal `scale:"max=200000"`
+}
+
+// VotedRounds is a bit vector of rounds in which the miner voted.
+type VotedRounds struct {
+	NodeID types.NodeID
+	Rounds []byte `scale:"max=128"`
+}
+
+// VoteMargin is the sum of weights of votes for the proposal, votes against have negative weight.
+type VoteMargin struct {
+	Proposal Proposal
+	Margin   []byte `scale:"max=64"`
+	Negative bool
+}
+
+// checkpoint captures the state of the epoch. Own votes are the votes that will be sent in the next round.
+// Must be called with the lock of the ProtocolDriver held.
+func (s *state) checkpoint(own allVotes) *EpochCheckpoint {
+	cp := &EpochCheckpoint{
+		Valid:            s.incomingProposals.valid.sorted(),
+		PotentiallyValid: s.incomingProposals.potentiallyValid.sorted(),
+		Support:          own.support.sorted(),
+		Against:          own.against.sorted(),
+	}
+	if !s.proposalPhaseFinishedTime.IsZero() {
+		cp.ProposalPhaseFinished = uint64(s.proposalPhaseFinishedTime.UnixNano())
+	}
+	for id := range s.hasProposed {
+		cp.Proposed = append(cp.Proposed, id)
+	}
+	for id, tracker := range s.hasVoted {
+		cp.Voted = append(cp.Voted, VotedRounds{NodeID: id, Rounds: tracker.votes.Bytes()})
+	}
+	for proposal, margin := range s.votesMargin {
+		cp.Margins = append(cp.Margins, VoteMargin{
+			Proposal: proposal,
+			Margin:   margin.Bytes(),
+			Negative: margin.Sign() < 0,
+		})
+	}
+	return cp
+}
+
+// restore applies the checkpoint to the state and returns own votes for the next round.
+// Must be called with the lock of the ProtocolDriver held.
+func (s *state) restore(cp *EpochCheckpoint) allVotes {
+	for _, proposal := range cp.Valid {
+		s.addValidProposal(proposal)
+	}
+	for _, proposal := range cp.PotentiallyValid {
+		s.addPotentiallyValidProposal(proposal)
+	}
+	if cp.ProposalPhaseFinished != 0 {
+		s.proposalPhaseFinishedTime = time.Unix(0, int64(cp.ProposalPhaseFinished))
+	}
+	for _, id := range cp.Proposed {
+		s.hasProposed[id] = struct{}{}
+	}
+	for _, voted := range cp.Voted {
+		s.hasVoted[voted.NodeID] = &votesTracker{votes: new(big.Int).SetBytes(voted.Rounds)}
+	}
+	for _, margin := range cp.Margins {
+		value := new(big.Int).SetBytes(margin.Margin)
+		if margin.Negative {
+			value.Neg(value)
+		}
+		s.votesMargin[margin.Proposal] = value
+	}
+	own := allVotes{support: make(proposalSet), against: make(proposalSet)}
+	for _, proposal := range cp.Support {
+		own.support[proposal] = struct{}{}
+	}
+	for _, proposal := range cp.Against {
+		own.against[proposal] = struct{}{}
+	}
+	return own
+}
+
+// persistState checkpoints the state of the epoch before the round. Own votes are the votes for the round.
+func (pd *ProtocolDriver) persistState(
+	logger log.Log,
+	epoch types.EpochID,
+	st *state,
+	round types.RoundID,
+	own allVotes,
+) {
+	if pd.localDB == nil {
+		return
+	}
+	pd.mu.RLock()
+	cp := st.checkpoint(own)
+	pd.mu.RUnlock()
+	if err := beaconstate.SetState(pd.localDB, epoch, round, codec.MustEncode(cp)); err != nil {
+		logger.With().Error("failed to persist beacon state", round, log.Err(err))
+	}
+}
+
+// resume rejoins the protocol for the current epoch from the persisted state,
+// if the node restarted while the protocol was in progress and the round it stopped at is still running.
+func (pd *ProtocolDriver) resume(ctx context.Context) {
+	epoch := pd.currentEpoch()
+	logger := pd.logger.WithContext(ctx).WithFields(epoch)
+	if epoch.FirstLayer() <= types.GetEffectiveGenesis() {
+		return
+	}
+	if !pd.sync.IsSynced(ctx) {
+		logger.Info("not resuming beacon protocol: node not synced")
+		return
+	}
+	round, data, err := beaconstate.GetState(pd.localDB, epoch)
+	if errors.Is(err, sql.ErrNotFound) {
+		return
+	} else if err != nil {
+		logger.With().Error("failed to load persisted beacon state", log.Err(err))
+		return
+	}
+	if _, err := pd.GetBeacon(epoch + 1); err == nil {
+		return
+	}
+	var cp EpochCheckpoint
+	if err := codec.Decode(data, &cp); err != nil {
+		logger.With().Error("failed to decode persisted beacon state", log.Err(err))
+		return
+	}
+
+	defer pd.cleanupEpoch(epoch)
+	st, err := pd.initEpochStateIfNotPresent(logger, epoch)
+	if err != nil {
+		logger.With().Error("failed to set up epoch", log.Err(err))
+		return
+	}
+	pd.mu.Lock()
+	own := st.restore(&cp)
+	pd.mu.Unlock()
+
+	if round >= pd.config.RoundsNumber {
+		logger.With().Info("beacon protocol finished before restart", round)
+		pd.setBeaconFromVotes(logger, epoch+1, own)
+		return
+	}
+	left, ok := pd.rejoinRound(epoch, round, time.Now())
+	if !ok {
+		logger.With().Info("too late to rejoin beacon protocol", round)
+		return
+	}
+	if err := pd.replayFirstVotes(logger, epoch, st); err != nil {
+		logger.With().Error("failed to load persisted first votes", log.Err(err))
+		return
+	}
+	if err := pd.replayFollowingVotes(logger, epoch, st); err != nil {
+		logger.With().Error("failed to load persisted following votes", log.Err(err))
+		return
+	}
+	logger.With().Info("rejoining beacon protocol", round, log.Duration("left", left))
+	pd.rejoinProtocol(ctx, epoch, st, round, own, left)
+}
+
+// rejoinRound returns the time left in the voting of the round, or false if the voting is over.
+func (pd *ProtocolDriver) rejoinRound(epoch types.EpochID, round types.RoundID, now time.Time) (time.Duration, bool) {
+	var (
+		end      time.Time
+		duration time.Duration
+	)
+	if round == types.FirstRound {
+		end = pd.msgTimes.firstVoteSendTime(epoch).Add(pd.config.FirstVotingRoundDuration)
+		duration = pd.config.FirstVotingRoundDuration
+	} else {
+		end = pd.msgTimes.followupVoteSendTime(epoch, round).Add(pd.config.VotingRoundDuration)
+		duration = pd.config.VotingRoundDuration
+	}
+	left := end.Sub(now)
+	if left <= 0 {
+		return 0, false
+	}
+	return min(left, duration), true
+}
+
+// replayFirstVotes loads the persisted first round votes. Votes that are not accounted in the checkpoint are tallied.
+func (pd *ProtocolDriver) replayFirstVotes(logger log.Log, epoch types.EpochID, st *state) error {
+	var bodies []FirstVotingMessageBody
+	var ids []types.NodeID
+	if err := beaconstate.IterateFirstVotes(pd.localDB, epoch, func(id types.NodeID, data []byte) bool {
+		var body FirstVotingMessageBody
+		if err := codec.Decode(data, &body); err != nil {
+			logger.With().Warning("ignoring malformed persisted first votes", id, log.Err(err))
+			return true
+		}
+		bodies = append(bodies, body)
+		ids = append(ids, id)
+		return true
+	}); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		voteWeight, err := pd.firstVotesWeight(epoch, id)
+		if err != nil {
+			logger.With().Warning("ignoring persisted first votes", id, log.Err(err))
+			continue
+		}
+		pd.mu.Lock()
+		if err := st.registerVoted(id, types.FirstRound); err == nil {
+			pd.addFirstVotes(st, &bodies[i], id, voteWeight)
+		} else {
+			st.setMinerFirstRoundVote(id, pd.firstRoundVoteList(&bodies[i]))
+		}
+		pd.mu.Unlock()
+	}
+	return nil
+}
+
+// replayFollowingVotes loads the persisted following votes. Votes that are not accounted in the checkpoint
+// are tallied.
+func (pd *ProtocolDriver) replayFollowingVotes(logger log.Log, epoch types.EpochID, st *state) error {
+	var bodies []FollowingVotingMessageBody
+	var ids []types.NodeID
+	if err := beaconstate.IterateFollowingVotes(
+		pd.localDB,
+		epoch,
+		func(id types.NodeID, round types.RoundID, data []byte) bool {
+			var body FollowingVotingMessageBody
+			if err := codec.Decode(data, &body); err != nil {
+				logger.With().Warning("ignoring malformed persisted following votes", id, round, log.Err(err))
+				return true
+			}
+			bodies = append(bodies, body)
+			ids = append(ids, id)
+			return true
+		},
+	); err != nil {
+		return err
+	}
+	for i, id := range ids {
+		pd.mu.Lock()
+		err := st.registerVoted(id, bodies[i].RoundID)
+		pd.mu.Unlock()
+		if err != nil {
+			continue
+		}
+		if err := pd.addFollowingVotes(epoch, bodies[i].VotesBitVector, id); err != nil {
+			logger.With().Warning("ignoring persisted following votes", id, bodies[i].RoundID, log.Err(err))
+		}
+	}
+	return nil
+}
+
+// replayWeakCoinProposals passes the persisted weak coin proposals of the round to the weak coin,
+// so that the coin of the round doesn't depend only on the proposals gossiped after restart.
+func (pd *ProtocolDriver) replayWeakCoinProposals(
+	ctx context.Context,
+	logger log.Log,
+	epoch types.EpochID,
+	round types.RoundID,
+) {
+	if err := beaconstate.IterateWeakCoinProposals(pd.localDB, epoch, round, func(data []byte) bool {
+		if err := pd.weakCoin.HandleProposal(ctx, p2p.NoPeer, data); err != nil {
+			logger.With().Debug("persisted weak coin proposal not accepted", round, log.Err(err))
+		}
+		return true
+	}); err != nil {
+		logger.With().Error("failed to load persisted weak coin proposals", round, log.Err(err))
+	}
+}
+
+func (pd *ProtocolDriver) rejoinProtocol(
+	ctx context.Context,
+	epoch types.EpochID,
+	st *state,
+	round types.RoundID,
+	own allVotes,
+	left time.Duration,
+) {
+	ctx = log.WithNewSessionID(ctx)
+	targetEpoch := epoch + 1
+	logger := pd.logger.WithContext(ctx).WithFields(epoch, log.Uint32("target_epoch", uint32(targetEpoch)))
+
+	pd.setBeginProtocol(ctx)
+	defer pd.setEndProtocol(ctx)
+
+	pd.weakCoin.StartEpoch(ctx, epoch)
+	defer pd.weakCoin.FinishEpoch(ctx, epoch)
+	pd.replayWeakCoinProposals(ctx, logger, epoch, round)
+
+	var (
+		lastRoundOwnVotes allVotes
+		err               error
+	)
+	if round == types.FirstRound {
+		lastRoundOwnVotes, err = pd.runConsensusPhase(ctx, epoch, st, left)
+	} else {
+		lastRoundOwnVotes, err = pd.runFollowingRounds(ctx, epoch, st, round, own, left)
+	}
+	if err != nil {
+		logger.With().Warning("consensus phase failed", log.Err(err))
+		return
+	}
+	pd.setBeaconFromVotes(logger, targetEpoch, lastRoundOwnVotes)
+}
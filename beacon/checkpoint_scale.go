@@ -0,0 +1,226 @@
+// Code generated by github.com/spacemeshos/go-scale/scalegen. DO NOT EDIT.
+
+// nolint
+package beacon
+
+import (
+	"github.com/spacemeshos/go-scale"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+func (t *EpochCheckpoint) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeCompact64(enc, uint64(t.ProposalPhaseFinished))
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Valid, 100000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.PotentiallyValid, 100000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Proposed, 100000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Voted, 16777216)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Margins, 200000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Support, 200000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeStructSliceWithLimit(enc, t.Against, 200000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *EpochCheckpoint) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		field, n, err := scale.DecodeCompact64(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.ProposalPhaseFinished = uint64(field)
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[Proposal](dec, 100000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Valid = field
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[Proposal](dec, 100000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.PotentiallyValid = field
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[types.NodeID](dec, 100000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Proposed = field
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[VotedRounds](dec, 16777216)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Voted = field
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[VoteMargin](dec, 200000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Margins = field
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[Proposal](dec, 200000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Support = field
+	}
+	{
+		field, n, err := scale.DecodeStructSliceWithLimit[Proposal](dec, 200000)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Against = field
+	}
+	return total, nil
+}
+
+func (t *VotedRounds) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeByteArray(enc, t.NodeID[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteSliceWithLimit(enc, t.Rounds, 128)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *VotedRounds) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		n, err := scale.DecodeByteArray(dec, t.NodeID[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		field, n, err := scale.DecodeByteSliceWithLimit(dec, 128)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Rounds = field
+	}
+	return total, nil
+}
+
+func (t *VoteMargin) EncodeScale(enc *scale.Encoder) (total int, err error) {
+	{
+		n, err := scale.EncodeByteArray(enc, t.Proposal[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeByteSliceWithLimit(enc, t.Margin, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		n, err := scale.EncodeBool(enc, t.Negative)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (t *VoteMargin) DecodeScale(dec *scale.Decoder) (total int, err error) {
+	{
+		n, err := scale.DecodeByteArray(dec, t.Proposal[:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	{
+		field, n, err := scale.DecodeByteSliceWithLimit(dec, 64)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Margin = field
+	}
+	{
+		field, n, err := scale.DecodeBool(dec)
+		if err != nil {
+			return total, err
+		}
+		total += n
+		t.Negative = field
+	}
+	return total, nil
+}
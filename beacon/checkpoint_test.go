@@ -0,0 +1,246 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/spacemeshos/go-spacemesh/beacon/weakcoin"
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	pubsubmocks "github.com/spacemeshos/go-spacemesh/p2p/pubsub/mocks"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/beaconstate"
+)
+
+func TestCheckpoint_RoundTrip(t *testing.T) {
+	cfg := UnitTestConfig()
+	st := newState(logtest.New(t), cfg, nil, 10, nil, nil)
+	valid := Proposal{1}
+	potentiallyValid := Proposal{2}
+	st.addValidProposal(valid)
+	st.addPotentiallyValidProposal(potentiallyValid)
+	st.proposalPhaseFinishedTime = time.Unix(0, 1234)
+	st.hasProposed[types.NodeID{1}] = struct{}{}
+	require.NoError(t, st.registerVoted(types.NodeID{1}, types.FirstRound))
+	require.NoError(t, st.registerVoted(types.NodeID{2}, types.FirstRound+1))
+	st.addVote(valid, up, big.NewInt(5))
+	st.addVote(potentiallyValid, down, big.NewInt(3))
+	own := allVotes{
+		support: proposalSet{valid: {}},
+		against: proposalSet{potentiallyValid: {}},
+	}
+
+	var cp EpochCheckpoint
+	require.NoError(t, codec.Decode(codec.MustEncode(st.checkpoint(own)), &cp))
+
+	restored := newState(logtest.New(t), cfg, nil, 10, nil, nil)
+	require.Equal(t, own, restored.restore(&cp))
+	require.Equal(t, st.incomingProposals, restored.incomingProposals)
+	require.Equal(t, st.proposalPhaseFinishedTime.UnixNano(), restored.proposalPhaseFinishedTime.UnixNano())
+	require.Equal(t, st.hasProposed, restored.hasProposed)
+	require.Equal(t, st.votesMargin, restored.votesMargin)
+	for id, tracker := range st.hasVoted {
+		require.Zero(t, tracker.votes.Cmp(restored.hasVoted[id].votes))
+	}
+}
+
+// newResumeDriver creates a driver that persists its state to the local database.
+// The driver is set in the middle of epoch 2, the protocol for it started at the given time.
+func newResumeDriver(
+	t *testing.T,
+	cfg Config,
+	db sql.Executor,
+	started time.Time,
+	signers []*signing.EdSigner,
+) *testProtocolDriver {
+	var node *testProtocolDriver
+	publisher := pubsubmocks.NewMockPublisher(gomock.NewController(t))
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, protocol string, data []byte) error {
+			peer := p2p.Peer(strconv.Itoa(0))
+			switch protocol {
+			case pubsub.BeaconProposalProtocol:
+				require.NoError(t, node.HandleProposal(ctx, peer, data))
+			case pubsub.BeaconFirstVotesProtocol:
+				require.NoError(t, node.HandleFirstVotes(ctx, peer, data))
+			case pubsub.BeaconFollowingVotesProtocol:
+				require.NoError(t, node.HandleFollowingVotes(ctx, peer, data))
+			}
+			return nil
+		}).AnyTimes()
+
+	atxPublishLid := types.LayerID(types.GetLayersPerEpoch()*2 - 1)
+	current := atxPublishLid.Add(1)
+	node = newTestDriver(t, cfg, publisher, 0, "")
+	node.localDB = db
+	node.mSync.EXPECT().IsSynced(gomock.Any()).Return(true).AnyTimes()
+	node.mClock.EXPECT().CurrentLayer().Return(current).AnyTimes()
+	node.mClock.EXPECT().LayerToTime(current).Return(started).AnyTimes()
+	for _, sig := range signers {
+		node.Register(sig)
+		createATX(t, node.cdb, atxPublishLid, sig, 1, started.Add(-time.Hour))
+	}
+	return node
+}
+
+func newSigners(t *testing.T, n int) []*signing.EdSigner {
+	signers := make([]*signing.EdSigner, 0, n)
+	for range n {
+		sig, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		signers = append(signers, sig)
+	}
+	return signers
+}
+
+func TestBeacon_ResumeFinished(t *testing.T) {
+	cfg := NodeSimUnitTestConfig()
+	db := localsql.InMemory()
+	signers := newSigners(t, 3)
+
+	node := newResumeDriver(t, cfg, db, time.Now(), signers)
+	require.NoError(t, node.onNewEpoch(context.Background(), types.EpochID(2)))
+	expected, err := node.GetBeacon(types.EpochID(3))
+	require.NoError(t, err)
+
+	round, _, err := beaconstate.GetState(db, types.EpochID(2))
+	require.NoError(t, err)
+	require.Equal(t, cfg.RoundsNumber, round)
+
+	restarted := newResumeDriver(t, cfg, db, time.Now(), signers)
+	restarted.resume(context.Background())
+	got, err := restarted.GetBeacon(types.EpochID(3))
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+}
+
+func TestBeacon_ResumeInProgress(t *testing.T) {
+	cfg := NodeSimUnitTestConfig()
+	db := localsql.InMemory()
+	signers := newSigners(t, 3)
+
+	node := newResumeDriver(t, cfg, db, time.Now(), signers)
+	st, err := node.setupEpoch(node.logger, types.EpochID(2))
+	require.NoError(t, err)
+	node.setBeginProtocol(context.Background())
+	require.NoError(t, node.runProposalPhase(context.Background(), types.EpochID(2), st))
+	node.setEndProtocol(context.Background())
+
+	round, _, err := beaconstate.GetState(db, types.EpochID(2))
+	require.NoError(t, err)
+	require.Equal(t, types.FirstRound, round)
+
+	t.Run("too late", func(t *testing.T) {
+		restarted := newResumeDriver(t, cfg, db, time.Now().Add(-time.Hour), signers)
+		restarted.resume(context.Background())
+		_, err := restarted.GetBeacon(types.EpochID(3))
+		require.ErrorIs(t, err, errBeaconNotCalculated)
+	})
+	t.Run("rejoin", func(t *testing.T) {
+		restarted := newResumeDriver(t, cfg, db, time.Now().Add(-cfg.ProposalDuration), signers)
+		restarted.resume(context.Background())
+		got, err := restarted.GetBeacon(types.EpochID(3))
+		require.NoError(t, err)
+		require.NotEqual(t, types.EmptyBeacon, got)
+
+		round, _, err := beaconstate.GetState(db, types.EpochID(2))
+		require.NoError(t, err)
+		require.Equal(t, cfg.RoundsNumber, round)
+	})
+}
+
+func TestBeacon_ResumeNotSynced(t *testing.T) {
+	cfg := NodeSimUnitTestConfig()
+	db := localsql.InMemory()
+	epoch := types.EpochID(2)
+	require.NoError(t, beaconstate.SetState(db, epoch, cfg.RoundsNumber, codec.MustEncode(&EpochCheckpoint{})))
+
+	node := newTestDriver(t, cfg, pubsubmocks.NewMockPublisher(gomock.NewController(t)), 0, "")
+	node.localDB = db
+	node.mSync.EXPECT().IsSynced(gomock.Any()).Return(false)
+	node.mClock.EXPECT().CurrentLayer().Return(epoch.FirstLayer().Add(1)).AnyTimes()
+	node.resume(context.Background())
+	_, err := node.GetBeacon(epoch + 1)
+	require.ErrorIs(t, err, errBeaconNotCalculated)
+}
+
+func TestBeacon_ReplayFollowingVotes(t *testing.T) {
+	cfg := NodeSimUnitTestConfig()
+	db := localsql.InMemory()
+	signers := newSigners(t, 1)
+	epoch := types.EpochID(2)
+	id := signers[0].NodeID()
+	support, against := Proposal{1}, Proposal{2}
+
+	node := newResumeDriver(t, cfg, db, time.Now(), signers)
+	st, err := node.initEpochStateIfNotPresent(node.logger, epoch)
+	require.NoError(t, err)
+	st.addValidProposal(support)
+	st.addValidProposal(against)
+	st.setMinerFirstRoundVote(id, proposalList{support, against})
+	weight, err := node.firstVotesWeight(epoch, id)
+	require.NoError(t, err)
+
+	body := FollowingVotingMessageBody{
+		EpochID: epoch,
+		RoundID: types.FirstRound + 1,
+		VotesBitVector: encodeVotes(
+			allVotes{support: proposalSet{support: {}}, against: proposalSet{against: {}}},
+			proposalList{support, against},
+		),
+	}
+	require.NoError(t, beaconstate.AddFollowingVotes(db, epoch, id, body.RoundID, codec.MustEncode(&body)))
+
+	require.NoError(t, node.replayFollowingVotes(node.logger, epoch, st))
+	require.Equal(t, weight, st.votesMargin[support])
+	require.Equal(t, new(big.Int).Neg(weight), st.votesMargin[against])
+
+	// votes accounted in the checkpoint are not tallied again
+	require.NoError(t, node.replayFollowingVotes(node.logger, epoch, st))
+	require.Equal(t, weight, st.votesMargin[support])
+}
+
+func TestBeacon_ReplayWeakCoinProposals(t *testing.T) {
+	cfg := NodeSimUnitTestConfig()
+	db := localsql.InMemory()
+	epoch := types.EpochID(2)
+	round := types.FirstRound + 1
+
+	node := newResumeDriver(t, cfg, db, time.Now(), nil)
+	wc := NewMockcoin(gomock.NewController(t))
+	node.weakCoin = wc
+	node.setBeginProtocol(context.Background())
+	t.Cleanup(func() { node.setEndProtocol(context.Background()) })
+
+	proposals := make([][]byte, 0, 3)
+	for _, r := range []types.RoundID{round, round, round + 1} {
+		msg := codec.MustEncode(&weakcoin.Message{Epoch: epoch, Round: r, NodeID: types.RandomNodeID()})
+		wc.EXPECT().HandleProposal(gomock.Any(), p2p.Peer("peer"), msg)
+		require.NoError(t, node.HandleWeakCoinProposal(context.Background(), "peer", msg))
+		proposals = append(proposals, msg)
+	}
+	rejected := codec.MustEncode(&weakcoin.Message{Epoch: epoch, Round: round, NodeID: types.RandomNodeID()})
+	wc.EXPECT().HandleProposal(gomock.Any(), p2p.Peer("peer"), rejected).Return(errors.New("not smallest"))
+	require.Error(t, node.HandleWeakCoinProposal(context.Background(), "peer", rejected))
+
+	var replayed [][]byte
+	wc.EXPECT().HandleProposal(gomock.Any(), p2p.NoPeer, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ p2p.Peer, msg []byte) error {
+			replayed = append(replayed, msg)
+			return nil
+		}).Times(2)
+	node.replayWeakCoinProposals(context.Background(), node.logger, epoch, round)
+	require.ElementsMatch(t, proposals[:2], replayed)
+}
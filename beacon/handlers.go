@@ -8,6 +8,7 @@ import (
 	"time"
 
 	bcnmetrics "github.com/spacemeshos/go-spacemesh/beacon/metrics"
+	"github.com/spacemeshos/go-spacemesh/beacon/weakcoin"
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
@@ -15,6 +16,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
 	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/beaconstate"
 )
 
 type category uint8
@@ -42,7 +44,21 @@ func (pd *ProtocolDriver) HandleWeakCoinProposal(ctx context.Context, peer p2p.P
 	if !pd.isInProtocol() {
 		return errBeaconProtocolInactive
 	}
-	return pd.weakCoin.HandleProposal(ctx, peer, msg)
+	if err := pd.weakCoin.HandleProposal(ctx, peer, msg); err != nil {
+		return err
+	}
+	if pd.localDB == nil {
+		return nil
+	}
+	var m weakcoin.Message
+	if err := codec.Decode(msg, &m); err != nil {
+		pd.logger.With().Error("failed to decode accepted weak coin proposal", log.Err(err))
+		return nil
+	}
+	if err := beaconstate.AddWeakCoinProposal(pd.localDB, m.Epoch, m.Round, m.NodeID, msg); err != nil {
+		pd.logger.With().Error("failed to persist weak coin proposal", m.Epoch, m.Round, m.NodeID, log.Err(err))
+	}
+	return nil
 }
 
 // HandleProposal handles beacon proposal from gossip.
@@ -289,40 +305,69 @@ func (pd *ProtocolDriver) storeFirstVotes(m FirstVotingMessage, nodeID types.Nod
 		return errProtocolNotRunning
 	}
 
-	atx, malicious, err := pd.minerAtxHdr(m.EpochID, nodeID)
+	voteWeight, err := pd.firstVotesWeight(m.EpochID, nodeID)
 	if err != nil {
 		return err
 	}
+
+	pd.mu.Lock()
+	st, ok := pd.states[m.EpochID]
+	if !ok {
+		pd.mu.Unlock()
+		return errEpochNotActive
+	}
+	pd.addFirstVotes(st, &m.FirstVotingMessageBody, nodeID, voteWeight)
+	pd.mu.Unlock()
+
+	if pd.localDB != nil {
+		if err := beaconstate.AddFirstVotes(
+			pd.localDB, m.EpochID, nodeID, codec.MustEncode(&m.FirstVotingMessageBody),
+		); err != nil {
+			pd.logger.With().Error("failed to persist first votes", m.EpochID, nodeID, log.Err(err))
+		}
+	}
+	return nil
+}
+
+func (pd *ProtocolDriver) firstVotesWeight(epoch types.EpochID, nodeID types.NodeID) (*big.Int, error) {
+	atx, malicious, err := pd.minerAtxHdr(epoch, nodeID)
+	if err != nil {
+		return nil, err
+	}
 	voteWeight := new(big.Int)
 	if !malicious {
 		voteWeight.SetUint64(atx.GetWeight())
 	} else {
 		pd.logger.With().Debug("malicious miner get 0 weight", log.Stringer("smesher", nodeID))
 	}
+	return voteWeight, nil
+}
 
-	pd.mu.Lock()
-	defer pd.mu.Unlock()
-
-	if _, ok := pd.states[m.EpochID]; !ok {
-		return errEpochNotActive
-	}
-
+// addFirstVotes tallies the first round votes of the miner. Must be called with the lock held.
+func (pd *ProtocolDriver) addFirstVotes(
+	st *state,
+	m *FirstVotingMessageBody,
+	nodeID types.NodeID,
+	voteWeight *big.Int,
+) {
 	for _, proposal := range m.ValidProposals {
-		pd.states[m.EpochID].addVote(proposal, up, voteWeight)
+		st.addVote(proposal, up, voteWeight)
 	}
 
 	for _, proposal := range m.PotentiallyValidProposals {
-		pd.states[m.EpochID].addVote(proposal, down, voteWeight)
+		st.addVote(proposal, down, voteWeight)
 	}
 
-	// this is used for bit vector calculation
+	st.setMinerFirstRoundVote(nodeID, pd.firstRoundVoteList(m))
+}
+
+// firstRoundVoteList returns the list of proposals that is used for bit vector calculation.
+func (pd *ProtocolDriver) firstRoundVoteList(m *FirstVotingMessageBody) proposalList {
 	voteList := append(m.ValidProposals, m.PotentiallyValidProposals...)
 	if uint32(len(voteList)) > pd.config.VotesLimit {
 		voteList = voteList[:pd.config.VotesLimit]
 	}
-
-	pd.states[m.EpochID].setMinerFirstRoundVote(nodeID, voteList)
-	return nil
+	return voteList
 }
 
 // HandleFollowingVotes handles beacon following votes from gossip.
@@ -391,25 +436,34 @@ func (pd *ProtocolDriver) storeFollowingVotes(m FollowingVotingMessage, nodeID t
 		pd.logger.Debug("beacon not in protocol, not storing following votes")
 		return errProtocolNotRunning
 	}
+	if err := pd.addFollowingVotes(m.EpochID, m.VotesBitVector, nodeID); err != nil {
+		return err
+	}
 
-	atx, malicious, err := pd.minerAtxHdr(m.EpochID, nodeID)
+	if pd.localDB != nil {
+		if err := beaconstate.AddFollowingVotes(
+			pd.localDB, m.EpochID, nodeID, m.RoundID, codec.MustEncode(&m.FollowingVotingMessageBody),
+		); err != nil {
+			pd.logger.With().Error("failed to persist following votes", m.EpochID, m.RoundID, nodeID, log.Err(err))
+		}
+	}
+	return nil
+}
+
+// addFollowingVotes tallies the votes of the miner relative to its first round votes.
+func (pd *ProtocolDriver) addFollowingVotes(epoch types.EpochID, votes []byte, nodeID types.NodeID) error {
+	voteWeight, err := pd.firstVotesWeight(epoch, nodeID)
 	if err != nil {
 		return err
 	}
-	voteWeight := new(big.Int)
-	if !malicious {
-		voteWeight.SetUint64(atx.GetWeight())
-	} else {
-		pd.logger.With().Debug("malicious miner get 0 weight", log.Stringer("smesher", nodeID))
-	}
 
-	firstRoundVotes, err := pd.getFirstRoundVote(m.EpochID, nodeID)
+	firstRoundVotes, err := pd.getFirstRoundVote(epoch, nodeID)
 	if err != nil {
 		return fmt.Errorf("get miner first round votes %v: %w", nodeID.ShortString(), err)
 	}
 
-	thisRoundVotes := decodeVotes(m.VotesBitVector, firstRoundVotes)
-	return pd.addToVoteMargin(m.EpochID, thisRoundVotes, voteWeight)
+	thisRoundVotes := decodeVotes(votes, firstRoundVotes)
+	return pd.addToVoteMargin(epoch, thisRoundVotes, voteWeight)
 }
 
 func (pd *ProtocolDriver) getProposalPhaseFinishedTime(epoch types.EpochID) time.Time {
@@ -142,6 +142,16 @@ func (c *Certifier) Register(sig *signing.EdSigner) {
 	c.signers[sig.NodeID()] = sig
 }
 
+// Unregister removes the signing key of the identity that was registered with Register.
+func (c *Certifier) Unregister(id types.NodeID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.signers[id]; exists {
+		c.logger.With().Info("unregistered signing key", log.ShortStringer("id", id))
+		delete(c.signers, id)
+	}
+}
+
 // Start starts the background goroutine for periodic pruning.
 func (c *Certifier) Start(ctx context.Context) {
 	c.once.Do(func() {
@@ -264,7 +274,10 @@ func (c *Certifier) certifySingleSigner(
 		return nil
 	}
 
-	msg := newCertifyMsg(s, lid, bid, proof, eligibilityCount)
+	msg, err := newCertifyMsg(s, lid, bid, proof, eligibilityCount)
+	if err != nil {
+		return fmt.Errorf("signing block certification message: %w", err)
+	}
 	if err = c.publisher.Publish(ctx, pubsub.BlockCertify, codec.MustEncode(msg)); err != nil {
 		return fmt.Errorf("publishing block certification message: %w", err)
 	}
@@ -276,19 +289,23 @@ func newCertifyMsg(
 	lid types.LayerID,
 	bid types.BlockID,
 	proof types.VrfSignature,
-	eligibility uint16,
-) *types.CertifyMessage {
+	eligibilityCnt uint16,
+) (*types.CertifyMessage, error) {
 	msg := &types.CertifyMessage{
 		CertifyContent: types.CertifyContent{
 			LayerID:        lid,
 			BlockID:        bid,
-			EligibilityCnt: eligibility,
+			EligibilityCnt: eligibilityCnt,
 			Proof:          proof,
 		},
 		SmesherID: s.NodeID(),
 	}
-	msg.Signature = s.Sign(signing.HARE, msg.Bytes())
-	return msg
+	signature, err := s.SignOnce(signing.HARE, lid, eligibility.CertifyRound, msg.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = signature
+	return msg, nil
 }
 
 // NumCached returns the number of layers being cached in memory.
@@ -0,0 +1,97 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/spacemeshos/merkle-tree"
+	"github.com/spacemeshos/poet/shared"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hash"
+)
+
+// contentHasher computes the checkpoint hash incrementally.
+//
+// The hash is computed over the merkle root of atxs sorted by id and the merkle root
+// of accounts sorted by address, so that the checkpoint can be hashed while it is streamed.
+type contentHasher struct {
+	atxs, accounts       *merkle.Tree
+	lastAtx, lastAccount []byte
+}
+
+func newContentHasher() (*contentHasher, error) {
+	atxs, err := merkle.NewTreeBuilder().WithHashFunc(shared.HashMembershipTreeNode).Build()
+	if err != nil {
+		return nil, fmt.Errorf("create atxs tree: %w", err)
+	}
+	accounts, err := merkle.NewTreeBuilder().WithHashFunc(shared.HashMembershipTreeNode).Build()
+	if err != nil {
+		return nil, fmt.Errorf("create accounts tree: %w", err)
+	}
+	return &contentHasher{atxs: atxs, accounts: accounts}, nil
+}
+
+func (h *contentHasher) addAtx(atx *types.AtxSnapshot) error {
+	if h.lastAtx != nil && bytes.Compare(h.lastAtx, atx.ID) >= 0 {
+		return fmt.Errorf("atxs are not sorted by id: %x after %x", atx.ID, h.lastAtx)
+	}
+	h.lastAtx = atx.ID
+	leaf := hash.Sum(
+		withLength(atx.ID),
+		binary.LittleEndian.AppendUint32(nil, atx.Epoch),
+		withLength(atx.CommitmentAtx),
+		binary.LittleEndian.AppendUint64(nil, atx.VrfNonce),
+		binary.LittleEndian.AppendUint32(nil, atx.NumUnits),
+		binary.LittleEndian.AppendUint64(nil, atx.BaseTickHeight),
+		binary.LittleEndian.AppendUint64(nil, atx.TickCount),
+		withLength(atx.PublicKey),
+		binary.LittleEndian.AppendUint64(nil, atx.Sequence),
+		withLength(atx.Coinbase),
+	)
+	return h.atxs.AddLeaf(leaf[:])
+}
+
+func (h *contentHasher) addAccount(account *types.AccountSnapshot) error {
+	if h.lastAccount != nil && bytes.Compare(h.lastAccount, account.Address) >= 0 {
+		return fmt.Errorf("accounts are not sorted by address: %x after %x", account.Address, h.lastAccount)
+	}
+	h.lastAccount = account.Address
+	leaf := hash.Sum(
+		withLength(account.Address),
+		binary.LittleEndian.AppendUint64(nil, account.Balance),
+		binary.LittleEndian.AppendUint64(nil, account.Nonce),
+		withLength(account.Template),
+		withLength(account.State),
+	)
+	return h.accounts.AddLeaf(leaf[:])
+}
+
+func (h *contentHasher) sum() types.Hash32 {
+	return hash.Sum(h.atxs.Root(), h.accounts.Root())
+}
+
+func withLength(data []byte) []byte {
+	return append(binary.AppendUvarint(nil, uint64(len(data))), data...)
+}
+
+// Hash computes the hash of the checkpoint data.
+// Atxs must be sorted by id and accounts must be sorted by address.
+func Hash(data *types.InnerData) (types.Hash32, error) {
+	hasher, err := newContentHasher()
+	if err != nil {
+		return types.Hash32{}, err
+	}
+	for i := range data.Atxs {
+		if err := hasher.addAtx(&data.Atxs[i]); err != nil {
+			return types.Hash32{}, err
+		}
+	}
+	for i := range data.Accounts {
+		if err := hasher.addAccount(&data.Accounts[i]); err != nil {
+			return types.Hash32{}, err
+		}
+	}
+	return hasher.sum(), nil
+}
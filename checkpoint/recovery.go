@@ -17,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
@@ -36,6 +37,17 @@ type Config struct {
 
 	// set to false if atxs are not compatible before and after the checkpoint recovery.
 	PreserveOwnAtx bool `mapstructure:"preserve-own-atx"`
+
+	// TrustedSigners is a list of hex encoded public keys of checkpoint signers. If set, the node
+	// recovers only from checkpoints signed by one of them.
+	TrustedSigners []string `mapstructure:"trusted-signers"`
+	// SignerKey is a path to the key file that signs generated checkpoints, relative path is resolved
+	// against the data directory. A new key is generated if the file doesn't exist.
+	//
+	// The key is dedicated to checkpoints and must not be a smeshing identity: recovering nodes trust it
+	// to vouch for the whole state, and a smeshing key that signs checkpoints would link the published
+	// checkpoints to the smesher and couldn't be replaced without losing the identity.
+	SignerKey string `mapstructure:"checkpoint-signer-key"`
 }
 
 func DefaultConfig() Config {
@@ -53,6 +65,9 @@ type RecoverConfig struct {
 	NodeIDs        []types.NodeID
 	Uri            string
 	Restore        types.LayerID
+	// GenesisID is used as a prefix to verify the signature of the checkpoint.
+	GenesisID      types.Hash20
+	TrustedSigners []types.NodeID
 }
 
 func RecoveryDir(dataDir string) string {
@@ -188,7 +203,7 @@ func recoverFromLocalFile(
 ) (*PreservedData, error) {
 	logger.With().Info("recovering from checkpoint file", log.String("file", file))
 	newGenesis := cfg.Restore - 1
-	data, err := checkpointData(fs, file, newGenesis)
+	data, err := checkpointData(logger, fs, file, newGenesis, cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -311,7 +326,28 @@ func recoverFromLocalFile(
 	return preserve, nil
 }
 
-func checkpointData(fs afero.Fs, file string, newGenesis types.LayerID) (*recoveryData, error) {
+func checkpointData(
+	logger log.Log,
+	fs afero.Fs,
+	file string,
+	newGenesis types.LayerID,
+	cfg *RecoverConfig,
+) (*recoveryData, error) {
+	checkpoint, err := Load(fs, file)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyCheckpoint(logger, checkpoint, cfg); err != nil {
+		return nil, err
+	}
+	return &recoveryData{
+		accounts: Accounts(checkpoint, newGenesis),
+		atxs:     Atxs(checkpoint),
+	}, nil
+}
+
+// Load reads and validates the checkpoint file. The hash and the signature of the checkpoint are not verified.
+func Load(fs afero.Fs, file string) (*types.Checkpoint, error) {
 	data, err := afero.ReadFile(fs, file)
 	if err != nil {
 		return nil, fmt.Errorf("%w: read recovery file %v", err, file)
@@ -326,11 +362,15 @@ func checkpointData(fs afero.Fs, file string, newGenesis types.LayerID) (*recove
 	if checkpoint.Version != SchemaVersion {
 		return nil, fmt.Errorf("expected version %v, got %v", SchemaVersion, checkpoint.Version)
 	}
+	return &checkpoint, nil
+}
 
+// Accounts converts accounts of the checkpoint to the accounts updated in the layer.
+func Accounts(checkpoint *types.Checkpoint, layer types.LayerID) []*types.Account {
 	allAccts := make([]*types.Account, 0, len(checkpoint.Data.Accounts))
 	for _, acct := range checkpoint.Data.Accounts {
 		a := types.Account{
-			Layer:     newGenesis,
+			Layer:     layer,
 			NextNonce: acct.Nonce,
 			Balance:   acct.Balance,
 			State:     acct.State,
@@ -343,6 +383,11 @@ func checkpointData(fs afero.Fs, file string, newGenesis types.LayerID) (*recove
 		}
 		allAccts = append(allAccts, &a)
 	}
+	return allAccts
+}
+
+// Atxs converts atxs of the checkpoint to the atxs that are stored in the database.
+func Atxs(checkpoint *types.Checkpoint) []*atxs.CheckpointAtx {
 	allAtxs := make([]*atxs.CheckpointAtx, 0, len(checkpoint.Data.Atxs))
 	for _, atx := range checkpoint.Data.Atxs {
 		var cAtx atxs.CheckpointAtx
@@ -358,10 +403,47 @@ func checkpointData(fs afero.Fs, file string, newGenesis types.LayerID) (*recove
 		copy(cAtx.Coinbase[:], atx.Coinbase)
 		allAtxs = append(allAtxs, &cAtx)
 	}
-	return &recoveryData{
-		accounts: allAccts,
-		atxs:     allAtxs,
-	}, nil
+	return allAtxs
+}
+
+// verifyCheckpoint checks the hash of the checkpoint and, if trusted signers are configured,
+// that the checkpoint is signed by one of them.
+func verifyCheckpoint(logger log.Log, checkpoint *types.Checkpoint, cfg *RecoverConfig) error {
+	if checkpoint.Hash == nil {
+		if len(cfg.TrustedSigners) > 0 {
+			return ErrNotSigned
+		}
+		logger.With().Warning("checkpoint doesn't have a hash, integrity is not verified")
+		return nil
+	}
+	hash, err := Hash(&checkpoint.Data)
+	if err != nil {
+		return fmt.Errorf("hash checkpoint: %w", err)
+	}
+	if !bytes.Equal(hash.Bytes(), checkpoint.Hash) {
+		return fmt.Errorf("%w: expected %x, got %s", ErrHashMismatch, checkpoint.Hash, hash)
+	}
+	if len(cfg.TrustedSigners) == 0 {
+		return nil
+	}
+	if checkpoint.Signature == nil {
+		return ErrNotSigned
+	}
+	signer := types.BytesToNodeID(checkpoint.Signature.PublicKey)
+	if !slices.Contains(cfg.TrustedSigners, signer) {
+		return fmt.Errorf("%w: %s", ErrUntrustedSigner, signer)
+	}
+	var signature types.EdSignature
+	if len(checkpoint.Signature.Signature) != len(signature) {
+		return fmt.Errorf("%w: length %d", ErrInvalidSignature, len(checkpoint.Signature.Signature))
+	}
+	copy(signature[:], checkpoint.Signature.Signature)
+	verifier := signing.NewEdVerifier(signing.WithVerifierPrefix(cfg.GenesisID.Bytes()))
+	if !verifier.Verify(signing.CHECKPOINT, signer, checkpoint.Hash, signature) {
+		return fmt.Errorf("%w: signed by %s", ErrInvalidSignature, signer)
+	}
+	logger.With().Info("checkpoint signature verified", log.Stringer("signer", signer))
+	return nil
 }
 
 func collectOwnAtxDeps(
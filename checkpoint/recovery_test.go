@@ -964,3 +964,85 @@ func TestRecover_OwnAtxInCheckpoint(t *testing.T) {
 	require.NoError(t, err)
 	require.GreaterOrEqual(t, len(files), 1)
 }
+
+func TestRecover_Verification(t *testing.T) {
+	genesisID := types.Hash20{1}
+	signer, err := signing.NewEdSigner(signing.WithPrefix(genesisID.Bytes()))
+	require.NoError(t, err)
+
+	generate := func(t *testing.T, opts ...checkpoint.GenerateOpt) []byte {
+		db := sql.InMemory()
+		createMesh(t, db, allAtxs, allAccounts)
+		fs := afero.NewMemMapFs()
+		snapshot := types.LayerID(5)
+		require.NoError(t, checkpoint.Generate(context.Background(), fs, db, "data", snapshot, 4, opts...))
+		data, err := afero.ReadFile(fs, checkpoint.SelfCheckpointFilename("data", snapshot))
+		require.NoError(t, err)
+		require.NoError(t, checkpoint.ValidateSchema(data))
+		return data
+	}
+	signed := generate(t, checkpoint.WithSigner(signer))
+	unsigned := generate(t)
+
+	var tampered types.Checkpoint
+	require.NoError(t, json.Unmarshal(signed, &tampered))
+	tampered.Data.Accounts[0].Balance++
+	tamperedData, err := json.Marshal(&tampered)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		desc    string
+		data    []byte
+		trusted []types.NodeID
+		err     error
+	}{
+		{desc: "signed by trusted", data: signed, trusted: []types.NodeID{signer.NodeID()}},
+		{desc: "signed without trusted signers", data: signed},
+		{desc: "unsigned without trusted signers", data: unsigned},
+		{
+			desc:    "signed by untrusted",
+			data:    signed,
+			trusted: []types.NodeID{types.RandomNodeID()},
+			err:     checkpoint.ErrUntrustedSigner,
+		},
+		{
+			desc:    "unsigned with trusted signers",
+			data:    unsigned,
+			trusted: []types.NodeID{signer.NodeID()},
+			err:     checkpoint.ErrNotSigned,
+		},
+		{desc: "tampered", data: tamperedData, err: checkpoint.ErrHashMismatch},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write(tc.data)
+				require.NoError(t, err)
+			}))
+			defer ts.Close()
+
+			fs := afero.NewMemMapFs()
+			cfg := &checkpoint.RecoverConfig{
+				GoldenAtx:      goldenAtx,
+				DataDir:        t.TempDir(),
+				DbFile:         "test.sql",
+				LocalDbFile:    "local.sql",
+				Uri:            fmt.Sprintf("%s/snapshot-5", ts.URL),
+				Restore:        types.LayerID(recoverLayer),
+				GenesisID:      genesisID,
+				TrustedSigners: tc.trusted,
+			}
+			db := sql.InMemory()
+			_, err := checkpoint.RecoverWithDb(context.Background(), logtest.New(t), db, localsql.InMemory(), fs, cfg)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				// database is not touched if verification failed
+				restore, err := recovery.CheckpointInfo(db)
+				require.NoError(t, err)
+				require.Zero(t, restore)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
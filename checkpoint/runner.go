@@ -3,17 +3,19 @@ package checkpoint
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 
 	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
 	"github.com/spf13/afero"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/accounts"
 	"github.com/spacemeshos/go-spacemesh/sql/atxs"
-	"github.com/spacemeshos/go-spacemesh/sql/identities"
 )
 
 const (
@@ -26,58 +28,90 @@ const (
 	dirPerm       = 0o700
 )
 
-func checkpointDB(
+type generateOptions struct {
+	signer *signing.EdSigner
+}
+
+// GenerateOpt configures checkpoint generation.
+type GenerateOpt func(*generateOptions)
+
+// WithSigner signs the hash of the generated checkpoint, so that it can be verified by the nodes
+// that trust the signer.
+func WithSigner(signer *signing.EdSigner) GenerateOpt {
+	return func(opts *generateOptions) {
+		opts.signer = signer
+	}
+}
+
+// streamEncoder writes json values one by one and keeps the first error.
+type streamEncoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *streamEncoder) raw(s string) {
+	if e.err == nil {
+		_, e.err = io.WriteString(e.w, s)
+	}
+}
+
+func (e *streamEncoder) encode(v any) {
+	if e.err != nil {
+		return
+	}
+	buf, err := json.Marshal(v)
+	if err != nil {
+		e.err = err
+		return
+	}
+	_, e.err = e.w.Write(buf)
+}
+
+// writeCheckpoint streams atxs and accounts from the database to w without loading them in memory.
+func writeCheckpoint(
 	ctx context.Context,
+	w io.Writer,
 	db *sql.Database,
 	snapshot types.LayerID,
 	numAtxs int,
-) (*types.Checkpoint, error) {
+	opts *generateOptions,
+) error {
 	request, err := json.Marshal(&pb.CheckpointStreamRequest{
 		SnapshotLayer: uint32(snapshot),
 		NumAtxs:       uint32(numAtxs),
 	})
 	if err != nil {
-		return nil, fmt.Errorf("marshal request: %w", err)
+		return fmt.Errorf("marshal request: %w", err)
 	}
-
-	checkpoint := &types.Checkpoint{
-		Command: fmt.Sprintf(CommandString, request),
-		Version: SchemaVersion,
-		Data: types.InnerData{
-			CheckpointId: fmt.Sprintf("snapshot-%d", snapshot),
-		},
+	hasher, err := newContentHasher()
+	if err != nil {
+		return err
 	}
 
 	tx, err := db.Tx(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("create db tx: %w", err)
+		return fmt.Errorf("create db tx: %w", err)
 	}
 	defer tx.Release()
 
-	atxSnapshot, err := atxs.LatestN(tx, numAtxs)
-	if err != nil {
-		return nil, fmt.Errorf("atxs snapshot: %w", err)
-	}
-	malicious := map[types.NodeID]bool{}
-	for i, catx := range atxSnapshot {
-		if _, ok := malicious[catx.SmesherID]; !ok {
-			mal, err := identities.IsMalicious(tx, catx.SmesherID)
-			if err != nil {
-				return nil, fmt.Errorf("atxs snapshot check identitiy: %w", err)
-			}
-			malicious[catx.SmesherID] = mal
-		}
-		commitmentAtx, err := atxs.CommitmentATX(tx, catx.SmesherID)
-		if err != nil {
-			return nil, fmt.Errorf("atxs snapshot commitment: %w", err)
-		}
-		copy(atxSnapshot[i].CommitmentATX[:], commitmentAtx[:])
-	}
-	for _, catx := range atxSnapshot {
-		if mal, ok := malicious[catx.SmesherID]; ok && mal {
-			continue
+	enc := &streamEncoder{w: w}
+	enc.raw(`{"command":`)
+	enc.encode(fmt.Sprintf(CommandString, request))
+	enc.raw(`,"version":`)
+	enc.encode(SchemaVersion)
+	enc.raw(`,"data":{"id":`)
+	enc.encode(fmt.Sprintf("snapshot-%d", snapshot))
+	enc.raw(`,"atxs":[`)
+	var (
+		count int
+		ierr  error
+	)
+	if err := atxs.IterateForCheckpoint(tx, numAtxs, func(catx *atxs.CheckpointAtx) bool {
+		if catx.CommitmentATX == types.EmptyATXID {
+			ierr = fmt.Errorf("atxs snapshot commitment %s: %w", catx.SmesherID.ShortString(), sql.ErrNotFound)
+			return false
 		}
-		checkpoint.Data.Atxs = append(checkpoint.Data.Atxs, types.AtxSnapshot{
+		atx := types.AtxSnapshot{
 			ID:             catx.ID.Bytes(),
 			Epoch:          catx.Epoch.Uint32(),
 			CommitmentAtx:  catx.CommitmentATX.Bytes(),
@@ -88,14 +122,30 @@ func checkpointDB(
 			PublicKey:      catx.SmesherID.Bytes(),
 			Sequence:       catx.Sequence,
 			Coinbase:       catx.Coinbase.Bytes(),
-		})
+		}
+		if err := hasher.addAtx(&atx); err != nil {
+			ierr = fmt.Errorf("atxs snapshot: %w", err)
+			return false
+		}
+		if count > 0 {
+			enc.raw(",")
+		}
+		enc.encode(&atx)
+		count++
+		return enc.err == nil
+	}); err != nil {
+		return fmt.Errorf("atxs snapshot: %w", err)
 	}
-
-	acctSnapshot, err := accounts.Snapshot(tx, snapshot)
-	if err != nil {
-		return nil, fmt.Errorf("accounts snapshot: %w", err)
+	if ierr != nil {
+		return ierr
+	}
+	if count == 0 {
+		return fmt.Errorf("atxs snapshot: %w", sql.ErrNotFound)
 	}
-	for _, acct := range acctSnapshot {
+
+	enc.raw(`],"accounts":[`)
+	count = 0
+	if err := accounts.IterateSnapshot(tx, snapshot, func(acct *types.Account) bool {
 		a := types.AccountSnapshot{
 			Address: acct.Address.Bytes(),
 			Balance: acct.Balance,
@@ -107,11 +157,44 @@ func checkpointDB(
 		if acct.State != nil {
 			a.State = acct.State
 		}
-		checkpoint.Data.Accounts = append(checkpoint.Data.Accounts, a)
+		if ierr = hasher.addAccount(&a); ierr != nil {
+			return false
+		}
+		if count > 0 {
+			enc.raw(",")
+		}
+		enc.encode(&a)
+		count++
+		return enc.err == nil
+	}); err != nil {
+		return fmt.Errorf("accounts snapshot: %w", err)
+	}
+	if ierr != nil {
+		return fmt.Errorf("accounts snapshot: %w", ierr)
 	}
-	return checkpoint, nil
+	if count == 0 {
+		return fmt.Errorf("accounts snapshot: %w", sql.ErrNotFound)
+	}
+
+	hash := hasher.sum()
+	enc.raw(`]},"hash":`)
+	enc.encode(hash.Bytes())
+	if opts.signer != nil {
+		signature := opts.signer.Sign(signing.CHECKPOINT, hash.Bytes())
+		enc.raw(`,"signature":`)
+		enc.encode(&types.CheckpointSignature{
+			PublicKey: opts.signer.NodeID().Bytes(),
+			Signature: signature.Bytes(),
+		})
+	}
+	enc.raw("}\n")
+	if enc.err != nil {
+		return fmt.Errorf("write checkpoint: %w", enc.err)
+	}
+	return nil
 }
 
+// Generate streams a checkpoint of the database at the snapshot layer to the file in the data directory.
 func Generate(
 	ctx context.Context,
 	fs afero.Fs,
@@ -119,23 +202,20 @@ func Generate(
 	dataDir string,
 	snapshot types.LayerID,
 	numAtxs int,
+	opts ...GenerateOpt,
 ) error {
-	checkpoint, err := checkpointDB(ctx, db, snapshot, numAtxs)
-	if err != nil {
-		return err
+	options := &generateOptions{}
+	for _, opt := range opts {
+		opt(options)
 	}
 	rf, err := NewRecoveryFile(fs, SelfCheckpointFilename(dataDir, snapshot))
 	if err != nil {
 		return fmt.Errorf("new recovery file: %w", err)
 	}
-	// one writer persist the checkpoint data, one returning result to caller.
-	if err = json.NewEncoder(rf.fwriter).Encode(checkpoint); err != nil {
-		return fmt.Errorf("marshal checkpoint json: %w", err)
+	if err := writeCheckpoint(ctx, rf.fwriter, db, snapshot, numAtxs, options); err != nil {
+		return errors.Join(err, rf.Abort(fs))
 	}
-	if err = rf.Save(fs); err != nil {
-		return err
-	}
-	return nil
+	return rf.Save(fs)
 }
 
 func SelfCheckpointFilename(dataDir string, snapshot types.LayerID) string {
@@ -20,6 +20,10 @@ import (
 var (
 	ErrCheckpointNotFound    = errors.New("checkpoint not found")
 	ErrUrlSchemeNotSupported = errors.New("url scheme not supported")
+	ErrHashMismatch          = errors.New("checkpoint hash mismatch")
+	ErrNotSigned             = errors.New("checkpoint is not signed")
+	ErrUntrustedSigner       = errors.New("checkpoint is signed by untrusted signer")
+	ErrInvalidSignature      = errors.New("invalid checkpoint signature")
 )
 
 type RecoveryFile struct {
@@ -75,6 +79,15 @@ func (rf *RecoveryFile) Save(fs afero.Fs) error {
 	return nil
 }
 
+// Abort discards the data written to the recovery file.
+func (rf *RecoveryFile) Abort(fs afero.Fs) error {
+	rf.file.Close()
+	if err := fs.Remove(rf.file.Name()); err != nil {
+		return fmt.Errorf("%w: remove tmp file %v", err, rf.file.Name())
+	}
+	return nil
+}
+
 func ValidateSchema(data []byte) error {
 	sch, err := jsonschema.CompileString(schemaFile, Schema)
 	if err != nil {
@@ -0,0 +1,152 @@
+package internal
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+const missing = "<missing>"
+
+// Kind of the checkpoint entry that differs.
+type Kind string
+
+const (
+	KindHeader  Kind = "header"
+	KindAtx     Kind = "atx"
+	KindAccount Kind = "account"
+)
+
+// Difference is a single field that differs between two sides of the comparison.
+// Entries that are present only on one side have Field set to "entry" and the value
+// of the other side set to "<missing>".
+type Difference struct {
+	Kind  Kind   `json:"kind"`
+	ID    string `json:"id"`
+	Field string `json:"field"`
+	Left  string `json:"left"`
+	Right string `json:"right"`
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s %s %s: %s != %s", d.Kind, d.ID, d.Field, d.Left, d.Right)
+}
+
+type field struct {
+	name, value string
+}
+
+func atxFields(atx *types.AtxSnapshot) []field {
+	return []field{
+		{"epoch", strconv.FormatUint(uint64(atx.Epoch), 10)},
+		{"commitmentAtx", hex.EncodeToString(atx.CommitmentAtx)},
+		{"vrfNonce", strconv.FormatUint(atx.VrfNonce, 10)},
+		{"numUnits", strconv.FormatUint(uint64(atx.NumUnits), 10)},
+		{"baseTickHeight", strconv.FormatUint(atx.BaseTickHeight, 10)},
+		{"tickCount", strconv.FormatUint(atx.TickCount, 10)},
+		{"publicKey", hex.EncodeToString(atx.PublicKey)},
+		{"sequence", strconv.FormatUint(atx.Sequence, 10)},
+		{"coinbase", hex.EncodeToString(atx.Coinbase)},
+	}
+}
+
+func accountFields(account *types.AccountSnapshot) []field {
+	return []field{
+		{"balance", strconv.FormatUint(account.Balance, 10)},
+		{"nonce", strconv.FormatUint(account.Nonce, 10)},
+		{"template", hex.EncodeToString(account.Template)},
+		{"state", hex.EncodeToString(account.State)},
+	}
+}
+
+type entry struct {
+	id     string
+	fields []field
+}
+
+func compare(kind Kind, left, right []entry) []Difference {
+	index := make(map[string]entry, len(right))
+	for _, e := range right {
+		index[e.id] = e
+	}
+	var diffs []Difference
+	for _, l := range left {
+		r, exists := index[l.id]
+		if !exists {
+			diffs = append(diffs, Difference{Kind: kind, ID: l.id, Field: "entry", Left: "present", Right: missing})
+			continue
+		}
+		delete(index, l.id)
+		lfields, rfields := l.fields, r.fields
+		for i := range lfields {
+			if lfields[i].value != rfields[i].value {
+				diffs = append(diffs, Difference{
+					Kind:  kind,
+					ID:    l.id,
+					Field: lfields[i].name,
+					Left:  lfields[i].value,
+					Right: rfields[i].value,
+				})
+			}
+		}
+	}
+	// preserve the order of the right side for entries that are missing on the left
+	for _, r := range right {
+		if _, exists := index[r.id]; exists {
+			diffs = append(diffs, Difference{Kind: kind, ID: r.id, Field: "entry", Left: missing, Right: "present"})
+		}
+	}
+	return diffs
+}
+
+func atxEntries(checkpoint *types.Checkpoint) []entry {
+	entries := make([]entry, 0, len(checkpoint.Data.Atxs))
+	for i := range checkpoint.Data.Atxs {
+		atx := &checkpoint.Data.Atxs[i]
+		entries = append(entries, entry{
+			id:     hex.EncodeToString(atx.ID),
+			fields: atxFields(atx),
+		})
+	}
+	return entries
+}
+
+func accountEntries(checkpoint *types.Checkpoint) []entry {
+	entries := make([]entry, 0, len(checkpoint.Data.Accounts))
+	for i := range checkpoint.Data.Accounts {
+		account := &checkpoint.Data.Accounts[i]
+		entries = append(entries, entry{
+			id:     toAddress(account.Address).String(),
+			fields: accountFields(account),
+		})
+	}
+	return entries
+}
+
+// Diff compares two checkpoints.
+// Atxs are matched by id and accounts are matched by address.
+func Diff(left, right *types.Checkpoint) []Difference {
+	var diffs []Difference
+	if left.Version != right.Version {
+		diffs = append(diffs, Difference{Kind: KindHeader, Field: "version", Left: left.Version, Right: right.Version})
+	}
+	if left.Data.CheckpointId != right.Data.CheckpointId {
+		diffs = append(diffs, Difference{
+			Kind:  KindHeader,
+			Field: "id",
+			Left:  left.Data.CheckpointId,
+			Right: right.Data.CheckpointId,
+		})
+	}
+	diffs = append(diffs, compare(KindAtx, atxEntries(left), atxEntries(right))...)
+	diffs = append(diffs, compare(KindAccount, accountEntries(left), accountEntries(right))...)
+	return diffs
+}
+
+func toAddress(buf []byte) types.Address {
+	var address types.Address
+	copy(address[:], buf)
+	return address
+}
@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+	os.Exit(m.Run())
+}
+
+func newCheckpoint() *types.Checkpoint {
+	return &types.Checkpoint{
+		Version: "https://spacemesh.io/checkpoint.schema.json.1.0",
+		Data: types.InnerData{
+			CheckpointId: "snapshot-10",
+			Atxs: []types.AtxSnapshot{
+				{ID: types.ATXID{1}.Bytes(), Epoch: 1, NumUnits: 2, PublicKey: types.NodeID{1}.Bytes()},
+				{ID: types.ATXID{2}.Bytes(), Epoch: 1, NumUnits: 3, PublicKey: types.NodeID{2}.Bytes()},
+			},
+			Accounts: []types.AccountSnapshot{
+				{Address: types.Address{1}.Bytes(), Balance: 100, Nonce: 1},
+				{Address: types.Address{2}.Bytes(), Balance: 200, Nonce: 2},
+			},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	left := newCheckpoint()
+	require.Empty(t, Diff(left, newCheckpoint()))
+
+	right := newCheckpoint()
+	right.Data.CheckpointId = "snapshot-11"
+	right.Data.Atxs[1].NumUnits = 4
+	right.Data.Atxs = append(right.Data.Atxs, types.AtxSnapshot{ID: types.ATXID{3}.Bytes()})
+	right.Data.Accounts = right.Data.Accounts[1:]
+	right.Data.Accounts[0].Balance = 300
+	right.Data.Accounts[0].Template = types.Address{9}.Bytes()
+
+	atx2 := hex.EncodeToString(types.ATXID{2}.Bytes())
+	atx3 := hex.EncodeToString(types.ATXID{3}.Bytes())
+	require.Equal(t, []Difference{
+		{Kind: KindHeader, Field: "id", Left: "snapshot-10", Right: "snapshot-11"},
+		{Kind: KindAtx, ID: atx2, Field: "numUnits", Left: "3", Right: "4"},
+		{Kind: KindAtx, ID: atx3, Field: "entry", Left: missing, Right: "present"},
+		{Kind: KindAccount, ID: types.Address{1}.String(), Field: "entry", Left: "present", Right: missing},
+		{Kind: KindAccount, ID: types.Address{2}.String(), Field: "balance", Left: "200", Right: "300"},
+		{
+			Kind:  KindAccount,
+			ID:    types.Address{2}.String(),
+			Field: "template",
+			Left:  "",
+			Right: hex.EncodeToString(types.Address{9}.Bytes()),
+		},
+	}, Diff(left, right))
+}
+
+func TestSnapshotLayer(t *testing.T) {
+	layer, err := SnapshotLayer(newCheckpoint())
+	require.NoError(t, err)
+	require.Equal(t, types.LayerID(10), layer)
+
+	data := newCheckpoint()
+	data.Data.CheckpointId = "checkpoint-10"
+	_, err = SnapshotLayer(data)
+	require.Error(t, err)
+}
+
+// fromState creates a checkpoint with accounts of the state at the layer.
+func fromState(tb testing.TB, db sql.Executor, layer types.LayerID) *types.Checkpoint {
+	snapshot, err := accounts.Snapshot(db, layer)
+	require.NoError(tb, err)
+	data := newCheckpoint()
+	data.Data.CheckpointId = fmt.Sprintf("snapshot-%d", layer)
+	data.Data.Accounts = nil
+	for _, account := range snapshot {
+		data.Data.Accounts = append(data.Data.Accounts, types.AccountSnapshot{
+			Address: account.Address.Bytes(),
+			Balance: account.Balance,
+			Nonce:   account.NextNonce,
+		})
+	}
+	return data
+}
+
+func TestVerify(t *testing.T) {
+	db := sql.InMemory()
+	genvm := vm.New(db, vm.WithLogger(log.NewNop()))
+	require.NoError(t, genvm.ApplyGenesis([]types.Account{
+		{Address: types.Address{1}, Balance: 1000},
+		{Address: types.Address{2}, Balance: 2000},
+	}))
+	first := types.GetEffectiveGenesis().Add(1)
+	for i := 0; i < 3; i++ {
+		// rewards are applied to a subset of accounts so that the layer doesn't update all accounts
+		_, _, err := genvm.Apply(vm.ApplyContext{Layer: first.Add(uint32(i))}, nil, []types.CoinbaseReward{
+			{SmesherID: types.NodeID{1}, Coinbase: types.Address{byte(3 + i%2)}, Weight: types.RatNum{Num: 1, Denom: 1}},
+			{SmesherID: types.NodeID{2}, Coinbase: types.Address{1}, Weight: types.RatNum{Num: 1, Denom: 1}},
+		})
+		require.NoError(t, err)
+	}
+	layer := first.Add(1)
+
+	t.Run("match", func(t *testing.T) {
+		rst, err := Verify(db, fromState(t, db, layer), layer)
+		require.NoError(t, err)
+		require.True(t, rst.Match())
+		require.NotEqual(t, types.Hash32{}, rst.Actual)
+	})
+	t.Run("state root of the other layer", func(t *testing.T) {
+		rst, err := Verify(db, fromState(t, db, layer), layer.Add(1))
+		require.NoError(t, err)
+		require.False(t, rst.Match())
+		require.NotEqual(t, rst.Expected, rst.Actual)
+		require.NotEmpty(t, rst.Accounts)
+	})
+	t.Run("tampered balance", func(t *testing.T) {
+		data := fromState(t, db, layer)
+		data.Data.Accounts[0].Balance++
+		rst, err := Verify(db, data, layer)
+		require.NoError(t, err)
+		require.False(t, rst.Match())
+		require.NotEqual(t, rst.Expected, rst.Actual)
+		require.Equal(t, []Difference{{
+			Kind:  KindAccount,
+			ID:    types.Address{1}.String(),
+			Field: "balance",
+			Left:  fmt.Sprint(data.Data.Accounts[0].Balance),
+			Right: fmt.Sprint(data.Data.Accounts[0].Balance - 1),
+		}}, rst.Accounts)
+	})
+	t.Run("account not updated in the layer", func(t *testing.T) {
+		// account {2} is not updated after genesis, so it doesn't change the state root of the layer
+		data := fromState(t, db, layer)
+		data.Data.Accounts[1].Balance++
+		rst, err := Verify(db, data, layer)
+		require.NoError(t, err)
+		require.Equal(t, rst.Expected, rst.Actual)
+		require.Len(t, rst.Accounts, 1)
+		require.False(t, rst.Match())
+	})
+	t.Run("missing state root", func(t *testing.T) {
+		_, err := Verify(db, fromState(t, db, layer), layer.Add(10))
+		require.ErrorIs(t, err, sql.ErrNotFound)
+	})
+}
@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spacemeshos/go-spacemesh/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	vm "github.com/spacemeshos/go-spacemesh/genvm"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+)
+
+const snapshotPrefix = "snapshot-"
+
+// OpenState opens state database in read-only mode without applying migrations.
+func OpenState(path string) (*sql.Database, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open database %s: %w", path, err)
+	}
+	db, err := sql.Open("file:"+path,
+		sql.WithReadOnly(),
+		sql.WithMigrations(nil),
+		sql.WithConnections(1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("open database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// SnapshotLayer parses the snapshot layer from the id of the checkpoint.
+func SnapshotLayer(data *types.Checkpoint) (types.LayerID, error) {
+	value, found := strings.CutPrefix(data.Data.CheckpointId, snapshotPrefix)
+	if !found {
+		return 0, fmt.Errorf("checkpoint id %q doesn't have %q prefix", data.Data.CheckpointId, snapshotPrefix)
+	}
+	layer, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parse snapshot layer from %q: %w", data.Data.CheckpointId, err)
+	}
+	return types.LayerID(layer), nil
+}
+
+// Verification is the result of comparing the checkpoint with the state database.
+type Verification struct {
+	Layer uint32 `json:"layer"`
+	// Expected is the state root stored in the database for the layer.
+	Expected types.Hash32 `json:"expected"`
+	// Actual is the state root rebuilt from the accounts of the checkpoint.
+	Actual types.Hash32 `json:"actual"`
+	// Accounts that differ between the checkpoint (left) and the database (right).
+	Accounts []Difference `json:"accounts,omitempty"`
+}
+
+// Match is true if the rebuilt state root is equal to the stored one and all accounts agree.
+func (v *Verification) Match() bool {
+	return v.Expected == v.Actual && len(v.Accounts) == 0
+}
+
+// Verify rebuilds the state root of the layer from the accounts of the checkpoint and compares it
+// with the state root stored in db.
+//
+// Accounts of the checkpoint are applied to the empty vm, and the state root is computed over accounts
+// that were updated in the layer according to db, in the order they were updated.
+func Verify(db sql.Executor, data *types.Checkpoint, layer types.LayerID) (*Verification, error) {
+	expected, err := layers.GetStateHash(db, layer)
+	if err != nil {
+		if errors.Is(err, sql.ErrNotFound) {
+			return nil, fmt.Errorf("state root for layer %d: %w", layer, err)
+		}
+		return nil, err
+	}
+
+	genvm := vm.New(sql.InMemory(), vm.WithLogger(log.NewNop()))
+	recovered := checkpoint.Accounts(data, layer)
+	genesis := make([]types.Account, 0, len(recovered))
+	for _, account := range recovered {
+		genesis = append(genesis, *account)
+	}
+	if err := genvm.ApplyGenesis(genesis); err != nil {
+		return nil, fmt.Errorf("apply checkpoint accounts: %w", err)
+	}
+	all, err := genvm.GetAllAccounts()
+	if err != nil {
+		return nil, err
+	}
+	rebuilt := make(map[types.Address]*types.Account, len(all))
+	for _, account := range all {
+		rebuilt[account.Address] = account
+	}
+
+	updated, err := accounts.InLayer(db, layer)
+	if err != nil {
+		return nil, err
+	}
+	ordered := make([]*types.Account, 0, len(updated))
+	for _, account := range updated {
+		// accounts that are missing in the checkpoint are hashed as empty accounts,
+		// the difference is reported by comparing snapshots below
+		rst, exists := rebuilt[account.Address]
+		if !exists {
+			rst = &types.Account{Address: account.Address}
+		}
+		rst.Layer = layer
+		ordered = append(ordered, rst)
+	}
+
+	snapshot, err := accounts.Snapshot(db, layer)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return nil, err
+	}
+	live := &types.Checkpoint{Data: types.InnerData{Accounts: make([]types.AccountSnapshot, 0, len(snapshot))}}
+	for _, account := range snapshot {
+		a := types.AccountSnapshot{
+			Address: account.Address.Bytes(),
+			Balance: account.Balance,
+			Nonce:   account.NextNonce,
+			State:   account.State,
+		}
+		if account.TemplateAddress != nil {
+			a.Template = account.TemplateAddress.Bytes()
+		}
+		live.Data.Accounts = append(live.Data.Accounts, a)
+	}
+	return &Verification{
+		Layer:    layer.Uint32(),
+		Expected: expected,
+		Actual:   vm.StateHash(ordered),
+		Accounts: compare(KindAccount, accountEntries(data), accountEntries(live)),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/urfave/cli/v2"
+
+	"github.com/spacemeshos/go-spacemesh/checkpoint"
+	"github.com/spacemeshos/go-spacemesh/cmd/checkpoint-diff/internal"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+var version string
+
+// errDifferent is returned when the compared data doesn't match, so that the tool exits with non-zero code.
+var errDifferent = errors.New("checkpoint doesn't match")
+
+func main() {
+	app := &cli.App{
+		Name:    "Spacemesh Checkpoint Diff",
+		Usage:   "Compare checkpoint files with each other or with the state of a node.",
+		Version: version,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print results in json",
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:      "diff",
+				Usage:     "Compare atxs and accounts of two checkpoint files",
+				ArgsUsage: "<left> <right>",
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 2 {
+						return fmt.Errorf("expected two checkpoint files, got %d", ctx.NArg())
+					}
+					left, err := checkpoint.Load(afero.NewOsFs(), ctx.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					right, err := checkpoint.Load(afero.NewOsFs(), ctx.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					diffs := internal.Diff(left, right)
+					if err := output(ctx, diffs, func() {
+						for _, diff := range diffs {
+							fmt.Println(diff)
+						}
+					}); err != nil {
+						return err
+					}
+					if len(diffs) > 0 {
+						return fmt.Errorf("%w: %d differences", errDifferent, len(diffs))
+					}
+					return nil
+				},
+			},
+			{
+				Name: "verify",
+				Usage: "Rebuild the state root from accounts of the checkpoint file " +
+					"and compare it with the state root stored in state.sql",
+				ArgsUsage: "<checkpoint>",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "db",
+						Usage:    "Path to the state.sql `file`",
+						Required: true,
+					},
+					&cli.UintFlag{
+						Name:  "layer",
+						Usage: "Layer of the state root, defaults to the snapshot layer of the checkpoint",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					if ctx.NArg() != 1 {
+						return fmt.Errorf("expected one checkpoint file, got %d", ctx.NArg())
+					}
+					data, err := checkpoint.Load(afero.NewOsFs(), ctx.Args().First())
+					if err != nil {
+						return err
+					}
+					var layer types.LayerID
+					if ctx.IsSet("layer") {
+						layer = types.LayerID(ctx.Uint("layer"))
+					} else if layer, err = internal.SnapshotLayer(data); err != nil {
+						return err
+					}
+					db, err := internal.OpenState(ctx.String("db"))
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+					rst, err := internal.Verify(db, data, layer)
+					if err != nil {
+						return err
+					}
+					if err := output(ctx, rst, func() {
+						fmt.Printf("layer %d: stored state root %s, rebuilt %s\n", rst.Layer, rst.Expected, rst.Actual)
+						for _, diff := range rst.Accounts {
+							fmt.Println(diff)
+						}
+					}); err != nil {
+						return err
+					}
+					if !rst.Match() {
+						return errDifferent
+					}
+					return nil
+				},
+			},
+		},
+	}
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func output(ctx *cli.Context, rst any, text func()) error {
+	if !ctx.Bool("json") {
+		text()
+		return nil
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rst)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spacemeshos/go-scale"
+
+	"github.com/spacemeshos/go-spacemesh/activation/wire"
+	"github.com/spacemeshos/go-spacemesh/beacon"
+	"github.com/spacemeshos/go-spacemesh/beacon/weakcoin"
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/fetch"
+	"github.com/spacemeshos/go-spacemesh/hare3"
+	mwire "github.com/spacemeshos/go-spacemesh/malfeasance/wire"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
+	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/p2p/server"
+)
+
+var version string
+
+type decoder func() scale.Decodable
+
+func decodeAs[T any, P interface {
+	*T
+	scale.Decodable
+}]() decoder {
+	return func() scale.Decodable {
+		return P(new(T))
+	}
+}
+
+// gossip messages by topic.
+var gossip = map[string]decoder{
+	pubsub.AtxProtocol:                  decodeAs[wire.ActivationTxV1](),
+	pubsub.ProposalProtocol:             decodeAs[types.Proposal](),
+	pubsub.BlockCertify:                 decodeAs[types.CertifyMessage](),
+	pubsub.BeaconWeakCoinProtocol:       decodeAs[weakcoin.Message](),
+	pubsub.BeaconProposalProtocol:       decodeAs[beacon.ProposalMessage](),
+	pubsub.BeaconFirstVotesProtocol:     decodeAs[beacon.FirstVotingMessage](),
+	pubsub.BeaconFollowingVotesProtocol: decodeAs[beacon.FollowingVotingMessage](),
+	pubsub.MalfeasanceProof:             decodeAs[mwire.MalfeasanceGossip](),
+	hare3.DefaultConfig().ProtocolName:  decodeAs[hare3.Message](),
+}
+
+// requests and responses by server protocol, keep in line with protocols registered in fetch.
+// Responses are always encoded as server.Response, decoder is applied to the data of the response.
+var requests = map[string]struct {
+	request, response decoder
+}{
+	"ax/1":            {decodeAs[types.EpochID](), decodeAs[fetch.EpochData]()},
+	"hs/1":            {decodeAs[fetch.RequestBatch](), decodeAs[fetch.ResponseBatch]()},
+	"as/1":            {decodeAs[fetch.RequestBatch](), decodeAs[fetch.ResponseBatch]()},
+	"ld/1":            {decodeAs[types.LayerID](), decodeAs[fetch.LayerData]()},
+	"mh/1":            {decodeAs[fetch.MeshHashRequest](), decodeAs[fetch.MeshHashes]()},
+	"ml/1":            {nil, decodeAs[fetch.MaliciousIDs]()},
+	"rs/1":            {decodeAs[fetch.RangeSyncRequest](), nil},
+	fetch.OpnProtocol: {decodeAs[fetch.OpinionRequest](), decodeAs[fetch.LayerOpinion]()},
+}
+
+func decode(rec *capture.Record) (any, error) {
+	var dec decoder
+	switch rec.Kind {
+	case capture.Gossip:
+		dec = gossip[rec.Protocol]
+	case capture.Request:
+		dec = requests[rec.Protocol].request
+	case capture.Response:
+		dec = requests[rec.Protocol].response
+		if dec == nil {
+			break
+		}
+		var resp server.Response
+		if err := codec.Decode(rec.Data, &resp); err != nil {
+			return nil, fmt.Errorf("decode response: %w", err)
+		}
+		if resp.Error != "" {
+			return &resp, nil
+		}
+		obj := dec()
+		if err := codec.Decode(resp.Data, obj); err != nil {
+			return nil, fmt.Errorf("decode response data: %w", err)
+		}
+		return obj, nil
+	}
+	if dec == nil {
+		return nil, nil
+	}
+	obj := dec()
+	if err := codec.Decode(rec.Data, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func main() {
+	printVersion := flag.Bool("version", false, "print the version and exit")
+	protocols := flag.String("protocols", "", "comma separated list of protocols to print, everything if empty")
+	flag.Parse()
+	if *printVersion {
+		fmt.Println(version)
+		return
+	}
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: decode-capture [-version] [-protocols ax1,hs/1] <path to capture file>...")
+		os.Exit(2)
+	}
+	var filter []string
+	if *protocols != "" {
+		filter = strings.Split(*protocols, ",")
+	}
+	for _, path := range flag.Args() {
+		if err := printFile(path, filter); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func printFile(path string, filter []string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rd := capture.NewReader(f)
+	for {
+		rec, err := rd.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(filter) > 0 && !slices.Contains(filter, rec.Protocol) {
+			continue
+		}
+		fmt.Printf("%s %s %s %s %s result=%q size=%d\n",
+			rec.Time().UTC().Format(time.RFC3339Nano),
+			rec.PeerID(),
+			rec.Protocol,
+			rec.Direction,
+			rec.Kind,
+			rec.Result,
+			rec.Size,
+		)
+		if rec.Truncated() {
+			fmt.Printf("\ttruncated to %d bytes\n\t%s\n", len(rec.Data), hex.EncodeToString(rec.Data))
+			continue
+		}
+		obj, err := decode(rec)
+		switch {
+		case err != nil:
+			fmt.Printf("\tfailed to decode: %v\n\t%s\n", err, hex.EncodeToString(rec.Data))
+		case obj == nil:
+			fmt.Printf("\t%s\n", hex.EncodeToString(rec.Data))
+		default:
+			fmt.Printf("\t%+v\n", obj)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/natefinch/atomic"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+const keyDir = "identities"
+
+// EncryptIdentities encrypts all plain text identity files of the node in dataDir with the passphrase.
+//
+// Identity files that are already encrypted are checked to be encrypted with the same passphrase,
+// so that the node can load all identities after the migration. Files are replaced atomically
+// and keep their permissions. Returns the number of encrypted files.
+func EncryptIdentities(logger *zap.Logger, dataDir string, passphrase []byte) (int, error) {
+	if len(passphrase) == 0 {
+		return 0, errors.New("passphrase is not set")
+	}
+	dir := filepath.Join(dataDir, keyDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("read key directory: %w", err)
+	}
+	encrypted := 0
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".key" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		signer, err := signing.NewEdSigner(
+			signing.FromFile(path),
+			signing.WithPassphrase(passphrase),
+		)
+		if err != nil {
+			return encrypted, fmt.Errorf("load identity file %s: %w", entry.Name(), err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return encrypted, err
+		}
+		if signing.IsEncryptedKey(data) {
+			logger.Info("identity is already encrypted", zap.String("name", entry.Name()))
+			continue
+		}
+		dst, err := signing.EncryptKey(signer.PrivateKey(), passphrase)
+		if err != nil {
+			return encrypted, fmt.Errorf("encrypt identity file %s: %w", entry.Name(), err)
+		}
+		if err := atomic.WriteFile(path, bytes.NewReader(dst)); err != nil {
+			return encrypted, fmt.Errorf("write identity file %s: %w", entry.Name(), err)
+		}
+		logger.Info("encrypted identity",
+			zap.String("name", entry.Name()),
+			zap.Stringer("id", signer.NodeID()),
+		)
+		encrypted++
+	}
+	return encrypted, nil
+}
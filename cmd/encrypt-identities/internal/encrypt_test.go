@@ -0,0 +1,73 @@
+package internal
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+func TestEncryptIdentities(t *testing.T) {
+	passphrase := []byte("passphrase")
+	dataDir := t.TempDir()
+	dir := filepath.Join(dataDir, keyDir)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+
+	plain, err := signing.NewEdSigner(signing.ToFile(filepath.Join(dir, "plain.key")))
+	require.NoError(t, err)
+	encrypted, err := signing.NewEdSigner(
+		signing.ToFile(filepath.Join(dir, "encrypted.key")),
+		signing.WithPassphrase(passphrase),
+	)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a key"), 0o600))
+
+	n, err := EncryptIdentities(zaptest.NewLogger(t), dataDir, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	for _, signer := range []*signing.EdSigner{plain, encrypted} {
+		path := filepath.Join(dir, signer.Name())
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.True(t, signing.IsEncryptedKey(data))
+		require.NotContains(t, string(data), hex.EncodeToString(signer.PrivateKey()))
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		loaded, err := signing.NewEdSigner(signing.FromFile(path), signing.WithPassphrase(passphrase))
+		require.NoError(t, err)
+		require.Equal(t, signer.PrivateKey(), loaded.PrivateKey())
+	}
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	n, err = EncryptIdentities(zaptest.NewLogger(t), dataDir, passphrase)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestEncryptIdentities_WrongPassphrase(t *testing.T) {
+	dataDir := t.TempDir()
+	dir := filepath.Join(dataDir, keyDir)
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	_, err := signing.NewEdSigner(
+		signing.ToFile(filepath.Join(dir, "encrypted.key")),
+		signing.WithPassphrase([]byte("passphrase")),
+	)
+	require.NoError(t, err)
+
+	_, err = EncryptIdentities(zaptest.NewLogger(t), dataDir, []byte("other"))
+	require.ErrorIs(t, err, signing.ErrInvalidPassphrase)
+
+	_, err = EncryptIdentities(zaptest.NewLogger(t), dataDir, nil)
+	require.Error(t, err)
+}
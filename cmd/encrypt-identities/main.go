@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/cmd/encrypt-identities/internal"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+var version string
+
+func main() {
+	cfg := zap.NewProductionConfig()
+	cfg.Encoding = "console"
+	logger, err := cfg.Build()
+	if err != nil {
+		fmt.Println("create logger:", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	app := &cli.App{
+		Name: "Spacemesh Identity Encryption",
+		Usage: "Encrypt plain text identity files of a Spacemesh node with a passphrase.\n" +
+			"The passphrase is read from the `passphrase-file` or from the " + signing.PassphraseEnv +
+			" environment variable.\n" +
+			"After the migration start the node with the same passphrase.\n" +
+			"NOTE: the node must be stopped before running this command.",
+		Version: version,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "data",
+				Aliases:  []string{"d"},
+				Usage:    "The `data` folder of the node",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "passphrase-file",
+				Usage: "The `file` with the passphrase",
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			passphrase, err := signing.LoadPassphrase(ctx.String("passphrase-file"))
+			if err != nil {
+				return err
+			}
+			n, err := internal.EncryptIdentities(logger, ctx.String("data"), passphrase)
+			if err != nil {
+				return err
+			}
+			logger.Info("encryption finished", zap.Int("encrypted", n))
+			return nil
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		logger.Sugar().Warnln("app run:", err)
+		os.Exit(1)
+	}
+}
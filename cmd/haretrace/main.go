@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spacemeshos/go-spacemesh/hare3"
+)
+
+var version string
+
+func main() {
+	printVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+	if *printVersion {
+		fmt.Println(version)
+		return
+	}
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: haretrace [-version] <path to trace>")
+		os.Exit(2)
+	}
+	diffs, err := hare3.RunTrace(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "run trace failed: %v\n", err)
+		os.Exit(1)
+	}
+	for _, diff := range diffs {
+		fmt.Println(diff)
+	}
+	if len(diffs) > 0 {
+		fmt.Fprintf(os.Stderr, "replayed outputs differ in %d rounds\n", len(diffs))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,413 @@
+package internal
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/malfeasance/wire"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/activesets"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/rewards"
+)
+
+// Open opens state database in read-only mode.
+// Migrations are not applied, so that the database is never modified by inspection.
+func Open(path string) (*sql.Database, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open database %s: %w", path, err)
+	}
+	db, err := sql.Open("file:"+path,
+		sql.WithReadOnly(),
+		sql.WithMigrations(nil),
+		sql.WithConnections(1),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("open database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// Inspector answers queries over the state database using the same queries as the node.
+type Inspector struct {
+	db sql.Executor
+}
+
+func New(db sql.Executor) *Inspector {
+	return &Inspector{db: db}
+}
+
+type ATX struct {
+	ID           string `json:"id"`
+	NodeID       string `json:"node_id"`
+	PublishEpoch uint32 `json:"publish_epoch"`
+	Sequence     uint64 `json:"sequence"`
+	Coinbase     string `json:"coinbase"`
+	NumUnits     uint32 `json:"num_units"`
+	Weight       uint64 `json:"weight"`
+	TickHeight   uint64 `json:"tick_height"`
+}
+
+func newATX(atx *types.VerifiedActivationTx) ATX {
+	id := atx.ID()
+	return ATX{
+		ID:           hex.EncodeToString(id[:]),
+		NodeID:       atx.SmesherID.String(),
+		PublishEpoch: atx.PublishEpoch.Uint32(),
+		Sequence:     atx.Sequence,
+		Coinbase:     atx.Coinbase.String(),
+		NumUnits:     atx.NumUnits,
+		Weight:       atx.GetWeight(),
+		TickHeight:   atx.TickHeight(),
+	}
+}
+
+// ATXsByEpoch returns all atxs published in the epoch.
+func (i *Inspector) ATXsByEpoch(ctx context.Context, publish types.EpochID) ([]ATX, error) {
+	ids, err := atxs.GetIDsByEpoch(ctx, i.db, publish)
+	if err != nil {
+		return nil, fmt.Errorf("get atxs in epoch %d: %w", publish, err)
+	}
+	rst := make([]ATX, 0, len(ids))
+	for _, id := range ids {
+		atx, err := atxs.Get(i.db, id)
+		if err != nil {
+			return nil, fmt.Errorf("get atx %s: %w", id, err)
+		}
+		rst = append(rst, newATX(atx))
+	}
+	return rst, nil
+}
+
+// ATXsByNode returns all atxs published by the identity ordered by epoch.
+func (i *Inspector) ATXsByNode(node types.NodeID) ([]ATX, error) {
+	latest, err := atxs.LatestEpoch(i.db)
+	if err != nil {
+		return nil, fmt.Errorf("get latest epoch: %w", err)
+	}
+	var rst []ATX
+	for epoch := types.EpochID(0); epoch <= latest; epoch++ {
+		atx, err := atxs.GetByEpochAndNodeID(i.db, epoch, node)
+		switch {
+		case errors.Is(err, sql.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("get atx in epoch %d: %w", epoch, err)
+		}
+		rst = append(rst, newATX(atx))
+	}
+	return rst, nil
+}
+
+type Ballot struct {
+	ID            string `json:"id"`
+	Layer         uint32 `json:"layer"`
+	NodeID        string `json:"node_id"`
+	ATX           string `json:"atx"`
+	Eligibilities int    `json:"eligibilities"`
+	RefBallot     string `json:"ref_ballot,omitempty"`
+	ActiveSet     string `json:"active_set,omitempty"`
+	Malicious     bool   `json:"malicious"`
+}
+
+// Ballots returns all ballots in the layer.
+func (i *Inspector) Ballots(layer types.LayerID) ([]Ballot, error) {
+	all, err := ballots.Layer(i.db, layer)
+	if err != nil {
+		return nil, fmt.Errorf("get ballots in layer %d: %w", layer, err)
+	}
+	rst := make([]Ballot, 0, len(all))
+	for _, ballot := range all {
+		id := ballot.ID()
+		b := Ballot{
+			ID:            hex.EncodeToString(id[:]),
+			Layer:         ballot.Layer.Uint32(),
+			NodeID:        ballot.SmesherID.String(),
+			ATX:           hex.EncodeToString(ballot.AtxID[:]),
+			Eligibilities: len(ballot.EligibilityProofs),
+			Malicious:     ballot.IsMalicious(),
+		}
+		if ballot.RefBallot != types.EmptyBallotID {
+			b.RefBallot = hex.EncodeToString(ballot.RefBallot[:])
+		}
+		if ballot.EpochData != nil {
+			b.ActiveSet = ballot.EpochData.ActiveSetHash.String()
+		}
+		rst = append(rst, b)
+	}
+	return rst, nil
+}
+
+type Block struct {
+	ID         string `json:"id"`
+	Layer      uint32 `json:"layer"`
+	TickHeight uint64 `json:"tick_height"`
+	Rewards    int    `json:"rewards"`
+	Txs        int    `json:"txs"`
+	// Validity is one of valid, invalid or undecided.
+	Validity   string `json:"validity"`
+	HareOutput bool   `json:"hare_output"`
+	Applied    bool   `json:"applied"`
+}
+
+// Blocks returns all blocks in the layer.
+func (i *Inspector) Blocks(layer types.LayerID) ([]Block, error) {
+	all, err := blocks.Layer(i.db, layer)
+	if err != nil {
+		return nil, fmt.Errorf("get blocks in layer %d: %w", layer, err)
+	}
+	hare, err := certificates.GetHareOutput(i.db, layer)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return nil, fmt.Errorf("get hare output in layer %d: %w", layer, err)
+	}
+	applied, err := layers.GetApplied(i.db, layer)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return nil, fmt.Errorf("get applied block in layer %d: %w", layer, err)
+	}
+	rst := make([]Block, 0, len(all))
+	for _, block := range all {
+		id := block.ID()
+		validity := "invalid"
+		valid, err := blocks.IsValid(i.db, id)
+		switch {
+		case errors.Is(err, blocks.ErrValidityNotDecided):
+			validity = "undecided"
+		case err != nil:
+			return nil, fmt.Errorf("get validity of block %s: %w", id, err)
+		case valid:
+			validity = "valid"
+		}
+		rst = append(rst, Block{
+			ID:         hex.EncodeToString(id[:]),
+			Layer:      block.LayerIndex.Uint32(),
+			TickHeight: block.TickHeight,
+			Rewards:    len(block.Rewards),
+			Txs:        len(block.TxIDs),
+			Validity:   validity,
+			HareOutput: id == hare,
+			Applied:    id == applied,
+		})
+	}
+	return rst, nil
+}
+
+type Certificate struct {
+	Layer      uint32 `json:"layer"`
+	Block      string `json:"block"`
+	Valid      bool   `json:"valid"`
+	Signatures int    `json:"signatures"`
+}
+
+// Certificates returns all certificates in the layer.
+func (i *Inspector) Certificates(layer types.LayerID) ([]Certificate, error) {
+	all, err := certificates.Get(i.db, layer)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return nil, fmt.Errorf("get certificates in layer %d: %w", layer, err)
+	}
+	rst := make([]Certificate, 0, len(all))
+	for _, cert := range all {
+		c := Certificate{
+			Layer: layer.Uint32(),
+			Block: hex.EncodeToString(cert.Block[:]),
+			Valid: cert.Valid,
+		}
+		if cert.Cert != nil {
+			c.Signatures = len(cert.Cert.Signatures)
+		}
+		rst = append(rst, c)
+	}
+	return rst, nil
+}
+
+type Account struct {
+	Address  string `json:"address"`
+	Layer    uint32 `json:"layer"`
+	Balance  uint64 `json:"balance"`
+	Nonce    uint64 `json:"nonce"`
+	Template string `json:"template,omitempty"`
+}
+
+// AccountHistory returns every recorded state of the account.
+func (i *Inspector) AccountHistory(address types.Address) ([]Account, error) {
+	history, err := accounts.History(i.db, address)
+	if err != nil {
+		return nil, err
+	}
+	rst := make([]Account, 0, len(history))
+	for _, account := range history {
+		a := Account{
+			Address: account.Address.String(),
+			Layer:   account.Layer.Uint32(),
+			Balance: account.Balance,
+			Nonce:   account.NextNonce,
+		}
+		if account.TemplateAddress != nil {
+			a.Template = account.TemplateAddress.String()
+		}
+		rst = append(rst, a)
+	}
+	return rst, nil
+}
+
+type Reward struct {
+	Layer       uint32 `json:"layer"`
+	Coinbase    string `json:"coinbase"`
+	NodeID      string `json:"node_id"`
+	TotalReward uint64 `json:"total_reward"`
+	LayerReward uint64 `json:"layer_reward"`
+}
+
+// Rewards returns rewards filtered by coinbase and/or smesher.
+func (i *Inspector) Rewards(coinbase *types.Address, smesher *types.NodeID) ([]Reward, error) {
+	all, err := rewards.ListByKey(i.db, coinbase, smesher)
+	if err != nil {
+		return nil, fmt.Errorf("list rewards: %w", err)
+	}
+	rst := make([]Reward, 0, len(all))
+	for _, reward := range all {
+		rst = append(rst, Reward{
+			Layer:       reward.Layer.Uint32(),
+			Coinbase:    reward.Coinbase.String(),
+			NodeID:      reward.SmesherID.String(),
+			TotalReward: reward.TotalReward,
+			LayerReward: reward.LayerReward,
+		})
+	}
+	return rst, nil
+}
+
+type Malfeasance struct {
+	NodeID   string    `json:"node_id"`
+	Layer    uint32    `json:"layer"`
+	Type     string    `json:"type"`
+	Received time.Time `json:"received"`
+}
+
+func proofType(typ uint8) string {
+	switch typ {
+	case wire.MultipleATXs:
+		return "multiple atxs"
+	case wire.MultipleBallots:
+		return "multiple ballots"
+	case wire.HareEquivocation:
+		return "hare equivocation"
+	case wire.InvalidPostIndex:
+		return "invalid post index"
+	}
+	return "unknown"
+}
+
+// Malfeasance returns malfeasance proofs for the provided identities,
+// or for all malicious identities if none were provided.
+func (i *Inspector) Malfeasance(ids ...types.NodeID) ([]Malfeasance, error) {
+	if len(ids) == 0 {
+		var err error
+		ids, err = identities.GetMalicious(context.Background(), i.db)
+		if err != nil {
+			return nil, fmt.Errorf("get malicious identities: %w", err)
+		}
+	}
+	rst := make([]Malfeasance, 0, len(ids))
+	for _, id := range ids {
+		proof, err := identities.GetMalfeasanceProof(i.db, id)
+		switch {
+		case errors.Is(err, sql.ErrNotFound):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("get malfeasance proof for %s: %w", id, err)
+		}
+		rst = append(rst, Malfeasance{
+			NodeID:   id.String(),
+			Layer:    proof.Layer.Uint32(),
+			Type:     proofType(proof.Proof.Type),
+			Received: proof.Received().UTC(),
+		})
+	}
+	return rst, nil
+}
+
+type ActiveSet struct {
+	ID     string `json:"id"`
+	Epoch  uint32 `json:"epoch"`
+	Size   int    `json:"size"`
+	Weight uint64 `json:"weight"`
+	// Missing is a number of atxs from the set that are not in the database.
+	Missing int `json:"missing"`
+}
+
+// ActiveSet returns a summary of the active set with the id.
+func (i *Inspector) ActiveSet(id types.Hash32) (ActiveSet, error) {
+	set, err := activesets.Get(i.db, id)
+	if err != nil {
+		return ActiveSet{}, fmt.Errorf("get active set %s: %w", id, err)
+	}
+	rst := ActiveSet{
+		ID:    id.String(),
+		Epoch: set.Epoch.Uint32(),
+		Size:  len(set.Set),
+	}
+	for _, atxid := range set.Set {
+		atx, err := atxs.Get(i.db, atxid)
+		switch {
+		case errors.Is(err, sql.ErrNotFound):
+			rst.Missing++
+			continue
+		case err != nil:
+			return ActiveSet{}, fmt.Errorf("get atx %s: %w", atxid, err)
+		}
+		rst.Weight += atx.GetWeight()
+	}
+	return rst, nil
+}
+
+// ActiveSetsInEpoch returns summaries of all active sets referenced by the first ballots in the epoch.
+func (i *Inspector) ActiveSetsInEpoch(epoch types.EpochID) ([]ActiveSet, error) {
+	first, err := ballots.AllFirstInEpoch(i.db, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("get first ballots in epoch %d: %w", epoch, err)
+	}
+	var (
+		rst  []ActiveSet
+		seen = map[types.Hash32]struct{}{}
+	)
+	for _, ballot := range first {
+		if ballot.EpochData == nil {
+			continue
+		}
+		if _, exists := seen[ballot.EpochData.ActiveSetHash]; exists {
+			continue
+		}
+		seen[ballot.EpochData.ActiveSetHash] = struct{}{}
+		set, err := i.ActiveSet(ballot.EpochData.ActiveSetHash)
+		if err != nil {
+			return nil, err
+		}
+		rst = append(rst, set)
+	}
+	return rst, nil
+}
+
+type ATXSummary struct {
+	Count  int    `json:"count"`
+	Weight uint64 `json:"weight"`
+}
+
+// Summarize returns the number and the total weight of atxs.
+func Summarize(all []ATX) ATXSummary {
+	rst := ATXSummary{Count: len(all)}
+	for _, atx := range all {
+		rst.Weight += atx.Weight
+	}
+	return rst
+}
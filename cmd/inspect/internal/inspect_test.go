@@ -0,0 +1,300 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/malfeasance/wire"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/accounts"
+	"github.com/spacemeshos/go-spacemesh/sql/activesets"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/rewards"
+)
+
+func newAtx(
+	t *testing.T,
+	id types.ATXID,
+	node types.NodeID,
+	publish types.EpochID,
+	units uint32,
+) *types.VerifiedActivationTx {
+	nonce := types.VRFPostIndex(1)
+	atx := types.NewActivationTx(types.NIPostChallenge{PublishEpoch: publish}, types.Address{1}, units, &nonce)
+	atx.SmesherID = node
+	atx.SetID(id)
+	atx.SetEffectiveNumUnits(units)
+	atx.SetReceived(time.Now())
+	vatx, err := atx.Verify(0, 10)
+	require.NoError(t, err)
+	return vatx
+}
+
+// setup creates a state database on disk, fills it with data and reopens it read-only.
+func setup(t *testing.T, fill func(db sql.Executor)) *Inspector {
+	path := filepath.Join(t.TempDir(), "state.sql")
+	db, err := sql.Open("file:" + path)
+	require.NoError(t, err)
+	fill(db)
+	require.NoError(t, db.Close())
+
+	db, err = Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	return New(db)
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.sql")
+	db, err := sql.Open("file:" + path)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = Open(path)
+	require.NoError(t, err)
+	defer db.Close()
+	require.Error(t, layers.SetProcessed(db, 1))
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.sql"))
+	require.Error(t, err)
+}
+
+func TestATXs(t *testing.T) {
+	nodes := []types.NodeID{{1}, {2}}
+	inspector := setup(t, func(db sql.Executor) {
+		require.NoError(t, atxs.Add(db, newAtx(t, types.ATXID{1}, nodes[0], 1, 2)))
+		require.NoError(t, atxs.Add(db, newAtx(t, types.ATXID{2}, nodes[1], 1, 3)))
+		require.NoError(t, atxs.Add(db, newAtx(t, types.ATXID{3}, nodes[0], 2, 4)))
+	})
+
+	all, err := inspector.ATXsByEpoch(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, ATXSummary{Count: 2, Weight: 50}, Summarize(all))
+
+	byNode, err := inspector.ATXsByNode(nodes[0])
+	require.NoError(t, err)
+	require.Len(t, byNode, 2)
+	require.Equal(t, hex.EncodeToString(types.ATXID{1}.Bytes()), byNode[0].ID)
+	require.EqualValues(t, 1, byNode[0].PublishEpoch)
+	require.EqualValues(t, 2, byNode[1].PublishEpoch)
+	require.EqualValues(t, 40, byNode[1].Weight)
+	require.Equal(t, nodes[0].String(), byNode[1].NodeID)
+}
+
+func TestLayerQueries(t *testing.T) {
+	const layer = types.LayerID(10)
+	block := types.NewExistingBlock(types.BlockID{1}, types.InnerBlock{
+		LayerIndex: layer,
+		TickHeight: 100,
+		TxIDs:      []types.TransactionID{{1}, {2}},
+	})
+	other := types.NewExistingBlock(types.BlockID{2}, types.InnerBlock{LayerIndex: layer})
+	ballot := types.NewExistingBallot(types.BallotID{1}, types.EmptyEdSignature, types.NodeID{1}, layer)
+	ballot.AtxID = types.ATXID{1}
+	ballot.EpochData = &types.EpochData{ActiveSetHash: types.Hash32{3}}
+	ballot.EligibilityProofs = []types.VotingEligibility{{J: 1}, {J: 2}}
+	inspector := setup(t, func(db sql.Executor) {
+		require.NoError(t, ballots.Add(db, &ballot))
+		require.NoError(t, blocks.Add(db, block))
+		require.NoError(t, blocks.Add(db, other))
+		require.NoError(t, blocks.SetValid(db, block.ID()))
+		require.NoError(t, certificates.SetHareOutput(db, layer, block.ID()))
+		require.NoError(t, certificates.Add(db, layer, &types.Certificate{
+			BlockID:    block.ID(),
+			Signatures: []types.CertifyMessage{{}, {}},
+		}))
+		require.NoError(t, layers.SetApplied(db, layer, block.ID()))
+	})
+
+	bid := ballot.ID()
+	bls, err := inspector.Ballots(layer)
+	require.NoError(t, err)
+	require.Equal(t, []Ballot{{
+		ID:            hex.EncodeToString(bid[:]),
+		Layer:         layer.Uint32(),
+		NodeID:        types.NodeID{1}.String(),
+		ATX:           hex.EncodeToString(types.ATXID{1}.Bytes()),
+		Eligibilities: 2,
+		ActiveSet:     types.Hash32{3}.String(),
+	}}, bls)
+
+	blockID := block.ID()
+	blks, err := inspector.Blocks(layer)
+	require.NoError(t, err)
+	require.Len(t, blks, 2)
+	for _, blk := range blks {
+		if blk.ID == hex.EncodeToString(blockID[:]) {
+			require.Equal(t, Block{
+				ID:         blk.ID,
+				Layer:      layer.Uint32(),
+				TickHeight: 100,
+				Txs:        2,
+				Validity:   "valid",
+				HareOutput: true,
+				Applied:    true,
+			}, blk)
+		} else {
+			require.Equal(t, "undecided", blk.Validity)
+			require.False(t, blk.HareOutput)
+			require.False(t, blk.Applied)
+		}
+	}
+
+	certs, err := inspector.Certificates(layer)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	require.Equal(t, 2, certs[0].Signatures)
+	require.True(t, certs[0].Valid)
+
+	certs, err = inspector.Certificates(layer + 1)
+	require.NoError(t, err)
+	require.Empty(t, certs)
+}
+
+func TestAccountsAndRewards(t *testing.T) {
+	address := types.Address{1, 1}
+	node := types.NodeID{1}
+	inspector := setup(t, func(db sql.Executor) {
+		for i := 1; i <= 3; i++ {
+			require.NoError(t, accounts.Update(db, &types.Account{
+				Address:   address,
+				Layer:     types.LayerID(i),
+				Balance:   uint64(i * 100),
+				NextNonce: uint64(i),
+			}))
+			require.NoError(t, rewards.Add(db, &types.Reward{
+				Layer:       types.LayerID(i),
+				Coinbase:    address,
+				SmesherID:   node,
+				TotalReward: 10,
+				LayerReward: 9,
+			}))
+		}
+	})
+
+	history, err := inspector.AccountHistory(address)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	require.EqualValues(t, 300, history[2].Balance)
+	require.EqualValues(t, 3, history[2].Nonce)
+	require.Equal(t, address.String(), history[0].Address)
+
+	byCoinbase, err := inspector.Rewards(&address, nil)
+	require.NoError(t, err)
+	require.Len(t, byCoinbase, 3)
+	bySmesher, err := inspector.Rewards(nil, &node)
+	require.NoError(t, err)
+	require.Equal(t, byCoinbase, bySmesher)
+	other := types.NodeID{2}
+	none, err := inspector.Rewards(nil, &other)
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
+
+func TestMalfeasance(t *testing.T) {
+	malicious := []types.NodeID{{1}, {2}}
+	received := time.Unix(1000, 0)
+	inspector := setup(t, func(db sql.Executor) {
+		for _, id := range malicious {
+			proof := &wire.MalfeasanceProof{
+				Layer: 11,
+				Proof: wire.Proof{Type: wire.MultipleBallots, Data: &wire.BallotProof{}},
+			}
+			require.NoError(t, identities.SetMalicious(db, id, codec.MustEncode(proof), received))
+		}
+	})
+
+	all, err := inspector.Malfeasance()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+
+	one, err := inspector.Malfeasance(malicious[1])
+	require.NoError(t, err)
+	require.Equal(t, []Malfeasance{{
+		NodeID:   malicious[1].String(),
+		Layer:    11,
+		Type:     "multiple ballots",
+		Received: received.UTC(),
+	}}, one)
+
+	none, err := inspector.Malfeasance(types.NodeID{3})
+	require.NoError(t, err)
+	require.Empty(t, none)
+}
+
+func TestActiveSet(t *testing.T) {
+	const epoch = types.EpochID(2)
+	id := types.Hash32{1}
+	ballot := types.NewExistingBallot(types.BallotID{1}, types.EmptyEdSignature, types.NodeID{1}, epoch.FirstLayer())
+	ballot.AtxID = types.ATXID{1}
+	ballot.EpochData = &types.EpochData{ActiveSetHash: id}
+	inspector := setup(t, func(db sql.Executor) {
+		require.NoError(t, atxs.Add(db, newAtx(t, types.ATXID{1}, types.NodeID{1}, epoch-1, 2)))
+		require.NoError(t, activesets.Add(db, id, &types.EpochActiveSet{
+			Epoch: epoch,
+			Set:   []types.ATXID{{1}, {2}},
+		}))
+		require.NoError(t, ballots.Add(db, &ballot))
+	})
+
+	expected := ActiveSet{ID: id.String(), Epoch: epoch.Uint32(), Size: 2, Weight: 20, Missing: 1}
+	set, err := inspector.ActiveSet(id)
+	require.NoError(t, err)
+	require.Equal(t, expected, set)
+
+	sets, err := inspector.ActiveSetsInEpoch(epoch)
+	require.NoError(t, err)
+	require.Equal(t, []ActiveSet{expected}, sets)
+
+	_, err = inspector.ActiveSet(types.Hash32{2})
+	require.ErrorIs(t, err, sql.ErrNotFound)
+}
+
+func TestPrint(t *testing.T) {
+	rows := []ATX{
+		{ID: "aa", NodeID: "01", PublishEpoch: 1, Weight: 10},
+		{ID: "bb", NodeID: "02", PublishEpoch: 1, Weight: 20},
+	}
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, FormatJSON, rows))
+		var decoded []ATX
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		require.Equal(t, rows, decoded)
+	})
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, FormatTable, rows))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		require.Equal(t,
+			[]string{"ID", "NODE_ID", "PUBLISH_EPOCH", "SEQUENCE", "COINBASE", "NUM_UNITS", "WEIGHT", "TICK_HEIGHT"},
+			strings.Fields(lines[0]))
+		require.Equal(t, []string{"bb", "02", "1", "0", "0", "20", "0"}, strings.Fields(lines[2]))
+	})
+	t.Run("single struct table", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, FormatTable, ATXSummary{Count: 2, Weight: 30}))
+		require.Equal(t, "COUNT  WEIGHT\n2      30\n", buf.String())
+	})
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := ParseFormat("xml")
+		require.Error(t, err)
+	})
+}
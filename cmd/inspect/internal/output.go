@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Format of the printed results.
+type Format string
+
+const (
+	FormatJSON  Format = "json"
+	FormatTable Format = "table"
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case FormatJSON, FormatTable:
+		return f, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected %s or %s", s, FormatJSON, FormatTable)
+}
+
+// Print writes rows to w. Rows must be a slice of structs or a single struct.
+//
+// Table columns are named after json tags of the struct fields.
+func Print(w io.Writer, format Format, rows any) error {
+	switch format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	case FormatTable:
+		return printTable(w, rows)
+	}
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func printTable(w io.Writer, rows any) error {
+	value := reflect.ValueOf(rows)
+	if value.Kind() == reflect.Struct {
+		slice := reflect.MakeSlice(reflect.SliceOf(value.Type()), 1, 1)
+		slice.Index(0).Set(value)
+		value = slice
+	}
+	if value.Kind() != reflect.Slice || value.Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("table output is not supported for %T", rows)
+	}
+	typ := value.Type().Elem()
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	columns := make([]string, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+		if name == "" {
+			name = typ.Field(i).Name
+		}
+		columns = append(columns, strings.ToUpper(name))
+	}
+	fmt.Fprintln(tw, strings.Join(columns, "\t"))
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		cells := make([]string, 0, row.NumField())
+		for j := 0; j < row.NumField(); j++ {
+			switch field := row.Field(j).Interface().(type) {
+			case time.Time:
+				cells = append(cells, field.Format(time.RFC3339))
+			default:
+				cells = append(cells, fmt.Sprint(field))
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
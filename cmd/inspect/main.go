@@ -0,0 +1,222 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/spacemeshos/go-spacemesh/cmd/inspect/internal"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+var version string
+
+func main() {
+	app := &cli.App{
+		Name: "Spacemesh State Inspector",
+		Usage: "Query state.sql of a Spacemesh node.\n" +
+			"The database is opened read-only and migrations are not applied, " +
+			"it is safe to use it while the node is running.",
+		Version: version,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "db",
+				Usage:    "Path to the state.sql `file`",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "Output format: table or json",
+				Value: string(internal.FormatTable),
+			},
+			&cli.StringFlag{
+				Name:  "hrp",
+				Usage: "Human readable part of the addresses of the network",
+				Value: types.NetworkHRP(),
+			},
+		},
+		Before: func(ctx *cli.Context) error {
+			types.SetNetworkHRP(ctx.String("hrp"))
+			return nil
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "atxs",
+				Usage: "List atxs published in the epoch or by the identity",
+				Flags: []cli.Flag{
+					&cli.UintFlag{Name: "epoch", Usage: "Publish epoch"},
+					&cli.StringFlag{Name: "node", Usage: "Hex encoded node id"},
+					&cli.BoolFlag{Name: "summary", Usage: "Print only the number and the total weight of atxs"},
+				},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					var (
+						rst []internal.ATX
+						err error
+					)
+					switch {
+					case ctx.IsSet("node"):
+						id, err := parseNodeID(ctx.String("node"))
+						if err != nil {
+							return nil, err
+						}
+						rst, err = inspector.ATXsByNode(id)
+						if err != nil {
+							return nil, err
+						}
+					case ctx.IsSet("epoch"):
+						rst, err = inspector.ATXsByEpoch(ctx.Context, types.EpochID(ctx.Uint("epoch")))
+						if err != nil {
+							return nil, err
+						}
+					default:
+						return nil, fmt.Errorf("either --epoch or --node must be set")
+					}
+					if ctx.Bool("summary") {
+						return internal.Summarize(rst), nil
+					}
+					return rst, nil
+				}),
+			},
+			{
+				Name:  "ballots",
+				Usage: "List ballots in the layer",
+				Flags: []cli.Flag{&cli.UintFlag{Name: "layer", Required: true}},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					return inspector.Ballots(types.LayerID(ctx.Uint("layer")))
+				}),
+			},
+			{
+				Name:  "blocks",
+				Usage: "List blocks in the layer",
+				Flags: []cli.Flag{&cli.UintFlag{Name: "layer", Required: true}},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					return inspector.Blocks(types.LayerID(ctx.Uint("layer")))
+				}),
+			},
+			{
+				Name:  "certificates",
+				Usage: "List certificates in the layer",
+				Flags: []cli.Flag{&cli.UintFlag{Name: "layer", Required: true}},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					return inspector.Certificates(types.LayerID(ctx.Uint("layer")))
+				}),
+			},
+			{
+				Name:  "account",
+				Usage: "Print history of the account",
+				Flags: []cli.Flag{&cli.StringFlag{Name: "address", Required: true}},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					address, err := types.StringToAddress(ctx.String("address"))
+					if err != nil {
+						return nil, err
+					}
+					return inspector.AccountHistory(address)
+				}),
+			},
+			{
+				Name:  "rewards",
+				Usage: "List rewards by coinbase and/or by identity",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "coinbase"},
+					&cli.StringFlag{Name: "node", Usage: "Hex encoded node id"},
+				},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					var (
+						coinbase *types.Address
+						node     *types.NodeID
+					)
+					if ctx.IsSet("coinbase") {
+						address, err := types.StringToAddress(ctx.String("coinbase"))
+						if err != nil {
+							return nil, err
+						}
+						coinbase = &address
+					}
+					if ctx.IsSet("node") {
+						id, err := parseNodeID(ctx.String("node"))
+						if err != nil {
+							return nil, err
+						}
+						node = &id
+					}
+					if coinbase == nil && node == nil {
+						return nil, fmt.Errorf("either --coinbase or --node must be set")
+					}
+					return inspector.Rewards(coinbase, node)
+				}),
+			},
+			{
+				Name:  "malfeasance",
+				Usage: "List malfeasance proofs of the identity or of all malicious identities",
+				Flags: []cli.Flag{&cli.StringFlag{Name: "node", Usage: "Hex encoded node id"}},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					if !ctx.IsSet("node") {
+						return inspector.Malfeasance()
+					}
+					id, err := parseNodeID(ctx.String("node"))
+					if err != nil {
+						return nil, err
+					}
+					return inspector.Malfeasance(id)
+				}),
+			},
+			{
+				Name:  "activeset",
+				Usage: "Print active set by id or all active sets used by ballots in the epoch",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "id", Usage: "Hex encoded active set hash"},
+					&cli.UintFlag{Name: "epoch"},
+				},
+				Action: run(func(ctx *cli.Context, inspector *internal.Inspector) (any, error) {
+					switch {
+					case ctx.IsSet("id"):
+						buf, err := hex.DecodeString(ctx.String("id"))
+						if err != nil {
+							return nil, fmt.Errorf("decode active set id: %w", err)
+						}
+						return inspector.ActiveSet(types.BytesToHash(buf))
+					case ctx.IsSet("epoch"):
+						return inspector.ActiveSetsInEpoch(types.EpochID(ctx.Uint("epoch")))
+					}
+					return nil, fmt.Errorf("either --id or --epoch must be set")
+				}),
+			},
+		},
+	}
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(query func(*cli.Context, *internal.Inspector) (any, error)) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		format, err := internal.ParseFormat(ctx.String("format"))
+		if err != nil {
+			return err
+		}
+		db, err := internal.Open(ctx.String("db"))
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		rst, err := query(ctx, internal.New(db))
+		if err != nil {
+			return err
+		}
+		return internal.Print(os.Stdout, format, rst)
+	}
+}
+
+func parseNodeID(s string) (types.NodeID, error) {
+	buf, err := hex.DecodeString(s)
+	if err != nil {
+		return types.EmptyNodeID, fmt.Errorf("decode node id: %w", err)
+	}
+	if len(buf) != len(types.EmptyNodeID) {
+		return types.EmptyNodeID, fmt.Errorf("node id must be %d bytes", len(types.EmptyNodeID))
+	}
+	return types.BytesToNodeID(buf), nil
+}
@@ -7,4 +7,7 @@ import (
 var (
 	ErrSupervisedNode = errors.New("merging of supervised smeshing nodes is not supported")
 	ErrInvalidSchema  = errors.New("database has an invalid schema version")
+	// ErrPassphraseRequired is returned when plain text identities are merged into a node
+	// with encrypted identities without the passphrase.
+	ErrPassphraseRequired = errors.New("target identities are encrypted, passphrase is required")
 )
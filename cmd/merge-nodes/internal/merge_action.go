@@ -24,7 +24,28 @@ const (
 	supervisedIDKeyFileName = "local.key"
 )
 
-func MergeDBs(ctx context.Context, dbLog *zap.Logger, from, to string) error {
+type mergeOptions struct {
+	passphrase []byte
+}
+
+// MergeOpt configures MergeDBs.
+type MergeOpt func(*mergeOptions)
+
+// WithPassphrase sets the passphrase of the encrypted identities of the target node.
+// Plain text identities of the source node are encrypted with it, encrypted identities
+// of both nodes must be decryptable with it.
+func WithPassphrase(passphrase []byte) MergeOpt {
+	return func(opts *mergeOptions) {
+		opts.passphrase = passphrase
+	}
+}
+
+func MergeDBs(ctx context.Context, dbLog *zap.Logger, from, to string, opts ...MergeOpt) error {
+	var options mergeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	// Open the target database
 	var dstDB *localsql.Database
 	var err error
@@ -108,6 +129,13 @@ func MergeDBs(ctx context.Context, dbLog *zap.Logger, from, to string) error {
 			}
 		}
 	}
+	encrypted, err := checkEncrypted(toKeyDir, toKeyDirFiles, options.passphrase)
+	if err != nil {
+		return err
+	}
+	if len(options.passphrase) > 0 {
+		encrypted = true
+	}
 
 	// copy files from `from` to `to`
 	err = filepath.WalkDir(fromKeyDir, func(path string, d fs.DirEntry, err error) error {
@@ -125,16 +153,12 @@ func MergeDBs(ctx context.Context, dbLog *zap.Logger, from, to string) error {
 			return nil
 		}
 
-		signer, err := signing.NewEdSigner(
-			signing.FromFile(path),
-		)
+		dst, err := readIdentity(path, encrypted, options.passphrase)
 		if err != nil {
 			return fmt.Errorf("not a valid key file %s: %w", d.Name(), err)
 		}
 
 		dstPath := filepath.Join(to, keyDir, d.Name())
-		dst := make([]byte, hex.EncodedLen(len(signer.PrivateKey())))
-		hex.Encode(dst, signer.PrivateKey())
 		err = os.WriteFile(dstPath, dst, 0o600)
 		if err != nil {
 			return fmt.Errorf("failed to write identity file: %w", err)
@@ -171,6 +195,16 @@ func MergeDBs(ctx context.Context, dbLog *zap.Logger, from, to string) error {
 		if _, err := tx.Exec("INSERT INTO main.nipost SELECT * FROM srcDB.nipost;", nil, nil); err != nil {
 			return fmt.Errorf("merge nipost: %w", err)
 		}
+		if _, err := tx.Exec(
+			"INSERT INTO main.signed_messages SELECT * FROM srcDB.signed_messages;", nil, nil,
+		); err != nil {
+			return fmt.Errorf("merge signed_messages: %w", err)
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO main.smeshing_identities SELECT * FROM srcDB.smeshing_identities;", nil, nil,
+		); err != nil {
+			return fmt.Errorf("merge smeshing_identities: %w", err)
+		}
 		return nil
 	})
 	if err != nil {
@@ -183,6 +217,66 @@ func MergeDBs(ctx context.Context, dbLog *zap.Logger, from, to string) error {
 	return nil
 }
 
+// checkEncrypted returns true if the target stores encrypted identities.
+// If passphrase is set it must decrypt them.
+func checkEncrypted(dir string, files []fs.DirEntry, passphrase []byte) (bool, error) {
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".key" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return false, fmt.Errorf("read target identity file: %w", err)
+		}
+		if !signing.IsEncryptedKey(data) {
+			continue
+		}
+		if len(passphrase) == 0 {
+			return true, nil
+		}
+		if _, err := signing.DecryptKey(data, passphrase); err != nil {
+			return false, fmt.Errorf("target identity file %s: %w", file.Name(), err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// readIdentity validates the identity file and returns the content to be written to the target.
+// Encrypted identity files are copied as is, so that the key is never written in plain text.
+// Plain text identity files are encrypted with the passphrase if the target stores encrypted identities.
+func readIdentity(path string, encrypted bool, passphrase []byte) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if signing.IsEncryptedKey(data) {
+		if len(passphrase) == 0 {
+			if _, err := signing.EncryptedKeyPublic(data); err != nil {
+				return nil, err
+			}
+		} else if _, err := signing.DecryptKey(data, passphrase); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	signer, err := signing.NewEdSigner(
+		signing.FromFile(path),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if encrypted {
+		if len(passphrase) == 0 {
+			return nil, ErrPassphraseRequired
+		}
+		return signing.EncryptKey(signer.PrivateKey(), passphrase)
+	}
+	dst := make([]byte, hex.EncodedLen(len(signer.PrivateKey())))
+	hex.Encode(dst, signer.PrivateKey())
+	return dst, nil
+}
+
 func openDB(dbLog *zap.Logger, path string) (*localsql.Database, error) {
 	dbPath := filepath.Join(path, localDbFile)
 	if _, err := os.Stat(dbPath); err != nil {
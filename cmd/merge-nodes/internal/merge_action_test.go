@@ -21,6 +21,8 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/localsql"
 	"github.com/spacemeshos/go-spacemesh/sql/localsql/nipost"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/signedmsgs"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/smeshing"
 )
 
 func Test_MergeDBs_InvalidTargetScheme(t *testing.T) {
@@ -401,6 +403,18 @@ func Test_MergeDBs_Successful_Empty_Dir(t *testing.T) {
 	err = nipost.AddPoetRegistration(srcDB, sig.NodeID(), sigPoet2)
 	require.NoError(t, err)
 
+	sigSigned := signedmsgs.Record{
+		Domain: signing.ATX,
+		ID:     sig.NodeID(),
+		Layer:  types.LayerID(rand.Uint32()),
+		Hash:   types.RandomHash(),
+	}
+	require.NoError(t, signedmsgs.Add(srcDB, sigSigned))
+
+	coinbase := types.GenerateAddress([]byte("coinbase"))
+	require.NoError(t, smeshing.SetCoinbase(srcDB, sig.NodeID(), coinbase))
+	require.NoError(t, smeshing.Pause(srcDB, sig.NodeID(), 7))
+
 	require.NoError(t, srcDB.Close())
 
 	err = MergeDBs(context.Background(), zaptest.NewLogger(t), tmpSrc, tmpDst)
@@ -425,5 +439,107 @@ func Test_MergeDBs_Successful_Empty_Dir(t *testing.T) {
 	require.Equal(t, poet[0], sigPoet1)
 	require.Equal(t, poet[1], sigPoet2)
 
+	signed, err := signedmsgs.ByIdentity(dstDB, sig.NodeID())
+	require.NoError(t, err)
+	require.Equal(t, []signedmsgs.Record{sigSigned}, signed)
+
+	settings, err := smeshing.Get(dstDB, sig.NodeID())
+	require.NoError(t, err)
+	require.Equal(t, &smeshing.Identity{ID: sig.NodeID(), Coinbase: coinbase, PauseEpoch: 7}, settings)
+
+	require.NoError(t, dstDB.Close())
+}
+
+func Test_MergeDBs_Successful_Encrypted_Key(t *testing.T) {
+	tmpDst := t.TempDir()
+
+	tmpSrc := t.TempDir()
+	err := os.MkdirAll(filepath.Join(tmpSrc, keyDir), 0o700)
+	require.NoError(t, err)
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	key, err := signing.EncryptKey(sig.PrivateKey(), []byte("passphrase"))
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpSrc, keyDir, "id.key"), key, 0o600)
+	require.NoError(t, err)
+
+	srcDB, err := localsql.Open("file:" + filepath.Join(tmpSrc, localDbFile))
+	require.NoError(t, err)
+	require.NoError(t, srcDB.Close())
+
+	err = MergeDBs(context.Background(), zaptest.NewLogger(t), tmpSrc, tmpDst)
+	require.NoError(t, err)
+
+	copied, err := os.ReadFile(filepath.Join(tmpDst, keyDir, "id.key"))
+	require.NoError(t, err)
+	require.Equal(t, key, copied)
+
+	loaded, err := signing.NewEdSigner(
+		signing.FromFile(filepath.Join(tmpDst, keyDir, "id.key")),
+		signing.WithPassphrase([]byte("passphrase")),
+	)
+	require.NoError(t, err)
+	require.Equal(t, sig.NodeID(), loaded.NodeID())
+}
+
+func Test_MergeDBs_Encrypts_Plain_Key(t *testing.T) {
+	passphrase := []byte("passphrase")
+
+	tmpDst := t.TempDir()
+	err := os.MkdirAll(filepath.Join(tmpDst, keyDir), 0o700)
+	require.NoError(t, err)
+	dstSig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	dstKey, err := signing.EncryptKey(dstSig.PrivateKey(), passphrase)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tmpDst, keyDir, "dst.key"), dstKey, 0o600)
+	require.NoError(t, err)
+	dstDB, err := localsql.Open("file:" + filepath.Join(tmpDst, localDbFile))
+	require.NoError(t, err)
 	require.NoError(t, dstDB.Close())
+
+	tmpSrc := t.TempDir()
+	err = os.MkdirAll(filepath.Join(tmpSrc, keyDir), 0o700)
+	require.NoError(t, err)
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	err = os.WriteFile(
+		filepath.Join(tmpSrc, keyDir, "id.key"),
+		[]byte(hex.EncodeToString(sig.PrivateKey())),
+		0o600,
+	)
+	require.NoError(t, err)
+	srcDB, err := localsql.Open("file:" + filepath.Join(tmpSrc, localDbFile))
+	require.NoError(t, err)
+	require.NoError(t, srcDB.Close())
+
+	t.Run("passphrase is required", func(t *testing.T) {
+		err := MergeDBs(context.Background(), zaptest.NewLogger(t), tmpSrc, tmpDst)
+		require.ErrorIs(t, err, ErrPassphraseRequired)
+		require.NoFileExists(t, filepath.Join(tmpDst, keyDir, "id.key"))
+	})
+	t.Run("wrong passphrase", func(t *testing.T) {
+		err := MergeDBs(context.Background(), zaptest.NewLogger(t), tmpSrc, tmpDst,
+			WithPassphrase([]byte("wrong")),
+		)
+		require.ErrorIs(t, err, signing.ErrInvalidPassphrase)
+		require.NoFileExists(t, filepath.Join(tmpDst, keyDir, "id.key"))
+	})
+	t.Run("encrypted", func(t *testing.T) {
+		err := MergeDBs(context.Background(), zaptest.NewLogger(t), tmpSrc, tmpDst, WithPassphrase(passphrase))
+		require.NoError(t, err)
+
+		copied, err := os.ReadFile(filepath.Join(tmpDst, keyDir, "id.key"))
+		require.NoError(t, err)
+		require.True(t, signing.IsEncryptedKey(copied))
+		require.NotContains(t, string(copied), hex.EncodeToString(sig.PrivateKey()))
+
+		loaded, err := signing.NewEdSigner(
+			signing.FromFile(filepath.Join(tmpDst, keyDir, "id.key")),
+			signing.WithPassphrase(passphrase),
+		)
+		require.NoError(t, err)
+		require.Equal(t, sig.NodeID(), loaded.NodeID())
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/spacemeshos/go-spacemesh/cmd/merge-nodes/internal"
+	"github.com/spacemeshos/go-spacemesh/signing"
 )
 
 var version string
@@ -44,9 +45,20 @@ func main() {
 				Usage:    "The `data` folder to write the merged node to. Can be an existing remote node or empty.",
 				Required: true,
 			},
+			&cli.StringFlag{
+				Name: "passphrase-file",
+				Usage: "The `file` with the passphrase of encrypted identities of the `to` node, " +
+					"plain text identities are encrypted with it. Defaults to " + signing.PassphraseEnv + " env variable",
+			},
 		},
 		Action: func(ctx *cli.Context) error {
-			return internal.MergeDBs(ctx.Context, dbLog, ctx.String("from"), ctx.String("to"))
+			passphrase, err := signing.LoadPassphrase(ctx.String("passphrase-file"))
+			if err != nil {
+				return err
+			}
+			return internal.MergeDBs(ctx.Context, dbLog, ctx.String("from"), ctx.String("to"),
+				internal.WithPassphrase(passphrase),
+			)
 		},
 	}
 
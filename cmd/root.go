@@ -9,6 +9,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/config"
 	"github.com/spacemeshos/go-spacemesh/config/presets"
 	"github.com/spacemeshos/go-spacemesh/node/flags"
+	"github.com/spacemeshos/go-spacemesh/signing"
 )
 
 func AddFlags(flagSet *pflag.FlagSet, cfg *config.Config) (configPath *string) {
@@ -32,6 +33,12 @@ func AddFlags(flagSet *pflag.FlagSet, cfg *config.Config) (configPath *string) {
 		cfg.BaseConfig.DataDirParent, "Specify data directory for spacemesh")
 	flagSet.StringVar(&cfg.BaseConfig.FileLock,
 		"filelock", cfg.BaseConfig.FileLock, "Filesystem lock to prevent running more than one instance.")
+	flagSet.StringVar(&cfg.BaseConfig.IdentityPassphraseFile, "identity-passphrase-file",
+		cfg.BaseConfig.IdentityPassphraseFile,
+		"File with the passphrase of encrypted identity files, overrides "+signing.PassphraseEnv+" environment variable")
+	flagSet.DurationVar(&cfg.BaseConfig.IdentitiesWatchInterval, "identities-watch-interval",
+		cfg.BaseConfig.IdentitiesWatchInterval,
+		"How often the identities directory is checked for added or removed identity files, 0 disables watching")
 	flagSet.StringVar(&cfg.LOGGING.Encoder, "log-encoder",
 		cfg.LOGGING.Encoder, "Log as JSON instead of plain text")
 	flagSet.BoolVar(&cfg.CollectMetrics, "metrics",
@@ -45,7 +52,7 @@ func AddFlags(flagSet *pflag.FlagSet, cfg *config.Config) (configPath *string) {
 	flagSet.Var(
 		&flags.JSONFlag{Value: &cfg.PoetServers},
 		"poet-servers",
-		"JSON-encoded list of poet servers (address and pubkey)",
+		"JSON-encoded list of poet servers (address and pubkey), use grpc:// or grpcs:// address for the grpc api",
 	)
 	flagSet.StringVar(&cfg.Genesis.GenesisTime, "genesis-time",
 		cfg.Genesis.GenesisTime, "Time of the genesis layer in 2019-13-02T17:02:00+00:00 format")
@@ -69,6 +76,8 @@ func AddFlags(flagSet *pflag.FlagSet, cfg *config.Config) (configPath *string) {
 		cfg.TxsPerProposal, "the number of transactions to select per proposal")
 	flagSet.Uint64Var(&cfg.BlockGasLimit, "block-gas-limit",
 		cfg.BlockGasLimit, "max gas allowed per block")
+	flagSet.Uint64Var(&cfg.MinGas, "min-gas",
+		cfg.MinGas, "min gas price for transactions to be accepted into the mempool")
 	flagSet.IntVar(&cfg.OptFilterThreshold, "optimistic-filtering-threshold",
 		cfg.OptFilterThreshold, "threshold for optimistic filtering in percentage")
 
@@ -321,6 +330,8 @@ func AddFlags(flagSet *pflag.FlagSet, cfg *config.Config) (configPath *string) {
 		cfg.POET.GracePeriod, "time before PoET round starts when the node builds and submits a challenge")
 	flagSet.DurationVar(&cfg.POET.RequestTimeout, "poet-request-timeout",
 		cfg.POET.RequestTimeout, "timeout for poet requests")
+	flagSet.IntVar(&cfg.POET.MinSubmissions, "poet-min-submissions",
+		cfg.POET.MinSubmissions, "number of poet services to submit the challenge to, all if zero")
 
 	/**======================== bootstrap data updater Flags ========================== **/
 
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/tortoise/scenario"
+)
+
+var level = zap.LevelFlag("level", zapcore.ErrorLevel, "set verbosity level for execution")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] <scenario.json|directory>...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	logger := log.NewWithLevel("scenario", zap.NewAtomicLevelAt(*level))
+	paths, err := collect(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	failed := 0
+	for _, path := range paths {
+		sc, err := scenario.Load(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		report, err := scenario.Run(context.Background(), logger, sc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", path, err)
+			os.Exit(1)
+		}
+		if !report.Failed() {
+			fmt.Printf("ok   %s: %d checks, last layer %d\n", report.Name, report.Checks, report.Last)
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL %s: %d of %d checks diverged, effective genesis %d\n",
+			report.Name, len(report.Divergences), report.Checks, report.Genesis)
+		for _, divergence := range report.Divergences {
+			fmt.Printf("     %s\n", divergence)
+		}
+	}
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// collect expands directories into json files in them.
+func collect(args []string) ([]string, error) {
+	var paths []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			paths = append(paths, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				paths = append(paths, filepath.Join(arg, entry.Name()))
+			}
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/equivocation"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+const localDbFile = "local.sql"
+
+var version string
+
+func main() {
+	cfg := zap.NewProductionConfig()
+	cfg.Encoding = "console"
+	logger, err := cfg.Build()
+	if err != nil {
+		fmt.Println("create logger:", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	app := &cli.App{
+		Name: "Spacemesh Signed Messages",
+		Usage: "Export and import records of messages signed by the identities of a Spacemesh node.\n" +
+			"Records protect the identity from signing conflicting messages, move them together with the\n" +
+			"identity file when the identity is moved to another node.\n" +
+			"NOTE: the node must be stopped before running this command.",
+		Version: version,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "data",
+				Aliases:  []string{"d"},
+				Usage:    "The `data` folder of the node",
+				Required: true,
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "export",
+				Usage: "Export records of the identities",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "out",
+						Aliases:  []string{"o"},
+						Usage:    "The `file` to write records to",
+						Required: true,
+					},
+					&cli.StringSliceFlag{
+						Name:  "id",
+						Usage: "Hex encoded node id of the exported identity, every identity is exported if not set",
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					var ids []types.NodeID
+					for _, value := range ctx.StringSlice("id") {
+						id, err := parseNodeID(value)
+						if err != nil {
+							return err
+						}
+						ids = append(ids, id)
+					}
+					db, err := openDB(logger, ctx.String("data"))
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+					f, err := os.Create(ctx.String("out"))
+					if err != nil {
+						return fmt.Errorf("create %s: %w", ctx.String("out"), err)
+					}
+					if err := equivocation.Export(db, f, ids...); err != nil {
+						f.Close()
+						return err
+					}
+					return f.Close()
+				},
+			},
+			{
+				Name:  "import",
+				Usage: "Import records exported from another node",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "in",
+						Aliases:  []string{"i"},
+						Usage:    "The `file` to read records from",
+						Required: true,
+					},
+				},
+				Action: func(ctx *cli.Context) error {
+					db, err := openDB(logger, ctx.String("data"))
+					if err != nil {
+						return err
+					}
+					defer db.Close()
+					f, err := os.Open(ctx.String("in"))
+					if err != nil {
+						return fmt.Errorf("open %s: %w", ctx.String("in"), err)
+					}
+					defer f.Close()
+					n, err := equivocation.Import(ctx.Context, logger, db, f)
+					if err != nil {
+						return err
+					}
+					logger.Info("import finished", zap.Int("imported", n))
+					return nil
+				},
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		logger.Sugar().Warnln("app run:", err)
+		os.Exit(1)
+	}
+}
+
+func openDB(logger *zap.Logger, dir string) (*localsql.Database, error) {
+	path := filepath.Join(dir, localDbFile)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("open database %s: %w", path, err)
+	}
+	db, err := localsql.Open("file:"+path, sql.WithLogger(logger))
+	if err != nil {
+		return nil, fmt.Errorf("open database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+func parseNodeID(s string) (types.NodeID, error) {
+	buf, err := hex.DecodeString(s)
+	if err != nil {
+		return types.EmptyNodeID, fmt.Errorf("decode node id: %w", err)
+	}
+	if len(buf) != len(types.EmptyNodeID) {
+		return types.EmptyNodeID, fmt.Errorf("node id must be %d bytes", len(types.EmptyNodeID))
+	}
+	return types.BytesToNodeID(buf), nil
+}
@@ -0,0 +1,243 @@
+// Package internal implements interactive debugger for the tortoise traces.
+package internal
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+)
+
+const help = `commands:
+  next [n]             execute next n events (default 1)
+  until layer <lid>    execute events until event that refers to the layer
+  until event <name>   execute events until event of the type, one of: %s
+  until ballot <hex>   execute events until event that refers to the ballot with id prefix
+  run                  execute all remaining events
+  state                print mode, last, processed, verified and evicted layers
+  layer <lid>          print the layer with block validity and margins
+  ballot <hex>         print the ballot weight and flags
+  tally <hex>          recount votes for the block
+  help                 print this message
+  quit                 exit
+`
+
+// Repl reads commands from in and executes them on the debugger until quit command or end of input.
+type Repl struct {
+	in  *bufio.Scanner
+	out io.Writer
+	d   *tortoise.Debugger
+	eof bool
+}
+
+// NewRepl creates Repl.
+func NewRepl(in io.Reader, out io.Writer, d *tortoise.Debugger) *Repl {
+	return &Repl{in: bufio.NewScanner(in), out: out, d: d}
+}
+
+// Run executes commands until quit command or end of input.
+func (r *Repl) Run() error {
+	for {
+		fmt.Fprint(r.out, "(trace) ")
+		if !r.in.Scan() {
+			fmt.Fprintln(r.out)
+			return r.in.Err()
+		}
+		fields := strings.Fields(r.in.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "quit" || fields[0] == "exit" || fields[0] == "q" {
+			return nil
+		}
+		if err := r.execute(fields[0], fields[1:]); err != nil {
+			fmt.Fprintf(r.out, "error: %v\n", err)
+		}
+	}
+}
+
+func (r *Repl) execute(cmd string, args []string) error {
+	switch cmd {
+	case "next", "n":
+		n := 1
+		if len(args) > 0 {
+			parsed, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("parse number of events: %w", err)
+			}
+			n = parsed
+		}
+		for range n {
+			ev, err := r.next()
+			if err != nil {
+				return err
+			}
+			r.printEvent(ev)
+		}
+	case "until", "u":
+		if len(args) != 2 {
+			return errors.New("expected: until layer|event|ballot <value>")
+		}
+		match, err := matcher(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		for {
+			ev, err := r.next()
+			if err != nil {
+				return err
+			}
+			if match(ev) {
+				r.printEvent(ev)
+				return nil
+			}
+		}
+	case "run", "c":
+		for {
+			if _, err := r.next(); err != nil {
+				return err
+			}
+		}
+	case "state", "s":
+		state, err := r.d.State()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out, "mode: %s\nlast: %d\nprocessed: %d\nverified: %d\nevicted: %d\n",
+			state.Mode, state.Last, state.Processed, state.Verified, state.Evicted)
+	case "layer", "l":
+		if len(args) != 1 {
+			return errors.New("expected: layer <lid>")
+		}
+		lid, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return fmt.Errorf("parse layer: %w", err)
+		}
+		layer, err := r.d.Layer(types.LayerID(lid))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out,
+			"layer: %d\nhare terminated: %t\ncoinflip: %s\nopinion: %s\nempty: %f\n"+
+				"good uncounted: %f\nreference height: %d\n",
+			layer.Layer, layer.HareTerminated, layer.Coinflip, layer.Opinion.ShortString(),
+			layer.Empty, layer.GoodUncounted, layer.ReferenceHeight)
+		for _, block := range layer.Blocks {
+			r.printBlock(block)
+		}
+	case "ballot", "b":
+		if len(args) != 1 {
+			return errors.New("expected: ballot <hex>")
+		}
+		var id types.BallotID
+		if err := decodeID(args[0], id[:]); err != nil {
+			return err
+		}
+		ballot, err := r.d.Ballot(id)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(r.out,
+			"ballot: %s\nlayer: %d\nbase: %s\nsmesher: %s\natx: %s\nweight: %f\nheight: %d\n"+
+				"malicious: %t\nbad beacon: %t\nopinion: %s\n",
+			hex.EncodeToString(ballot.ID[:]), ballot.Layer, hex.EncodeToString(ballot.Base[:]),
+			ballot.Smesher.ShortString(), ballot.ATX.ShortString(), ballot.Weight, ballot.Height,
+			ballot.Malicious, ballot.BadBeacon, ballot.Opinion.ShortString())
+	case "tally", "t":
+		if len(args) != 1 {
+			return errors.New("expected: tally <hex>")
+		}
+		var id types.BlockID
+		if err := decodeID(args[0], id[:]); err != nil {
+			return err
+		}
+		tally, err := r.d.Tally(id)
+		if err != nil {
+			return err
+		}
+		r.printBlock(tally.Block)
+		fmt.Fprintf(r.out,
+			"ballots: %d\nsupport: %f\nagainst: %f\nabstain: %f\nlocal threshold: %f\nglobal threshold: %f\n",
+			tally.Ballots, tally.Support, tally.Against, tally.Abstain, tally.LocalThreshold, tally.GlobalThreshold)
+	case "help", "h":
+		fmt.Fprintf(r.out, help, strings.Join(tortoise.TraceEventNames(), ", "))
+	default:
+		return fmt.Errorf("unknown command %q, see help", cmd)
+	}
+	return nil
+}
+
+func (r *Repl) next() (*tortoise.TraceEvent, error) {
+	if r.eof {
+		return nil, errors.New("trace finished")
+	}
+	ev, err := r.d.Next()
+	if errors.Is(err, io.EOF) {
+		r.eof = true
+		return nil, errors.New("trace finished")
+	}
+	return ev, err
+}
+
+func (r *Repl) printEvent(ev *tortoise.TraceEvent) {
+	fmt.Fprintf(r.out, "#%d %s", ev.Index, ev.Name)
+	if ev.Layer != 0 {
+		fmt.Fprintf(r.out, " layer=%d", ev.Layer)
+	}
+	if ev.Ballot != types.EmptyBallotID {
+		fmt.Fprintf(r.out, " ballot=%s", hex.EncodeToString(ev.Ballot[:]))
+	}
+	fmt.Fprintln(r.out)
+}
+
+func (r *Repl) printBlock(block tortoise.DebugBlock) {
+	fmt.Fprintf(r.out, "block: %s height=%d hare=%s validity=%s margin=%f data=%t\n",
+		hex.EncodeToString(block.ID[:]), block.Height, block.Hare, block.Validity, block.Margin, block.Data)
+}
+
+func matcher(kind, value string) (func(*tortoise.TraceEvent) bool, error) {
+	switch kind {
+	case "layer":
+		lid, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("parse layer: %w", err)
+		}
+		return func(ev *tortoise.TraceEvent) bool {
+			return ev.Layer == types.LayerID(lid)
+		}, nil
+	case "event":
+		for _, name := range tortoise.TraceEventNames() {
+			if name == value {
+				return func(ev *tortoise.TraceEvent) bool {
+					return ev.Name == value
+				}, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown event %q", value)
+	case "ballot":
+		prefix := strings.ToLower(value)
+		return func(ev *tortoise.TraceEvent) bool {
+			return ev.Ballot != types.EmptyBallotID && strings.HasPrefix(hex.EncodeToString(ev.Ballot[:]), prefix)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown condition %q, expected layer, event or ballot", kind)
+	}
+}
+
+func decodeID(value string, dst []byte) error {
+	buf, err := hex.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("decode id: %w", err)
+	}
+	if len(buf) != len(dst) {
+		return fmt.Errorf("expected id of %d bytes, got %d", len(dst), len(buf))
+	}
+	copy(dst, buf)
+	return nil
+}
@@ -0,0 +1,135 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/atxsdata"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestMain(m *testing.M) {
+	types.SetLayersPerEpoch(4)
+	os.Exit(m.Run())
+}
+
+func TestRepl(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tortoise.trace")
+	trt, err := tortoise.New(atxsdata.New(), tortoise.WithTracer(tortoise.WithOutput(path)))
+	require.NoError(t, err)
+	lid := types.GetEffectiveGenesis().Add(1)
+	block := types.BlockHeader{ID: types.BlockID{1, 2, 3}, LayerID: lid}
+	trt.OnBlock(block)
+	trt.OnHareOutput(lid, block.ID)
+	trt.TallyVotes(context.Background(), lid)
+
+	d, err := tortoise.NewDebugger(path, tortoise.WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, d.Close()) })
+
+	blockID := hex.EncodeToString(block.ID[:])
+	in := strings.Join([]string{
+		"state",
+		"next",
+		"until event hare",
+		"next",
+		"state",
+		"layer " + lid.String(),
+		"tally " + blockID,
+		"until layer 1000",
+		"next",
+		"unknown",
+		"help",
+		"quit",
+		"state",
+	}, "\n")
+	var out bytes.Buffer
+	require.NoError(t, NewRepl(strings.NewReader(in), &out, d).Run())
+
+	output := out.String()
+	require.Contains(t, output, "error: tortoise is not started yet")
+	require.Contains(t, output, "#0 config\n")
+	require.Contains(t, output, "#2 hare layer="+lid.String()+"\n")
+	require.Contains(t, output, "#3 tally layer="+lid.String()+"\n")
+	require.Contains(t, output, "mode: verifying\nlast: "+lid.String()+"\n")
+	require.Contains(t, output, "hare terminated: true\n")
+	require.Contains(t, output, "block: "+blockID+" height=0 hare=support")
+	require.Contains(t, output, "ballots: 0\n")
+	require.Contains(t, output, "error: trace finished\n")
+	require.Contains(t, output, `error: unknown command "unknown", see help`)
+	require.Contains(t, output, "until event <name>")
+	require.Equal(t, 1, strings.Count(output, "mode:"), "commands after quit must not be executed")
+}
+
+func TestRepl_Tally(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tortoise.trace")
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+	var last types.LayerID
+	for range 10 {
+		last = s.Next()
+	}
+	state := s.GetState(0)
+	cfg := tortoise.DefaultConfig()
+	cfg.LayerSize = size
+	_, err := tortoise.Recover(context.Background(), state.DB.Executor, state.Atxdata, last,
+		tortoise.WithConfig(cfg),
+		tortoise.WithLogger(logtest.New(t)),
+		tortoise.WithTracer(tortoise.WithOutput(path)),
+	)
+	require.NoError(t, err)
+
+	// replay the trace to find a block supported by hare and the expected tally of its votes
+	expected, err := tortoise.NewDebugger(path, tortoise.WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, expected.Close()) })
+	for {
+		if _, err := expected.Next(); err != nil {
+			require.ErrorIs(t, err, io.EOF)
+			break
+		}
+	}
+	st, err := expected.State()
+	require.NoError(t, err)
+	layer, err := expected.Layer(st.Verified)
+	require.NoError(t, err)
+	var block types.BlockID
+	for _, candidate := range layer.Blocks {
+		if candidate.Hare == "support" {
+			block = candidate.ID
+		}
+	}
+	require.NotEqual(t, types.EmptyBlockID, block)
+	tally, err := expected.Tally(block)
+	require.NoError(t, err)
+	require.Positive(t, tally.Ballots)
+	require.Greater(t, tally.Support, tally.GlobalThreshold)
+
+	d, err := tortoise.NewDebugger(path, tortoise.WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, d.Close()) })
+	in := strings.Join([]string{
+		"until layer 1000",
+		"tally " + hex.EncodeToString(block[:]),
+	}, "\n")
+	var out bytes.Buffer
+	require.NoError(t, NewRepl(strings.NewReader(in), &out, d).Run())
+
+	output := out.String()
+	require.Contains(t, output, fmt.Sprintf("ballots: %d\nsupport: %f\nagainst: %f\n",
+		tally.Ballots, tally.Support, tally.Against))
+	require.NotContains(t, output, "ballots: 0\n")
+}
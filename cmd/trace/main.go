@@ -1,19 +1,32 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"os"
 	"runtime"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
+	"github.com/spacemeshos/go-spacemesh/cmd/trace/internal"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/config/presets"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 var (
 	level  = zap.LevelFlag("level", zapcore.ErrorLevel, "set verbosity level for execution")
 	bpoint = flag.Bool("breakpoint", false, "enable breakpoint after every step")
+	repl   = flag.Bool("repl", false, "step through the trace and inspect tortoise state interactively")
+	record = flag.String("record", "", "record the trace from the state.sql instead of executing it")
+	preset = flag.String("preset", "", "config preset of the network that produced state.sql, mainnet by default")
+	from   = flag.Uint("from", 0, "first layer to record, after effective genesis or first in epoch")
+	to     = flag.Uint("to", 0, "last layer to record")
 )
 
 func main() {
@@ -21,6 +34,23 @@ func main() {
 	atom := zap.NewAtomicLevelAt(*level)
 	logger := log.NewWithLevel("trace", atom)
 	logger.With().Debug("using trace", log.String("path", flag.Arg(0)))
+	if len(*record) > 0 {
+		if err := recordTrace(logger, flag.Arg(0)); err != nil {
+			logger.With().Fatal("record trace failed", log.Err(err))
+		}
+		return
+	}
+	if *repl {
+		d, err := tortoise.NewDebugger(flag.Arg(0), tortoise.WithLogger(logger))
+		if err != nil {
+			logger.With().Fatal("open trace failed", log.Err(err))
+		}
+		defer d.Close()
+		if err := internal.NewRepl(os.Stdin, os.Stdout, d).Run(); err != nil {
+			logger.With().Fatal("repl failed", log.Err(err))
+		}
+		return
+	}
 	var breakpoint func()
 	if *bpoint {
 		breakpoint = runtime.Breakpoint
@@ -29,3 +59,33 @@ func main() {
 		logger.With().Fatal("run trace failed", log.Err(err))
 	}
 }
+
+func recordTrace(logger log.Log, path string) error {
+	cfg := config.MainnetConfig()
+	if len(*preset) > 0 {
+		var err error
+		cfg, err = presets.Get(*preset)
+		if err != nil {
+			return err
+		}
+	}
+	types.SetLayersPerEpoch(cfg.LayersPerEpoch)
+	db, err := sql.Open(fmt.Sprintf("file:%s?mode=ro", *record))
+	if err != nil {
+		return fmt.Errorf("open %s: %w", *record, err)
+	}
+	defer db.Close()
+	expected, err := tortoise.RecordTrace(
+		context.Background(),
+		db,
+		path,
+		types.LayerID(*from),
+		types.LayerID(*to),
+		tortoise.WithLogger(logger),
+		tortoise.WithConfig(cfg.Tortoise),
+	)
+	if err != nil {
+		return err
+	}
+	return tortoise.WriteExpectation(tortoise.ExpectationPath(path), expected)
+}
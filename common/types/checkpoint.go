@@ -4,6 +4,15 @@ type Checkpoint struct {
 	Command string    `json:"command"`
 	Version string    `json:"version"`
 	Data    InnerData `json:"data"`
+	// Hash commits to atxs and accounts in Data, it is empty in checkpoints generated by older versions.
+	Hash      []byte               `json:"hash,omitempty"`
+	Signature *CheckpointSignature `json:"signature,omitempty"`
+}
+
+// CheckpointSignature is a signature of the node that generated the checkpoint over Checkpoint.Hash.
+type CheckpointSignature struct {
+	PublicKey []byte `json:"publicKey"`
+	Signature []byte `json:"signature"`
 }
 
 type InnerData struct {
@@ -24,6 +24,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/hare3/eligibility"
 	"github.com/spacemeshos/go-spacemesh/miner"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/prune"
 	"github.com/spacemeshos/go-spacemesh/syncer"
 	timeConfig "github.com/spacemeshos/go-spacemesh/timesync/config"
 	"github.com/spacemeshos/go-spacemesh/tortoise"
@@ -87,6 +88,14 @@ type BaseConfig struct {
 	DataDirParent string `mapstructure:"data-folder"`
 	FileLock      string `mapstructure:"filelock"`
 
+	// IdentityPassphraseFile is the path to the file with the passphrase of encrypted identity files.
+	// If not set the passphrase is read from SPACEMESH_IDENTITY_PASSPHRASE environment variable.
+	// When the passphrase is configured new identities are saved encrypted.
+	IdentityPassphraseFile string `mapstructure:"identity-passphrase-file"`
+	// IdentitiesWatchInterval is how often the identities directory is checked for added or removed
+	// identity files. Zero disables watching.
+	IdentitiesWatchInterval time.Duration `mapstructure:"identities-watch-interval"`
+
 	TestConfig TestConfig `mapstructure:"testing"`
 	Standalone bool       `mapstructure:"standalone"`
 
@@ -108,6 +117,9 @@ type BaseConfig struct {
 
 	TxsPerProposal int    `mapstructure:"txs-per-proposal"`
 	BlockGasLimit  uint64 `mapstructure:"block-gas-limit"`
+	// MinGas is the minimal gas price for transactions to be accepted into the mempool.
+	// It can be changed at runtime using the SmesherService API.
+	MinGas uint64 `mapstructure:"min-gas"`
 	// if the number of proposals with the same mesh state crosses this threshold (in percentage),
 	// then we optimistically filter out infeasible transactions before constructing the block.
 	OptFilterThreshold int    `mapstructure:"optimistic-filtering-threshold"`
@@ -123,6 +135,8 @@ type BaseConfig struct {
 	DatabaseQueryCacheSizes      DatabaseQueryCacheSizes `mapstructure:"db-query-cache-sizes"`
 
 	PruneActivesetsFrom types.EpochID `mapstructure:"prune-activesets-from"`
+	// DatabaseRetention configures how long data is kept in the database, by default everything is kept.
+	DatabaseRetention prune.Retention `mapstructure:"db-retention"`
 
 	NetworkHRP string `mapstructure:"network-hrp"`
 
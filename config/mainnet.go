@@ -160,6 +160,8 @@ func MainnetConfig() Config {
 			RequestTimeout:    1100 * time.Second, // RequestRetryDelay * 2 * MaxRequestRetries*(MaxRequestRetries+1)/2
 			RequestRetryDelay: 10 * time.Second,
 			MaxRequestRetries: 10,
+			FailureBackoff:    time.Hour,
+			MaxFailureBackoff: 7 * 24 * time.Hour,
 		},
 		POST: activation.PostConfig{
 			MinNumUnits:   4,
@@ -115,6 +115,8 @@ func testnet() config.Config {
 			RequestTimeout:    550 * time.Second, // RequestRetryDelay * 2 * MaxRequestRetries*(MaxRequestRetries+1)/2
 			RequestRetryDelay: 5 * time.Second,
 			MaxRequestRetries: 10,
+			FailureBackoff:    time.Hour,
+			MaxFailureBackoff: 7 * 24 * time.Hour,
 		},
 		POST: activation.PostConfig{
 			MinNumUnits:   2,
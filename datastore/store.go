@@ -266,7 +266,7 @@ func (db *CachedDB) IterateEpochATXHeaders(
 func (db *CachedDB) IterateMalfeasanceProofs(
 	iter func(types.NodeID, *wire.MalfeasanceProof) error,
 ) error {
-	ids, err := identities.GetMalicious(db)
+	ids, err := identities.GetMalicious(context.Background(), db)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,208 @@
+// Package equivocation protects local identities from signing conflicting messages, for example after
+// the node is restored from a backup or when the same key is used by two nodes.
+//
+// Hash of every message signed in the consensus paths is recorded in the local database together with its
+// slot. Message that conflicts with the recorded one is never signed, so that the identity can't be proven
+// malicious. Records can be exported and imported to move an identity between nodes.
+package equivocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/hash"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/signedmsgs"
+)
+
+// InterchangeVersion is the version of the export format.
+const InterchangeVersion = 1
+
+// Interchange is the format in which records are exported and imported.
+type Interchange struct {
+	Version int      `json:"version"`
+	Records []Record `json:"records"`
+}
+
+// Record is a hash of the message signed by the identity for the slot.
+type Record struct {
+	Domain signing.Domain `json:"domain"`
+	NodeID types.NodeID   `json:"node_id"`
+	Layer  types.LayerID  `json:"layer"`
+	Round  uint32         `json:"round"`
+	Hash   types.Hash32   `json:"hash"`
+}
+
+type Opt func(*Guard)
+
+func WithLogger(logger *zap.Logger) Opt {
+	return func(g *Guard) {
+		g.logger = logger
+	}
+}
+
+// New creates a guard that records signed messages in the local database.
+func New(db *localsql.Database, opts ...Opt) *Guard {
+	g := &Guard{
+		logger: zap.NewNop(),
+		db:     db,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Guard implements signing.Guard.
+type Guard struct {
+	logger *zap.Logger
+	db     *localsql.Database
+
+	// mu serializes checks, so that the lookup and insert of a record are atomic
+	mu sync.Mutex
+}
+
+// Check records the hash of the message for the slot. It returns signing.ErrEquivocation if
+// a message with a different hash was already recorded for the slot.
+func (g *Guard) Check(d signing.Domain, id types.NodeID, layer types.LayerID, round uint32, msg []byte) error {
+	rec := signedmsgs.Record{
+		Domain: d,
+		ID:     id,
+		Layer:  layer,
+		Round:  round,
+		Hash:   hash.Sum(msg),
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	existing, err := signedmsgs.Get(g.db, d, id, layer, round)
+	switch {
+	case errors.Is(err, sql.ErrNotFound):
+		return signedmsgs.Add(g.db, rec)
+	case err != nil:
+		return err
+	case existing != rec.Hash:
+		refused.WithLabelValues(d.String()).Inc()
+		g.logger.Error("refused to sign conflicting message",
+			zap.Stringer("domain", d),
+			log.ZShortStringer("smesherID", id),
+			zap.Uint32("layer", layer.Uint32()),
+			zap.Uint32("round", round),
+			log.ZShortStringer("signed", existing),
+			log.ZShortStringer("conflicting", rec.Hash),
+		)
+		return fmt.Errorf("%w: %s by %s in layer %d round %d",
+			signing.ErrEquivocation, d, id.ShortString(), layer, round)
+	}
+	return nil
+}
+
+// Export writes records of the identities to w. If no identities are given records of every
+// identity are written.
+func (g *Guard) Export(w io.Writer, ids ...types.NodeID) error {
+	return Export(g.db, w, ids...)
+}
+
+// Import reads records from r and adds them to the records of the node.
+func (g *Guard) Import(ctx context.Context, r io.Reader) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return Import(ctx, g.logger, g.db, r)
+}
+
+// Export writes records of the identities from the database to w. If no identities are given records of
+// every identity are written.
+func Export(db sql.Executor, w io.Writer, ids ...types.NodeID) error {
+	var records []signedmsgs.Record
+	if len(ids) == 0 {
+		all, err := signedmsgs.All(db)
+		if err != nil {
+			return err
+		}
+		records = all
+	}
+	for _, id := range ids {
+		byID, err := signedmsgs.ByIdentity(db, id)
+		if err != nil {
+			return err
+		}
+		records = append(records, byID...)
+	}
+	interchange := Interchange{
+		Version: InterchangeVersion,
+		Records: make([]Record, 0, len(records)),
+	}
+	for _, rec := range records {
+		interchange.Records = append(interchange.Records, Record{
+			Domain: rec.Domain,
+			NodeID: rec.ID,
+			Layer:  rec.Layer,
+			Round:  rec.Round,
+			Hash:   rec.Hash,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(&interchange); err != nil {
+		return fmt.Errorf("encode records: %w", err)
+	}
+	return nil
+}
+
+// Import reads records from r and adds them to the database. Records that conflict with the records
+// in the database are skipped, recorded message is still protected as nothing else can be signed
+// for the slot. It returns the number of added records.
+func Import(ctx context.Context, logger *zap.Logger, db *localsql.Database, r io.Reader) (int, error) {
+	var interchange Interchange
+	if err := json.NewDecoder(r).Decode(&interchange); err != nil {
+		return 0, fmt.Errorf("decode records: %w", err)
+	}
+	if interchange.Version != InterchangeVersion {
+		return 0, fmt.Errorf("unsupported records version %d", interchange.Version)
+	}
+	added := 0
+	err := db.WithTx(ctx, func(tx *sql.Tx) error {
+		for _, rec := range interchange.Records {
+			err := signedmsgs.Add(tx, signedmsgs.Record{
+				Domain: rec.Domain,
+				ID:     rec.NodeID,
+				Layer:  rec.Layer,
+				Round:  rec.Round,
+				Hash:   rec.Hash,
+			})
+			switch {
+			case errors.Is(err, sql.ErrObjectExists):
+				existing, err := signedmsgs.Get(tx, rec.Domain, rec.NodeID, rec.Layer, rec.Round)
+				if err != nil {
+					return err
+				}
+				if existing != rec.Hash {
+					logger.Warn("skipped conflicting record",
+						zap.Stringer("domain", rec.Domain),
+						log.ZShortStringer("smesherID", rec.NodeID),
+						zap.Uint32("layer", rec.Layer.Uint32()),
+						zap.Uint32("round", rec.Round),
+					)
+				}
+			case err != nil:
+				return err
+			default:
+				added++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("import records: %w", err)
+	}
+	return added, nil
+}
@@ -0,0 +1,80 @@
+package equivocation
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func TestGuard(t *testing.T) {
+	guard := New(localsql.InMemory(), WithLogger(zaptest.NewLogger(t)))
+	signer, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	signer.SetGuard(guard)
+
+	first, err := signer.SignOnce(signing.HARE, 10, 1, []byte("first"))
+	require.NoError(t, err)
+	require.Equal(t, signer.Sign(signing.HARE, []byte("first")), first)
+
+	again, err := signer.SignOnce(signing.HARE, 10, 1, []byte("first"))
+	require.NoError(t, err)
+	require.Equal(t, first, again)
+
+	_, err = signer.SignOnce(signing.HARE, 10, 1, []byte("second"))
+	require.ErrorIs(t, err, signing.ErrEquivocation)
+
+	_, err = signer.SignOnce(signing.HARE, 10, 2, []byte("second"))
+	require.NoError(t, err)
+	_, err = signer.SignOnce(signing.BALLOT, 10, 1, []byte("second"))
+	require.NoError(t, err)
+	_, err = signer.SignOnce(signing.HARE, 11, 1, []byte("second"))
+	require.NoError(t, err)
+
+	other, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	other.SetGuard(guard)
+	_, err = other.SignOnce(signing.HARE, 10, 1, []byte("second"))
+	require.NoError(t, err)
+}
+
+func TestExportImport(t *testing.T) {
+	src := New(localsql.InMemory())
+	ids := []types.NodeID{types.RandomNodeID(), types.RandomNodeID()}
+	for _, id := range ids {
+		require.NoError(t, src.Check(signing.ATX, id, 20, 0, []byte("atx")))
+		require.NoError(t, src.Check(signing.HARE, id, 21, 3, []byte("hare")))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, src.Export(&buf, ids[0]))
+
+	dst := New(localsql.InMemory(), WithLogger(zaptest.NewLogger(t)))
+	require.NoError(t, dst.Check(signing.ATX, ids[0], 20, 0, []byte("other atx")))
+	n, err := dst.Import(context.Background(), bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	require.NoError(t, dst.Check(signing.HARE, ids[0], 21, 3, []byte("hare")))
+	require.ErrorIs(t, dst.Check(signing.HARE, ids[0], 21, 3, []byte("other hare")), signing.ErrEquivocation)
+	// conflicting record is skipped and the local record is kept
+	require.NoError(t, dst.Check(signing.ATX, ids[0], 20, 0, []byte("other atx")))
+	// records of other identities are not exported
+	require.NoError(t, dst.Check(signing.HARE, ids[1], 21, 3, []byte("other hare")))
+
+	buf.Reset()
+	require.NoError(t, src.Export(&buf))
+	dst = New(localsql.InMemory())
+	n, err = dst.Import(context.Background(), &buf)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+
+	_, err = dst.Import(context.Background(), bytes.NewBufferString(`{"version": 2}`))
+	require.ErrorContains(t, err, "unsupported records version")
+}
@@ -0,0 +1,20 @@
+package equivocation
+
+import (
+	"github.com/spacemeshos/go-spacemesh/metrics"
+)
+
+const (
+	namespace = "equivocation"
+
+	domainLabel = "domain"
+)
+
+var refused = metrics.NewCounter(
+	"refused",
+	namespace,
+	"number of conflicting messages that local identities refused to sign",
+	[]string{
+		domainLabel,
+	},
+)
@@ -0,0 +1,53 @@
+package events
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// AppEvent is an account level event emitted by the vm when transaction is applied.
+//
+// When the vm reverts layers a single event with Revert set is emitted, Layer of such event is the
+// last layer that was kept. Events of the reverted layers are emitted again when they are re-applied.
+type AppEvent struct {
+	Layer       types.LayerID
+	Revert      bool
+	Transaction types.TransactionID
+	// Kind is one of spawn, transfer, relay or drain_vault.
+	Kind        string
+	Principal   types.Address
+	Account     types.Address
+	Destination types.Address
+	Template    types.Address
+	Amount      uint64
+}
+
+// Involves returns true if address is a principal, an account or a destination of the event.
+func (ev *AppEvent) Involves(address types.Address) bool {
+	return ev.Principal == address || ev.Account == address || ev.Destination == address
+}
+
+// SubscribeAppEvents subscribes to app events.
+func SubscribeAppEvents() Subscription {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		sub, err := reporter.bus.Subscribe(new(AppEvent))
+		if err != nil {
+			log.With().Panic("Failed to subscribe to app events")
+		}
+		return sub
+	}
+	return nil
+}
+
+// ReportAppEvent reports an app event.
+func ReportAppEvent(ev AppEvent) {
+	mu.RLock()
+	defer mu.RUnlock()
+	if reporter != nil {
+		if err := reporter.appEmitter.Emit(ev); err != nil {
+			log.With().Error("failed to emit app event", ev.Transaction, log.Err(err))
+		}
+	}
+}
@@ -414,6 +414,7 @@ type EventReporter struct {
 	resultsEmitter     event.Emitter
 	proposalsEmitter   event.Emitter
 	malfeasanceEmitter event.Emitter
+	appEmitter         event.Emitter
 	events             struct {
 		sync.Mutex
 		buf     *Ring[UserEvent]
@@ -486,6 +487,10 @@ func newEventReporter() *EventReporter {
 	if err != nil {
 		log.With().Panic("failed to create malfeasance emitter", log.Err(err))
 	}
+	appEmitter, err := bus.Emitter(new(AppEvent))
+	if err != nil {
+		log.With().Panic("failed to create app events emitter", log.Err(err))
+	}
 
 	reporter := &EventReporter{
 		bus:                bus,
@@ -499,6 +504,7 @@ func newEventReporter() *EventReporter {
 		errorEmitter:       errorEmitter,
 		proposalsEmitter:   proposalsEmitter,
 		malfeasanceEmitter: malfeasanceEmitter,
+		appEmitter:         appEmitter,
 		stopChan:           make(chan struct{}),
 	}
 	reporter.events.buf = newRing[UserEvent](100)
@@ -541,6 +547,9 @@ func CloseEventReporter() {
 		if err := reporter.malfeasanceEmitter.Close(); err != nil {
 			log.With().Panic("failed to close malfeasanceEmitter", log.Err(err))
 		}
+		if err := reporter.appEmitter.Close(); err != nil {
+			log.With().Panic("failed to close appEmitter", log.Err(err))
+		}
 
 		close(reporter.stopChan)
 		reporter = nil
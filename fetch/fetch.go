@@ -7,6 +7,7 @@ import (
 	"errors"
 	"io"
 	"math/rand/v2"
+	"slices"
 	"sync"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/fetch/peers"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 	"github.com/spacemeshos/go-spacemesh/p2p/server"
 	"github.com/spacemeshos/go-spacemesh/proposals/store"
 )
@@ -31,6 +33,7 @@ const (
 	activeSetProtocol = "as/1"
 	meshHashProtocol  = "mh/1"
 	malProtocol       = "ml/1"
+	rangeSyncProtocol = "rs/1"
 	OpnProtocol       = "lp/2"
 
 	cacheSize = 1000
@@ -54,6 +57,14 @@ type request struct {
 	validator dataReceiver
 	promise   *promise
 	retries   int
+
+	// inflight is the number of batches with the hash that wait for response,
+	// it is more than one if the hash was hedged.
+	inflight int
+	// hedged is set when the hash is requested from another peer, hash is hedged at most once per retry.
+	hedged bool
+	// received is set when the first response is received, responses to other batches are ignored.
+	received bool
 }
 
 type promise struct {
@@ -138,6 +149,16 @@ type Config struct {
 	GetAtxsConcurrency   int64                  `mapstructure:"getatxsconcurrency"`
 	DecayingTag          server.DecayingTagSpec `mapstructure:"decaying-tag"`
 	LogPeerStatsInterval time.Duration          `mapstructure:"log-peer-stats-interval"`
+	// PeerWindow is the initial number of batches in flight to every peer. The window grows
+	// up to MaxPeerWindow while the peer responds in time, and shrinks when responses slow down or fail.
+	// Batches are not limited if MaxPeerWindow is zero.
+	PeerWindow    int `mapstructure:"peer-window"`
+	MaxPeerWindow int `mapstructure:"max-peer-window"`
+	// HedgeDelay is the minimal time after which hashes that the peer didn't respond with yet
+	// are requested from another peer. The delay is HedgeLatencyFactor times the average duration
+	// of requests to the peer, if it is longer. Hedging is disabled if HedgeDelay is zero.
+	HedgeDelay         time.Duration `mapstructure:"hedge-delay"`
+	HedgeLatencyFactor float64       `mapstructure:"hedge-latency-factor"`
 }
 
 func (c Config) getServerConfig(protocol string) ServerConfig {
@@ -189,6 +210,10 @@ func DefaultConfig() Config {
 			Cap:      10000,
 		},
 		LogPeerStatsInterval: 20 * time.Minute,
+		PeerWindow:           4,
+		MaxPeerWindow:        32,
+		HedgeDelay:           time.Second,
+		HedgeLatencyFactor:   3,
 	}
 }
 
@@ -241,6 +266,8 @@ type Fetch struct {
 	host   host
 	peers  *peers.Peers
 
+	reputation *reputation.Reputation
+
 	servers    map[string]requester
 	validators *dataValidators
 
@@ -283,7 +310,15 @@ func NewFetch(
 		opt(f)
 	}
 	f.getAtxsLimiter = semaphore.NewWeighted(f.cfg.GetAtxsConcurrency)
-	f.peers = peers.New()
+	if host != nil {
+		f.reputation = host.Reputation()
+	} else {
+		f.reputation = reputation.New()
+	}
+	f.peers = peers.New(
+		peers.WithReputation(f.reputation),
+		peers.WithWindow(f.cfg.PeerWindow, f.cfg.MaxPeerWindow),
+	)
 	// NOTE(dshulyak) this is to avoid tests refactoring.
 	// there is one test that covers this part.
 	if host != nil {
@@ -325,6 +360,7 @@ func NewFetch(
 				})
 			f.registerServer(host, meshHashProtocol, h.handleMeshHashReqStream)
 			f.registerServer(host, malProtocol, h.handleMaliciousIDsReqStream)
+			f.registerServer(host, rangeSyncProtocol, h.handleRangeSyncReqStream)
 		} else {
 			f.registerServer(host, atxProtocol, server.WrapHandler(h.handleEpochInfoReq))
 			f.registerServer(host, hashProtocol, server.WrapHandler(h.handleHashReq))
@@ -356,6 +392,9 @@ func (f *Fetch) registerServer(
 	if f.cfg.EnableServerMetrics {
 		opts = append(opts, server.WithMetrics())
 	}
+	if recorder := host.Recorder(); recorder != nil {
+		opts = append(opts, server.WithRecorder(recorder))
+	}
 	opts = append(opts, f.cfg.getServerConfig(protocol).toOpts()...)
 	f.servers[protocol] = server.New(host, protocol, handler, opts...)
 }
@@ -482,7 +521,7 @@ func (f *Fetch) meteredRequest(
 	start := time.Now()
 	resp, err := f.servers[protocol].Request(ctx, peer, req, extraProtocols...)
 	if err != nil {
-		f.peers.OnFailure(peer, len(resp), time.Since(start))
+		f.onFailure(peer, len(resp), time.Since(start), err)
 	} else {
 		f.peers.OnLatency(peer, len(resp), time.Since(start))
 	}
@@ -508,13 +547,21 @@ func (f *Fetch) meteredStreamRequest(
 		extraProtocols...,
 	)
 	if err != nil {
-		f.peers.OnFailure(peer, nBytes, time.Since(start))
+		f.onFailure(peer, nBytes, time.Since(start), err)
 	} else {
 		f.peers.OnLatency(peer, nBytes, time.Since(start))
 	}
 	return err
 }
 
+func (f *Fetch) onFailure(peer p2p.Peer, size int, latency time.Duration, err error) {
+	f.peers.OnFailure(peer, size, latency)
+	// only the peer is penalized for timeouts, expiration of the caller's context is not its fault
+	if server.IsStreamTimeout(err) {
+		f.reputation.Record(peer, reputation.Timeout)
+	}
+}
+
 // receive Data from message server and call response handlers accordingly.
 func (f *Fetch) receiveResponse(data []byte, batch *batchInfo) {
 	if f.stopped() {
@@ -545,18 +592,15 @@ func (f *Fetch) receiveResponse(data []byte, batch *batchInfo) {
 	// iterate all hash Responses
 	for _, resp := range response.Responses {
 		f.logger.With().Debug("received response for hash", log.Stringer("hash", resp.Hash))
-		f.mu.Lock()
-		req, ok := f.ongoing[resp.Hash]
-		f.mu.Unlock()
-
+		req, ok := f.receive(resp.Hash)
 		if !ok {
-			f.logger.With().Warning("response received for unknown hash", log.Stringer("hash", resp.Hash))
+			delete(batchMap, resp.Hash)
 			continue
 		}
 
 		f.eg.Go(func() error {
 			// validation fetch data recursively. offload to another goroutine
-			f.hashValidationDone(resp.Hash, req.validator(req.ctx, resp.Hash, batch.peer, resp.Data))
+			f.validateResponse(req, resp.Hash, batch.peer, resp.Data)
 			return nil
 		})
 		delete(batchMap, resp.Hash)
@@ -574,6 +618,37 @@ func (f *Fetch) receiveResponse(data []byte, batch *batchInfo) {
 	}
 }
 
+// receive returns the request for the hash if it is the first response for it.
+// Responses to hedged batches, and responses for hashes that completed already are ignored.
+func (f *Fetch) receive(hash types.Hash32) (*request, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	req, ok := f.ongoing[hash]
+	if !ok || req.received {
+		f.logger.With().Debug("response received for completed hash", log.Stringer("hash", hash))
+		return nil, false
+	}
+	req.received = true
+	return req, true
+}
+
+// settle is called when the batch with the request completed without a response for it.
+// It returns false if the response is still expected from other batches.
+func (req *request) settle() bool {
+	req.inflight--
+	return !req.received && req.inflight <= 0
+}
+
+// validateResponse validates data served by the peer and records it as a useful contribution if it is valid.
+// Invalid data is recorded by the validators, see pubsub.DropPeerOnSyncValidationReject.
+func (f *Fetch) validateResponse(req *request, hash types.Hash32, peer p2p.Peer, data []byte) {
+	err := req.validator(req.ctx, hash, peer, data)
+	if err == nil {
+		f.reputation.Record(peer, reputation.Useful)
+	}
+	f.hashValidationDone(hash, err)
+}
+
 func (f *Fetch) hashValidationDone(hash types.Hash32, err error) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -599,7 +674,11 @@ func (f *Fetch) failAfterRetry(hash types.Hash32) {
 
 	req, ok := f.ongoing[hash]
 	if !ok {
-		f.logger.With().Error("hash missing from ongoing requests", log.Stringer("hash", hash))
+		// hash was received in response to another batch
+		f.logger.With().Debug("hash missing from ongoing requests", log.Stringer("hash", hash))
+		return
+	}
+	if !req.settle() {
 		return
 	}
 
@@ -641,6 +720,9 @@ func (f *Fetch) getUnprocessed() []RequestMessage {
 			With().
 			Debug("processing hash request", log.Stringer("hash", hash))
 		requestList = append(requestList, RequestMessage{Hash: hash, Hint: req.hint})
+		req.inflight = 1
+		req.hedged = false
+		req.received = false
 		// move the processed requests to pending
 		f.ongoing[hash] = req
 		delete(f.unprocessed, hash)
@@ -659,31 +741,121 @@ func (f *Fetch) send(requests []RequestMessage) {
 	peer2batches := f.organizeRequests(requests)
 	for peer, batches := range peer2batches {
 		for _, batch := range batches {
-			go func() {
-				if f.cfg.Streaming {
-					if err := f.streamBatch(peer, batch); err != nil {
-						f.logger.With().Debug(
-							"failed to process batch request",
-							log.Stringer("batch", batch.ID),
-							log.Stringer("peer", peer),
-							log.Err(err),
-						)
-					}
-					return
-				}
-				data, err := f.sendBatch(peer, batch)
-				if err != nil {
-					f.logger.With().Debug(
-						"failed to send batch request",
-						log.Stringer("batch", batch.ID),
-						log.Stringer("peer", peer),
-						log.Err(err),
-					)
-					f.handleHashError(batch, err)
-				} else {
-					f.receiveResponse(data, batch)
-				}
-			}()
+			go f.dispatch(peer, batch)
+		}
+	}
+}
+
+// dispatch waits until the peer has a free slot in its window of batches in flight, and sends the batch.
+// Hashes that are not received before the hedge delay are requested from another peer.
+func (f *Fetch) dispatch(peer p2p.Peer, batch *batchInfo) {
+	if f.cfg.HedgeDelay != 0 {
+		hedge := time.AfterFunc(f.hedgeDelay(peer), func() {
+			f.hedge(batch)
+		})
+		defer hedge.Stop()
+	}
+	if err := f.peers.Acquire(f.shutdownCtx, peer); err != nil {
+		f.handleHashError(batch, err)
+		return
+	}
+	defer f.peers.Release(peer)
+	if !f.expected(batch) {
+		f.logger.With().Debug("batch was received from other peers",
+			log.Stringer("batch", batch.ID),
+			log.Stringer("peer", peer),
+		)
+		return
+	}
+	if f.cfg.Streaming {
+		if err := f.streamBatch(peer, batch); err != nil {
+			f.logger.With().Debug(
+				"failed to process batch request",
+				log.Stringer("batch", batch.ID),
+				log.Stringer("peer", peer),
+				log.Err(err),
+			)
+		}
+		return
+	}
+	data, err := f.sendBatch(peer, batch)
+	if err != nil {
+		f.logger.With().Debug(
+			"failed to send batch request",
+			log.Stringer("batch", batch.ID),
+			log.Stringer("peer", peer),
+			log.Err(err),
+		)
+		f.handleHashError(batch, err)
+	} else {
+		f.receiveResponse(data, batch)
+	}
+}
+
+// expected returns true if a response is still expected for any hash in the batch.
+func (f *Fetch) expected(batch *batchInfo) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range batch.Requests {
+		if req, ok := f.ongoing[r.Hash]; ok && !req.received {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fetch) hedgeDelay(peer p2p.Peer) time.Duration {
+	expected := time.Duration(f.cfg.HedgeLatencyFactor * float64(f.peers.Expected(peer)))
+	return max(f.cfg.HedgeDelay, expected)
+}
+
+// hedge requests hashes that were not received yet in response to the batch from other peers.
+// Whichever response comes first is validated, the other one is ignored.
+func (f *Fetch) hedge(batch *batchInfo) {
+	if f.stopped() {
+		return
+	}
+	var seed [32]byte
+	binary.LittleEndian.PutUint64(seed[:], uint64(time.Now().UnixNano()))
+	rng := rand.New(rand.NewChaCha8(seed))
+	notBatchPeer := func(peer p2p.Peer) bool {
+		return peer == batch.peer
+	}
+	others := slices.DeleteFunc(f.peers.SelectBest(RedundantPeers), notBatchPeer)
+
+	peer2requests := make(map[p2p.Peer][]RequestMessage)
+	f.mu.Lock()
+	for _, r := range batch.Requests {
+		req, ok := f.ongoing[r.Hash]
+		if !ok || req.received || req.hedged {
+			continue
+		}
+		hashPeers := slices.DeleteFunc(f.hashToPeers.GetRandom(r.Hash, r.Hint, rng), notBatchPeer)
+		target := f.peers.SelectBestFrom(hashPeers)
+		if target == p2p.NoPeer {
+			if len(others) == 0 {
+				continue
+			}
+			target = randomPeer(others)
+		}
+		req.hedged = true
+		req.inflight++
+		hedgedHashReqs.WithLabelValues(string(r.Hint)).Inc()
+		peer2requests[target] = append(peer2requests[target], r)
+	}
+	f.mu.Unlock()
+
+	for peer, reqs := range peer2requests {
+		f.logger.With().Debug("hedging batch request",
+			log.Stringer("batch", batch.ID),
+			log.Stringer("slow_peer", batch.peer),
+			log.Stringer("peer", peer),
+			log.Int("num_requests", len(reqs)),
+		)
+		for i := 0; i < len(reqs); i += max(f.cfg.BatchSize, 1) {
+			hedged := makeBatch(peer, reqs[i:min(i+max(f.cfg.BatchSize, 1), len(reqs))])
+			hedged.protocol = batch.protocol
+			go f.dispatch(peer, hedged)
 		}
 	}
 }
@@ -851,9 +1023,7 @@ func (f *Fetch) receiveStreamedBatch(
 		nBytes += n
 
 		f.logger.With().Debug("received response for hash", log.Stringer("hash", respHash))
-		f.mu.Lock()
-		req, ok := f.ongoing[respHash]
-		f.mu.Unlock()
+		req, ok := f.receive(respHash)
 
 		blobLen, n, err := codec.DecodeLen(s)
 		if err != nil {
@@ -870,14 +1040,13 @@ func (f *Fetch) receiveStreamedBatch(
 
 		if !ok {
 			// we make sure to read the blob before continuing
-			f.logger.With().Warning("response received for unknown hash",
-				log.Stringer("hash", respHash))
+			delete(batchMap, respHash)
 			continue
 		}
 
 		f.eg.Go(func() error {
 			// validation fetches data recursively. offload to another goroutine
-			f.hashValidationDone(respHash, req.validator(req.ctx, respHash, batch.peer, b))
+			f.validateResponse(req, respHash, batch.peer, b)
 			return nil
 		})
 
@@ -913,7 +1082,10 @@ func (f *Fetch) handleHashError(batch *batchInfo, err error) {
 	for _, br := range batch.Requests {
 		req, ok := f.ongoing[br.Hash]
 		if !ok {
-			f.logger.With().Warning("hash missing from ongoing requests", log.Stringer("hash", br.Hash))
+			f.logger.With().Debug("hash missing from ongoing requests", log.Stringer("hash", br.Hash))
+			continue
+		}
+		if !req.settle() {
 			continue
 		}
 		f.logger.WithContext(req.ctx).With().
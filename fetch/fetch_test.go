@@ -3,6 +3,7 @@ package fetch
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/fetch/mocks"
+	"github.com/spacemeshos/go-spacemesh/fetch/peers"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
@@ -33,6 +35,7 @@ type testFetch struct {
 	mHashS  *mocks.Mockrequester
 	mMHashS *mocks.Mockrequester
 	mOpn2S  *mocks.Mockrequester
+	mRngS   *mocks.Mockrequester
 
 	mMalH        *mocks.MockSyncValidator
 	mAtxH        *mocks.MockSyncValidator
@@ -56,6 +59,7 @@ func createFetch(tb testing.TB) *testFetch {
 		mHashS:       mocks.NewMockrequester(ctrl),
 		mMHashS:      mocks.NewMockrequester(ctrl),
 		mOpn2S:       mocks.NewMockrequester(ctrl),
+		mRngS:        mocks.NewMockrequester(ctrl),
 		mMalH:        mocks.NewMockSyncValidator(ctrl),
 		mAtxH:        mocks.NewMockSyncValidator(ctrl),
 		mBallotH:     mocks.NewMockSyncValidator(ctrl),
@@ -66,7 +70,9 @@ func createFetch(tb testing.TB) *testFetch {
 		mTxProposalH: mocks.NewMockSyncValidator(ctrl),
 		mPoetH:       mocks.NewMockSyncValidator(ctrl),
 	}
-	for _, srv := range []*mocks.Mockrequester{tf.mMalS, tf.mAtxS, tf.mLyrS, tf.mHashS, tf.mMHashS, tf.mOpn2S} {
+	for _, srv := range []*mocks.Mockrequester{
+		tf.mMalS, tf.mAtxS, tf.mLyrS, tf.mHashS, tf.mMHashS, tf.mOpn2S, tf.mRngS,
+	} {
 		srv.EXPECT().Run(gomock.Any()).AnyTimes()
 	}
 	cfg := Config{
@@ -85,12 +91,13 @@ func createFetch(tb testing.TB) *testFetch {
 		WithConfig(cfg),
 		WithLogger(lg),
 		withServers(map[string]requester{
-			malProtocol:      tf.mMalS,
-			atxProtocol:      tf.mAtxS,
-			lyrDataProtocol:  tf.mLyrS,
-			hashProtocol:     tf.mHashS,
-			meshHashProtocol: tf.mMHashS,
-			OpnProtocol:      tf.mOpn2S,
+			malProtocol:       tf.mMalS,
+			atxProtocol:       tf.mAtxS,
+			lyrDataProtocol:   tf.mLyrS,
+			hashProtocol:      tf.mHashS,
+			meshHashProtocol:  tf.mMHashS,
+			OpnProtocol:       tf.mOpn2S,
+			rangeSyncProtocol: tf.mRngS,
 		}),
 		withHost(tf.mh))
 	tf.Fetch.SetValidators(
@@ -416,7 +423,7 @@ func TestFetch_PeerDroppedWhenMessageResultsInValidationReject(t *testing.T) {
 
 	// Now wrap the atx validator with  DropPeerOnValidationReject and set it again
 	fetcher.SetValidators(
-		ValidatorFunc(pubsub.DropPeerOnSyncValidationReject(vf, h, lg)),
+		ValidatorFunc(pubsub.DropPeerOnSyncValidationReject(vf, h, h.Reputation(), lg)),
 		nil,
 		nil,
 		nil,
@@ -442,4 +449,103 @@ func TestFetch_PeerDroppedWhenMessageResultsInValidationReject(t *testing.T) {
 		return len(h.Host.Network().ConnsToPeer(badPeerHost.ID())) == 0
 	}, time.Second*15, time.Millisecond*200)
 	require.Empty(t, h.GetPeers())
+	require.Negative(t, h.Reputation().Score(badPeerHost.ID()))
+}
+
+func hashResponse(tb testing.TB, req []byte) []byte {
+	tb.Helper()
+	var rb RequestBatch
+	require.NoError(tb, codec.Decode(req, &rb))
+	resps := make([]ResponseMessage, 0, len(rb.Requests))
+	for _, r := range rb.Requests {
+		resps = append(resps, ResponseMessage{Hash: r.Hash, Data: []byte("a")})
+	}
+	bts, err := codec.Encode(&ResponseBatch{ID: rb.ID, Responses: resps})
+	require.NoError(tb, err)
+	return bts
+}
+
+func TestFetch_HedgeSlowPeer(t *testing.T) {
+	f := createFetch(t)
+	f.cfg.HedgeDelay = 50 * time.Millisecond
+	f.cfg.HedgeLatencyFactor = 0
+	slow, fast := p2p.Peer("slow"), p2p.Peer("fast")
+	f.peers.Add(slow)
+	f.peers.Add(fast)
+	hash := types.RandomHash()
+	f.hashToPeers.Add(hash, slow)
+
+	release := make(chan struct{})
+	slowDone := make(chan struct{})
+	f.mHashS.EXPECT().
+		Request(gomock.Any(), slow, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ p2p.Peer, req []byte, _ ...string) ([]byte, error) {
+			defer close(slowDone)
+			<-release
+			return hashResponse(t, req), nil
+		})
+	f.mHashS.EXPECT().
+		Request(gomock.Any(), fast, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ p2p.Peer, req []byte, _ ...string) ([]byte, error) {
+			return hashResponse(t, req), nil
+		})
+
+	var received atomic.Int32
+	receiver := func(_ context.Context, _ types.Hash32, peer p2p.Peer, _ []byte) error {
+		require.Equal(t, fast, peer)
+		received.Add(1)
+		return nil
+	}
+	p, err := f.getHash(context.Background(), hash, datastore.ProposalDB, receiver)
+	require.NoError(t, err)
+	f.requestHashBatchFromPeers()
+
+	select {
+	case <-p.completed:
+		require.NoError(t, p.err)
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "hash wasn't hedged")
+	}
+	// late response from the slow peer is ignored
+	close(release)
+	<-slowDone
+	require.Equal(t, int32(1), received.Load())
+}
+
+func TestFetch_PeerWindow(t *testing.T) {
+	f := createFetch(t)
+	f.cfg.BatchSize = 1
+	f.peers = peers.New(peers.WithWindow(1, 1))
+	peer := p2p.Peer("buddy")
+	f.peers.Add(peer)
+
+	var inflight, maxInflight atomic.Int32
+	f.mHashS.EXPECT().
+		Request(gomock.Any(), peer, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ p2p.Peer, req []byte, _ ...string) ([]byte, error) {
+			n := inflight.Add(1)
+			defer inflight.Add(-1)
+			for {
+				current := maxInflight.Load()
+				if n <= current || maxInflight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			return hashResponse(t, req), nil
+		}).
+		Times(3)
+
+	var promises []*promise
+	for range 3 {
+		p, err := f.getHash(context.Background(), types.RandomHash(), datastore.ProposalDB, goodReceiver)
+		require.NoError(t, err)
+		promises = append(promises, p)
+	}
+	f.requestHashBatchFromPeers()
+	for _, p := range promises {
+		<-p.completed
+		require.NoError(t, p.err)
+	}
+	require.Equal(t, int32(1), maxInflight.Load())
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/fetch/rangesync"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p/server"
 	"github.com/spacemeshos/go-spacemesh/sql"
@@ -22,7 +23,8 @@ import (
 )
 
 const (
-	fetchSubKey sql.QueryCacheSubKey = "epoch-info-req"
+	fetchSubKey     sql.QueryCacheSubKey = "epoch-info-req"
+	rangeSyncSubKey sql.QueryCacheSubKey = "range-sync-set"
 )
 
 type handler struct {
@@ -45,7 +47,7 @@ func newHandler(
 
 // handleMaliciousIDsReq returns the IDs of all known malicious nodes.
 func (h *handler) handleMaliciousIDsReq(ctx context.Context, _ []byte) ([]byte, error) {
-	nodes, err := identities.GetMalicious(h.cdb)
+	nodes, err := identities.GetMalicious(ctx, h.cdb)
 	if err != nil {
 		h.logger.With().Warning("serve: failed to get malicious IDs",
 			log.Context(ctx), log.Err(err))
@@ -66,7 +68,7 @@ func (h *handler) handleMaliciousIDsReq(ctx context.Context, _ []byte) ([]byte,
 
 func (h *handler) handleMaliciousIDsReqStream(ctx context.Context, msg []byte, s io.ReadWriter) error {
 	if err := h.streamIDs(ctx, s, func(cbk retrieveCallback) error {
-		nodeIDs, err := identities.GetMalicious(h.cdb)
+		nodeIDs, err := identities.GetMalicious(ctx, h.cdb)
 		if err != nil {
 			h.logger.With().Warning("serve: failed to get malicious IDs",
 				log.Context(ctx), log.Err(err))
@@ -86,6 +88,52 @@ func (h *handler) handleMaliciousIDsReqStream(ctx context.Context, msg []byte, s
 	return nil
 }
 
+// handleRangeSyncReqStream reconciles the requested set of IDs with the peer.
+func (h *handler) handleRangeSyncReqStream(ctx context.Context, msg []byte, s io.ReadWriter) error {
+	var req RangeSyncRequest
+	if err := codec.Decode(msg, &req); err != nil {
+		return err
+	}
+	set, err := h.rangeSyncSet(ctx, &req)
+	if err != nil {
+		h.logger.With().Warning("serve: failed to get range sync set",
+			log.Context(ctx), log.Uint32("set", uint32(req.Set)), log.Err(err))
+		return server.WriteErrorResponse(s, err)
+	}
+	if err := rangesync.Serve(s, set, &req.Message); err != nil {
+		h.logger.With().Debug("serve: failed to reconcile range sync set",
+			log.Context(ctx), log.Uint32("set", uint32(req.Set)), log.Err(err))
+	}
+	return nil
+}
+
+func (h *handler) rangeSyncSet(ctx context.Context, req *RangeSyncRequest) (*rangesync.Set, error) {
+	switch req.Set {
+	case RangeSyncEpochAtxs:
+		// sorted set is cached until new atx is added to the epoch
+		cacheKey := sql.QueryCacheKey(atxs.CacheKindEpochATXs, req.Epoch.String())
+		return sql.WithCachedSubKey(ctx, h.cdb, cacheKey, rangeSyncSubKey,
+			func(ctx context.Context) (*rangesync.Set, error) {
+				atxids, err := atxs.GetIDsByEpoch(ctx, h.cdb, req.Epoch)
+				if err != nil {
+					return nil, err
+				}
+				return rangesync.NewSet(types.ATXIDsToHashes(atxids)), nil
+			})
+	case RangeSyncMalicious:
+		// sorted set is cached until new identity is recorded as malicious
+		return sql.WithCachedSubKey(ctx, h.cdb, identities.MaliciousCacheKey, rangeSyncSubKey,
+			func(ctx context.Context) (*rangesync.Set, error) {
+				nodeIDs, err := identities.GetMalicious(ctx, h.cdb)
+				if err != nil {
+					return nil, err
+				}
+				return rangesync.NewSet(types.NodeIDsToHashes(nodeIDs)), nil
+			})
+	}
+	return nil, fmt.Errorf("%w: unknown range sync set %d", errBadRequest, req.Set)
+}
+
 // handleEpochInfoReq returns the ATXs published in the specified epoch.
 func (h *handler) handleEpochInfoReq(ctx context.Context, msg []byte) ([]byte, error) {
 	var epoch types.EpochID
@@ -12,6 +12,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/fetch/rangesync"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p/server"
 	"github.com/spacemeshos/go-spacemesh/proposals/store"
@@ -261,8 +262,8 @@ func TestHandleMeshHashReq(t *testing.T) {
 	}
 }
 
-func newAtx(t *testing.T, published types.EpochID) *types.VerifiedActivationTx {
-	t.Helper()
+func newAtx(tb testing.TB, published types.EpochID) *types.VerifiedActivationTx {
+	tb.Helper()
 	nonce := types.VRFPostIndex(123)
 	atx := &types.ActivationTx{
 		InnerActivationTx: types.InnerActivationTx{
@@ -276,12 +277,12 @@ func newAtx(t *testing.T, published types.EpochID) *types.VerifiedActivationTx {
 	}
 
 	signer, err := signing.NewEdSigner()
-	require.NoError(t, err)
+	require.NoError(tb, err)
 	activation.SignAndFinalizeAtx(signer, atx)
 	atx.SetEffectiveNumUnits(atx.NumUnits)
 	atx.SetReceived(time.Now())
 	vatx, err := atx.Verify(0, 1)
-	require.NoError(t, err)
+	require.NoError(tb, err)
 	return vatx
 }
 
@@ -445,3 +446,63 @@ func TestHandleMaliciousIDsReq(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleRangeSyncReq(t *testing.T) {
+	th := createTestHandler(t, sql.WithQueryCache(true))
+	epoch := types.EpochID(11)
+	var atxIDs []types.ATXID
+	for i := 0; i < 10; i++ {
+		vatx := newAtx(t, epoch)
+		require.NoError(t, atxs.Add(th.cdb, vatx))
+		atxIDs = append(atxIDs, vatx.ID())
+	}
+	var bad []types.NodeID
+	for i := 0; i < 4; i++ {
+		nid := types.NodeID{byte(i + 1)}
+		bad = append(bad, nid)
+		require.NoError(t, identities.SetMalicious(th.cdb, nid, types.RandomBytes(11), time.Now()))
+	}
+
+	reconcile := func(t *testing.T, req *RangeSyncRequest, local *rangesync.Set) []types.Hash32 {
+		req.Message = *local.Initial()
+		var b bytes.Buffer
+		require.NoError(t, th.handleRangeSyncReqStream(context.Background(), codec.MustEncode(req), &b))
+		var resp server.Response
+		require.NoError(t, codec.Decode(b.Bytes(), &resp))
+		require.Empty(t, resp.Error)
+		var msg rangesync.Message
+		require.NoError(t, codec.Decode(resp.Data, &msg))
+		require.Empty(t, msg.Ranges)
+		var missing []types.Hash32
+		local.Next(&msg, func(id types.Hash32) {
+			missing = append(missing, id)
+		})
+		return missing
+	}
+
+	t.Run("epoch atxs", func(t *testing.T) {
+		local := rangesync.NewSet(types.ATXIDsToHashes(atxIDs[:5]))
+		missing := reconcile(t, &RangeSyncRequest{Set: RangeSyncEpochAtxs, Epoch: epoch}, local)
+		require.ElementsMatch(t, types.ATXIDsToHashes(atxIDs[5:]), missing)
+	})
+	t.Run("malicious", func(t *testing.T) {
+		local := rangesync.NewSet(types.NodeIDsToHashes(bad[:1]))
+		missing := reconcile(t, &RangeSyncRequest{Set: RangeSyncMalicious}, local)
+		require.ElementsMatch(t, types.NodeIDsToHashes(bad[1:]), missing)
+	})
+	t.Run("malicious after new proof", func(t *testing.T) {
+		nid := types.RandomNodeID()
+		require.NoError(t, identities.SetMalicious(th.cdb, nid, types.RandomBytes(11), time.Now()))
+		local := rangesync.NewSet(types.NodeIDsToHashes(bad))
+		missing := reconcile(t, &RangeSyncRequest{Set: RangeSyncMalicious}, local)
+		require.Equal(t, []types.Hash32{types.Hash32(nid)}, missing)
+	})
+	t.Run("unknown set", func(t *testing.T) {
+		req := &RangeSyncRequest{Set: RangeSyncMalicious + 1, Message: *rangesync.NewSet(nil).Initial()}
+		var b bytes.Buffer
+		require.NoError(t, th.handleRangeSyncReqStream(context.Background(), codec.MustEncode(req), &b))
+		var resp server.Response
+		require.NoError(t, codec.Decode(b.Bytes(), &resp))
+		require.Contains(t, resp.Error, "unknown range sync set")
+	})
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/fetch/rangesync"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
@@ -22,7 +23,14 @@ import (
 	"github.com/spacemeshos/go-spacemesh/system"
 )
 
-var errBadRequest = errors.New("invalid request")
+var (
+	errBadRequest = errors.New("invalid request")
+
+	// ErrRangeSyncUnavailable is returned if range sync can't be used with the peer, because either
+	// streaming is disabled or the peer doesn't support it. Caller should fall back to downloading
+	// the whole set of IDs.
+	ErrRangeSyncUnavailable = errors.New("range sync unavailable")
+)
 
 // GetAtxs gets the data for given atx IDs and validates them. returns an error if at least one ATX cannot be fetched.
 func (f *Fetch) GetAtxs(ctx context.Context, ids []types.ATXID, opts ...system.GetAtxOpt) error {
@@ -250,6 +258,83 @@ func (f *Fetch) GetMaliciousIDs(ctx context.Context, peer p2p.Peer) ([]types.Nod
 	return malIDs.NodeIDs, nil
 }
 
+// RangeSyncMaliciousIDs returns IDs of malicious identities that the peer has and that are not
+// in the local set. Only differences between the sets are transferred.
+func (f *Fetch) RangeSyncMaliciousIDs(
+	ctx context.Context,
+	peer p2p.Peer,
+	local []types.NodeID,
+) ([]types.NodeID, error) {
+	missing, err := f.rangeSync(ctx, peer, RangeSyncMalicious, 0, types.NodeIDsToHashes(local))
+	if err != nil {
+		return nil, err
+	}
+	f.RegisterPeerHashes(peer, missing)
+	nodeIDs := make([]types.NodeID, 0, len(missing))
+	for _, id := range missing {
+		nodeIDs = append(nodeIDs, types.NodeID(id))
+	}
+	return nodeIDs, nil
+}
+
+// RangeSyncEpochAtxs returns IDs of ATXs published in the epoch that the peer has and that are not
+// in the local set. Only differences between the sets are transferred.
+func (f *Fetch) RangeSyncEpochAtxs(
+	ctx context.Context,
+	peer p2p.Peer,
+	epoch types.EpochID,
+	local []types.ATXID,
+) ([]types.ATXID, error) {
+	missing, err := f.rangeSync(ctx, peer, RangeSyncEpochAtxs, epoch, types.ATXIDsToHashes(local))
+	if err != nil {
+		return nil, err
+	}
+	atxIDs := make([]types.ATXID, 0, len(missing))
+	for _, id := range missing {
+		atxIDs = append(atxIDs, types.ATXID(id))
+	}
+	return atxIDs, nil
+}
+
+func (f *Fetch) rangeSync(
+	ctx context.Context,
+	peer p2p.Peer,
+	kind RangeSyncSet,
+	epoch types.EpochID,
+	local []types.Hash32,
+) ([]types.Hash32, error) {
+	if !f.cfg.Streaming {
+		return nil, ErrRangeSyncUnavailable
+	}
+	set := rangesync.NewSet(local)
+	req := codec.MustEncode(&RangeSyncRequest{
+		Set:     kind,
+		Epoch:   epoch,
+		Message: *set.Initial(),
+	})
+	var missing []types.Hash32
+	err := f.meteredStreamRequest(
+		ctx, rangeSyncProtocol, peer, req,
+		func(ctx context.Context, s io.ReadWriter) (n int, err error) {
+			missing, n, err = rangesync.Sync(s, set)
+			return n, err
+		},
+	)
+	if errors.Is(err, server.ErrProtocolNotSupported) {
+		return nil, fmt.Errorf("%w: %w", ErrRangeSyncUnavailable, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	f.logger.WithContext(ctx).With().Debug("range sync finished",
+		log.Stringer("peer", peer),
+		log.Uint32("set", uint32(kind)),
+		log.Int("local", set.Len()),
+		log.Int("missing", len(missing)),
+	)
+	return missing, nil
+}
+
 // GetLayerData get layer data from peers.
 func (f *Fetch) GetLayerData(ctx context.Context, peer p2p.Peer, lid types.LayerID) ([]byte, error) {
 	lidBytes := codec.MustEncode(&lid)
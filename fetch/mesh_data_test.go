@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"testing"
 
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
@@ -18,6 +19,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
 	"github.com/spacemeshos/go-spacemesh/fetch/mocks"
+	"github.com/spacemeshos/go-spacemesh/fetch/rangesync"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk/wallet"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
 	"github.com/spacemeshos/go-spacemesh/p2p"
@@ -531,6 +533,67 @@ func TestFetch_GetMaliciousIDs(t *testing.T) {
 	})
 }
 
+func TestFetch_RangeSync(t *testing.T) {
+	remote := generateMaliciousIDs(t)
+	expectServe := func(f *testFetch) {
+		f.mRngS.EXPECT().
+			StreamRequest(gomock.Any(), p2p.Peer("p0"), gomock.Any(), gomock.Any()).
+			DoAndReturn(
+				func(ctx context.Context, _ p2p.Peer, msg []byte, cbk server.StreamRequestCallback, _ ...string) error {
+					var req RangeSyncRequest
+					require.NoError(t, codec.Decode(msg, &req))
+					require.Equal(t, RangeSyncMalicious, req.Set)
+					client, srv := net.Pipe()
+					defer client.Close()
+					go func() {
+						defer srv.Close()
+						set := rangesync.NewSet(types.NodeIDsToHashes(remote))
+						assert.NoError(t, rangesync.Serve(srv, set, &req.Message))
+					}()
+					return cbk(ctx, client)
+				})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		f := createFetch(t)
+		f.cfg.Streaming = true
+		f.mh.EXPECT().ID().Return("self").AnyTimes()
+		expectServe(f)
+		ids, err := f.RangeSyncMaliciousIDs(context.Background(), "p0", remote[:1])
+		require.NoError(t, err)
+		require.ElementsMatch(t, remote[1:], ids)
+	})
+	t.Run("streaming disabled", func(t *testing.T) {
+		t.Parallel()
+		f := createFetch(t)
+		_, err := f.RangeSyncMaliciousIDs(context.Background(), "p0", remote)
+		require.ErrorIs(t, err, ErrRangeSyncUnavailable)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		t.Parallel()
+		f := createFetch(t)
+		f.cfg.Streaming = true
+		f.mRngS.EXPECT().
+			StreamRequest(gomock.Any(), p2p.Peer("p0"), gomock.Any(), gomock.Any()).
+			Return(fmt.Errorf("%w: test", server.ErrProtocolNotSupported))
+		_, err := f.RangeSyncEpochAtxs(context.Background(), "p0", 1, nil)
+		require.ErrorIs(t, err, ErrRangeSyncUnavailable)
+	})
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		errUnknown := errors.New("unknown")
+		f := createFetch(t)
+		f.cfg.Streaming = true
+		f.mRngS.EXPECT().
+			StreamRequest(gomock.Any(), p2p.Peer("p0"), gomock.Any(), gomock.Any()).
+			Return(errUnknown)
+		_, err := f.RangeSyncEpochAtxs(context.Background(), "p0", 1, nil)
+		require.ErrorIs(t, err, errUnknown)
+		require.NotErrorIs(t, err, ErrRangeSyncUnavailable)
+	})
+}
+
 func TestFetch_GetLayerOpinions(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
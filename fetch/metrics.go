@@ -60,6 +60,12 @@ var (
 		"total requests for block certificate received",
 		[]string{}).WithLabelValues()
 
+	hedgedHashReqs = metrics.NewCounter(
+		"hedged_hash_reqs",
+		subsystem,
+		"total hash requests that were sent to another peer because the first peer was slow",
+		[]string{hint})
+
 	opnReqV2 = metrics.NewCounter(
 		"opn_reqs",
 		subsystem,
@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/spacemeshos/go-spacemesh/activation/wire"
 	"github.com/spacemeshos/go-spacemesh/codec"
@@ -29,6 +30,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
 	"github.com/spacemeshos/go-spacemesh/sql/poets"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
+	"github.com/spacemeshos/go-spacemesh/system"
 )
 
 type blobKey struct {
@@ -74,11 +76,11 @@ func p2pFetchCfg(streaming bool) Config {
 	return cfg
 }
 
-func p2pCfg(t *testing.T) p2p.Config {
+func p2pCfg(tb testing.TB) p2p.Config {
 	p2pconf := p2p.DefaultConfig()
 	p2pconf.Listen = p2p.MustParseAddresses("/ip4/127.0.0.1/tcp/0")
 	p2pconf.IP4Blocklist = nil
-	p2pconf.DataDir = t.TempDir()
+	p2pconf.DataDir = tb.TempDir()
 	return p2pconf
 }
 
@@ -527,3 +529,87 @@ func TestP2PGetMalfeasanceProofs(t *testing.T) {
 				proof)
 		})
 }
+
+// benchmarkSlowPeer measures how long it takes to fetch atxs from a set of peers,
+// when one of them delays every response.
+func benchmarkSlowPeer(b *testing.B, cfg Config) {
+	const (
+		numServers = 4
+		numAtxs    = 100
+		slowDelay  = 500 * time.Millisecond
+	)
+	lg := logtest.New(b)
+	ctx, cancel := context.WithCancel(context.Background())
+	var eg errgroup.Group
+	b.Cleanup(func() {
+		cancel()
+		assert.NoError(b, eg.Wait())
+	})
+
+	ids := make([]types.ATXID, numAtxs)
+	vatxs := make([]*types.VerifiedActivationTx, numAtxs)
+	for i := range vatxs {
+		vatxs[i] = newAtx(b, 11)
+		ids[i] = vatxs[i].ID()
+	}
+
+	clientHost, err := p2p.AutoStart(ctx, lg, p2pCfg(b), []byte{}, []byte{})
+	require.NoError(b, err)
+	b.Cleanup(func() { assert.NoError(b, clientHost.Stop()) })
+	client := NewFetch(datastore.NewCachedDB(sql.InMemory(), lg), store.New(), clientHost,
+		WithContext(ctx),
+		WithConfig(cfg),
+		WithLogger(lg))
+	vf := ValidatorFunc(
+		func(context.Context, types.Hash32, peer.ID, []byte) error { return nil },
+	)
+	client.SetValidators(vf, vf, vf, vf, vf, vf, vf, vf, vf)
+	require.NoError(b, client.Start())
+	b.Cleanup(client.Stop)
+
+	for i := range numServers {
+		host, err := p2p.AutoStart(ctx, lg, p2pCfg(b), []byte{}, []byte{})
+		require.NoError(b, err)
+		b.Cleanup(func() { assert.NoError(b, host.Stop()) })
+		cdb := datastore.NewCachedDB(sql.InMemory(), lg)
+		for _, vatx := range vatxs {
+			require.NoError(b, atxs.Add(cdb, vatx))
+		}
+		h := newHandler(cdb, datastore.NewBlobStore(cdb, store.New()), lg)
+		handler := h.handleHashReq
+		if i == 0 {
+			handler = func(ctx context.Context, data []byte) ([]byte, error) {
+				time.Sleep(slowDelay)
+				return h.handleHashReq(ctx, data)
+			}
+		}
+		srv := server.New(host, hashProtocol, server.WrapHandler(handler),
+			server.WithRequestsPerInterval(1000, time.Second))
+		eg.Go(func() error {
+			return srv.Run(ctx)
+		})
+		require.NoError(b, clientHost.Connect(ctx, peer.AddrInfo{ID: host.ID(), Addrs: host.Addrs()}))
+	}
+	require.Eventually(b, func() bool {
+		return len(client.peers.SelectBest(numServers)) == numServers
+	}, 10*time.Second, 10*time.Millisecond)
+
+	b.ResetTimer()
+	for range b.N {
+		require.NoError(b, client.GetAtxs(ctx, ids, system.WithoutLimiting()))
+	}
+}
+
+func BenchmarkP2PSlowPeer(b *testing.B) {
+	b.Run("baseline", func(b *testing.B) {
+		cfg := p2pFetchCfg(false)
+		cfg.MaxPeerWindow = 0
+		cfg.HedgeDelay = 0
+		benchmarkSlowPeer(b, cfg)
+	})
+	b.Run("adaptive", func(b *testing.B) {
+		cfg := p2pFetchCfg(false)
+		cfg.HedgeDelay = 100 * time.Millisecond
+		benchmarkSlowPeer(b, cfg)
+	})
+}
@@ -1,6 +1,8 @@
 package peers
 
 import (
+	"context"
+	"math"
 	"strings"
 	"sync"
 	"time"
@@ -11,20 +13,42 @@ import (
 	"github.com/spacemeshos/go-spacemesh/p2p"
 )
 
+// reputationScale is the difference in reputation scores that makes the peer look e times faster or slower.
+const reputationScale = 100
+
+// congestionFactor is how much slower than average the response must be for the window to shrink.
+const congestionFactor = 2
+
 type data struct {
 	id                peer.ID
 	success, failures int
 	failRate          float64
 	averageLatency    float64
+	// score is the reputation score of the peer, refreshed before every selection.
+	score float64
+
+	// averageDuration is the average duration of successful requests, not adjusted for the size of the response.
+	averageDuration float64
+	// window is the number of requests that can be in flight, inflight is the number of requests
+	// that are in flight now. released is closed and replaced every time when a slot may become free.
+	window, inflight int
+	released         chan struct{}
+}
+
+func (d *data) notify() {
+	close(d.released)
+	d.released = make(chan struct{})
 }
 
 func (d *data) latency(global float64) float64 {
+	var latency float64
 	switch {
 	case d.success+d.failures == 0:
-		return 0.9 * global // to prioritize trying out new peer
+		latency = 0.9 * global // to prioritize trying out new peer
 	default:
-		return d.averageLatency + d.failRate*global
+		latency = d.averageLatency + d.failRate*global
 	}
+	return latency * math.Exp(-d.score/reputationScale)
 }
 
 func (p *data) less(other *data, global float64) bool {
@@ -38,20 +62,55 @@ func (p *data) less(other *data, global float64) bool {
 	return strings.Compare(string(p.id), string(other.id)) == -1
 }
 
-func New() *Peers {
-	return &Peers{
+// Reputation of peers that is taken into account when selecting best peers.
+type Reputation interface {
+	Score(peer.ID) float64
+	Banned(peer.ID) bool
+}
+
+type Opt func(*Peers)
+
+// WithWindow limits the number of requests in flight to every peer. Window starts at initial size,
+// grows by one after every timely response up to max, and is halved after every failure or slow response.
+// Requests are not limited if max is zero.
+func WithWindow(initial, max int) Opt {
+	return func(p *Peers) {
+		p.initialWindow = initial
+		p.maxWindow = max
+	}
+}
+
+// WithReputation makes selection prefer peers with higher reputation score and skip banned peers.
+func WithReputation(reputation Reputation) Opt {
+	return func(p *Peers) {
+		p.reputation = reputation
+	}
+}
+
+func New(opts ...Opt) *Peers {
+	p := &Peers{
 		peers: map[peer.ID]*data{},
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	p.initialWindow = min(max(p.initialWindow, 1), p.maxWindow)
+	return p
 }
 
 type Peers struct {
-	mu    sync.Mutex
-	peers map[peer.ID]*data
+	mu         sync.Mutex
+	peers      map[peer.ID]*data
+	reputation Reputation
+
+	initialWindow, maxWindow int
 
 	// globalLatency is the average latency of all successful responses from peers.
 	// It is used as a reference value for new peers.
 	// And to adjust average peer latency based on failure rate.
 	globalLatency float64
+	// globalDuration is the average duration of all successful requests.
+	globalDuration float64
 }
 
 func (p *Peers) Add(id peer.ID) bool {
@@ -61,18 +120,70 @@ func (p *Peers) Add(id peer.ID) bool {
 	if exist {
 		return false
 	}
-	p.peers[id] = &data{id: id}
+	p.peers[id] = &data{id: id, window: p.initialWindow, released: make(chan struct{})}
 	return true
 }
 
 func (p *Peers) Delete(id peer.ID) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if peer, exist := p.peers[id]; exist {
+		// requests that wait for the window won't be limited
+		peer.notify()
+	}
 	delete(p.peers, id)
 }
 
+// Acquire waits until the number of requests in flight to the peer is below its window,
+// and reserves the slot for the request. Requests to unknown peers are not limited.
+// Every successful Acquire must be followed by Release.
+func (p *Peers) Acquire(ctx context.Context, id peer.ID) error {
+	for {
+		p.mu.Lock()
+		peer, exist := p.peers[id]
+		if !exist || p.maxWindow == 0 || peer.inflight < peer.window {
+			if exist {
+				peer.inflight++
+			}
+			p.mu.Unlock()
+			return nil
+		}
+		released := peer.released
+		p.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// Release the slot reserved by Acquire.
+func (p *Peers) Release(id peer.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	peer, exist := p.peers[id]
+	if !exist || peer.inflight == 0 {
+		return
+	}
+	peer.inflight--
+	peer.notify()
+}
+
+// Expected returns the average duration of the request to the peer,
+// or the average across all peers if there were no successful requests to the peer.
+func (p *Peers) Expected(id peer.ID) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if peer, exist := p.peers[id]; exist && peer.averageDuration != 0 {
+		return time.Duration(peer.averageDuration)
+	}
+	return time.Duration(p.globalDuration)
+}
+
 // OnLatency updates average peer and global latency.
 func (p *Peers) onLatency(id peer.ID, size int, latency time.Duration, failed bool) {
+	duration := latency
 	// We assume that latency is proportional to the size of the message
 	// and define it as a duration to transmit 1kiB.
 	// To account for the additional overhead of transmitting small messages,
@@ -84,10 +195,29 @@ func (p *Peers) onLatency(id peer.ID, size int, latency time.Duration, failed bo
 	if !exist {
 		return
 	}
+	// window is adjusted using the average latency before it is updated with this response
+	congested := peer.averageLatency != 0 && float64(latency) > congestionFactor*peer.averageLatency
+	switch {
+	case failed || congested:
+		peer.window = max(peer.window/2, 1)
+	case peer.window < p.maxWindow:
+		peer.window++
+		peer.notify()
+	}
 	if failed {
 		peer.failures++
 	} else {
 		peer.success++
+		if peer.averageDuration != 0 {
+			peer.averageDuration += (float64(duration) - peer.averageDuration) / 10
+		} else {
+			peer.averageDuration = float64(duration)
+		}
+		if p.globalDuration != 0 {
+			p.globalDuration += (float64(duration) - p.globalDuration) / 25
+		} else {
+			p.globalDuration = float64(duration)
+		}
 	}
 	peer.failRate = float64(peer.failures) / float64(peer.success+peer.failures)
 	if peer.averageLatency != 0 {
@@ -120,7 +250,7 @@ func (p *Peers) SelectBestFrom(peers []peer.ID) peer.ID {
 	var best *data
 	for _, peer := range peers {
 		pdata, exist := p.peers[peer]
-		if !exist {
+		if !exist || !p.refreshScore(pdata) {
 			continue
 		}
 		if best == nil {
@@ -150,6 +280,9 @@ func (p *Peers) SelectBest(n int) []peer.ID {
 	}
 	best := make([]*data, 0, lth)
 	for _, peer := range p.peers {
+		if !p.refreshScore(peer) {
+			continue
+		}
 		for i := range best {
 			if peer.less(best[i], p.globalLatency) {
 				best[i], peer = peer, best[i]
@@ -166,6 +299,18 @@ func (p *Peers) SelectBest(n int) []peer.ID {
 	return rst
 }
 
+// refreshScore updates reputation score of the peer and returns false if the peer is banned.
+func (p *Peers) refreshScore(d *data) bool {
+	if p.reputation == nil {
+		return true
+	}
+	if p.reputation.Banned(d.id) {
+		return false
+	}
+	d.score = p.reputation.Score(d.id)
+	return true
+}
+
 func (p *Peers) Total() int {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -190,6 +335,8 @@ func (p *Peers) Stats() Stats {
 			Success:  peerData.success,
 			Failures: peerData.failures,
 			Latency:  time.Duration(peerData.averageLatency),
+			Window:   peerData.window,
+			InFlight: peerData.inflight,
 		})
 	}
 	return stats
@@ -218,6 +365,8 @@ type PeerStats struct {
 	Success  int
 	Failures int
 	Latency  time.Duration
+	Window   int
+	InFlight int
 }
 
 func (p *PeerStats) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -225,5 +374,7 @@ func (p *PeerStats) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("success", p.Success)
 	enc.AddInt("failures", p.Failures)
 	enc.AddDuration("latency per 1024 bytes", p.Latency)
+	enc.AddInt("window", p.Window)
+	enc.AddInt("in flight", p.InFlight)
 	return nil
 }
@@ -1,6 +1,7 @@
 package peers
 
 import (
+	"context"
 	"encoding/binary"
 	"math/rand/v2"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/p2p"
 )
 
 // any random non zero number that will be used if size is not specified in the test case
@@ -210,6 +213,96 @@ func TestSelect(t *testing.T) {
 	}
 }
 
+type reputation struct {
+	scores map[peer.ID]float64
+	banned map[peer.ID]bool
+}
+
+func (r *reputation) Score(id peer.ID) float64 {
+	return r.scores[id]
+}
+
+func (r *reputation) Banned(id peer.ID) bool {
+	return r.banned[id]
+}
+
+func TestSelectReputation(t *testing.T) {
+	rep := &reputation{scores: map[peer.ID]float64{}, banned: map[peer.ID]bool{}}
+	tracker := New(WithReputation(rep))
+	for _, id := range []peer.ID{"a", "b", "c"} {
+		tracker.Add(id)
+	}
+	tracker.OnLatency("a", testSize, 10)
+	tracker.OnLatency("b", testSize, 12)
+	tracker.OnLatency("c", testSize, 14)
+	require.Equal(t, []peer.ID{"a", "b", "c"}, tracker.SelectBest(3))
+
+	rep.scores["c"] = 100
+	rep.scores["a"] = -50
+	require.Equal(t, []peer.ID{"c", "b", "a"}, tracker.SelectBest(3))
+	require.Equal(t, peer.ID("b"), tracker.SelectBestFrom([]peer.ID{"a", "b"}))
+
+	rep.banned["c"] = true
+	require.Equal(t, []peer.ID{"b", "a"}, tracker.SelectBest(3))
+	require.Equal(t, p2p.NoPeer, tracker.SelectBestFrom([]peer.ID{"c"}))
+}
+
+func TestWindow(t *testing.T) {
+	tracker := New(WithWindow(1, 3))
+	tracker.Add("a")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.NoError(t, tracker.Acquire(ctx, "a"))
+	require.ErrorIs(t, tracker.Acquire(ctx, "a"), context.DeadlineExceeded)
+	// unknown peers are not limited
+	require.NoError(t, tracker.Acquire(ctx, "b"))
+
+	acquired := make(chan error, 1)
+	go func() {
+		acquired <- tracker.Acquire(context.Background(), "a")
+	}()
+	tracker.Release("a")
+	require.NoError(t, <-acquired)
+	tracker.Release("a")
+
+	// timely responses grow the window up to the max
+	for range 5 {
+		tracker.OnLatency("a", testSize, 10*time.Millisecond)
+	}
+	require.Equal(t, 3, tracker.Stats().BestPeers[0].Window)
+	for range 3 {
+		require.NoError(t, tracker.Acquire(context.Background(), "a"))
+	}
+	require.Equal(t, 3, tracker.Stats().BestPeers[0].InFlight)
+
+	// slow response and failure shrink it
+	tracker.OnLatency("a", testSize, time.Second)
+	require.Equal(t, 1, tracker.Stats().BestPeers[0].Window)
+	tracker.OnLatency("a", testSize, 10*time.Millisecond)
+	require.Equal(t, 2, tracker.Stats().BestPeers[0].Window)
+	tracker.OnFailure("a", testSize, 10*time.Millisecond)
+	require.Equal(t, 1, tracker.Stats().BestPeers[0].Window)
+
+	// waiting requests are released when peer is deleted
+	go func() {
+		acquired <- tracker.Acquire(context.Background(), "a")
+	}()
+	tracker.Delete("a")
+	require.NoError(t, <-acquired)
+}
+
+func TestExpected(t *testing.T) {
+	tracker := New()
+	tracker.Add("a")
+	tracker.Add("b")
+	require.Zero(t, tracker.Expected("a"))
+	tracker.OnLatency("a", 10*testSize*1024, time.Second)
+	require.Equal(t, time.Second, tracker.Expected("a"))
+	require.Equal(t, time.Second, tracker.Expected("b"))
+	tracker.OnFailure("b", testSize, time.Minute)
+	require.Equal(t, time.Second, tracker.Expected("b"))
+}
+
 func TestTotal(t *testing.T) {
 	const total = 100
 	events := []event{}
@@ -0,0 +1,161 @@
+// Package rangesync implements range-based set reconciliation over sorted ids.
+//
+// Client starts the session with the fingerprint of its whole set. Server compares it with its own
+// fingerprint, and for every range that differs either sends ids that it has in the range, if there
+// are few of them, or splits the range into smaller ones at quantiles of its ids. Client compares
+// fingerprints of the smaller ranges with its own and asks again only for those that differ. Session
+// ends when there are no more ranges that differ, so that only differences and a logarithmic number
+// of fingerprints go over the wire.
+package rangesync
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"sort"
+
+	"github.com/spacemeshos/go-scale"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+const (
+	// LeafSize is the maximal number of ids that server sends for the range instead of splitting it.
+	LeafSize = 256
+	// SplitParts is the number of ranges that server splits the differing range into.
+	SplitParts = 16
+	// MaxRounds is the maximal number of round trips in the session.
+	MaxRounds = 16
+)
+
+var (
+	maxRanges = scale.MustGetMaxElements[Message]("Ranges")
+	maxItems  = scale.MustGetMaxElements[Message]("Items")
+)
+
+// ErrTooLarge is returned if the response doesn't fit into a single message.
+var ErrTooLarge = errors.New("rangesync: response is too large")
+
+// Set is an immutable sorted set of ids.
+type Set struct {
+	ids []types.Hash32
+}
+
+// NewSet creates a set from ids. Ids are copied, so that the caller can modify them afterwards.
+func NewSet(ids []types.Hash32) *Set {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, compare)
+	return &Set{ids: slices.Compact(sorted)}
+}
+
+func compare(a, b types.Hash32) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// Len returns the number of ids in the set.
+func (s *Set) Len() int {
+	return len(s.ids)
+}
+
+// Has returns true if the id is in the set.
+func (s *Set) Has(id types.Hash32) bool {
+	_, found := slices.BinarySearchFunc(s.ids, id, compare)
+	return found
+}
+
+// Fingerprint returns the fingerprint of the whole set.
+func (s *Set) Fingerprint() Fingerprint {
+	return fingerprint(s.ids)
+}
+
+// Initial returns the message that starts the session.
+func (s *Set) Initial() *Message {
+	return &Message{Ranges: []Range{{Fingerprint: s.Fingerprint()}}}
+}
+
+func (s *Set) inRange(r *Range) []types.Hash32 {
+	lo := sort.Search(len(s.ids), func(i int) bool {
+		return compare(s.ids[i], r.Lo) >= 0
+	})
+	hi := len(s.ids)
+	if r.Hi != (types.Hash32{}) {
+		hi = sort.Search(len(s.ids), func(i int) bool {
+			return compare(s.ids[i], r.Hi) >= 0
+		})
+	}
+	if hi < lo {
+		return nil
+	}
+	return s.ids[lo:hi]
+}
+
+func fingerprint(ids []types.Hash32) Fingerprint {
+	fp := Fingerprint{Count: uint32(len(ids))}
+	for _, id := range ids {
+		for i := range fp.Xor {
+			fp.Xor[i] ^= id[i]
+		}
+	}
+	return fp
+}
+
+// Respond returns the server response to the client message.
+//
+// Ranges with equal fingerprints are skipped. Ids of the range are sent as is if the client has
+// nothing in the range or there are at most LeafSize of them, otherwise the range is split into
+// SplitParts smaller ranges.
+func (s *Set) Respond(req *Message) (*Message, error) {
+	resp := &Message{}
+	for i := range req.Ranges {
+		r := &req.Ranges[i]
+		ids := s.inRange(r)
+		if fingerprint(ids) == r.Fingerprint {
+			continue
+		}
+		if len(ids) <= LeafSize || (r.Fingerprint.Count == 0 && len(resp.Items)+len(ids) <= int(maxItems)) {
+			resp.Items = append(resp.Items, ids...)
+			continue
+		}
+		for part := 0; part < SplitParts; part++ {
+			start, end := part*len(ids)/SplitParts, (part+1)*len(ids)/SplitParts
+			if start == end {
+				continue
+			}
+			sub := Range{Lo: ids[start], Hi: r.Hi, Fingerprint: fingerprint(ids[start:end])}
+			if start == 0 {
+				sub.Lo = r.Lo
+			}
+			if end != len(ids) {
+				sub.Hi = ids[end]
+			}
+			resp.Ranges = append(resp.Ranges, sub)
+		}
+	}
+	if len(resp.Ranges) > int(maxRanges) || len(resp.Items) > int(maxItems) {
+		return nil, ErrTooLarge
+	}
+	return resp, nil
+}
+
+// Next handles the server response on the client. It reports ids that the server has and that are
+// missing in the set, and returns the message with ranges that still differ.
+func (s *Set) Next(resp *Message, missing func(types.Hash32)) *Message {
+	for _, id := range resp.Items {
+		if !s.Has(id) {
+			missing(id)
+		}
+	}
+	next := &Message{}
+	for i := range resp.Ranges {
+		r := &resp.Ranges[i]
+		if r.Fingerprint.Count == 0 {
+			continue
+		}
+		fp := fingerprint(s.inRange(r))
+		if fp == r.Fingerprint {
+			continue
+		}
+		next.Ranges = append(next.Ranges, Range{Lo: r.Lo, Hi: r.Hi, Fingerprint: fp})
+	}
+	return next
+}
@@ -0,0 +1,178 @@
+package rangesync
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+func genIDs(rng *rand.Rand, n int) []types.Hash32 {
+	ids := make([]types.Hash32, n)
+	for i := range ids {
+		for j := 0; j < len(ids[i]); j += 8 {
+			v := rng.Uint64()
+			for k := 0; k < 8; k++ {
+				ids[i][j+k] = byte(v >> (8 * k))
+			}
+		}
+	}
+	return ids
+}
+
+func sorted(ids []types.Hash32) []types.Hash32 {
+	ids = slices.Clone(ids)
+	slices.SortFunc(ids, compare)
+	return ids
+}
+
+func runSession(tb testing.TB, local, remote []types.Hash32) ([]types.Hash32, int) {
+	tb.Helper()
+	client, srv := net.Pipe()
+	tb.Cleanup(func() {
+		client.Close()
+		srv.Close()
+	})
+	localSet := NewSet(local)
+	remoteSet := NewSet(remote)
+
+	var eg errgroup.Group
+	eg.Go(func() error {
+		defer srv.Close()
+		return Serve(srv, remoteSet, localSet.Initial())
+	})
+	missing, n, err := Sync(client, localSet)
+	require.NoError(tb, err)
+	require.NoError(tb, eg.Wait())
+	return missing, n
+}
+
+func TestSession(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	common := genIDs(rng, 10000)
+	onlyRemote := genIDs(rng, 100)
+	onlyLocal := genIDs(rng, 50)
+
+	for _, tc := range []struct {
+		desc          string
+		local, remote []types.Hash32
+		expect        []types.Hash32
+	}{
+		{desc: "empty"},
+		{desc: "equal", local: common, remote: common},
+		{desc: "empty local", remote: common, expect: common},
+		{desc: "empty remote", local: common},
+		{
+			desc:   "missing locally",
+			local:  common,
+			remote: append(slices.Clone(common), onlyRemote...),
+			expect: onlyRemote,
+		},
+		{
+			desc:   "missing remotely",
+			local:  append(slices.Clone(common), onlyLocal...),
+			remote: common,
+		},
+		{
+			desc:   "both",
+			local:  append(slices.Clone(common), onlyLocal...),
+			remote: append(slices.Clone(common), onlyRemote...),
+			expect: onlyRemote,
+		},
+		{desc: "disjoint", local: onlyLocal, remote: onlyRemote, expect: onlyRemote},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			missing, _ := runSession(t, tc.local, tc.remote)
+			require.Equal(t, sorted(tc.expect), sorted(missing))
+		})
+	}
+}
+
+func TestSessionTransfersDifferences(t *testing.T) {
+	rng := rand.New(rand.NewPCG(2, 2))
+	common := genIDs(rng, 100000)
+	onlyRemote := genIDs(rng, 10)
+
+	missing, n := runSession(t, common, append(slices.Clone(common), onlyRemote...))
+	require.Equal(t, sorted(onlyRemote), sorted(missing))
+	require.Less(t, n, len(common)*types.Hash32Length/10)
+}
+
+func TestRespond(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 3))
+	set := NewSet(genIDs(rng, 10*LeafSize))
+
+	t.Run("equal", func(t *testing.T) {
+		resp, err := set.Respond(set.Initial())
+		require.NoError(t, err)
+		require.Empty(t, resp.Ranges)
+		require.Empty(t, resp.Items)
+	})
+	t.Run("empty client", func(t *testing.T) {
+		resp, err := set.Respond(NewSet(nil).Initial())
+		require.NoError(t, err)
+		require.Empty(t, resp.Ranges)
+		require.Equal(t, set.ids, resp.Items)
+	})
+	t.Run("split", func(t *testing.T) {
+		resp, err := set.Respond(NewSet(set.ids[1:]).Initial())
+		require.NoError(t, err)
+		require.Empty(t, resp.Items)
+		require.Len(t, resp.Ranges, SplitParts)
+		require.Equal(t, types.Hash32{}, resp.Ranges[0].Lo)
+		require.Equal(t, types.Hash32{}, resp.Ranges[SplitParts-1].Hi)
+		var total uint32
+		for i, r := range resp.Ranges {
+			total += r.Fingerprint.Count
+			require.Equal(t, fingerprint(set.inRange(&r)), r.Fingerprint)
+			if i > 0 {
+				require.Equal(t, resp.Ranges[i-1].Hi, r.Lo)
+			}
+		}
+		require.EqualValues(t, set.Len(), total)
+	})
+}
+
+func TestSessionTooManyRounds(t *testing.T) {
+	client, srv := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+		srv.Close()
+	})
+	set := NewSet(genIDs(rand.New(rand.NewPCG(4, 4)), 1))
+	go func() {
+		// misbehaving server splits ranges forever
+		for {
+			resp := &Message{Ranges: []Range{{Fingerprint: Fingerprint{Count: 2}}}}
+			if err := writeMessage(srv, resp); err != nil {
+				return
+			}
+			var req Message
+			if _, err := codec.DecodeFrom(srv, &req); err != nil {
+				return
+			}
+		}
+	}()
+	_, _, err := Sync(client, set)
+	require.ErrorIs(t, err, errTooManyRounds)
+}
+
+func TestNewSet(t *testing.T) {
+	ids := genIDs(rand.New(rand.NewPCG(5, 5)), 10)
+	set := NewSet(append(slices.Clone(ids), ids...))
+	require.Equal(t, len(ids), set.Len())
+	require.True(t, slices.IsSortedFunc(set.ids, func(a, b types.Hash32) int {
+		return bytes.Compare(a[:], b[:])
+	}))
+	for _, id := range ids {
+		require.True(t, set.Has(id))
+	}
+	require.False(t, set.Has(types.Hash32{}))
+}
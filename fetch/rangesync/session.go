@@ -0,0 +1,103 @@
+package rangesync
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/p2p/server"
+)
+
+var errTooManyRounds = errors.New("rangesync: too many rounds")
+
+// Serve runs the server side of the session on the stream, starting with the message that was
+// received in the request. Every response is written as server.Response.
+func Serve(s io.ReadWriter, set *Set, req *Message) error {
+	for round := 0; ; round++ {
+		if round == MaxRounds {
+			return writeError(s, errTooManyRounds)
+		}
+		resp, err := set.Respond(req)
+		if err != nil {
+			return writeError(s, err)
+		}
+		if err := writeMessage(s, resp); err != nil {
+			return err
+		}
+		if len(resp.Ranges) == 0 {
+			return nil
+		}
+		req = &Message{}
+		if _, err := codec.DecodeFrom(s, req); err != nil {
+			return fmt.Errorf("read request: %w", err)
+		}
+		if len(req.Ranges) == 0 {
+			return nil
+		}
+	}
+}
+
+func writeError(s io.Writer, err error) error {
+	if werr := server.WriteErrorResponse(s, err); werr != nil {
+		return werr
+	}
+	return err
+}
+
+func writeMessage(s io.Writer, msg *Message) error {
+	buf, err := codec.Encode(msg)
+	if err != nil {
+		return err
+	}
+	wr := bufio.NewWriter(s)
+	if _, err := codec.EncodeTo(wr, &server.Response{Data: buf}); err != nil {
+		return err
+	}
+	return wr.Flush()
+}
+
+// Sync runs the client side of the session on the stream, after the message returned by
+// Set.Initial was sent in the request. It returns ids that the server has and that are missing
+// in the set, and the number of bytes that were read from the stream.
+func Sync(s io.ReadWriter, set *Set) ([]types.Hash32, int, error) {
+	var (
+		missing []types.Hash32
+		seen    = map[types.Hash32]struct{}{}
+		total   int
+	)
+	for round := 0; ; round++ {
+		var resp Message
+		n, err := server.ReadResponse(s, func(uint32) (int, error) {
+			return codec.DecodeFrom(s, &resp)
+		})
+		total += n
+		if err != nil {
+			return nil, total, err
+		}
+		next := set.Next(&resp, func(id types.Hash32) {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				missing = append(missing, id)
+			}
+		})
+		if len(resp.Ranges) == 0 {
+			return missing, total, nil
+		}
+		if len(next.Ranges) != 0 && round+1 == MaxRounds {
+			return nil, total, errTooManyRounds
+		}
+		wr := bufio.NewWriter(s)
+		if _, err := codec.EncodeTo(wr, next); err != nil {
+			return nil, total, err
+		}
+		if err := wr.Flush(); err != nil {
+			return nil, total, err
+		}
+		if len(next.Ranges) == 0 {
+			return missing, total, nil
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package rangesync
+
+import (
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+//go:generate scalegen
+
+// Fingerprint summarizes ids in the range. Two sets that have equal fingerprints for the range
+// are considered equal in that range.
+type Fingerprint struct {
+	// Xor of all ids in the range.
+	Xor types.Hash32
+	// Count of ids in the range.
+	Count uint32
+}
+
+// Range is a half-open interval [Lo, Hi) of ids together with the fingerprint of the sender.
+// Zero Hi is the end of the id space, range with both bounds zero covers the whole set.
+type Range struct {
+	Lo          types.Hash32
+	Hi          types.Hash32
+	Fingerprint Fingerprint
+}
+
+// Message is exchanged by both sides of the session.
+//
+// Client sends ranges with its own fingerprints. Server replies with ids in the ranges that
+// differ and are small enough, and splits the rest into smaller ranges with server fingerprints.
+type Message struct {
+	Ranges []Range        `scale:"max=262144"`  // in line with the number of leaves for 3.5 mio ids
+	Items  []types.Hash32 `scale:"max=3500000"` // in line with `fetch.EpochData.AtxIDs`
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/fetch/rangesync"
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/p2p"
 )
@@ -128,9 +129,28 @@ type EpochData struct {
 	// - the size of `Rewards` in the type `InnerBlock` in common/types/block.go
 	// - the size of `Ballots` in the type `LayerData` below
 	// - the size of `Proposals` in the type `Value` in hare3/types.go
+	// - the size of `Items` in the type `Message` in fetch/rangesync/types.go
 	AtxIDs []types.ATXID `scale:"max=3500000"`
 }
 
+// RangeSyncSet is the set of IDs that is reconciled with range sync.
+type RangeSyncSet uint8
+
+const (
+	// RangeSyncEpochAtxs is the set of ATX IDs published in the epoch.
+	RangeSyncEpochAtxs RangeSyncSet = iota
+	// RangeSyncMalicious is the set of IDs of malicious identities.
+	RangeSyncMalicious
+)
+
+// RangeSyncRequest starts the range sync session for the set.
+type RangeSyncRequest struct {
+	Set RangeSyncSet
+	// Epoch is used only for RangeSyncEpochAtxs.
+	Epoch   types.EpochID
+	Message rangesync.Message
+}
+
 // LayerData is the data response for a given layer ID.
 type LayerData struct {
 	// Ballots contains the ballots for the given layer.
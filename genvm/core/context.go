@@ -41,6 +41,7 @@ type Context struct {
 
 	touched []Address
 	changed map[Address]*Account
+	events  []Event
 }
 
 // Principal returns address of the account that signed transaction.
@@ -96,6 +97,7 @@ func (c *Context) Spawn(args scale.Encodable) error {
 	account.State = buf.Bytes()
 	account.TemplateAddress = &c.Header.TemplateAddress
 	c.change(account)
+	c.Emit(Event{Kind: EventSpawn, Account: account.Address, Template: c.Header.TemplateAddress})
 	return nil
 }
 
@@ -172,9 +174,24 @@ func (c *Context) Relay(remoteTemplate, address Address, call func(Host) error)
 	}
 	account.State = buf.Bytes()
 	c.change(account)
+	c.Emit(Event{Kind: EventRelay, Account: address, Template: remoteTemplate})
 	return nil
 }
 
+// Emit event. Principal is always set to the principal of the transaction.
+func (c *Context) Emit(ev Event) {
+	ev.Principal = c.Principal()
+	if ev.Account == (Address{}) {
+		ev.Account = c.Principal()
+	}
+	c.events = append(c.events, ev)
+}
+
+// Events emitted during execution.
+func (c *Context) Events() []Event {
+	return c.events
+}
+
 // Consume gas from the account after validation passes.
 func (c *Context) Consume(gas uint64) (err error) {
 	amount := gas * c.Header.GasPrice
@@ -277,6 +294,14 @@ func (r *RemoteContext) Handler() Handler {
 	return r.handler
 }
 
+// Emit event on behalf of the remote account.
+func (r *RemoteContext) Emit(ev Event) {
+	if ev.Account == (Address{}) {
+		ev.Account = r.remote.Address
+	}
+	r.Context.Emit(ev)
+}
+
 // Transfer ...
 func (r *RemoteContext) Transfer(to Address, amount uint64) error {
 	if err := r.transfer(r.remote, to, amount, amount); err != nil {
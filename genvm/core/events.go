@@ -0,0 +1,46 @@
+package core
+
+// EventKind is a kind of the event emitted during transaction execution.
+type EventKind uint8
+
+const (
+	// EventSpawn is emitted when an account is spawned.
+	EventSpawn EventKind = iota + 1
+	// EventTransfer is emitted when coins are transferred from one account to another.
+	EventTransfer
+	// EventRelay is emitted when a call is relayed to the remote account.
+	EventRelay
+	// EventDrainVault is emitted when vesting account drains coins from a vault.
+	EventDrainVault
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventSpawn:
+		return "spawn"
+	case EventTransfer:
+		return "transfer"
+	case EventRelay:
+		return "relay"
+	case EventDrainVault:
+		return "drain_vault"
+	}
+	return "unknown"
+}
+
+// Event is emitted by the host and templates during transaction execution.
+// Events emitted by the failed transaction are discarded.
+type Event struct {
+	Kind EventKind
+	// Principal is an account that submitted transaction, it is set by the host.
+	Principal Address
+	// Account is an account the event refers to. Spawned account, account
+	// that transferred coins, relay target or a drained vault.
+	// If not set by template it is set by the host to the account that is executing the call.
+	Account Address
+	// Destination is a receiver of the transferred coins.
+	Destination Address
+	// Template is a template of the spawned account or a relay target.
+	Template Address
+	Amount   uint64
+}
@@ -105,6 +105,7 @@ type Host interface {
 	Spawn(scale.Encodable) error
 	Transfer(Address, uint64) error
 	Relay(expectedTemplate, address Address, call func(Host) error) error
+	Emit(Event)
 
 	Principal() Address
 	Handler() Handler
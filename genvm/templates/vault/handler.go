@@ -69,7 +69,11 @@ func (h *handler) Exec(host core.Host, method uint8, args scale.Encodable) error
 		return fmt.Errorf("%w: unknown method %d", core.ErrMalformed, method)
 	}
 	spend := args.(*SpendArguments)
-	return host.Template().(*Vault).Spend(host, spend.Destination, spend.Amount)
+	if err := host.Template().(*Vault).Spend(host, spend.Destination, spend.Amount); err != nil {
+		return err
+	}
+	host.Emit(core.Event{Kind: core.EventTransfer, Destination: spend.Destination, Amount: spend.Amount})
+	return nil
 }
 
 // Args ...
@@ -57,9 +57,19 @@ func (h *handler) Load(state []byte) (core.Template, error) {
 func (h *handler) Exec(host core.Host, method uint8, args scale.Encodable) error {
 	if method == MethodDrainVault {
 		drain := args.(*DrainVaultArguments)
-		return host.Relay(vault.TemplateAddress, drain.Vault, func(host core.Host) error {
+		err := host.Relay(vault.TemplateAddress, drain.Vault, func(host core.Host) error {
 			return host.Handler().Exec(host, core.MethodSpend, &drain.SpendArguments)
 		})
+		if err != nil {
+			return err
+		}
+		host.Emit(core.Event{
+			Kind:        core.EventDrainVault,
+			Account:     drain.Vault,
+			Destination: drain.Destination,
+			Amount:      drain.Amount,
+		})
+		return nil
 	}
 	return h.multisig.Exec(host, method, args)
 }
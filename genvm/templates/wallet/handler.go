@@ -62,9 +62,11 @@ func (*handler) Exec(host core.Host, method uint8, args scale.Encodable) error {
 			return err
 		}
 	case core.MethodSpend:
-		if err := host.Template().(*Wallet).Spend(host, args.(*SpendArguments)); err != nil {
+		spend := args.(*SpendArguments)
+		if err := host.Template().(*Wallet).Spend(host, spend); err != nil {
 			return err
 		}
+		host.Emit(core.Event{Kind: core.EventTransfer, Destination: spend.Destination, Amount: spend.Amount})
 	default:
 		return fmt.Errorf("%w: unknown method %d", core.ErrMalformed, method)
 	}
@@ -144,6 +144,7 @@ func (v *VM) Revert(lid types.LayerID) error {
 		return err
 	}
 	v.logger.With().Info("vm reverted to layer", lid)
+	events.ReportAppEvent(events.AppEvent{Layer: lid, Revert: true})
 	return nil
 }
 
@@ -202,7 +203,7 @@ func (v *VM) Apply(
 	blockDurationWait.Observe(float64(time.Since(t1)))
 
 	ss := core.NewStagedCache(core.DBLoader{Executor: v.db})
-	results, skipped, fees, err := v.execute(lctx, ss, txs)
+	results, skipped, fees, appEvents, err := v.execute(lctx, ss, txs)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -264,6 +265,9 @@ func (v *VM) Apply(
 	for _, reward := range rewardsResult {
 		events.ReportRewardReceived(reward)
 	}
+	for _, ev := range appEvents {
+		events.ReportAppEvent(ev)
+	}
 
 	blockDurationPersist.Observe(float64(time.Since(t3)))
 	blockDuration.Observe(float64(time.Since(t1)))
@@ -283,13 +287,14 @@ func (v *VM) execute(
 	lctx ApplyContext,
 	ss *core.StagedCache,
 	txs []types.Transaction,
-) ([]types.TransactionWithResult, []types.Transaction, uint64, error) {
+) ([]types.TransactionWithResult, []types.Transaction, uint64, []events.AppEvent, error) {
 	var (
 		rd          bytes.Reader
 		decoder     = scale.NewDecoder(&rd)
 		fees        uint64
 		ineffective []types.Transaction
 		executed    []types.TransactionWithResult
+		appEvents   []events.AppEvent
 		limit       = v.cfg.GasLimit
 	)
 	for i, tx := range txs {
@@ -393,7 +398,7 @@ func (v *VM) execute(
 				log.Err(err),
 			)
 			if errors.Is(err, core.ErrInternal) {
-				return nil, nil, 0, err
+				return nil, nil, 0, nil, err
 			}
 		}
 		transactionDurationExecute.Observe(float64(time.Since(t2)))
@@ -409,9 +414,24 @@ func (v *VM) execute(
 		rst.Fee = ctx.Fee()
 		rst.Addresses = ctx.Updated()
 
+		if rst.Status == types.TransactionSuccess {
+			for _, ev := range ctx.Events() {
+				appEvents = append(appEvents, events.AppEvent{
+					Layer:       lctx.Layer,
+					Transaction: rst.ID,
+					Kind:        ev.Kind.String(),
+					Principal:   ev.Principal,
+					Account:     ev.Account,
+					Destination: ev.Destination,
+					Template:    ev.Template,
+					Amount:      ev.Amount,
+				})
+			}
+		}
+
 		err = ctx.Apply(ss)
 		if err != nil {
-			return nil, nil, 0, fmt.Errorf("%w: %w", core.ErrInternal, err)
+			return nil, nil, 0, nil, fmt.Errorf("%w: %w", core.ErrInternal, err)
 		}
 		fees += ctx.Fee()
 		limit -= ctx.Consumed()
@@ -419,7 +439,20 @@ func (v *VM) execute(
 		executed = append(executed, rst)
 		transactionDuration.Observe(float64(time.Since(t1)))
 	}
-	return executed, ineffective, fees, nil
+	return executed, ineffective, fees, appEvents, nil
+}
+
+// StateHash computes the hash of the accounts updated in the layer in the same way as Apply.
+// Accounts must be in the order of updates and have Layer set to the applied layer.
+func StateHash(accounts []*types.Account) types.Hash32 {
+	hasher := hash.New()
+	encoder := scale.NewEncoder(hasher)
+	for _, account := range accounts {
+		account.EncodeScale(encoder)
+	}
+	var rst types.Hash32
+	hasher.Sum(rst[:0])
+	return rst
 }
 
 // Request used to implement 2-step validation flow.
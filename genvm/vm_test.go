@@ -22,6 +22,7 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/genvm/core"
 	"github.com/spacemeshos/go-spacemesh/genvm/sdk"
 	sdkmultisig "github.com/spacemeshos/go-spacemesh/genvm/sdk/multisig"
@@ -2023,6 +2024,74 @@ func TestVestingWithVault(t *testing.T) {
 	runTestCases(t, tcs, genTester)
 }
 
+func TestAppEvents(t *testing.T) {
+	events.CloseEventReporter()
+	events.InitializeReporter()
+	t.Cleanup(events.CloseEventReporter)
+	sub := events.SubscribeAppEvents()
+
+	genesis := types.GetEffectiveGenesis()
+	tt := newTester(t).
+		addVesting(1, 1, 2).
+		addVault(1, 1000, 0, genesis, genesis.Add(1)).
+		addSingleSig(1).
+		applyGenesis()
+	var (
+		vestingAddr = tt.accounts[0].getAddress()
+		vaultAddr   = tt.accounts[1].getAddress()
+		walletAddr  = tt.accounts[2].getAddress()
+	)
+
+	_, results, err := tt.Apply(testContext(genesis),
+		notVerified(tt.selfSpawn(0), tt.spawn(0, 1), tt.selfSpawn(2)), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	_, results, err = tt.Apply(testContext(genesis.Add(1)),
+		notVerified(
+			(&drainVault{owner: 0, vault: 1, recipient: 2, amount: 100}).gen(tt),
+			tt.spend(2, 0, 10),
+			tt.spend(2, 0, math.MaxUint64),
+		), nil)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, types.TransactionFailure, results[2].Status)
+
+	expected := []events.AppEvent{
+		{Kind: "spawn", Principal: vestingAddr, Account: vestingAddr, Template: vesting.TemplateAddress},
+		{Kind: "spawn", Principal: vestingAddr, Account: vaultAddr, Template: vault.TemplateAddress},
+		{Kind: "spawn", Principal: walletAddr, Account: walletAddr, Template: wallet.TemplateAddress},
+		{Kind: "transfer", Principal: vestingAddr, Account: vaultAddr, Destination: walletAddr, Amount: 100},
+		{Kind: "relay", Principal: vestingAddr, Account: vaultAddr, Template: vault.TemplateAddress},
+		{Kind: "drain_vault", Principal: vestingAddr, Account: vaultAddr, Destination: walletAddr, Amount: 100},
+		{Kind: "transfer", Principal: walletAddr, Account: walletAddr, Destination: vestingAddr, Amount: 10},
+	}
+	for _, exp := range expected {
+		select {
+		case ev := <-sub.Out():
+			received := ev.(events.AppEvent)
+			require.NotEqual(t, types.TransactionID{}, received.Transaction)
+			received.Layer = 0
+			received.Transaction = types.TransactionID{}
+			require.Equal(t, exp, received)
+		case <-time.After(time.Second):
+			require.FailNow(t, "timed out waiting for app event", exp.Kind)
+		}
+	}
+	select {
+	case ev := <-sub.Out():
+		require.FailNow(t, "unexpected event", ev)
+	default:
+	}
+
+	require.NoError(t, tt.Revert(genesis))
+	select {
+	case ev := <-sub.Out():
+		require.Equal(t, events.AppEvent{Layer: genesis, Revert: true}, ev)
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for revert event")
+	}
+}
+
 func TestValidation(t *testing.T) {
 	t.Parallel()
 	t.Run("SingleSig", func(t *testing.T) {
@@ -2370,6 +2439,10 @@ func TestStateHashFromUpdatedAccounts(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, statehash)
 
+	updated, err := accounts.InLayer(tt.db, lid)
+	require.NoError(t, err)
+	require.Equal(t, expected, StateHash(updated))
+
 	root, err = tt.GetStateRoot()
 	require.NoError(t, err)
 	require.Equal(t, expected, root)
@@ -0,0 +1,348 @@
+package hare3
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+type traceType string
+
+const (
+	traceConfig   traceType = "config"
+	traceStart    traceType = "start"
+	traceStop     traceType = "stop"
+	traceActive   traceType = "active"
+	traceSent     traceType = "sent"
+	traceReceived traceType = "received"
+	traceInitial  traceType = "initial"
+	traceInput    traceType = "input"
+	traceNext     traceType = "next"
+)
+
+// traceEvent is a single line in the trace file.
+//
+// Messages are stored scale encoded, so that they can be decoded into exactly the same
+// values during replay.
+type traceEvent struct {
+	Type traceType `json:"t"`
+	// Offset is a wall clock time since the tracer was created.
+	Offset        time.Duration       `json:"offset"`
+	Layer         types.LayerID       `json:"layer,omitempty"`
+	IterRound     *IterRound          `json:"iter_round,omitempty"`
+	Committee     uint16              `json:"committee,omitempty"`
+	Threshold     uint16              `json:"threshold,omitempty"`
+	Eligibilities []*traceEligibility `json:"eligibilities,omitempty"`
+	Message       []byte              `json:"msg,omitempty"`
+	Proposals     []types.Hash20      `json:"proposals,omitempty"`
+	Grade         uint8               `json:"grade,omitempty"`
+	Malicious     bool                `json:"malicious,omitempty"`
+	Hash          *types.Hash32       `json:"hash,omitempty"`
+	Output        *traceOutput        `json:"output,omitempty"`
+}
+
+type traceEligibility struct {
+	Proof []byte `json:"proof"`
+	Count uint16 `json:"count"`
+}
+
+type traceOutput struct {
+	Iter       uint8           `json:"iter"`
+	Round      Round           `json:"round"`
+	Coin       *bool           `json:"coin,omitempty"`
+	Result     *[]types.Hash20 `json:"result,omitempty"`
+	Terminated bool            `json:"terminated,omitempty"`
+	Message    []byte          `json:"msg,omitempty"`
+}
+
+func toHashes(proposals []types.ProposalID) []types.Hash20 {
+	if proposals == nil {
+		return nil
+	}
+	rst := make([]types.Hash20, len(proposals))
+	for i := range proposals {
+		rst[i] = types.Hash20(proposals[i])
+	}
+	return rst
+}
+
+func fromHashes(hashes []types.Hash20) []types.ProposalID {
+	if hashes == nil {
+		return nil
+	}
+	rst := make([]types.ProposalID, len(hashes))
+	for i := range hashes {
+		rst[i] = types.ProposalID(hashes[i])
+	}
+	return rst
+}
+
+func newTraceOutput(ir IterRound, out *output) *traceOutput {
+	rst := &traceOutput{
+		Iter:       ir.Iter,
+		Round:      ir.Round,
+		Coin:       out.coin,
+		Terminated: out.terminated,
+	}
+	if out.result != nil {
+		result := toHashes(out.result)
+		rst.Result = &result
+	}
+	if out.message != nil {
+		rst.Message = codec.MustEncode(out.message)
+	}
+	return rst
+}
+
+// protocolTracer is notified about every call that changes protocol state.
+// It is called while protocol lock is held, therefore events are recorded
+// in exactly the same order as they were applied.
+//
+// onActive and onSent are called by the session when eligibilities are computed and
+// messages are sent, so that they are recorded with the layer of the session.
+type protocolTracer interface {
+	onInitial([]types.ProposalID)
+	onInput(*input)
+	onNext(IterRound, *output)
+	onActive(IterRound, []*types.HareEligibility)
+	onSent(*Message)
+}
+
+// flusher is implemented by tracers that buffer events, hare flushes them after every session.
+type flusher interface {
+	Flush() error
+}
+
+// sessionTracer is implemented by tracers that want to observe protocol state
+// transitions in addition to the events exposed by Tracer.
+type sessionTracer interface {
+	session(types.LayerID) protocolTracer
+}
+
+var (
+	_ Tracer        = (*FileTracer)(nil)
+	_ sessionTracer = (*FileTracer)(nil)
+	_ flusher       = (*FileTracer)(nil)
+)
+
+// FileTracer writes hare events as json lines into a file.
+//
+// In addition to the events exposed by Tracer it records every input to the protocol
+// with the grade assigned by the node, and every protocol output.
+// Recorded trace can be replayed with RunTrace.
+type FileTracer struct {
+	path    string
+	maxSize int64
+	config  traceEvent
+
+	mu     sync.Mutex
+	start  time.Time
+	active map[types.LayerID]struct{}
+	f      *os.File
+	w      *bufio.Writer
+	enc    *json.Encoder
+	err    error
+}
+
+// NewFileTracer opens a file at path in append mode and writes configuration header into it.
+//
+// Trace recorded before restart is preserved, incomplete record left after crash is discarded.
+// Once file grows larger than cfg.TraceMaxSize it is rotated when no sessions are in progress,
+// only one previous file is kept with .1 suffix.
+func NewFileTracer(path string, cfg Config) (*FileTracer, error) {
+	t := &FileTracer{
+		path:    path,
+		maxSize: int64(cfg.TraceMaxSize),
+		config: traceEvent{
+			Type:      traceConfig,
+			Committee: cfg.Committee,
+			Threshold: cfg.Committee/2 + 1,
+		},
+		start:  time.Now(),
+		active: map[types.LayerID]struct{}{},
+	}
+	if err := t.open(); err != nil {
+		return nil, err
+	}
+	t.write(&t.config)
+	return t, t.Flush()
+}
+
+func (t *FileTracer) open() error {
+	f, err := os.OpenFile(t.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("open trace file %s: %w", t.path, err)
+	}
+	if err := truncatePartial(f); err != nil {
+		f.Close()
+		return fmt.Errorf("truncate incomplete record in %s: %w", t.path, err)
+	}
+	t.f = f
+	t.w = bufio.NewWriterSize(f, 1<<16)
+	t.enc = json.NewEncoder(t.w)
+	return nil
+}
+
+// truncatePartial truncates file after the last new line.
+func truncatePartial(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 4096)
+	for end := info.Size(); end > 0; {
+		start := max(end-int64(len(buf)), 0)
+		n, err := f.ReadAt(buf[:end-start], start)
+		if err != nil {
+			return err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			if start+int64(i)+1 == info.Size() {
+				return nil
+			}
+			return f.Truncate(start + int64(i) + 1)
+		}
+		end = start
+	}
+	return f.Truncate(0)
+}
+
+func (t *FileTracer) write(ev *traceEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return
+	}
+	ev.Offset = time.Since(t.start)
+	t.err = t.enc.Encode(ev)
+}
+
+// Flush writes buffered events to the file and rotates it if it exceeds the size limit.
+// Errors are sticky, once writing failed tracer stops recording.
+func (t *FileTracer) Flush() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return t.err
+	}
+	if t.err = t.w.Flush(); t.err != nil {
+		return t.err
+	}
+	if t.maxSize == 0 || len(t.active) > 0 {
+		return nil
+	}
+	info, err := t.f.Stat()
+	if err != nil {
+		t.err = err
+		return t.err
+	}
+	if info.Size() > t.maxSize {
+		t.err = t.rotate()
+	}
+	return t.err
+}
+
+func (t *FileTracer) rotate() error {
+	if err := t.f.Close(); err != nil {
+		return fmt.Errorf("close trace file: %w", err)
+	}
+	if err := os.Rename(t.path, t.path+".1"); err != nil {
+		return fmt.Errorf("rotate trace file: %w", err)
+	}
+	if err := t.open(); err != nil {
+		return err
+	}
+	t.config.Offset = time.Since(t.start)
+	if err := t.enc.Encode(&t.config); err != nil {
+		return err
+	}
+	return t.w.Flush()
+}
+
+func (t *FileTracer) OnStart(layer types.LayerID) {
+	t.mu.Lock()
+	t.active[layer] = struct{}{}
+	t.mu.Unlock()
+	t.write(&traceEvent{Type: traceStart, Layer: layer})
+}
+
+// OnStop records the end of the session. Hare flushes the tracer after the session is stopped.
+func (t *FileTracer) OnStop(layer types.LayerID) {
+	t.write(&traceEvent{Type: traceStop, Layer: layer})
+	t.mu.Lock()
+	delete(t.active, layer)
+	t.mu.Unlock()
+}
+
+// OnActive is a no-op, eligibilities are recorded with the layer and the round by the session tracer.
+func (t *FileTracer) OnActive([]*types.HareEligibility) {}
+
+// OnMessageSent is a no-op, sent messages are recorded with the layer by the session tracer.
+func (t *FileTracer) OnMessageSent(*Message) {}
+
+func (t *FileTracer) OnMessageReceived(msg *Message) {
+	t.write(&traceEvent{Type: traceReceived, Layer: msg.Layer, Message: codec.MustEncode(msg)})
+}
+
+func (t *FileTracer) session(layer types.LayerID) protocolTracer {
+	return &layerTracer{layer: layer, tracer: t}
+}
+
+// Close flushes buffered events and closes the file.
+func (t *FileTracer) Close() error {
+	err := t.Flush()
+	if cerr := t.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type layerTracer struct {
+	layer  types.LayerID
+	tracer *FileTracer
+}
+
+func (t *layerTracer) onInitial(proposals []types.ProposalID) {
+	t.tracer.write(&traceEvent{Type: traceInitial, Layer: t.layer, Proposals: toHashes(proposals)})
+}
+
+func (t *layerTracer) onInput(in *input) {
+	t.tracer.write(&traceEvent{
+		Type:      traceInput,
+		Layer:     t.layer,
+		Message:   codec.MustEncode(in.Message),
+		Grade:     uint8(in.atxgrade),
+		Malicious: in.malicious,
+		Hash:      &in.msgHash,
+	})
+}
+
+func (t *layerTracer) onNext(ir IterRound, out *output) {
+	t.tracer.write(&traceEvent{Type: traceNext, Layer: t.layer, Output: newTraceOutput(ir, out)})
+}
+
+func (t *layerTracer) onActive(ir IterRound, vrfs []*types.HareEligibility) {
+	ev := &traceEvent{
+		Type:          traceActive,
+		Layer:         t.layer,
+		IterRound:     &ir,
+		Eligibilities: make([]*traceEligibility, len(vrfs)),
+	}
+	for i, vrf := range vrfs {
+		if vrf != nil {
+			ev.Eligibilities[i] = &traceEligibility{Proof: vrf.Proof[:], Count: vrf.Count}
+		}
+	}
+	t.tracer.write(ev)
+}
+
+func (t *layerTracer) onSent(msg *Message) {
+	t.tracer.write(&traceEvent{Type: traceSent, Layer: t.layer, Message: codec.MustEncode(msg)})
+}
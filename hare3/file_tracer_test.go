@@ -0,0 +1,190 @@
+package hare3
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+func recordTrace(t *testing.T, path string) {
+	recordTraceLayer(t, path, Config{Committee: 1}, 10)
+}
+
+func recordTraceLayer(t *testing.T, path string, cfg Config, layer types.LayerID) {
+	tracer, err := NewFileTracer(path, cfg)
+	require.NoError(t, err)
+
+	proto := newProtocol(1)
+	proto.tracer = tracer.session(layer)
+	tracer.OnStart(layer)
+	proto.OnInitial(castIds("a", "b"))
+	result := false
+	for i := 0; i < 2*int(notify); i++ {
+		proto.tracer.onActive(proto.IterRound, []*types.HareEligibility{{Count: 1}})
+		out := proto.Next()
+		if out.message != nil {
+			proto.tracer.onSent(out.message)
+		}
+		if out.result != nil {
+			result = true
+		}
+		if out.terminated {
+			break
+		}
+		if out.message == nil {
+			continue
+		}
+		// node receives its own messages through gossip
+		msg := *out.message
+		msg.Layer = layer
+		msg.Sender = types.NodeID{1}
+		msg.Eligibility.Count = 1
+		tracer.OnMessageReceived(&msg)
+		proto.OnInput(&input{Message: &msg, atxgrade: grade5, msgHash: msg.ToHash()})
+	}
+	require.True(t, result)
+	tracer.OnStop(layer)
+	require.NoError(t, tracer.Flush())
+	require.NoError(t, tracer.Close())
+}
+
+func readTrace(t *testing.T, path string) []traceEvent {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var events []traceEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var ev traceEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev))
+		events = append(events, ev)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestFileTracer(t *testing.T) {
+	t.Run("replay", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hare.trace")
+		recordTrace(t, path)
+		diffs, err := RunTrace(path)
+		require.NoError(t, err)
+		require.Empty(t, diffs)
+	})
+	t.Run("different output", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hare.trace")
+		recordTrace(t, path)
+
+		events := readTrace(t, path)
+
+		tampered := -1
+		for i := range events {
+			if events[i].Type == traceNext && events[i].Output.Result != nil {
+				events[i].Output.Result = nil
+				tampered = i
+				break
+			}
+		}
+		require.NotEqual(t, -1, tampered)
+
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		enc := json.NewEncoder(f)
+		for i := range events {
+			require.NoError(t, enc.Encode(&events[i]))
+		}
+		require.NoError(t, f.Close())
+
+		diffs, err := RunTrace(path)
+		require.NoError(t, err)
+		require.Len(t, diffs, 1)
+		require.Equal(t, events[tampered].Layer, diffs[0].Layer)
+		require.Equal(t, events[tampered].Output.Round, diffs[0].Round)
+	})
+	t.Run("events are attributed to layers", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hare.trace")
+		recordTraceLayer(t, path, Config{Committee: 1}, 10)
+		recordTraceLayer(t, path, Config{Committee: 1}, 11)
+
+		seen := map[traceType]bool{}
+		rounds := map[types.LayerID]int{}
+		for _, ev := range readTrace(t, path) {
+			seen[ev.Type] = true
+			if ev.Type == traceConfig {
+				continue
+			}
+			require.Contains(t, []types.LayerID{10, 11}, ev.Layer, ev.Type)
+			if ev.Type == traceActive {
+				require.NotNil(t, ev.IterRound)
+				require.Len(t, ev.Eligibilities, 1)
+				rounds[ev.Layer]++
+			}
+		}
+		require.True(t, seen[traceActive])
+		require.True(t, seen[traceSent])
+		require.Equal(t, rounds[10], rounds[11])
+	})
+	t.Run("appends after restart", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hare.trace")
+		recordTrace(t, path)
+		before := readTrace(t, path)
+
+		// crash in the middle of the record
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+		require.NoError(t, err)
+		_, err = f.WriteString(`{"t":"inp`)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		recordTraceLayer(t, path, Config{Committee: 1}, 11)
+		after := readTrace(t, path)
+		require.Greater(t, len(after), len(before))
+		require.Equal(t, before, after[:len(before)])
+		require.Equal(t, traceConfig, after[len(before)].Type)
+
+		diffs, err := RunTrace(path)
+		require.NoError(t, err)
+		require.Empty(t, diffs)
+	})
+	t.Run("rotate", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hare.trace")
+		cfg := Config{Committee: 1, TraceMaxSize: 200}
+		recordTraceLayer(t, path, cfg, 10)
+		recordTraceLayer(t, path, cfg, 11)
+		recordTraceLayer(t, path, cfg, 12)
+
+		for _, tc := range []struct {
+			path   string
+			layers []types.LayerID
+		}{
+			{path: path + ".1", layers: []types.LayerID{12}},
+			{path: path},
+		} {
+			events := readTrace(t, tc.path)
+			require.Equal(t, traceConfig, events[0].Type)
+			var layers []types.LayerID
+			for _, ev := range events {
+				if ev.Type == traceStart {
+					layers = append(layers, ev.Layer)
+				}
+			}
+			require.Equal(t, tc.layers, layers)
+			diffs, err := RunTrace(tc.path)
+			require.NoError(t, err)
+			require.Empty(t, diffs)
+		}
+	})
+	t.Run("missing config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "hare.trace")
+		require.NoError(t, os.WriteFile(path, []byte(`{"t":"start","layer":10}`), 0o600))
+		_, err := RunTrace(path)
+		require.ErrorContains(t, err, "config must be recorded")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"sync"
 	"time"
@@ -44,6 +45,13 @@ type Config struct {
 	// This requires additional computation and should be used for debugging only.
 	LogStats     bool   `mapstructure:"log-stats"`
 	ProtocolName string `mapstructure:"protocolname"`
+	// TraceFile if not empty enables recording of hare sessions into the file.
+	// Relative path is resolved against the data directory.
+	// Recorded trace can be replayed with cmd/haretrace.
+	TraceFile string `mapstructure:"trace-file"`
+	// TraceMaxSize is a size in bytes after which trace file is rotated, only one previous file is kept.
+	// Zero disables rotation.
+	TraceMaxSize uint64 `mapstructure:"trace-max-size"`
 }
 
 func (cfg *Config) Validate(zdist time.Duration) error {
@@ -69,6 +77,8 @@ func (cfg *Config) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
 	encoder.AddDuration("round duration", cfg.RoundDuration)
 	encoder.AddBool("log stats", cfg.LogStats)
 	encoder.AddString("p2p protocol", cfg.ProtocolName)
+	encoder.AddString("trace file", cfg.TraceFile)
+	encoder.AddUint64("trace max size", cfg.TraceMaxSize)
 	return nil
 }
 
@@ -96,6 +106,7 @@ func DefaultConfig() Config {
 		// can be bumped to 3.1 when oracle upgrades
 		ProtocolName: "/h/3.0",
 		DisableLayer: math.MaxUint32,
+		TraceMaxSize: 256 << 20,
 	}
 }
 
@@ -225,6 +236,15 @@ func (h *Hare) Register(sig *signing.EdSigner) {
 	h.signers[string(sig.NodeID().Bytes())] = sig
 }
 
+// Unregister removes the signing key of the identity. Sessions that are already running
+// keep using the key until they finish.
+func (h *Hare) Unregister(id types.NodeID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.log.Info("unregistered signing key", log.ZShortStringer("id", id))
+	delete(h.signers, string(id.Bytes()))
+}
+
 func (h *Hare) Results() <-chan ConsensusOutput {
 	return h.results
 }
@@ -354,6 +374,9 @@ func (h *Hare) onLayer(layer types.LayerID) {
 		vrfs:    make([]*types.HareEligibility, len(h.signers)),
 		proto:   newProtocol(h.config.Committee/2 + 1),
 	}
+	if tracer, ok := h.tracer.(sessionTracer); ok {
+		s.proto.tracer = tracer.session(layer)
+	}
 	h.sessions[layer] = s.proto
 	h.mu.Unlock()
 
@@ -380,6 +403,11 @@ func (h *Hare) onLayer(layer types.LayerID) {
 		h.mu.Unlock()
 		sessionTerminated.Inc()
 		h.tracer.OnStop(layer)
+		if flusher, ok := h.tracer.(flusher); ok {
+			if err := flusher.Flush(); err != nil {
+				h.log.Error("failed to flush tracer", zap.Error(err))
+			}
+		}
 		return nil
 	})
 }
@@ -397,7 +425,7 @@ func (h *Hare) run(session *session) error {
 		session.vrfs[i] = h.oracle.active(session.signers[i], session.beacon, session.lid, current)
 		active = active || session.vrfs[i] != nil
 	}
-	h.tracer.OnActive(session.vrfs)
+	h.traceActive(session, current)
 	activeLatency.Observe(time.Since(start).Seconds())
 
 	walltime := h.nodeclock.LayerToTime(session.lid).Add(h.config.PreroundDelay)
@@ -429,7 +457,7 @@ func (h *Hare) run(session *session) error {
 				session.vrfs[i] = nil
 			}
 		}
-		h.tracer.OnActive(session.vrfs)
+		h.traceActive(session, current)
 		activeLatency.Observe(time.Since(start).Seconds())
 
 		select {
@@ -467,6 +495,13 @@ func (h *Hare) run(session *session) error {
 	}
 }
 
+func (h *Hare) traceActive(session *session, ir IterRound) {
+	h.tracer.OnActive(session.vrfs)
+	if session.proto.tracer != nil {
+		session.proto.tracer.onActive(ir, session.vrfs)
+	}
+}
+
 func (h *Hare) onOutput(session *session, ir IterRound, out output) error {
 	for i, vrf := range session.vrfs {
 		if vrf == nil || out.message == nil {
@@ -476,12 +511,22 @@ func (h *Hare) onOutput(session *session, ir IterRound, out output) error {
 		msg.Layer = session.lid
 		msg.Eligibility = *vrf
 		msg.Sender = session.signers[i].NodeID()
-		msg.Signature = session.signers[i].Sign(signing.HARE, msg.ToMetadata().ToBytes())
+		signature, err := session.signers[i].SignOnce(
+			signing.HARE, session.lid, msg.IterRound.Absolute(), msg.ToMetadata().ToBytes(),
+		)
+		if err != nil {
+			h.log.Error("failed to sign", zap.Inline(&msg), zap.Error(err))
+			continue
+		}
+		msg.Signature = signature
 		if err := h.pubsub.Publish(h.ctx, h.config.ProtocolName, msg.ToBytes()); err != nil {
 			h.log.Error("failed to publish", zap.Inline(&msg), zap.Error(err))
 		}
 	}
 	h.tracer.OnMessageSent(out.message)
+	if session.proto.tracer != nil && out.message != nil {
+		session.proto.tracer.onSent(out.message)
+	}
 	h.log.Debug("round output",
 		zap.Uint32("lid", session.lid.Uint32()),
 		zap.Uint8("iter", ir.Iter), zap.Stringer("round", ir.Round),
@@ -603,6 +648,11 @@ func (h *Hare) Stop() {
 	h.eg.Wait()
 	close(h.results)
 	close(h.coins)
+	if closer, ok := h.tracer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			h.log.Error("failed to close tracer", zap.Error(err))
+		}
+	}
 	h.log.Info("stopped")
 }
 
@@ -108,17 +108,24 @@ type protocol struct {
 	hardLocked     bool
 	validProposals map[types.Hash32][]types.ProposalID // Ti
 	gossip         gossip
+	tracer         protocolTracer // optional
 }
 
 func (p *protocol) OnInitial(proposals []types.ProposalID) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if p.tracer != nil {
+		p.tracer.onInitial(proposals)
+	}
 	p.initial = proposals
 }
 
 func (p *protocol) OnInput(msg *input) (bool, *wire.HareProof) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if p.tracer != nil {
+		p.tracer.onInput(msg)
+	}
 
 	gossip, equivocation := p.gossip.receive(p.IterRound, msg)
 	if !gossip {
@@ -267,6 +274,7 @@ func (p *protocol) Next() output {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
+	ir := p.IterRound
 	out := output{}
 	p.execution(&out)
 	if p.Round >= softlock && p.coin != nil && !p.coinout {
@@ -285,6 +293,9 @@ func (p *protocol) Next() output {
 	} else {
 		p.Round++
 	}
+	if p.tracer != nil {
+		p.tracer.onNext(ir, &out)
+	}
 	return out
 }
 
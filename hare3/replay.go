@@ -0,0 +1,140 @@
+package hare3
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// TraceDiff is a difference between the output recorded in the trace
+// and the output produced by the protocol during replay.
+type TraceDiff struct {
+	Layer types.LayerID
+	IterRound
+	// Diff is a human readable difference, prefixed with - for recorded values
+	// and + for replayed values.
+	Diff string
+}
+
+func (d TraceDiff) String() string {
+	return fmt.Sprintf("layer %d iter %d round %s:\n%s", d.Layer, d.Iter, d.Round, d.Diff)
+}
+
+// RunTrace replays a trace recorded by FileTracer.
+//
+// Every session is replayed by a fresh protocol instance that receives inputs
+// with the same grades and in the same order as they were received by the node.
+// Protocol is executed without a clock, rounds are advanced at the recorded points.
+// Returns all differences between recorded and replayed outputs.
+func RunTrace(path string) ([]TraceDiff, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dec := json.NewDecoder(bufio.NewReaderSize(f, 1<<20))
+	runner := &replayRunner{sessions: map[types.LayerID]*protocol{}}
+	for i := 0; ; i++ {
+		var ev traceEvent
+		if err := dec.Decode(&ev); err != nil {
+			if errors.Is(err, io.EOF) {
+				return runner.diffs, nil
+			}
+			return nil, fmt.Errorf("decode event %d: %w", i, err)
+		}
+		if err := runner.run(&ev); err != nil {
+			return nil, fmt.Errorf("event %d (%s): %w", i, ev.Type, err)
+		}
+	}
+}
+
+type replayRunner struct {
+	threshold uint16
+	sessions  map[types.LayerID]*protocol
+	diffs     []TraceDiff
+}
+
+func (r *replayRunner) session(layer types.LayerID) (*protocol, error) {
+	if r.threshold == 0 {
+		return nil, errors.New("config must be recorded before sessions")
+	}
+	proto, exists := r.sessions[layer]
+	if !exists {
+		proto = newProtocol(r.threshold)
+		r.sessions[layer] = proto
+	}
+	return proto, nil
+}
+
+func (r *replayRunner) run(ev *traceEvent) error {
+	switch ev.Type {
+	case traceConfig:
+		// config is recorded when the node is restarted, sessions that were in progress
+		// before restart will never be completed
+		r.threshold = ev.Threshold
+		clear(r.sessions)
+	case traceStart:
+		// inputs may be recorded before start, as session is registered before tracer is notified
+		if _, err := r.session(ev.Layer); err != nil {
+			return err
+		}
+	case traceStop:
+		delete(r.sessions, ev.Layer)
+	case traceInitial:
+		proto, err := r.session(ev.Layer)
+		if err != nil {
+			return err
+		}
+		proto.OnInitial(fromHashes(ev.Proposals))
+	case traceInput:
+		proto, err := r.session(ev.Layer)
+		if err != nil {
+			return err
+		}
+		msg := &Message{}
+		if err := codec.Decode(ev.Message, msg); err != nil {
+			return fmt.Errorf("decode message: %w", err)
+		}
+		in := &input{
+			Message:   msg,
+			atxgrade:  grade(ev.Grade),
+			malicious: ev.Malicious,
+		}
+		if ev.Hash != nil {
+			in.msgHash = *ev.Hash
+		} else {
+			in.msgHash = msg.ToHash()
+		}
+		proto.OnInput(in)
+	case traceNext:
+		if ev.Output == nil {
+			return errors.New("output is missing")
+		}
+		proto, err := r.session(ev.Layer)
+		if err != nil {
+			return err
+		}
+		ir := proto.IterRound
+		out := proto.Next()
+		if diff := cmp.Diff(ev.Output, newTraceOutput(ir, &out)); diff != "" {
+			r.diffs = append(r.diffs, TraceDiff{
+				Layer:     ev.Layer,
+				IterRound: IterRound{Iter: ev.Output.Iter, Round: ev.Output.Round},
+				Diff:      diff,
+			})
+		}
+	case traceActive, traceSent, traceReceived:
+		// recorded for inspection, they don't change protocol state
+	default:
+		return fmt.Errorf("unknown event type %q", ev.Type)
+	}
+	return nil
+}
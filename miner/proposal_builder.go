@@ -311,6 +311,16 @@ func (pb *ProposalBuilder) Register(sig *signing.EdSigner) {
 	}
 }
 
+// Unregister removes the signing key of the identity that was registered with Register.
+func (pb *ProposalBuilder) Unregister(id types.NodeID) {
+	pb.signers.mu.Lock()
+	defer pb.signers.mu.Unlock()
+	if _, exist := pb.signers.signers[id]; exist {
+		pb.logger.With().Info("unregistered signing key", log.ShortStringer("id", id))
+		delete(pb.signers.signers, id)
+	}
+}
+
 // Start the loop that listens to layers and build proposals.
 func (pb *ProposalBuilder) Run(ctx context.Context) error {
 	current := pb.clock.CurrentLayer()
@@ -655,7 +665,7 @@ func (pb *ProposalBuilder) build(ctx context.Context, lid types.LayerID) error {
 		}
 
 		eg.Go(func() error {
-			proposal := createProposal(
+			proposal, err := createProposal(
 				&ss.session,
 				pb.shared.beacon,
 				pb.shared.active.set,
@@ -666,6 +676,14 @@ func (pb *ProposalBuilder) build(ctx context.Context, lid types.LayerID) error {
 				proofs,
 				meshHash,
 			)
+			if err != nil {
+				ss.log.Error("failed to sign proposal",
+					log.Context(ctx),
+					log.Uint32("lid", lid.Uint32()),
+					log.Err(err),
+				)
+				return nil
+			}
 			if err := pb.publisher.Publish(ctx, pubsub.ProposalProtocol, codec.MustEncode(proposal)); err != nil {
 				ss.log.Error("failed to publish proposal",
 					log.Context(ctx),
@@ -700,7 +718,7 @@ func createProposal(
 	opinion *types.Opinion,
 	eligibility []types.VotingEligibility,
 	meshHash types.Hash32,
-) *types.Proposal {
+) (*types.Proposal, error) {
 	p := &types.Proposal{
 		InnerProposal: types.InnerProposal{
 			Ballot: types.Ballot{
@@ -726,11 +744,19 @@ func createProposal(
 	} else {
 		p.Ballot.RefBallot = session.ref
 	}
-	p.Ballot.Signature = signer.Sign(signing.BALLOT, p.Ballot.SignedBytes())
+	signature, err := signer.SignOnce(signing.BALLOT, lid, 0, p.Ballot.SignedBytes())
+	if err != nil {
+		return nil, fmt.Errorf("sign ballot: %w", err)
+	}
+	p.Ballot.Signature = signature
 	p.SmesherID = signer.NodeID()
-	p.Signature = signer.Sign(signing.PROPOSAL, p.SignedBytes())
+	signature, err = signer.SignOnce(signing.PROPOSAL, lid, 0, p.SignedBytes())
+	if err != nil {
+		return nil, fmt.Errorf("sign proposal: %w", err)
+	}
+	p.Signature = signature
 	p.MustInitialize()
-	return p
+	return p, nil
 }
 
 // calcEligibilityProofs calculates the eligibility proofs of proposals for the miner in the given epoch
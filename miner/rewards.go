@@ -0,0 +1,126 @@
+package miner
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/spacemeshos/economics/rewards"
+
+	"github.com/spacemeshos/go-spacemesh/atxsdata"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/miner/minweight"
+	"github.com/spacemeshos/go-spacemesh/proposals"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/activeset"
+)
+
+// EstimatedReward is an estimation of the rewards for a single identity in an epoch.
+type EstimatedReward struct {
+	NodeID        types.NodeID
+	ATX           types.ATXID
+	NumUnits      uint32
+	Weight        uint64
+	Eligibilities uint32
+	// Amount is an estimated layer reward, without fees as they can't be predicted.
+	Amount uint64
+}
+
+// EstimateRewards estimates rewards of the provided identities in the next epoch.
+//
+// The estimate is based on the weight of an ATX that the identity published for the next epoch
+// and on the total weight of the active set. If the active set is not yet prepared by the generator
+// the weight of all known ATXs targeting the next epoch is used instead.
+// Identities without an ATX for the next epoch, or with an ATX that is not yet known to atxsdata,
+// are estimated to receive nothing.
+func (pb *ProposalBuilder) EstimateRewards(ids []types.NodeID) ([]EstimatedReward, error) {
+	target := pb.clock.CurrentLayer().GetEpoch() + 1
+	totalWeight, err := pb.activeSetWeight(target)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		minWeight = minweight.Select(target, pb.cfg.minActiveSetWeight)
+		slots     = uint64(pb.cfg.layerSize) * uint64(pb.cfg.layersPerEpoch)
+		subsidy   = epochSubsidy(target)
+		result    = make([]EstimatedReward, 0, len(ids))
+	)
+	for _, id := range ids {
+		estimate := EstimatedReward{NodeID: id}
+		atxid, err := atxs.GetIDByEpochAndNodeID(pb.db, target-1, id)
+		switch {
+		case errors.Is(err, sql.ErrNotFound):
+			result = append(result, estimate)
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("get atx in epoch %v: %w", target-1, err)
+		}
+		full, err := atxs.Get(pb.db, atxid)
+		if err != nil {
+			return nil, fmt.Errorf("get atx %v: %w", atxid, err)
+		}
+		estimate.ATX = atxid
+		estimate.NumUnits = full.NumUnits
+		atx := pb.atxsdata.Get(target, atxid)
+		if atx == nil {
+			// atx is not loaded into atxsdata yet, it is not eligible until it is
+			result = append(result, estimate)
+			continue
+		}
+		estimate.Weight = atx.Weight
+		if totalWeight == 0 || slots == 0 || pb.atxsdata.IsMalicious(id) {
+			result = append(result, estimate)
+			continue
+		}
+		estimate.Eligibilities, err = proposals.GetNumEligibleSlots(
+			atx.Weight,
+			minWeight,
+			totalWeight,
+			pb.cfg.layerSize,
+			pb.cfg.layersPerEpoch,
+		)
+		if err != nil {
+			return nil, err
+		}
+		amount := new(big.Int).SetUint64(subsidy)
+		amount.
+			Mul(amount, new(big.Int).SetUint64(uint64(estimate.Eligibilities))).
+			Quo(amount, new(big.Int).SetUint64(slots))
+		if !amount.IsUint64() {
+			return nil, fmt.Errorf("estimated reward %v for %v overflows uint64", amount, id)
+		}
+		estimate.Amount = amount.Uint64()
+		result = append(result, estimate)
+	}
+	return result, nil
+}
+
+// activeSetWeight returns the weight of the active set prepared for the target epoch,
+// or the weight of all non-malicious atxs targeting the epoch if it wasn't prepared yet.
+func (pb *ProposalBuilder) activeSetWeight(target types.EpochID) (uint64, error) {
+	_, weight, _, err := activeset.Get(pb.localdb, activeset.Tortoise, target)
+	switch {
+	case err == nil:
+		return weight, nil
+	case !errors.Is(err, sql.ErrNotFound):
+		return 0, fmt.Errorf("get prepared active set: %w", err)
+	}
+	pb.atxsdata.IterateInEpoch(target, func(_ types.ATXID, atx *atxsdata.ATX) {
+		weight += atx.Weight
+	}, atxsdata.NotMalicious)
+	return weight, nil
+}
+
+// epochSubsidy is a sum of the subsidies for every layer in the epoch.
+// It uses the same schedule as the vm when rewards are applied.
+func epochSubsidy(epoch types.EpochID) uint64 {
+	var total uint64
+	for lid := epoch.FirstLayer(); lid < (epoch + 1).FirstLayer(); lid++ {
+		if lid < types.FirstEffectiveGenesis() {
+			continue
+		}
+		total += rewards.TotalSubsidyAtLayer(lid.Difference(types.FirstEffectiveGenesis()))
+	}
+	return total
+}
@@ -0,0 +1,102 @@
+package miner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/spacemeshos/go-spacemesh/atxsdata"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/miner/mocks"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/activeset"
+)
+
+func TestEstimateRewards(t *testing.T) {
+	const (
+		layerSize = 10
+		publish   = types.EpochID(1)
+		target    = publish + 1
+	)
+	setup := func(t *testing.T) (*ProposalBuilder, sql.Executor, []types.NodeID) {
+		var (
+			ctrl    = gomock.NewController(t)
+			clock   = mocks.NewMocklayerClock(ctrl)
+			db      = sql.InMemory()
+			localdb = localsql.InMemory()
+			data    = atxsdata.New()
+		)
+		clock.EXPECT().CurrentLayer().Return(publish.FirstLayer() + 1).AnyTimes()
+		ids := []types.NodeID{{1}, {2}, {3}}
+		for i, units := range []uint32{10, 30} {
+			atx := gatx(types.ATXID{byte(i + 1)}, publish, ids[i], units)
+			require.NoError(t, atxs.Add(db, atx))
+			data.AddFromHeader(atx.ToHeader(), *atx.VRFNonce, false)
+		}
+		builder := New(clock, db, localdb, data, nil, nil, nil, nil,
+			WithLogger(logtest.New(t)),
+			WithLayerSize(layerSize),
+			WithLayerPerEpoch(layersPerEpoch),
+		)
+		return builder, localdb, ids
+	}
+	expected := func(eligibilities uint32) uint64 {
+		return epochSubsidy(target) * uint64(eligibilities) / (layerSize * layersPerEpoch)
+	}
+
+	t.Run("weight from atxs", func(t *testing.T) {
+		builder, _, ids := setup(t)
+		estimates, err := builder.EstimateRewards(ids)
+		require.NoError(t, err)
+		require.Len(t, estimates, len(ids))
+
+		require.Equal(t, ids[0], estimates[0].NodeID)
+		require.Equal(t, types.ATXID{1}, estimates[0].ATX)
+		require.EqualValues(t, 10, estimates[0].NumUnits)
+		require.EqualValues(t, 12, estimates[0].Eligibilities)
+		require.Equal(t, expected(12), estimates[0].Amount)
+
+		require.EqualValues(t, 30, estimates[1].NumUnits)
+		require.EqualValues(t, 37, estimates[1].Eligibilities)
+		require.Equal(t, expected(37), estimates[1].Amount)
+
+		require.Equal(t, EstimatedReward{NodeID: ids[2]}, estimates[2])
+	})
+
+	t.Run("weight from prepared active set", func(t *testing.T) {
+		builder, localdb, ids := setup(t)
+		// only the first atx is in the active set
+		require.NoError(t, activeset.Add(localdb, activeset.Tortoise, target, types.Hash32{1},
+			10*ticks, []types.ATXID{{1}}))
+		estimates, err := builder.EstimateRewards(ids[:1])
+		require.NoError(t, err)
+		require.Len(t, estimates, 1)
+		require.EqualValues(t, layerSize*layersPerEpoch, estimates[0].Eligibilities)
+		require.Equal(t, epochSubsidy(target), estimates[0].Amount)
+	})
+
+	t.Run("atx not in atxsdata", func(t *testing.T) {
+		builder, _, ids := setup(t)
+		atx := gatx(types.ATXID{3}, publish, ids[2], 20)
+		require.NoError(t, atxs.Add(builder.db, atx))
+		estimates, err := builder.EstimateRewards(ids)
+		require.NoError(t, err)
+		require.Len(t, estimates, len(ids))
+		require.EqualValues(t, 12, estimates[0].Eligibilities)
+		require.Equal(t, EstimatedReward{NodeID: ids[2], ATX: types.ATXID{3}, NumUnits: 20}, estimates[2])
+	})
+
+	t.Run("malicious", func(t *testing.T) {
+		builder, _, ids := setup(t)
+		builder.atxsdata.SetMalicious(ids[0])
+		estimates, err := builder.EstimateRewards(ids[:1])
+		require.NoError(t, err)
+		require.Len(t, estimates, 1)
+		require.Zero(t, estimates[0].Amount)
+		require.Equal(t, types.ATXID{1}, estimates[0].ATX)
+	})
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	sqlmigrations "github.com/spacemeshos/go-spacemesh/sql/migrations"
+)
+
+// runMigrateDown reverts migrations of the state or local database to the target version.
+// Pending migrations are not applied, database is migrated down from its current version.
+func runMigrateDown(ctx context.Context, cfg *config.Config, database string, target int, allowDataLoss bool) error {
+	logger := log.NewWithLevel("node", zap.NewAtomicLevelAt(zap.InfoLevel)).WithName(StateDbLogger).Zap()
+	fl := flock.New(cfg.FileLock)
+	locked, err := fl.TryLock()
+	if err != nil {
+		return fmt.Errorf("flock %s: %w", cfg.FileLock, err)
+	} else if !locked {
+		return fmt.Errorf("database can't be migrated while the node is running (locking file %s)", fl.Path())
+	}
+	defer fl.Unlock()
+
+	var (
+		path string
+		db   *sql.Database
+	)
+	switch database {
+	case "state":
+		path = filepath.Join(cfg.DataDir(), dbFile)
+		var migrations []sql.Migration
+		migrations, err = sql.StateMigrations()
+		if err != nil {
+			return fmt.Errorf("failed to load migrations: %w", err)
+		}
+		db, err = sql.Open("file:"+path,
+			sql.WithLogger(logger),
+			sql.WithMigrations(migrations),
+			sql.WithMigration(sqlmigrations.New0017Migration(logger)),
+			sql.WithoutApplyingMigrations(),
+		)
+	case "local":
+		path = filepath.Join(cfg.DataDir(), localDbFile)
+		var local *localsql.Database
+		local, err = localsql.Open("file:"+path, sql.WithLogger(logger), sql.WithoutApplyingMigrations())
+		if local != nil {
+			db = local.Database
+		}
+	default:
+		return fmt.Errorf("unknown database %q, expected state or local", database)
+	}
+	if err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	if err := db.MigrateDown(ctx, target, allowDataLoss); err != nil {
+		if errors.Is(err, sql.ErrDataLoss) {
+			err = fmt.Errorf("%w (rerun with --allow-data-loss to proceed)", err)
+		}
+		return errors.Join(err, db.Close())
+	}
+	if err := db.Close(); err != nil {
+		return fmt.Errorf("close %s: %w", path, err)
+	}
+	version, err := sql.Version(path)
+	if err != nil {
+		return fmt.Errorf("failed to get db version: %w", err)
+	}
+	if version != target {
+		return fmt.Errorf("database %s is at version %d after rollback, expected %d", path, version, target)
+	}
+	logger.Info("database migrated down", zap.String("path", path), zap.Int("version", version))
+	return nil
+}
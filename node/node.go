@@ -4,6 +4,7 @@ package node
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -16,6 +17,7 @@ import (
 	"runtime"
 	"slices"
 	"sort"
+	"sync"
 	"syscall"
 	"time"
 
@@ -23,6 +25,8 @@ import (
 	pyroscope "github.com/grafana/pyroscope-go"
 	grpc_logsettable "github.com/grpc-ecosystem/go-grpc-middleware/logging/settable"
 	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
+	"github.com/jonboulle/clockwork"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/mitchellh/mapstructure"
 	"github.com/spacemeshos/poet/server"
 	"github.com/spf13/afero"
@@ -50,6 +54,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/config"
 	"github.com/spacemeshos/go-spacemesh/config/presets"
 	"github.com/spacemeshos/go-spacemesh/datastore"
+	"github.com/spacemeshos/go-spacemesh/equivocation"
 	"github.com/spacemeshos/go-spacemesh/events"
 	"github.com/spacemeshos/go-spacemesh/fetch"
 	vm "github.com/spacemeshos/go-spacemesh/genvm"
@@ -135,6 +140,7 @@ const (
 	ExecutorLogger         = "executor"
 	MalfeasanceLogger      = "malfeasance"
 	BootstrapLogger        = "bootstrap"
+	EquivocationLogger     = "equivocation"
 )
 
 func GetCommand() *cobra.Command {
@@ -253,6 +259,32 @@ func GetCommand() *cobra.Command {
 	}
 	c.AddCommand(&relayCmd)
 
+	var (
+		migrateDatabase string
+		migrateTarget   int
+		allowDataLoss   bool
+	)
+	migrateDownCmd := cobra.Command{
+		Use:   "migrate-down",
+		Short: "Revert database migrations to an older schema version",
+		Long: "Revert migrations of the state or local database to the target schema version.\n" +
+			"All migrations are reverted in a single transaction. Migrations that discard data\n" +
+			"are reverted only if --allow-data-loss is set.\n" +
+			"The node must be stopped and the database should be backed up before running this command.",
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			if err := configure(c, *configPath, &conf); err != nil {
+				return err
+			}
+			return runMigrateDown(c.Context(), &conf, migrateDatabase, migrateTarget, allowDataLoss)
+		},
+	}
+	migrateDownCmd.Flags().StringVar(&migrateDatabase, "db", "state", "database to migrate: state or local")
+	migrateDownCmd.Flags().IntVar(&migrateTarget, "target", -1, "schema version to migrate the database to")
+	migrateDownCmd.Flags().BoolVar(&allowDataLoss, "allow-data-loss", false,
+		"revert migrations even if they discard data")
+	c.AddCommand(&migrateDownCmd)
+
 	return c
 }
 
@@ -368,6 +400,34 @@ func WithConfig(conf *config.Config) Option {
 	}
 }
 
+// WithClock replaces the real clock used to track layers and hare rounds.
+func WithClock(clock clockwork.Clock) Option {
+	return func(app *App) {
+		app.wallclock = clock
+	}
+}
+
+// WithLibp2pHost makes the app use the libp2p host instead of creating one from the p2p config.
+// It is used to run several nodes in one process over a mock network.
+func WithLibp2pHost(h host.Host) Option {
+	return func(app *App) {
+		app.libp2pHost = h
+	}
+}
+
+// WithBootstrapBeacon sets the beacon for the epoch once services are started,
+// so that the network can run without waiting for the beacon protocol.
+func WithBootstrapBeacon(epoch types.EpochID, beacon types.Beacon) Option {
+	return func(app *App) {
+		app.bootstrapBeacon = &bootstrapBeacon{epoch: epoch, beacon: beacon}
+	}
+}
+
+type bootstrapBeacon struct {
+	epoch  types.EpochID
+	beacon types.Beacon
+}
+
 // New creates an instance of the spacemesh app.
 func New(opts ...Option) *App {
 	defaultConfig := config.DefaultConfig()
@@ -378,6 +438,7 @@ func New(opts ...Option) *App {
 		grpcServices: make(map[grpcserver.Service]grpcserver.ServiceAPI),
 		started:      make(chan struct{}),
 		eg:           &errgroup.Group{},
+		identitiesMu: &sync.Mutex{},
 	}
 	for _, opt := range opts {
 		opt(app)
@@ -402,6 +463,7 @@ type App struct {
 	cachedDB          *datastore.CachedDB
 	dbMetrics         *dbmetrics.DBMetricsCollector
 	localDB           *localsql.Database
+	equivocationGuard *equivocation.Guard
 	grpcPublicServer  *grpcserver.Server
 	grpcPrivateServer *grpcserver.Server
 	grpcPostServer    *grpcserver.Server
@@ -443,9 +505,18 @@ type App struct {
 
 	host *p2p.Host
 
+	wallclock       clockwork.Clock
+	libp2pHost      host.Host
+	bootstrapBeacon *bootstrapBeacon
+
 	loggers map[string]*zap.AtomicLevel
 	started chan struct{} // this channel is closed once the app has finished starting
 	eg      *errgroup.Group
+
+	// identitiesMu protects signers when identities are added or removed at runtime.
+	// The slice is replaced on every change, readers use signersSnapshot.
+	identitiesMu       *sync.Mutex
+	identityRegistries []identityRegistry
 }
 
 func (app *App) LoadCheckpoint(ctx context.Context) (*checkpoint.PreservedData, error) {
@@ -457,10 +528,19 @@ func (app *App) LoadCheckpoint(ctx context.Context) (*checkpoint.PreservedData,
 	if restore == 0 {
 		return nil, errors.New("restore layer not set")
 	}
-	nodeIDs := make([]types.NodeID, len(app.signers))
-	for i, sig := range app.signers {
+	signers := app.signersSnapshot()
+	nodeIDs := make([]types.NodeID, len(signers))
+	for i, sig := range signers {
 		nodeIDs[i] = sig.NodeID()
 	}
+	trusted := make([]types.NodeID, 0, len(app.Config.Recovery.TrustedSigners))
+	for _, signer := range app.Config.Recovery.TrustedSigners {
+		id, err := parseNodeID(signer)
+		if err != nil {
+			return nil, fmt.Errorf("trusted checkpoint signer: %w", err)
+		}
+		trusted = append(trusted, id)
+	}
 	cfg := &checkpoint.RecoverConfig{
 		GoldenAtx:      types.ATXID(app.Config.Genesis.GoldenATX()),
 		DataDir:        app.Config.DataDir(),
@@ -470,6 +550,8 @@ func (app *App) LoadCheckpoint(ctx context.Context) (*checkpoint.PreservedData,
 		NodeIDs:        nodeIDs,
 		Uri:            checkpointFile,
 		Restore:        restore,
+		GenesisID:      app.Config.Genesis.GenesisID(),
+		TrustedSigners: trusted,
 	}
 	app.log.WithContext(ctx).With().Info("recover from checkpoint",
 		log.String("url", checkpointFile),
@@ -478,6 +560,17 @@ func (app *App) LoadCheckpoint(ctx context.Context) (*checkpoint.PreservedData,
 	return checkpoint.Recover(ctx, app.log, afero.NewOsFs(), cfg)
 }
 
+func parseNodeID(s string) (types.NodeID, error) {
+	buf, err := hex.DecodeString(s)
+	if err != nil {
+		return types.EmptyNodeID, fmt.Errorf("decode node id %s: %w", s, err)
+	}
+	if len(buf) != len(types.EmptyNodeID) {
+		return types.EmptyNodeID, fmt.Errorf("node id %s must be %d bytes", s, len(types.EmptyNodeID))
+	}
+	return types.BytesToNodeID(buf), nil
+}
+
 func (app *App) Started() <-chan struct{} {
 	return app.started
 }
@@ -622,13 +715,14 @@ func (app *App) initServices(ctx context.Context) error {
 	layersPerEpoch := types.GetLayersPerEpoch()
 	lg := app.log
 
+	signers := app.signersSnapshot()
 	poetDb := activation.NewPoetDb(app.db, app.addLogger(PoetDbLogger, lg))
 	postStates := activation.NewPostStates(app.addLogger(PostLogger, lg).Zap())
 	opts := []activation.PostVerifierOpt{
 		activation.WithVerifyingOpts(app.Config.SMESHING.VerifyingOpts),
 		activation.WithAutoscaling(postStates),
 	}
-	for _, sig := range app.signers {
+	for _, sig := range signers {
 		opts = append(opts, activation.WithPrioritizedID(sig.NodeID()))
 	}
 
@@ -661,7 +755,9 @@ func (app *App) initServices(ctx context.Context) error {
 		txs.WithCSConfig(txs.CSConfig{
 			BlockGasLimit:     app.Config.BlockGasLimit,
 			NumTXsPerProposal: app.Config.TxsPerProposal,
+			MinGasPrice:       app.Config.MinGas,
 		}),
+		txs.WithLocalDB(app.localDB),
 		txs.WithLogger(app.addLogger(ConStateLogger, lg)))
 
 	genesisAccts := app.Config.Genesis.ToAccounts()
@@ -699,8 +795,9 @@ func (app *App) initServices(ctx context.Context) error {
 		app.clock,
 		beacon.WithConfig(app.Config.Beacon),
 		beacon.WithLogger(app.addLogger(BeaconLogger, lg)),
+		beacon.WithLocalDB(app.localDB),
 	)
-	for _, sig := range app.signers {
+	for _, sig := range signers {
 		beaconProtocol.Register(sig)
 	}
 
@@ -751,7 +848,15 @@ func (app *App) initServices(ctx context.Context) error {
 		return fmt.Errorf("create mesh: %w", err)
 	}
 
-	pruner := prune.New(app.db, app.Config.Tortoise.Hdist, app.Config.PruneActivesetsFrom, prune.WithLogger(mlog.Zap()))
+	if err := app.Config.DatabaseRetention.Validate(); err != nil {
+		return fmt.Errorf("db retention: %w", err)
+	}
+	pruner := prune.New(app.db, app.Config.Tortoise.Hdist, app.Config.PruneActivesetsFrom,
+		prune.WithLogger(mlog.Zap()),
+		prune.WithRetention(app.Config.DatabaseRetention),
+		prune.WithTortoiseConfig(app.Config.Tortoise),
+		prune.WithLocalDB(app.localDB),
+	)
 	if err := pruner.Prune(app.clock.CurrentLayer()); err != nil {
 		return fmt.Errorf("pruner %w", err)
 	}
@@ -776,7 +881,7 @@ func (app *App) initServices(ctx context.Context) error {
 		trtl,
 		app.addLogger(ATXHandlerLogger, lg),
 	)
-	for _, sig := range app.signers {
+	for _, sig := range signers {
 		atxHandler.Register(sig)
 	}
 
@@ -838,7 +943,7 @@ func (app *App) initServices(ctx context.Context) error {
 		blocks.WithCertConfig(app.Config.Certificate),
 		blocks.WithCertifierLogger(app.addLogger(BlockCertLogger, lg)),
 	)
-	for _, sig := range app.signers {
+	for _, sig := range signers {
 		app.certifier.Register(sig)
 	}
 
@@ -900,6 +1005,24 @@ func (app *App) initServices(ctx context.Context) error {
 	}
 	logger := app.addLogger(HareLogger, lg).Zap()
 
+	hareOpts := []hare3.Opt{
+		hare3.WithLogger(logger),
+		hare3.WithConfig(app.Config.HARE3),
+	}
+	if app.wallclock != nil {
+		hareOpts = append(hareOpts, hare3.WithWallclock(app.wallclock))
+	}
+	if path := app.Config.HARE3.TraceFile; path != "" {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(app.Config.DataDir(), path)
+		}
+		tracer, err := hare3.NewFileTracer(path, app.Config.HARE3)
+		if err != nil {
+			return fmt.Errorf("create hare tracer: %w", err)
+		}
+		logger.Info("recording hare trace", zap.String("path", path))
+		hareOpts = append(hareOpts, hare3.WithTracer(tracer))
+	}
 	app.hare3 = hare3.New(
 		app.clock,
 		app.host,
@@ -910,10 +1033,9 @@ func (app *App) initServices(ctx context.Context) error {
 		app.hOracle,
 		newSyncer,
 		patrol,
-		hare3.WithLogger(logger),
-		hare3.WithConfig(app.Config.HARE3),
+		hareOpts...,
 	)
-	for _, sig := range app.signers {
+	for _, sig := range signers {
 		app.hare3.Register(sig)
 	}
 	app.hare3.Start()
@@ -990,7 +1112,7 @@ func (app *App) initServices(ctx context.Context) error {
 		miner.WithLogger(app.addLogger(ProposalBuilderLogger, lg)),
 		miner.WithActivesetPreparation(app.Config.ActiveSet),
 	)
-	for _, sig := range app.signers {
+	for _, sig := range signers {
 		proposalBuilder.Register(sig)
 	}
 
@@ -1047,7 +1169,7 @@ func (app *App) initServices(ctx context.Context) error {
 		activation.WithPostValidityDelay(app.Config.PostValidDelay),
 		activation.WithPostStates(postStates),
 	)
-	if len(app.signers) > 1 || app.signers[0].Name() != supervisedIDKeyFileName {
+	if len(signers) > 1 || signers[0].Name() != supervisedIDKeyFileName {
 		// in a remote setup we register eagerly so the atxBuilder can warn about missing connections asap.
 		// Any setup with more than one signer is considered a remote setup. If there is only one signer it
 		// is considered a remote setup if the key for the signer has not been sourced from `supervisedIDKeyFileName`.
@@ -1055,7 +1177,7 @@ func (app *App) initServices(ctx context.Context) error {
 		// In a supervised setup the postSetupManager will register at the atxBuilder when
 		// it finished initializing, to avoid warning about a missing connection when the supervised post
 		// service isn't ready yet.
-		for _, sig := range app.signers {
+		for _, sig := range signers {
 			atxBuilder.Register(sig)
 		}
 	}
@@ -1070,8 +1192,8 @@ func (app *App) initServices(ctx context.Context) error {
 		return fmt.Errorf("init post service: %w", err)
 	}
 
-	nodeIDs := make([]types.NodeID, 0, len(app.signers))
-	for _, s := range app.signers {
+	nodeIDs := make([]types.NodeID, 0, len(signers))
+	for _, s := range signers {
 		nodeIDs = append(nodeIDs, s.NodeID())
 	}
 	malfeasanceHandler := malfeasance.NewHandler(
@@ -1083,30 +1205,33 @@ func (app *App) initServices(ctx context.Context) error {
 		trtl,
 		app.postVerifier,
 	)
+	rep := app.host.Reputation()
 	fetcher.SetValidators(
 		fetch.ValidatorFunc(
-			pubsub.DropPeerOnSyncValidationReject(atxHandler.HandleSyncedAtx, app.host, lg),
+			pubsub.DropPeerOnSyncValidationReject(atxHandler.HandleSyncedAtx, app.host, rep, lg),
 		),
 		fetch.ValidatorFunc(
-			pubsub.DropPeerOnSyncValidationReject(poetDb.ValidateAndStoreMsg, app.host, lg),
+			pubsub.DropPeerOnSyncValidationReject(poetDb.ValidateAndStoreMsg, app.host, rep, lg),
 		),
 		fetch.ValidatorFunc(
 			pubsub.DropPeerOnSyncValidationReject(
 				proposalListener.HandleSyncedBallot,
 				app.host,
+				rep,
 				lg,
 			),
 		),
 		fetch.ValidatorFunc(
-			pubsub.DropPeerOnSyncValidationReject(proposalListener.HandleActiveSet, app.host, lg),
+			pubsub.DropPeerOnSyncValidationReject(proposalListener.HandleActiveSet, app.host, rep, lg),
 		),
 		fetch.ValidatorFunc(
-			pubsub.DropPeerOnSyncValidationReject(blockHandler.HandleSyncedBlock, app.host, lg),
+			pubsub.DropPeerOnSyncValidationReject(blockHandler.HandleSyncedBlock, app.host, rep, lg),
 		),
 		fetch.ValidatorFunc(
 			pubsub.DropPeerOnSyncValidationReject(
 				proposalListener.HandleSyncedProposal,
 				app.host,
+				rep,
 				lg,
 			),
 		),
@@ -1114,6 +1239,7 @@ func (app *App) initServices(ctx context.Context) error {
 			pubsub.DropPeerOnSyncValidationReject(
 				app.txHandler.HandleBlockTransaction,
 				app.host,
+				rep,
 				lg,
 			),
 		),
@@ -1121,6 +1247,7 @@ func (app *App) initServices(ctx context.Context) error {
 			pubsub.DropPeerOnSyncValidationReject(
 				app.txHandler.HandleProposalTransaction,
 				app.host,
+				rep,
 				lg,
 			),
 		),
@@ -1128,6 +1255,7 @@ func (app *App) initServices(ctx context.Context) error {
 			pubsub.DropPeerOnSyncValidationReject(
 				malfeasanceHandler.HandleSyncedMalfeasanceProof,
 				app.host,
+				rep,
 				lg,
 			),
 		),
@@ -1202,6 +1330,11 @@ func (app *App) initServices(ctx context.Context) error {
 	app.fetcher = fetcher
 	app.beaconProtocol = beaconProtocol
 	app.tortoise = trtl
+	// the atx builder is last, so that it starts after the other components know the identity
+	// and stops before them
+	app.identityRegistries = []identityRegistry{
+		beaconProtocol, atxHandler, app.certifier, app.hare3, proposalBuilder, atxBuilder,
+	}
 	if !app.Config.TIME.Peersync.Disable {
 		app.ptimesync = peersync.New(
 			app.host,
@@ -1349,6 +1482,18 @@ func (app *App) startServices(ctx context.Context) error {
 	app.eg.Go(func() error {
 		return app.proposalBuilder.Run(ctx)
 	})
+	if interval := app.Config.IdentitiesWatchInterval; interval > 0 {
+		switch {
+		case supervised(app.signersSnapshot()):
+			app.log.With().Warning("identities are not watched in supervised smeshing")
+		case app.Config.TestConfig.SmesherKey != "":
+			app.log.With().Warning("identities are not watched with the pre-configured testing identity")
+		default:
+			app.eg.Go(func() error {
+				return app.watchIdentities(ctx, interval)
+			})
+		}
+	}
 
 	if app.Config.SMESHING.CoinbaseAccount != "" {
 		coinbaseAddr, err := types.StringToAddress(app.Config.SMESHING.CoinbaseAccount)
@@ -1414,14 +1559,23 @@ func (app *App) grpcService(svc grpcserver.Service, lg log.Log) (grpcserver.Serv
 		app.grpcServices[svc] = service
 		return service, nil
 	case grpcserver.Admin:
-		service := grpcserver.NewAdminService(app.db, app.Config.DataDir(), app.host)
+		var opts []grpcserver.AdminServiceOpt
+		if app.Config.Recovery.SignerKey != "" {
+			signer, err := app.checkpointSigner()
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, grpcserver.WithCheckpointSigner(signer))
+		}
+		service := grpcserver.NewAdminService(app.db, app.Config.DataDir(), app.host, opts...)
 		app.grpcServices[svc] = service
 		return service, nil
 	case grpcserver.Smesher:
+		signers := app.signersSnapshot()
 		var sig *signing.EdSigner
-		if len(app.signers) == 1 && app.signers[0].Name() == supervisedIDKeyFileName {
+		if len(signers) == 1 && signers[0].Name() == supervisedIDKeyFileName {
 			// StartSmeshing is only supported in a supervised setup (single signer)
-			sig = app.signers[0]
+			sig = signers[0]
 		}
 		postService, err := app.grpcService(grpcserver.Post, lg)
 		if err != nil {
@@ -1431,12 +1585,22 @@ func (app *App) grpcService(svc grpcserver.Service, lg log.Log) (grpcserver.Serv
 			app.atxBuilder,
 			app.postSupervisor,
 			postService.(*grpcserver.PostService),
+			app.conState,
+			app.proposalBuilder,
 			app.Config.API.SmesherStreamInterval,
 			app.Config.SMESHING.Opts,
 			sig,
 		)
 		app.grpcServices[svc] = service
 		return service, nil
+	case grpcserver.AppEventV1:
+		service := grpcserver.NewAppEventService()
+		app.grpcServices[svc] = service
+		return service, nil
+	case grpcserver.IdentityV1:
+		service := grpcserver.NewIdentityService(app.atxBuilder, app.proposalBuilder, app.nipostBuilder, app)
+		app.grpcServices[svc] = service
+		return service, nil
 	case grpcserver.Post:
 		service := grpcserver.NewPostService(app.addLogger(PostServiceLogger, lg).Zap())
 		isCoinbaseSet := app.Config.SMESHING.CoinbaseAccount != ""
@@ -1654,21 +1818,22 @@ func (app *App) startAPIServices(ctx context.Context) error {
 			return err
 		}
 		svc.(*grpcserver.SmesherService).SetPostServiceConfig(app.Config.POSTService)
+		signers := app.signersSnapshot()
 		if app.Config.SMESHING.Start {
 			if app.Config.SMESHING.CoinbaseAccount == "" {
 				return errors.New("smeshing enabled but no coinbase account provided")
 			}
-			if len(app.signers) > 1 {
+			if len(signers) > 1 {
 				return errors.New("supervised smeshing cannot be started in a multi-smeshing setup")
 			}
 			if err := app.postSupervisor.Start(
 				app.Config.POSTService,
 				app.Config.SMESHING.Opts,
-				app.signers[0],
+				signers[0],
 			); err != nil {
 				return fmt.Errorf("start post service: %w", err)
 			}
-		} else if len(app.signers) == 1 && app.signers[0].Name() == supervisedIDKeyFileName {
+		} else if len(signers) == 1 && signers[0].Name() == supervisedIDKeyFileName {
 			// supervised setup but not started
 			app.log.Info("smeshing not started, waiting to be triggered via smesher api")
 		}
@@ -1763,6 +1928,12 @@ func (app *App) stopServices(ctx context.Context) {
 		app.atxBuilder.StopSmeshing(false)
 	}
 
+	if app.nipostBuilder != nil {
+		if err := app.nipostBuilder.Close(); err != nil {
+			app.log.With().Warning("error closing poet clients", log.Err(err))
+		}
+	}
+
 	if app.postVerifier != nil {
 		app.postVerifier.Close()
 	}
@@ -1907,6 +2078,13 @@ func (app *App) setupDBs(ctx context.Context, lg log.Log) error {
 		return fmt.Errorf("open sqlite db %w", err)
 	}
 	app.localDB = localDB
+	app.equivocationGuard = equivocation.New(localDB,
+		equivocation.WithLogger(app.addLogger(EquivocationLogger, lg).Zap()),
+	)
+	signers := app.signersSnapshot()
+	for _, sig := range signers {
+		sig.SetGuard(app.equivocationGuard)
+	}
 	return nil
 }
 
@@ -2048,12 +2226,16 @@ func (app *App) startSynchronous(ctx context.Context) (err error) {
 	if err != nil {
 		return fmt.Errorf("cannot parse genesis time %s: %w", app.Config.Genesis.GenesisTime, err)
 	}
-	app.clock, err = timesync.NewClock(
+	clockOpts := []timesync.OptionFunc{
 		timesync.WithLayerDuration(app.Config.LayerDuration),
-		timesync.WithTickInterval(1*time.Second),
+		timesync.WithTickInterval(1 * time.Second),
 		timesync.WithGenesisTime(gTime),
 		timesync.WithLogger(app.addLogger(ClockLogger, logger).Zap()),
-	)
+	}
+	if app.wallclock != nil {
+		clockOpts = append(clockOpts, timesync.WithClock(app.wallclock))
+	}
+	app.clock, err = timesync.NewClock(clockOpts...)
 	if err != nil {
 		return fmt.Errorf("cannot create clock: %w", err)
 	}
@@ -2076,9 +2258,18 @@ func (app *App) startSynchronous(ctx context.Context) (err error) {
 	if !onMainNet(app.Config) {
 		nc = handshake.NetworkCookie(prologue)
 	}
-	app.host, err = p2p.New(ctx, p2plog, cfg, []byte(prologue), nc,
-		p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
-	)
+	if app.libp2pHost != nil {
+		app.host, err = p2p.Upgrade(app.libp2pHost,
+			p2p.WithContext(ctx),
+			p2p.WithConfig(cfg),
+			p2p.WithLog(p2plog),
+			p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
+		)
+	} else {
+		app.host, err = p2p.New(ctx, p2plog, cfg, []byte(prologue), nc,
+			p2p.WithNodeReporter(events.ReportNodeStatusUpdate),
+		)
+	}
 	if err != nil {
 		return fmt.Errorf("initialize p2p host: %w", err)
 	}
@@ -2109,6 +2300,12 @@ func (app *App) startSynchronous(ctx context.Context) (err error) {
 		return fmt.Errorf("start services: %w", err)
 	}
 
+	if app.bootstrapBeacon != nil {
+		if err := app.beaconProtocol.UpdateBeacon(app.bootstrapBeacon.epoch, app.bootstrapBeacon.beacon); err != nil {
+			return fmt.Errorf("update bootstrap beacon: %w", err)
+		}
+	}
+
 	// need post verifying service to start first
 	app.preserveAfterRecovery(ctx)
 
@@ -2175,6 +2372,14 @@ func (app *App) Host() *p2p.Host {
 	return app.host
 }
 
+// GrpcPublicAddress returns the address the public grpc server is bound to.
+func (app *App) GrpcPublicAddress() string {
+	if app.grpcPublicServer == nil {
+		return ""
+	}
+	return app.grpcPublicServer.BoundAddress
+}
+
 type layerFetcher struct {
 	system.Fetcher
 }
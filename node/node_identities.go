@@ -112,6 +112,52 @@ func (app *App) MigrateExistingIdentity() error {
 	return nil
 }
 
+// passphraseOpts returns the option to encrypt and decrypt identity files if the passphrase is configured.
+func (app *App) passphraseOpts() ([]signing.EdSignerOptionFunc, error) {
+	passphrase, err := signing.LoadPassphrase(app.Config.IdentityPassphraseFile)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == nil {
+		return nil, nil
+	}
+	return []signing.EdSignerOptionFunc{signing.WithPassphrase(passphrase)}, nil
+}
+
+// checkpointSigner loads the dedicated key that signs generated checkpoints, the key is created if it doesn't exist.
+func (app *App) checkpointSigner() (*signing.EdSigner, error) {
+	path := app.Config.Recovery.SignerKey
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(app.Config.DataDir(), path)
+	}
+	if filepath.Dir(path) == filepath.Join(app.Config.DataDir(), keyDir) {
+		return nil, fmt.Errorf("checkpoint signer key %s must not be stored with smeshing identities", path)
+	}
+	opts, err := app.passphraseOpts()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()))
+	_, err = os.Stat(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return nil, fmt.Errorf("failed to create directory for checkpoint signer key: %w", err)
+		}
+		opts = append(opts, signing.ToFile(path))
+	case err != nil:
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	default:
+		opts = append(opts, signing.FromFile(path))
+	}
+	signer, err := signing.NewEdSigner(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("checkpoint signer key: %w", err)
+	}
+	app.log.With().Info("loaded checkpoint signer key", log.String("path", path), signer.NodeID())
+	return signer, nil
+}
+
 // NewIdentity creates a new identity, saves it to `keyDir/supervisedIDKeyFileName` in the config directory and
 // initializes app.signers with that identity.
 func (app *App) NewIdentity() error {
@@ -120,11 +166,15 @@ func (app *App) NewIdentity() error {
 		return fmt.Errorf("failed to create directory for identity file: %w", err)
 	}
 
+	opts, err := app.passphraseOpts()
+	if err != nil {
+		return err
+	}
 	keyFile := filepath.Join(dir, supervisedIDKeyFileName)
-	signer, err := signing.NewEdSigner(
+	signer, err := signing.NewEdSigner(append(opts,
 		signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()),
 		signing.ToFile(keyFile),
-	)
+	)...)
 	if err != nil {
 		return fmt.Errorf("failed to create identity: %w", err)
 	}
@@ -140,9 +190,13 @@ func (app *App) NewIdentity() error {
 // LoadIdentities loads all existing identities from the config directory.
 func (app *App) LoadIdentities() error {
 	signers := make([]*signing.EdSigner, 0)
+	opts, err := app.passphraseOpts()
+	if err != nil {
+		return err
+	}
 
 	dir := filepath.Join(app.Config.DataDir(), keyDir)
-	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return fmt.Errorf("failed to walk directory at %s: %w", path, err)
 		}
@@ -157,10 +211,10 @@ func (app *App) LoadIdentities() error {
 			return nil
 		}
 
-		signer, err := signing.NewEdSigner(
+		signer, err := signing.NewEdSigner(append(opts,
 			signing.FromFile(path),
 			signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()),
-		)
+		)...)
 		if err != nil {
 			return fmt.Errorf("failed to construct identity %s: %w", d.Name(), err)
 		}
@@ -216,6 +216,53 @@ func TestSpacemeshApp_LoadIdentities(t *testing.T) {
 	})
 }
 
+func TestSpacemeshApp_EncryptedIdentities(t *testing.T) {
+	t.Run("new identity is encrypted", func(t *testing.T) {
+		t.Setenv(signing.PassphraseEnv, "passphrase")
+		app := New(WithLog(logtest.New(t)))
+		app.Config.DataDirParent = t.TempDir()
+		require.NoError(t, app.NewIdentity())
+		created := app.signers[0]
+
+		data, err := os.ReadFile(filepath.Join(app.Config.DataDirParent, keyDir, supervisedIDKeyFileName))
+		require.NoError(t, err)
+		require.True(t, signing.IsEncryptedKey(data))
+
+		require.NoError(t, app.LoadIdentities())
+		require.Len(t, app.signers, 1)
+		require.Equal(t, created.PublicKey(), app.signers[0].PublicKey())
+	})
+
+	t.Run("encrypted and plain text keys", func(t *testing.T) {
+		key1, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		key2, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		encrypted, err := signing.EncryptKey(key2.PrivateKey(), []byte("passphrase"))
+		require.NoError(t, err)
+
+		app, _ := setupAppWithKeys(t, []byte(hex.EncodeToString(key1.PrivateKey())), encrypted)
+		passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+		require.NoError(t, os.WriteFile(passphraseFile, []byte("passphrase\n"), 0o600))
+		app.Config.IdentityPassphraseFile = passphraseFile
+
+		require.NoError(t, app.LoadIdentities())
+		require.Len(t, app.signers, 2)
+	})
+
+	t.Run("missing passphrase", func(t *testing.T) {
+		t.Setenv(signing.PassphraseEnv, "")
+		key, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		encrypted, err := signing.EncryptKey(key.PrivateKey(), []byte("passphrase"))
+		require.NoError(t, err)
+
+		app, _ := setupAppWithKeys(t, encrypted)
+		require.ErrorIs(t, app.LoadIdentities(), signing.ErrPassphraseRequired)
+		require.Empty(t, app.signers)
+	})
+}
+
 func Test_MigrateExistingIdentity(t *testing.T) {
 	t.Run("no key - no migration", func(t *testing.T) {
 		app := New(WithLog(logtest.New(t)))
@@ -314,3 +361,32 @@ func Test_MigrateExistingIdentity(t *testing.T) {
 		require.Equal(t, []byte(sigBackup.PrivateKey()), backupKeyBin)
 	})
 }
+
+func TestSpacemeshApp_CheckpointSigner(t *testing.T) {
+	t.Run("created and reloaded", func(t *testing.T) {
+		app := New(WithLog(logtest.New(t)))
+		app.Config.DataDirParent = t.TempDir()
+		app.Config.Recovery.SignerKey = "checkpoint.key"
+
+		signer, err := app.checkpointSigner()
+		require.NoError(t, err)
+		require.FileExists(t, filepath.Join(app.Config.DataDirParent, "checkpoint.key"))
+
+		reloaded, err := app.checkpointSigner()
+		require.NoError(t, err)
+		require.Equal(t, signer.NodeID(), reloaded.NodeID())
+
+		// checkpoint key is not loaded as a smeshing identity
+		require.NoError(t, app.NewIdentity())
+		require.Len(t, app.signers, 1)
+		require.NotEqual(t, signer.NodeID(), app.signers[0].NodeID())
+	})
+	t.Run("smeshing identity is rejected", func(t *testing.T) {
+		app := New(WithLog(logtest.New(t)))
+		app.Config.DataDirParent = t.TempDir()
+		app.Config.Recovery.SignerKey = filepath.Join(keyDir, supervisedIDKeyFileName)
+
+		_, err := app.checkpointSigner()
+		require.ErrorContains(t, err, "must not be stored with smeshing identities")
+	})
+}
@@ -0,0 +1,187 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+// identityRegistry is a component that signs with the identities of the node.
+type identityRegistry interface {
+	Register(sig *signing.EdSigner)
+	Unregister(id types.NodeID)
+}
+
+// signersSnapshot returns identities of the node. The returned slice is never modified, identities
+// that are added or removed later are not reflected in it.
+func (app *App) signersSnapshot() []*signing.EdSigner {
+	app.identitiesMu.Lock()
+	defer app.identitiesMu.Unlock()
+	return app.signers
+}
+
+// supervised returns true if signers are a single identity with the supervised post service.
+func supervised(signers []*signing.EdSigner) bool {
+	return len(signers) == 1 && signers[0].Name() == supervisedIDKeyFileName
+}
+
+// AddIdentity loads the identity file with the given name from the identities directory and registers
+// it with every component of the running node.
+//
+// Identity whose smesher ID is already registered is rejected, as smeshing with the same key twice
+// leads to equivocation.
+func (app *App) AddIdentity(name string) (types.NodeID, error) {
+	if filepath.Base(name) != name || filepath.Ext(name) != ".key" {
+		return types.EmptyNodeID, fmt.Errorf("%w: identity file name %s", fs.ErrInvalid, name)
+	}
+	opts, err := app.passphraseOpts()
+	if err != nil {
+		return types.EmptyNodeID, err
+	}
+	signer, err := signing.NewEdSigner(append(opts,
+		signing.FromFile(filepath.Join(app.Config.DataDir(), keyDir, name)),
+		signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()),
+	)...)
+	if err != nil {
+		return types.EmptyNodeID, fmt.Errorf("failed to construct identity %s: %w", name, err)
+	}
+
+	app.identitiesMu.Lock()
+	defer app.identitiesMu.Unlock()
+	if name == supervisedIDKeyFileName || supervised(app.signers) {
+		return types.EmptyNodeID, fmt.Errorf("%w: identities can't be added in supervised smeshing",
+			errors.ErrUnsupported)
+	}
+	for _, sig := range app.signers {
+		if sig.NodeID() == signer.NodeID() {
+			return types.EmptyNodeID, fmt.Errorf("%w: identity %s from %s is already loaded from %s",
+				fs.ErrExist, signer.NodeID().ShortString(), name, sig.Name())
+		}
+		if sig.Name() == name {
+			return types.EmptyNodeID, fmt.Errorf("%w: identity file %s is already loaded", fs.ErrExist, name)
+		}
+	}
+	if app.equivocationGuard != nil {
+		signer.SetGuard(app.equivocationGuard)
+	}
+	// signers are replaced rather than modified in place, as readers hold snapshots without the lock
+	app.signers = append(slices.Clip(app.signers), signer)
+	for _, registry := range app.identityRegistries {
+		registry.Register(signer)
+	}
+	app.log.With().Info("Added identity",
+		log.String("filename", name),
+		signer.PublicKey(),
+	)
+	return signer.NodeID(), nil
+}
+
+// RemoveIdentity unregisters the identity loaded from the identity file with the given name from every
+// component of the running node. The identity file and local state of the identity are kept.
+func (app *App) RemoveIdentity(name string) (types.NodeID, error) {
+	app.identitiesMu.Lock()
+	defer app.identitiesMu.Unlock()
+	idx := slices.IndexFunc(app.signers, func(sig *signing.EdSigner) bool { return sig.Name() == name })
+	switch {
+	case idx == -1:
+		return types.EmptyNodeID, fmt.Errorf("%w: identity file %s is not loaded", fs.ErrNotExist, name)
+	case supervised(app.signers):
+		return types.EmptyNodeID, fmt.Errorf("%w: identities can't be removed in supervised smeshing",
+			errors.ErrUnsupported)
+	case len(app.signers) == 1:
+		return types.EmptyNodeID, fmt.Errorf("%w: last identity can't be removed", errors.ErrUnsupported)
+	}
+	signer := app.signers[idx]
+	app.signers = slices.Delete(slices.Clone(app.signers), idx, idx+1)
+	// unregister in reverse order so that the atx builder stops first
+	for i := len(app.identityRegistries) - 1; i >= 0; i-- {
+		app.identityRegistries[i].Unregister(signer.NodeID())
+	}
+	app.log.With().Info("Removed identity",
+		log.String("filename", name),
+		signer.PublicKey(),
+	)
+	return signer.NodeID(), nil
+}
+
+// watchIdentities periodically checks the identities directory and adds or removes identities when
+// identity files are added or removed.
+func (app *App) watchIdentities(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	// files that failed to load are retried only after they are modified
+	failed := map[string]time.Time{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			app.syncIdentities(failed)
+		}
+	}
+}
+
+func (app *App) syncIdentities(failed map[string]time.Time) {
+	dir := filepath.Join(app.Config.DataDir(), keyDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		app.log.Zap().Warn("failed to read identities directory", zap.String("dir", dir), zap.Error(err))
+		return
+	}
+	files := make(map[string]time.Time, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".key" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files[entry.Name()] = info.ModTime()
+	}
+
+	signers := app.signersSnapshot()
+	loaded := make(map[string]struct{}, len(signers))
+	for _, sig := range signers {
+		loaded[sig.Name()] = struct{}{}
+	}
+
+	for name, modified := range files {
+		if _, exists := loaded[name]; exists {
+			continue
+		}
+		if last, exists := failed[name]; exists && last.Equal(modified) {
+			continue
+		}
+		if _, err := app.AddIdentity(name); err != nil {
+			app.log.Zap().Error("failed to add identity", zap.String("filename", name), zap.Error(err))
+			failed[name] = modified
+			continue
+		}
+		delete(failed, name)
+	}
+	for name := range loaded {
+		if _, exists := files[name]; exists || name == "" {
+			continue
+		}
+		if _, err := app.RemoveIdentity(name); err != nil {
+			app.log.Zap().Error("failed to remove identity", zap.String("filename", name), zap.Error(err))
+		}
+	}
+	for name := range failed {
+		if _, exists := files[name]; !exists {
+			delete(failed, name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package node
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+)
+
+type testRegistry struct {
+	signers map[types.NodeID]*signing.EdSigner
+}
+
+func (r *testRegistry) Register(sig *signing.EdSigner) {
+	r.signers[sig.NodeID()] = sig
+}
+
+func (r *testRegistry) Unregister(id types.NodeID) {
+	delete(r.signers, id)
+}
+
+func writeIdentity(tb testing.TB, app *App, name string) *signing.EdSigner {
+	signer, err := signing.NewEdSigner(signing.WithPrefix(app.Config.Genesis.GenesisID().Bytes()))
+	require.NoError(tb, err)
+	keyFile := filepath.Join(app.Config.DataDirParent, keyDir, name)
+	require.NoError(tb, os.WriteFile(keyFile, []byte(hex.EncodeToString(signer.PrivateKey())), 0o600))
+	return signer
+}
+
+func setupAppWithRegistry(tb testing.TB) (*App, *testRegistry) {
+	first, err := signing.NewEdSigner()
+	require.NoError(tb, err)
+	second, err := signing.NewEdSigner()
+	require.NoError(tb, err)
+	app, _ := setupAppWithKeys(tb,
+		[]byte(hex.EncodeToString(first.PrivateKey())),
+		[]byte(hex.EncodeToString(second.PrivateKey())),
+	)
+	require.NoError(tb, app.LoadIdentities())
+	registry := &testRegistry{signers: map[types.NodeID]*signing.EdSigner{}}
+	for _, sig := range app.signers {
+		registry.Register(sig)
+	}
+	app.identityRegistries = []identityRegistry{registry}
+	return app, registry
+}
+
+func TestSpacemeshApp_AddIdentity(t *testing.T) {
+	t.Run("added", func(t *testing.T) {
+		app, registry := setupAppWithRegistry(t)
+		signer := writeIdentity(t, app, "new.key")
+
+		id, err := app.AddIdentity("new.key")
+		require.NoError(t, err)
+		require.Equal(t, signer.NodeID(), id)
+		require.Len(t, app.signers, 3)
+		require.Contains(t, registry.signers, id)
+	})
+
+	t.Run("duplicate smesher id", func(t *testing.T) {
+		app, registry := setupAppWithRegistry(t)
+		data, err := os.ReadFile(filepath.Join(app.Config.DataDirParent, keyDir, "identity_0.key"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(app.Config.DataDirParent, keyDir, "copy.key"), data, 0o600))
+
+		_, err = app.AddIdentity("copy.key")
+		require.ErrorIs(t, err, fs.ErrExist)
+		require.Len(t, app.signers, 2)
+		require.Len(t, registry.signers, 2)
+	})
+
+	t.Run("already loaded", func(t *testing.T) {
+		app, _ := setupAppWithRegistry(t)
+		_, err := app.AddIdentity("identity_1.key")
+		require.ErrorIs(t, err, fs.ErrExist)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		app, _ := setupAppWithRegistry(t)
+		_, err := app.AddIdentity("../identity_1.key")
+		require.ErrorIs(t, err, fs.ErrInvalid)
+		_, err = app.AddIdentity("identity_1.txt")
+		require.ErrorIs(t, err, fs.ErrInvalid)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		app, _ := setupAppWithRegistry(t)
+		_, err := app.AddIdentity("missing.key")
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+}
+
+func TestSpacemeshApp_RemoveIdentity(t *testing.T) {
+	t.Run("removed", func(t *testing.T) {
+		app, registry := setupAppWithRegistry(t)
+		snapshot := app.signersSnapshot()
+		before := slices.Clone(snapshot)
+		id, err := app.RemoveIdentity("identity_0.key")
+		require.NoError(t, err)
+		require.Len(t, app.signers, 1)
+		require.NotContains(t, registry.signers, id)
+		require.Equal(t, before, snapshot, "snapshot must not be modified")
+		require.FileExists(t, filepath.Join(app.Config.DataDirParent, keyDir, "identity_0.key"))
+	})
+
+	t.Run("not loaded", func(t *testing.T) {
+		app, _ := setupAppWithRegistry(t)
+		_, err := app.RemoveIdentity("missing.key")
+		require.ErrorIs(t, err, fs.ErrNotExist)
+	})
+
+	t.Run("last identity", func(t *testing.T) {
+		app, _ := setupAppWithRegistry(t)
+		_, err := app.RemoveIdentity("identity_0.key")
+		require.NoError(t, err)
+		_, err = app.RemoveIdentity("identity_1.key")
+		require.ErrorIs(t, err, errors.ErrUnsupported)
+		require.Len(t, app.signers, 1)
+	})
+}
+
+func TestSpacemeshApp_SyncIdentities(t *testing.T) {
+	app, registry := setupAppWithRegistry(t)
+	failed := map[string]time.Time{}
+
+	signer := writeIdentity(t, app, "new.key")
+	require.NoError(t, os.Remove(filepath.Join(app.Config.DataDirParent, keyDir, "identity_0.key")))
+	bad := filepath.Join(app.Config.DataDirParent, keyDir, "bad.key")
+	require.NoError(t, os.WriteFile(bad, []byte("bad"), 0o600))
+
+	app.syncIdentities(failed)
+	require.Len(t, app.signers, 2)
+	require.Len(t, registry.signers, 2)
+	require.Contains(t, registry.signers, signer.NodeID())
+	require.Contains(t, failed, "bad.key")
+
+	require.NoError(t, os.Remove(bad))
+	app.syncIdentities(failed)
+	require.Empty(t, failed)
+}
@@ -0,0 +1,297 @@
+// Package capture records gossip and request-protocol traffic to rotating files.
+//
+// Every record is prefixed with the uvarint length of the scale encoded Record,
+// and is written with a single write, so that the rotated file never contains partial records.
+package capture
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-varint"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/codec"
+)
+
+// File is the name of the file with captured traffic. Rotated files are kept next to it,
+// with the sequence number of rotation added to the name.
+const File = "traffic.bin"
+
+const (
+	// maxRecordSize is larger than the largest server response.
+	maxRecordSize = 1 << 27
+	// recordOverhead is larger than the encoded record without data.
+	recordOverhead = 2 << 10
+)
+
+// Direction of the message relative to the local node.
+type Direction uint8
+
+const (
+	// Inbound messages are received from the peer.
+	Inbound Direction = iota + 1
+	// Outbound messages are sent to the peer, or published to gossip.
+	Outbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "in"
+	case Outbound:
+		return "out"
+	default:
+		return fmt.Sprintf("direction(%d)", d)
+	}
+}
+
+// Kind of the recorded message.
+type Kind uint8
+
+const (
+	// Gossip is a message that is published or received on a gossip topic.
+	Gossip Kind = iota + 1
+	// Request is a request of the request-response protocol.
+	Request
+	// Response is a response of the request-response protocol, as it is written to the stream.
+	Response
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Gossip:
+		return "gossip"
+	case Request:
+		return "request"
+	case Response:
+		return "response"
+	default:
+		return fmt.Sprintf("kind(%d)", k)
+	}
+}
+
+//go:generate scalegen -types Record
+
+// Record is a single captured message.
+type Record struct {
+	// Timestamp is unix time in nanoseconds when the message was sent or received.
+	Timestamp uint64
+	Peer      string `scale:"max=128"`
+	// Protocol is either gossip topic (ax1, pp1) or server protocol (hs/1, ax/1).
+	Protocol  string `scale:"max=64"`
+	Direction Direction
+	Kind      Kind
+	// Result is the validation result of gossip message or the outcome of the request.
+	// It is empty if the outcome is not known when the message is recorded.
+	Result string `scale:"max=1024"`
+	// Size of the message in bytes. Data is truncated if the message is larger than the configured limit.
+	Size uint64
+	Data []byte `scale:"max=134217728"` // keep in line with maxRecordSize
+}
+
+// Truncated returns true if Data is not the whole message.
+func (r *Record) Truncated() bool {
+	return uint64(len(r.Data)) < r.Size
+}
+
+// Time when the message was sent or received.
+func (r *Record) Time() time.Time {
+	return time.Unix(0, int64(r.Timestamp))
+}
+
+// PeerID returns the ID of the peer that sent or received the message.
+func (r *Record) PeerID() peer.ID {
+	return peer.ID(r.Peer)
+}
+
+// Config for the Recorder.
+type Config struct {
+	// Enable traffic capture. Files are written to the capture directory inside p2p data directory.
+	Enable bool `mapstructure:"enable"`
+	// MaxSize is the size of the file in megabytes before it is rotated.
+	MaxSize int `mapstructure:"max-size"`
+	// MaxFiles is the number of rotated files that are kept.
+	MaxFiles int `mapstructure:"max-files"`
+	// MaxDataSize is the number of bytes of the message that are recorded, larger messages are truncated.
+	// It is reduced to fit the record into a single file if MaxSize is smaller.
+	MaxDataSize int `mapstructure:"max-data-size"`
+	// Protocols limits capture to the listed gossip topics and server protocols. Everything is captured if empty.
+	Protocols []string `mapstructure:"protocols"`
+}
+
+// DefaultConfig for the Recorder.
+func DefaultConfig() Config {
+	return Config{
+		MaxSize:     100,
+		MaxFiles:    10,
+		MaxDataSize: 1 << 20,
+	}
+}
+
+// Opt is for configuring Recorder.
+type Opt func(r *Recorder)
+
+// WithLogger sets logger for Recorder.
+func WithLogger(logger *zap.Logger) Opt {
+	return func(r *Recorder) {
+		r.logger = logger
+	}
+}
+
+// WithConfig sets Config for Recorder.
+func WithConfig(cfg Config) Opt {
+	return func(r *Recorder) {
+		r.cfg = cfg
+	}
+}
+
+// withClock is used in tests.
+func withClock(now func() time.Time) Opt {
+	return func(r *Recorder) {
+		r.now = now
+	}
+}
+
+// Recorder writes records to the file in the directory, and rotates it when it exceeds configured size.
+// It is safe for concurrent use.
+type Recorder struct {
+	logger *zap.Logger
+	cfg    Config
+	now    func() time.Time
+
+	maxData int
+
+	mu  sync.Mutex
+	out *rotator
+	buf []byte
+}
+
+// New creates Recorder that writes to the File in the directory.
+func New(dir string, opts ...Opt) *Recorder {
+	r := &Recorder{
+		logger: zap.NewNop(),
+		cfg:    DefaultConfig(),
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.maxData = max(0, min(r.cfg.MaxDataSize, r.cfg.MaxSize<<20-recordOverhead))
+	r.out = &rotator{
+		dir:      dir,
+		maxSize:  int64(r.cfg.MaxSize) << 20,
+		maxFiles: r.cfg.MaxFiles,
+	}
+	return r
+}
+
+// Enabled returns true if messages of the protocol should be recorded.
+func (r *Recorder) Enabled(protocol string) bool {
+	return len(r.cfg.Protocols) == 0 || slices.Contains(r.cfg.Protocols, protocol)
+}
+
+// NewBuffer returns Buffer that keeps as much of the message as is recorded.
+func (r *Recorder) NewBuffer() *Buffer {
+	return &Buffer{limit: r.maxData}
+}
+
+// Record the message, timestamp is set to the current time.
+// Size is set to the length of Data if it is zero, and Data is truncated to the configured limit.
+// Errors are logged and otherwise ignored, capture never affects handling of the message.
+func (r *Recorder) Record(rec Record) {
+	if !r.Enabled(rec.Protocol) {
+		return
+	}
+	rec.Timestamp = uint64(r.now().UnixNano())
+	rec.Size = max(rec.Size, uint64(len(rec.Data)))
+	if len(rec.Data) > r.maxData {
+		rec.Data = rec.Data[:r.maxData]
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := codec.Encode(&rec)
+	if err != nil {
+		r.logger.Debug("failed to encode captured message", zap.String("protocol", rec.Protocol), zap.Error(err))
+		return
+	}
+	r.buf = binary.AppendUvarint(r.buf[:0], uint64(len(data)))
+	r.buf = append(r.buf, data...)
+	if _, err := r.out.Write(r.buf); err != nil {
+		r.logger.Warn("failed to write captured message", zap.String("protocol", rec.Protocol), zap.Error(err))
+	}
+}
+
+// Close the file.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.out.Close()
+}
+
+// Buffer keeps the beginning of the message that is written to it, up to the limit of the Recorder,
+// so that large messages are not held in memory only to be truncated when they are recorded.
+type Buffer struct {
+	limit int
+	data  []byte
+	size  int
+}
+
+// Write never fails, data over the limit is counted and discarded.
+func (b *Buffer) Write(p []byte) (int, error) {
+	if keep := min(len(p), b.limit-len(b.data)); keep > 0 {
+		b.data = append(b.data, p[:keep]...)
+	}
+	b.size += len(p)
+	return len(p), nil
+}
+
+// Bytes returns the kept beginning of the message.
+func (b *Buffer) Bytes() []byte {
+	return b.data
+}
+
+// Size returns the number of bytes written to the Buffer.
+func (b *Buffer) Size() int {
+	return b.size
+}
+
+// Reader reads records written by Recorder.
+type Reader struct {
+	rd *bufio.Reader
+}
+
+// NewReader creates Reader.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{rd: bufio.NewReader(r)}
+}
+
+// Next returns the next record, or io.EOF when there are no more records.
+func (r *Reader) Next() (*Record, error) {
+	size, err := varint.ReadUvarint(r.rd)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxRecordSize {
+		return nil, fmt.Errorf("record size %d exceeds limit %d", size, maxRecordSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r.rd, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, fmt.Errorf("read record: %w", err)
+	}
+	var rec Record
+	if err := codec.Decode(data, &rec); err != nil {
+		return nil, fmt.Errorf("decode record: %w", err)
+	}
+	return &rec, nil
+}
@@ -0,0 +1,161 @@
+package capture
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readAll(tb testing.TB, path string) []*Record {
+	tb.Helper()
+	f, err := os.Open(path)
+	require.NoError(tb, err)
+	defer f.Close()
+	rd := NewReader(f)
+	var records []*Record
+	for {
+		rec, err := rd.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		require.NoError(tb, err)
+		records = append(records, rec)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Unix(0, 1_000_000)
+	cfg := DefaultConfig()
+	cfg.Protocols = []string{"ax1", "hs/1"}
+	r := New(dir, WithConfig(cfg), withClock(func() time.Time { return now }))
+
+	records := []Record{
+		{Peer: "a", Protocol: "ax1", Direction: Inbound, Kind: Gossip, Result: "accept", Data: []byte{1, 2}},
+		{Peer: "b", Protocol: "hs/1", Direction: Outbound, Kind: Request, Data: []byte{3}},
+		{Peer: "b", Protocol: "hs/1", Direction: Inbound, Kind: Response, Result: "ok", Data: []byte{4}},
+	}
+	for _, rec := range records {
+		r.Record(rec)
+	}
+	r.Record(Record{Peer: "c", Protocol: "pp1", Direction: Inbound, Kind: Gossip})
+	require.False(t, r.Enabled("pp1"))
+	require.NoError(t, r.Close())
+
+	got := readAll(t, filepath.Join(dir, File))
+	require.Len(t, got, len(records))
+	for i := range records {
+		records[i].Timestamp = uint64(now.UnixNano())
+		records[i].Size = uint64(len(records[i].Data))
+		require.Equal(t, records[i], *got[i])
+		require.Equal(t, now, got[i].Time())
+	}
+}
+
+func TestRecorderRotate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.MaxSize = 1
+	cfg.MaxFiles = 2
+	data := make([]byte, 400<<10)
+	record := func(r *Recorder, ids ...byte) {
+		for _, id := range ids {
+			data[0] = id
+			r.Record(Record{Protocol: "ax1", Direction: Inbound, Kind: Gossip, Data: data})
+		}
+	}
+	read := func() []byte {
+		var ids []byte
+		for _, f := range []string{rotatedFile(3), rotatedFile(4), File} {
+			for _, rec := range readAll(t, filepath.Join(dir, f)) {
+				ids = append(ids, rec.Data[0])
+			}
+		}
+		return ids
+	}
+	r := New(dir, WithConfig(cfg))
+	record(r, 0, 1, 2, 3, 4, 5, 6, 7, 8)
+	require.NoError(t, r.Close())
+
+	// files are rotated between records, every file is readable.
+	// old files are removed on rotation.
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.Len(t, files, cfg.MaxFiles+1)
+	require.Equal(t, []byte{4, 5, 6, 7, 8}, read())
+
+	// sequence continues after restart
+	r = New(dir, WithConfig(cfg))
+	record(r, 9, 10)
+	require.NoError(t, r.Close())
+	files, err = filepath.Glob(filepath.Join(dir, "*"))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		filepath.Join(dir, rotatedFile(4)),
+		filepath.Join(dir, rotatedFile(5)),
+		filepath.Join(dir, File),
+	}, files)
+	ids := readAll(t, filepath.Join(dir, File))
+	require.Len(t, ids, 1)
+	require.Equal(t, byte(10), ids[0].Data[0])
+}
+
+func TestRecorderTruncate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig()
+	cfg.MaxDataSize = 4
+	r := New(dir, WithConfig(cfg))
+	r.Record(Record{Protocol: "ax1", Direction: Inbound, Kind: Gossip, Data: []byte{1, 2, 3}})
+	r.Record(Record{Protocol: "ax1", Direction: Inbound, Kind: Gossip, Data: []byte{1, 2, 3, 4, 5, 6}})
+
+	buf := r.NewBuffer()
+	for range 3 {
+		n, err := buf.Write([]byte{7, 8, 9})
+		require.NoError(t, err)
+		require.Equal(t, 3, n)
+	}
+	require.Equal(t, []byte{7, 8, 9, 7}, buf.Bytes())
+	require.Equal(t, 9, buf.Size())
+	r.Record(Record{Protocol: "hs/1", Direction: Outbound, Kind: Response, Size: uint64(buf.Size()), Data: buf.Bytes()})
+	require.NoError(t, r.Close())
+
+	got := readAll(t, filepath.Join(dir, File))
+	require.Len(t, got, 3)
+	require.Equal(t, []byte{1, 2, 3}, got[0].Data)
+	require.EqualValues(t, 3, got[0].Size)
+	require.False(t, got[0].Truncated())
+	require.Equal(t, []byte{1, 2, 3, 4}, got[1].Data)
+	require.EqualValues(t, 6, got[1].Size)
+	require.True(t, got[1].Truncated())
+	require.Equal(t, []byte{7, 8, 9, 7}, got[2].Data)
+	require.EqualValues(t, 9, got[2].Size)
+	require.True(t, got[2].Truncated())
+
+	// data is reduced to fit into a single file
+	cfg.MaxDataSize = 2 << 20
+	cfg.MaxSize = 1
+	r = New(t.TempDir(), WithConfig(cfg))
+	require.Equal(t, 1<<20-recordOverhead, r.maxData)
+}
+
+func TestReaderTruncated(t *testing.T) {
+	dir := t.TempDir()
+	r := New(dir)
+	r.Record(Record{Protocol: "ax1", Direction: Inbound, Kind: Gossip, Data: []byte{1, 2, 3}})
+	require.NoError(t, r.Close())
+
+	path := filepath.Join(dir, File)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data[:len(data)-1], 0o600))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = NewReader(f).Next()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,136 @@
+package capture
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	rotatedPrefix = "traffic-"
+	rotatedSuffix = ".bin"
+)
+
+// rotatedFile is the name of the file with sequence number seq. Larger sequence numbers are rotated later.
+func rotatedFile(seq uint64) string {
+	return fmt.Sprintf("%s%d%s", rotatedPrefix, seq, rotatedSuffix)
+}
+
+// rotatedSeq parses the sequence number from the name of the rotated file.
+func rotatedSeq(name string) (uint64, bool) {
+	if !strings.HasPrefix(name, rotatedPrefix) || !strings.HasSuffix(name, rotatedSuffix) {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, rotatedPrefix), rotatedSuffix), 10, 64)
+	return seq, err == nil
+}
+
+// rotator writes to the File in the directory. The file is renamed to the rotatedFile with the next
+// sequence number when the write would exceed maxSize, and only maxFiles rotated files with the largest
+// sequence numbers are kept.
+//
+// Sequence numbers are used instead of the time of rotation, so that the order of files doesn't depend
+// on the clock, and files are removed synchronously on rotation.
+type rotator struct {
+	dir      string
+	maxSize  int64
+	maxFiles int
+
+	f    *os.File
+	size int64
+	// seq is the largest sequence number of the rotated file.
+	seq uint64
+}
+
+func (r *rotator) open() error {
+	if err := os.MkdirAll(r.dir, 0o700); err != nil {
+		return err
+	}
+	seqs, err := r.rotated()
+	if err != nil {
+		return err
+	}
+	if len(seqs) > 0 {
+		r.seq = seqs[len(seqs)-1]
+	}
+	f, err := os.OpenFile(filepath.Join(r.dir, File), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Join(err, f.Close())
+	}
+	r.f = f
+	r.size = info.Size()
+	return nil
+}
+
+// rotated returns sorted sequence numbers of the rotated files.
+func (r *rotator) rotated() ([]uint64, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		if seq, ok := rotatedSeq(entry.Name()); ok && entry.Type().IsRegular() {
+			seqs = append(seqs, seq)
+		}
+	}
+	slices.Sort(seqs)
+	return seqs, nil
+}
+
+func (r *rotator) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	r.f = nil
+	if err := os.Rename(filepath.Join(r.dir, File), filepath.Join(r.dir, rotatedFile(r.seq+1))); err != nil {
+		return err
+	}
+	r.seq++
+	seqs, err := r.rotated()
+	if err != nil {
+		return err
+	}
+	for _, seq := range seqs[:max(0, len(seqs)-r.maxFiles)] {
+		if err := os.Remove(filepath.Join(r.dir, rotatedFile(seq))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return r.open()
+}
+
+// Write p to the file with a single write. A write larger than maxSize is written to the empty file.
+func (r *rotator) Write(p []byte) (int, error) {
+	if r.f == nil {
+		if err := r.open(); err != nil {
+			return 0, fmt.Errorf("open %s: %w", File, err)
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, fmt.Errorf("rotate %s: %w", File, err)
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close the file, the next write opens it again.
+func (r *rotator) Close() error {
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 var _ connmgr.ConnectionGater = (*gater)(nil)
@@ -26,6 +28,10 @@ func newGater(cfg Config) (*gater, error) {
 	if err != nil {
 		return nil, err
 	}
+	g.rules, err = loadRules(cfg.DataDir)
+	if err != nil {
+		return nil, err
+	}
 	if !cfg.PrivateNetwork {
 		g.ip4blocklist, err = parseCIDR(cfg.IP4Blocklist)
 		if err != nil {
@@ -44,6 +50,8 @@ func newGater(cfg Config) (*gater, error) {
 
 type gater struct {
 	h                 host.Host
+	reputation        *reputation.Reputation
+	rules             *rules
 	inbound, outbound int
 	direct            map[peer.ID]struct{}
 	ip4blocklist      []*net.IPNet
@@ -54,33 +62,67 @@ func (g *gater) updateHost(h host.Host) {
 	g.h = h
 }
 
+func (g *gater) updateReputation(r *reputation.Reputation) {
+	g.reputation = r
+}
+
 func (g *gater) InterceptPeerDial(pid peer.ID) bool {
-	if _, exist := g.direct[pid]; exist {
+	allowed, blocked := g.match(pid, nil)
+	if allowed {
 		return true
 	}
-	return len(g.h.Network().Peers()) <= g.outbound
+	return len(g.h.Network().Peers()) <= g.outbound && !blocked
 }
 
 func (g *gater) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) bool {
-	if _, exist := g.direct[pid]; exist {
+	allowed, blocked := g.match(pid, nil)
+	if allowed {
 		return true
 	}
-	return len(g.h.Network().Peers()) <= g.outbound && g.allowed(m)
+	return len(g.h.Network().Peers()) <= g.outbound && g.allowed(m) && !blocked
 }
 
 func (g *gater) InterceptAccept(n network.ConnMultiaddrs) bool {
-	return len(g.h.Network().Peers()) <= g.inbound
+	allowed, blocked := g.match("", ipFromMultiaddr(n.RemoteMultiaddr()))
+	if allowed {
+		return true
+	}
+	return len(g.h.Network().Peers()) <= g.inbound && !blocked
 }
 
-func (*gater) InterceptSecured(_ network.Direction, _ peer.ID, _ network.ConnMultiaddrs) bool {
-	return true
+func (g *gater) InterceptSecured(_ network.Direction, pid peer.ID, _ network.ConnMultiaddrs) bool {
+	allowed, blocked := g.match(pid, nil)
+	return allowed || !blocked
+}
+
+// match returns true in allowed if the peer is direct or allowlisted,
+// and true in blocked if the peer or the address is blocked by the rules or the peer is banned.
+func (g *gater) match(pid peer.ID, ip net.IP) (allowed, blocked bool) {
+	if _, exist := g.direct[pid]; exist {
+		return true, false
+	}
+	if g.rules != nil {
+		allowed, blocked = g.rules.match(pid, ip)
+	}
+	if pid != "" && g.reputation != nil && g.reputation.Banned(pid) {
+		blocked = true
+	}
+	return allowed, blocked
 }
 
 func (*gater) InterceptUpgraded(_ network.Conn) (allow bool, reason control.DisconnectReason) {
 	return true, 0
 }
 
+// allowed checks the address against the rules and the static blocklists.
+// Address that is allowed by the rules bypasses the static blocklists.
 func (g *gater) allowed(m multiaddr.Multiaddr) bool {
+	if g.rules != nil {
+		allowed, blocked := g.rules.match("", ipFromMultiaddr(m))
+		if allowed || blocked {
+			return allowed
+		}
+	}
 	allow := true
 	multiaddr.ForEach(m, func(c multiaddr.Component) bool {
 		switch c.Protocol().Code {
@@ -3,9 +3,13 @@ package p2p
 import (
 	"testing"
 
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 func TestGater(t *testing.T) {
@@ -32,3 +36,31 @@ func TestGater(t *testing.T) {
 		})
 	}
 }
+
+func TestGaterBanned(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Reputation.BanThreshold = -1
+	h, err := mocknet.New().GenPeer()
+	require.NoError(t, err)
+	g, err := newGater(cfg)
+	require.NoError(t, err)
+	g.updateHost(h)
+	rep := reputation.New(reputation.WithConfig(cfg.Reputation))
+	g.updateReputation(rep)
+	g.direct["direct"] = struct{}{}
+	addr, err := multiaddr.NewMultiaddr("/ip4/95.217.200.84/tcp/8000")
+	require.NoError(t, err)
+
+	for _, pid := range []peer.ID{"banned", "direct"} {
+		require.True(t, g.InterceptPeerDial(pid))
+		require.True(t, g.InterceptAddrDial(pid, addr))
+		require.True(t, g.InterceptSecured(network.DirInbound, pid, nil))
+		rep.Record(pid, reputation.InvalidData)
+	}
+	require.False(t, g.InterceptPeerDial("banned"))
+	require.False(t, g.InterceptAddrDial("banned", addr))
+	require.False(t, g.InterceptSecured(network.DirInbound, "banned", nil))
+	require.True(t, g.InterceptPeerDial("direct"))
+	require.True(t, g.InterceptAddrDial("direct", addr))
+	require.True(t, g.InterceptSecured(network.DirInbound, "direct", nil))
+}
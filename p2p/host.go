@@ -31,8 +31,10 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 	"github.com/spacemeshos/go-spacemesh/p2p/handshake"
 	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 // DefaultConfig config.
@@ -102,6 +104,8 @@ func DefaultConfig() Config {
 			AdvertiseRetryDelay:     time.Minute,
 			FindPeersRetryDelay:     time.Minute,
 		},
+		Reputation: reputation.DefaultConfig(),
+		Capture:    capture.DefaultConfig(),
 	}
 }
 
@@ -118,47 +122,50 @@ type Config struct {
 	MaxMessageSize     int           `mapstructure:"maxmessagesize"`
 
 	// see https://lwn.net/Articles/542629/ for reuseport explanation
-	DisableReusePort            bool             `mapstructure:"disable-reuseport"`
-	DisableNatPort              bool             `mapstructure:"disable-natport"`
-	DisableConnectionManager    bool             `mapstructure:"disable-connection-manager"`
-	DisableResourceManager      bool             `mapstructure:"disable-resource-manager"`
-	DisableDHT                  bool             `mapstructure:"disable-dht"`
-	DisablePubSub               bool             `mapstructure:"disable-pubsub"`
-	Flood                       bool             `mapstructure:"flood"`
-	Listen                      AddressList      `mapstructure:"listen"`
-	Bootnodes                   []string         `mapstructure:"bootnodes"`
-	Direct                      []string         `mapstructure:"direct"`
-	MinPeers                    int              `mapstructure:"min-peers"`
-	LowPeers                    int              `mapstructure:"low-peers"`
-	HighPeers                   int              `mapstructure:"high-peers"`
-	InboundFraction             float64          `mapstructure:"inbound-fraction"`
-	OutboundFraction            float64          `mapstructure:"outbound-fraction"`
-	AutoscalePeers              bool             `mapstructure:"autoscale-peers"`
-	AdvertiseAddress            AddressList      `mapstructure:"advertise-address"`
-	AcceptQueue                 int              `mapstructure:"p2p-accept-queue"`
-	Metrics                     bool             `mapstructure:"p2p-metrics"`
-	Bootnode                    bool             `mapstructure:"p2p-bootnode"`
-	ForceReachability           string           `mapstructure:"p2p-reachability"`
-	ForceDHTServer              bool             `mapstructure:"force-dht-server"`
-	EnableHolepunching          bool             `mapstructure:"p2p-holepunching"`
-	PrivateNetwork              bool             `mapstructure:"p2p-private-network"`
-	RelayServer                 RelayServer      `mapstructure:"relay-server"`
-	IP4Blocklist                []string         `mapstructure:"ip4-blocklist"`
-	IP6Blocklist                []string         `mapstructure:"ip6-blocklist"`
-	GossipQueueSize             int              `mapstructure:"gossip-queue-size"`
-	GossipPeerOutboundQueueSize int              `mapstructure:"gossip-peer-outbound-queue-size"`
-	GossipValidationThrottle    int              `mapstructure:"gossip-validation-throttle"`
-	GossipAtxValidationThrottle int              `mapstructure:"gossip-atx-validation-throttle"`
-	PingPeers                   []string         `mapstructure:"ping-peers"`
-	PingInterval                time.Duration    `mapstructure:"ping-interval"`
-	Relay                       bool             `mapstructure:"relay"`
-	StaticRelays                []string         `mapstructure:"static-relays"`
-	EnableTCPTransport          bool             `mapstructure:"enable-tcp-transport"`
-	EnableQUICTransport         bool             `mapstructure:"enable-quic-transport"`
-	EnableRoutingDiscovery      bool             `mapstructure:"enable-routing-discovery"`
-	RoutingDiscoveryAdvertise   bool             `mapstructure:"routing-discovery-advertise"`
-	DiscoveryTimings            DiscoveryTimings `mapstructure:"discovery-timings"`
-	AutoNATServer               AutoNATServer    `mapstructure:"auto-nat-server"`
+	DisableReusePort            bool              `mapstructure:"disable-reuseport"`
+	DisableNatPort              bool              `mapstructure:"disable-natport"`
+	DisableConnectionManager    bool              `mapstructure:"disable-connection-manager"`
+	DisableResourceManager      bool              `mapstructure:"disable-resource-manager"`
+	DisableDHT                  bool              `mapstructure:"disable-dht"`
+	DisablePubSub               bool              `mapstructure:"disable-pubsub"`
+	Flood                       bool              `mapstructure:"flood"`
+	Listen                      AddressList       `mapstructure:"listen"`
+	Bootnodes                   []string          `mapstructure:"bootnodes"`
+	Direct                      []string          `mapstructure:"direct"`
+	MinPeers                    int               `mapstructure:"min-peers"`
+	LowPeers                    int               `mapstructure:"low-peers"`
+	HighPeers                   int               `mapstructure:"high-peers"`
+	InboundFraction             float64           `mapstructure:"inbound-fraction"`
+	OutboundFraction            float64           `mapstructure:"outbound-fraction"`
+	AutoscalePeers              bool              `mapstructure:"autoscale-peers"`
+	AdvertiseAddress            AddressList       `mapstructure:"advertise-address"`
+	AcceptQueue                 int               `mapstructure:"p2p-accept-queue"`
+	Metrics                     bool              `mapstructure:"p2p-metrics"`
+	Bootnode                    bool              `mapstructure:"p2p-bootnode"`
+	ForceReachability           string            `mapstructure:"p2p-reachability"`
+	ForceDHTServer              bool              `mapstructure:"force-dht-server"`
+	EnableHolepunching          bool              `mapstructure:"p2p-holepunching"`
+	PrivateNetwork              bool              `mapstructure:"p2p-private-network"`
+	RelayServer                 RelayServer       `mapstructure:"relay-server"`
+	IP4Blocklist                []string          `mapstructure:"ip4-blocklist"`
+	IP6Blocklist                []string          `mapstructure:"ip6-blocklist"`
+	GossipQueueSize             int               `mapstructure:"gossip-queue-size"`
+	GossipPeerOutboundQueueSize int               `mapstructure:"gossip-peer-outbound-queue-size"`
+	GossipValidationThrottle    int               `mapstructure:"gossip-validation-throttle"`
+	GossipAtxValidationThrottle int               `mapstructure:"gossip-atx-validation-throttle"`
+	PingPeers                   []string          `mapstructure:"ping-peers"`
+	PingInterval                time.Duration     `mapstructure:"ping-interval"`
+	Relay                       bool              `mapstructure:"relay"`
+	StaticRelays                []string          `mapstructure:"static-relays"`
+	EnableTCPTransport          bool              `mapstructure:"enable-tcp-transport"`
+	EnableQUICTransport         bool              `mapstructure:"enable-quic-transport"`
+	EnableRoutingDiscovery      bool              `mapstructure:"enable-routing-discovery"`
+	RoutingDiscoveryAdvertise   bool              `mapstructure:"routing-discovery-advertise"`
+	DiscoveryTimings            DiscoveryTimings  `mapstructure:"discovery-timings"`
+	AutoNATServer               AutoNATServer     `mapstructure:"auto-nat-server"`
+	Reputation                  reputation.Config `mapstructure:"reputation"`
+	// Capture records gossip and request-protocol traffic to the capture directory inside DataDir.
+	Capture capture.Config `mapstructure:"capture"`
 }
 
 type DiscoveryTimings struct {
@@ -390,6 +397,8 @@ func New(
 		return nil, fmt.Errorf("failed to initialize libp2p host: %w", err)
 	}
 	g.updateHost(h)
+	rep := newReputation(logger, cfg, h)
+	g.updateReputation(rep)
 	h.Network().Notify(p2pmetrics.NewConnectionsMeeter())
 
 	logger.Zap().Info("local node identity", zap.Stringer("identity", h.ID()))
@@ -401,6 +410,8 @@ func New(
 		WithLog(logger),
 		WithBootnodes(bootnodesMap),
 		WithDirectNodes(g.direct),
+		WithReputation(rep),
+		withRules(g.rules),
 	)
 	return Upgrade(h, opts...)
 }
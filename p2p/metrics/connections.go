@@ -34,6 +34,14 @@ var (
 		"Connections dropped due to ErrValidationReject result",
 		nil,
 	).WithLabelValues()
+
+	// BannedPeers is incremented every time a peer is banned due to low reputation score.
+	BannedPeers = metrics.NewCounter(
+		"banned_peers",
+		subsystem,
+		"Peers banned due to low reputation score",
+		nil,
+	).WithLabelValues()
 )
 
 // ConnectionsMeeter stores the number of connections for node.
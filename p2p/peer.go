@@ -15,6 +15,8 @@ type PeerInfo struct {
 	ID          Peer
 	Connections []ConnectionInfo
 	Tags        []string
+	// Reputation is the score of the peer, see reputation package.
+	Reputation float64
 }
 
 type ConnectionInfo struct {
@@ -14,6 +14,8 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 const (
@@ -33,6 +35,7 @@ type Ping struct {
 	pr       routing.PeerRouting
 	interval time.Duration
 	stats    map[peer.ID]*pingStat
+	rep      *reputation.Reputation
 	cancel   context.CancelFunc
 	eg       errgroup.Group
 }
@@ -45,6 +48,13 @@ func WithPingInterval(d time.Duration) PingOpt {
 	}
 }
 
+// WithPingReputation records failed pings as timeouts in the reputation service.
+func WithPingReputation(r *reputation.Reputation) PingOpt {
+	return func(p *Ping) {
+		p.rep = r
+	}
+}
+
 func NewPing(logger *zap.Logger, h host.Host, peers []peer.ID, pr routing.PeerRouting, opts ...PingOpt) *Ping {
 	p := &Ping{
 		logger:   logger,
@@ -150,6 +160,9 @@ func (p *Ping) startForPeer(ctx context.Context, peerID peer.ID) {
 }
 
 func (p *Ping) record(peerID peer.ID, success bool) {
+	if !success && p.rep != nil {
+		p.rep.Record(peerID, reputation.Timeout)
+	}
 	p.Lock()
 	defer p.Unlock()
 	st := p.stats[peerID]
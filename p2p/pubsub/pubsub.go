@@ -14,7 +14,9 @@ import (
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/hash"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 func init() {
@@ -93,21 +95,30 @@ type Config struct {
 	PeerOutboundQueueSize int
 	QueueSize             int
 	Throttle              int
+	// Reputation receives rejected messages and contributes to the gossipsub peer score.
+	Reputation *reputation.Reputation
+	// Recorder captures received and published messages, if not nil.
+	Recorder *capture.Recorder
 }
 
 // New creates PubSub instance.
 func New(ctx context.Context, logger log.Log, h host.Host, cfg Config) (*GossipPubSub, error) {
 	// TODO(dshulyak) refactor code to accept options
+	if cfg.Reputation == nil {
+		cfg.Reputation = reputation.New()
+	}
 	opts := getOptions(cfg)
 	ps, err := pubsub.NewGossipSub(ctx, h, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize gossipsub instance: %w", err)
 	}
 	return &GossipPubSub{
-		logger: logger,
-		pubsub: ps,
-		topics: map[string]*pubsub.Topic{},
-		host:   h,
+		logger:     logger,
+		pubsub:     ps,
+		topics:     map[string]*pubsub.Topic{},
+		host:       h,
+		reputation: cfg.Reputation,
+		recorder:   cfg.Recorder,
 	}, nil
 }
 
@@ -161,8 +172,14 @@ func ChainGossipHandler(handlers ...GossipHandler) GossipHandler {
 }
 
 // DropPeerOnValidationReject wraps a gossip handler to provide a handler that drops a
-// peer if the wrapped handler returns ErrValidationReject.
-func DropPeerOnValidationReject(handler GossipHandler, h host.Host, logger log.Log) GossipHandler {
+// peer if the wrapped handler returns ErrValidationReject. Rejected message is recorded
+// in the reputation of the peer, so that repeatedly misbehaving peer is banned.
+func DropPeerOnValidationReject(
+	handler GossipHandler,
+	h host.Host,
+	rep *reputation.Reputation,
+	logger log.Log,
+) GossipHandler {
 	return func(ctx context.Context, peer peer.ID, data []byte) error {
 		err := handler(ctx, peer, data)
 		if errors.Is(err, ErrValidationReject) {
@@ -171,6 +188,7 @@ func DropPeerOnValidationReject(handler GossipHandler, h host.Host, logger log.L
 				log.Stringer("peer", peer),
 				log.Err(err),
 			)
+			rep.Record(peer, reputation.GossipReject)
 			p2pmetrics.DroppedConnectionsValidationReject.Inc()
 			err := h.Network().ClosePeer(peer)
 			if err != nil {
@@ -184,10 +202,19 @@ func DropPeerOnValidationReject(handler GossipHandler, h host.Host, logger log.L
 	}
 }
 
-func DropPeerOnSyncValidationReject(handler SyncHandler, h host.Host, logger log.Log) SyncHandler {
+// DropPeerOnSyncValidationReject wraps a sync handler to provide a handler that drops a
+// peer if the wrapped handler returns ErrValidationReject. Data that failed validation is recorded
+// in the reputation of the peer that served it.
+func DropPeerOnSyncValidationReject(
+	handler SyncHandler,
+	h host.Host,
+	rep *reputation.Reputation,
+	logger log.Log,
+) SyncHandler {
 	return func(ctx context.Context, hash types.Hash32, peer peer.ID, data []byte) error {
 		err := handler(ctx, hash, peer, data)
 		if errors.Is(err, ErrValidationReject) {
+			rep.Record(peer, reputation.InvalidData)
 			p2pmetrics.DroppedConnectionsValidationReject.Inc()
 			err := h.Network().ClosePeer(peer)
 			if err != nil {
@@ -233,7 +260,7 @@ func getOptions(cfg Config) []pubsub.Option {
 					if exist && !cfg.IsBootnode {
 						return 10000
 					}
-					return 0
+					return cfg.Reputation.Score(p)
 				},
 				AppSpecificWeight: 1,
 
@@ -2,6 +2,10 @@ package pubsub
 
 import (
 	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 )
 
 func TestGossip(t *testing.T) {
@@ -48,3 +53,99 @@ func TestGossip(t *testing.T) {
 	}
 	require.Eventually(t, func() bool { return len(received) == count }, 5*time.Second, 10*time.Millisecond)
 }
+
+func TestGossipCapture(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	mesh, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	topic := "test"
+	received := make(chan []byte, 1)
+	var (
+		pubsubs []PubSub
+		dirs    []string
+		recs    []*capture.Recorder
+	)
+	for _, h := range mesh.Hosts() {
+		dir := t.TempDir()
+		rec := capture.New(dir)
+		ps, err := New(ctx, logtest.New(t), h, Config{
+			Flood:      true,
+			IsBootnode: true,
+			QueueSize:  1000,
+			Throttle:   1000,
+			Recorder:   rec,
+		})
+		require.NoError(t, err)
+		ps.Register(topic, func(ctx context.Context, pid peer.ID, msg []byte) error {
+			if string(msg) == "bad" {
+				return ErrValidationReject
+			}
+			received <- msg
+			return nil
+		})
+		pubsubs = append(pubsubs, ps)
+		dirs = append(dirs, dir)
+		recs = append(recs, rec)
+	}
+	require.NoError(t, mesh.ConnectAllButSelf())
+	require.Eventually(t, func() bool {
+		return len(pubsubs[0].ProtocolPeers(topic)) == 1 && len(pubsubs[1].ProtocolPeers(topic)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, pubsubs[0].Publish(ctx, topic, []byte("good")))
+	// local validation of the published message and delivery on the remote side
+	for range 2 {
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "message wasn't delivered")
+		}
+	}
+	require.Error(t, pubsubs[1].Publish(ctx, topic, []byte("bad")))
+
+	read := func(i int) []capture.Record {
+		require.NoError(t, recs[i].Close())
+		f, err := os.Open(filepath.Join(dirs[i], capture.File))
+		require.NoError(t, err)
+		defer f.Close()
+		rd := capture.NewReader(f)
+		var records []capture.Record
+		for {
+			rec, err := rd.Next()
+			if errors.Is(err, io.EOF) {
+				return records
+			}
+			require.NoError(t, err)
+			rec.Timestamp = 0
+			records = append(records, *rec)
+		}
+	}
+	first, second := mesh.Hosts()[0].ID(), mesh.Hosts()[1].ID()
+	require.Equal(t, []capture.Record{{
+		Peer:      string(first),
+		Protocol:  topic,
+		Direction: capture.Outbound,
+		Kind:      capture.Gossip,
+		Result:    "accept",
+		Size:      4,
+		Data:      []byte("good"),
+	}}, read(0))
+	require.Equal(t, []capture.Record{{
+		Peer:      string(first),
+		Protocol:  topic,
+		Direction: capture.Inbound,
+		Kind:      capture.Gossip,
+		Result:    "accept",
+		Size:      4,
+		Data:      []byte("good"),
+	}, {
+		Peer:      string(second),
+		Protocol:  topic,
+		Direction: capture.Outbound,
+		Kind:      capture.Gossip,
+		Result:    "reject",
+		Size:      3,
+		Data:      []byte("bad"),
+	}}, read(1))
+}
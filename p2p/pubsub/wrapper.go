@@ -12,7 +12,9 @@ import (
 	"github.com/libp2p/go-libp2p/core/peer"
 
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 	"github.com/spacemeshos/go-spacemesh/p2p/metrics"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
 type PubSub interface {
@@ -49,6 +51,9 @@ type GossipPubSub struct {
 	pubsub *pubsub.PubSub
 	host   host.Host
 
+	reputation *reputation.Reputation
+	recorder   *capture.Recorder // recorder can be nil
+
 	mu     sync.RWMutex
 	topics map[string]*pubsub.Topic
 }
@@ -63,7 +68,7 @@ func (ps *GossipPubSub) Register(topic string, handler GossipHandler, opts ...Va
 		ps.logger.Panic("already registered a topic %s", topic)
 	}
 	// Drop peers on ValidationRejectErr
-	handler = DropPeerOnValidationReject(handler, ps.host, ps.logger)
+	handler = DropPeerOnValidationReject(handler, ps.host, ps.reputation, ps.logger)
 	ps.pubsub.RegisterTopicValidator(
 		topic,
 		func(ctx context.Context, pid peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
@@ -71,6 +76,17 @@ func (ps *GossipPubSub) Register(topic string, handler GossipHandler, opts ...Va
 			err := handler(log.WithNewRequestID(ctx), pid, msg.Data)
 			metrics.ProcessedMessagesDuration.WithLabelValues(topic, castResult(err)).
 				Observe(float64(time.Since(start)))
+			// locally published messages are validated too, they are captured in Publish
+			if ps.recorder != nil && pid != ps.host.ID() {
+				ps.recorder.Record(capture.Record{
+					Peer:      string(pid),
+					Protocol:  topic,
+					Direction: capture.Inbound,
+					Kind:      capture.Gossip,
+					Result:    castResult(err),
+					Data:      msg.Data,
+				})
+			}
 			if err != nil {
 				ps.logger.With().Debug("topic validation failed",
 					log.String("topic", topic), log.Err(err))
@@ -107,12 +123,38 @@ func (ps *GossipPubSub) Publish(ctx context.Context, topic string, msg []byte) e
 	if topich == nil {
 		ps.logger.Panic("Publish is called before Register for topic %s", topic)
 	}
-	if err := topich.Publish(ctx, msg); err != nil {
+	err := topich.Publish(ctx, msg)
+	if ps.recorder != nil {
+		ps.recorder.Record(capture.Record{
+			Peer:      string(ps.host.ID()),
+			Protocol:  topic,
+			Direction: capture.Outbound,
+			Kind:      capture.Gossip,
+			Result:    publishResult(err),
+			Data:      msg,
+		})
+	}
+	if err != nil {
 		return fmt.Errorf("failed to publish to topic %v: %w", topic, err)
 	}
 	return nil
 }
 
+// publishResult is the result of local validation of the published message, in terms of castResult.
+func publishResult(err error) string {
+	var verr pubsub.ValidationError
+	switch {
+	case err == nil:
+		return "accept"
+	case errors.As(err, &verr) && verr.Reason == pubsub.RejectValidationIgnored:
+		return "ignore"
+	case errors.As(err, &verr):
+		return "reject"
+	default:
+		return "failed"
+	}
+}
+
 // ProtocolPeers returns list of peers that are communicating in a given protocol.
 func (ps *GossipPubSub) ProtocolPeers(protocol string) []peer.ID {
 	return ps.pubsub.ListPeers(protocol)
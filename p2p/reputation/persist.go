@@ -0,0 +1,145 @@
+package reputation
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc64"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/natefinch/atomic"
+	"go.uber.org/zap"
+)
+
+// File is the name of the file with persisted scores. It is written next to the list of connected peers.
+const File = "reputation.txt"
+
+// scores that are closer to zero than this are not persisted.
+const negligibleScore = 0.01
+
+type entry struct {
+	ID          peer.ID
+	Score       float64
+	Updated     time.Time
+	BannedUntil time.Time `json:",omitempty"`
+}
+
+// Persist writes scores to the directory every period, and once more when the context is canceled.
+func (r *Reputation) Persist(ctx context.Context, dir string, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := r.Save(dir); err != nil {
+				r.logger.Warn("failed to write peers reputation to file", zap.String("directory", dir), zap.Error(err))
+			}
+			return
+		case <-ticker.C:
+			if err := r.Save(dir); err != nil {
+				r.logger.Warn("failed to write peers reputation to file", zap.String("directory", dir), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *Reputation) entries() []entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	entries := make([]entry, 0, len(r.peers))
+	for id, rec := range r.peers {
+		score := rec.decayed(now, r.cfg.HalfLife)
+		banned := rec.bannedUntil.After(now)
+		if math.Abs(score) < negligibleScore && !banned {
+			delete(r.peers, id)
+			continue
+		}
+		e := entry{ID: id, Score: score, Updated: now}
+		if banned {
+			e.BannedUntil = rec.bannedUntil
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// Save writes scores to the file in the directory.
+func (r *Reputation) Save(dir string) error {
+	entries := r.entries()
+	checksum := crc64.New(crc64.MakeTable(crc64.ISO))
+	tmp, err := os.CreateTemp(dir, "reputation.tmp")
+	if err != nil {
+		return err
+	}
+	crc := make([]byte, crc64.Size)
+	if _, err := tmp.Write(crc); err != nil {
+		tmp.Close()
+		return err
+	}
+	codec := json.NewEncoder(io.MultiWriter(tmp, checksum))
+	for i := range entries {
+		if err := codec.Encode(&entries[i]); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	binary.BigEndian.PutUint64(crc, checksum.Sum64())
+	if _, err = tmp.WriteAt(crc, 0); err != nil {
+		tmp.Close()
+		return err
+	}
+	tmp.Close()
+	return atomic.ReplaceFile(tmp.Name(), filepath.Join(dir, File))
+}
+
+// Load reads scores from the file in the directory. Missing file is not an error.
+func (r *Reputation) Load(dir string) error {
+	f, err := os.Open(filepath.Join(dir, File))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	defer f.Close()
+	crc := make([]byte, crc64.Size)
+	if _, err := f.Read(crc); err != nil {
+		return err
+	}
+	checksum := crc64.New(crc64.MakeTable(crc64.ISO))
+	codec := json.NewDecoder(io.TeeReader(f, checksum))
+	var entries []entry
+	for {
+		var e entry
+		if err := codec.Decode(&e); err != nil {
+			if !errors.Is(err, io.EOF) {
+				return err
+			}
+			break
+		}
+		entries = append(entries, e)
+	}
+	if saved := binary.BigEndian.Uint64(crc); saved != checksum.Sum64() {
+		return fmt.Errorf("invalid checksum %d != %d", saved, checksum.Sum64())
+	}
+
+	r.mu.Lock()
+	for _, e := range entries {
+		r.peers[e.ID] = &record{score: e.Score, updated: e.Updated, bannedUntil: e.BannedUntil}
+	}
+	r.mu.Unlock()
+	if r.tagger != nil {
+		for _, e := range entries {
+			r.tagger.TagPeer(e.ID, Tag, int(e.Score))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+// Package reputation tracks quality of peers across subsystems.
+//
+// Every subsystem that talks to peers records events: invalid data served, timeouts, rejected gossip
+// and useful contributions. Events change the score of the peer, and the score decays towards zero
+// with configured half-life, so that old events matter less than recent ones. Peers with the score
+// below the ban threshold are disconnected and not allowed to reconnect until the ban expires.
+package reputation
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"go.uber.org/zap"
+
+	p2pmetrics "github.com/spacemeshos/go-spacemesh/p2p/metrics"
+)
+
+// Tag is the name of the connection manager tag that holds the score of the peer.
+const Tag = "reputation"
+
+// Event that changes the score of the peer.
+type Event uint8
+
+const (
+	// InvalidData is recorded when the peer served data that failed validation.
+	InvalidData Event = iota + 1
+	// Timeout is recorded when the peer didn't respond in time.
+	Timeout
+	// GossipReject is recorded when gossip message from the peer was rejected.
+	GossipReject
+	// Useful is recorded when the peer served valid data.
+	Useful
+)
+
+func (e Event) String() string {
+	switch e {
+	case InvalidData:
+		return "invalid data"
+	case Timeout:
+		return "timeout"
+	case GossipReject:
+		return "gossip reject"
+	case Useful:
+		return "useful"
+	default:
+		return "unknown"
+	}
+}
+
+// Config for the reputation service.
+type Config struct {
+	InvalidDataPenalty  float64 `mapstructure:"invalid-data-penalty"`
+	TimeoutPenalty      float64 `mapstructure:"timeout-penalty"`
+	GossipRejectPenalty float64 `mapstructure:"gossip-reject-penalty"`
+	UsefulReward        float64 `mapstructure:"useful-reward"`
+	// MaxScore caps the score, so that long history of useful contributions doesn't hide misbehavior.
+	MaxScore float64 `mapstructure:"max-score"`
+	// HalfLife is the time it takes for the score to decay by half.
+	HalfLife time.Duration `mapstructure:"half-life"`
+	// BanThreshold is the score at or below which the peer is banned.
+	BanThreshold float64 `mapstructure:"ban-threshold"`
+	// BanDuration is the time the banned peer is not allowed to connect.
+	BanDuration time.Duration `mapstructure:"ban-duration"`
+	// PersistInterval is the interval between writing scores to disk.
+	PersistInterval time.Duration `mapstructure:"persist-interval"`
+}
+
+// DefaultConfig for the reputation service.
+func DefaultConfig() Config {
+	return Config{
+		InvalidDataPenalty:  20,
+		TimeoutPenalty:      2,
+		GossipRejectPenalty: 10,
+		UsefulReward:        1,
+		MaxScore:            100,
+		HalfLife:            time.Hour,
+		BanThreshold:        -50,
+		BanDuration:         time.Hour,
+		PersistInterval:     10 * time.Minute,
+	}
+}
+
+func (cfg *Config) delta(ev Event) float64 {
+	switch ev {
+	case InvalidData:
+		return -cfg.InvalidDataPenalty
+	case Timeout:
+		return -cfg.TimeoutPenalty
+	case GossipReject:
+		return -cfg.GossipRejectPenalty
+	case Useful:
+		return cfg.UsefulReward
+	default:
+		return 0
+	}
+}
+
+// Tagger is the subset of the connection manager that is used to tag peers with their score.
+type Tagger interface {
+	TagPeer(peer.ID, string, int)
+}
+
+// Opt is for configuring Reputation.
+type Opt func(*Reputation)
+
+// WithLogger configures logger for Reputation.
+func WithLogger(logger *zap.Logger) Opt {
+	return func(r *Reputation) {
+		r.logger = logger
+	}
+}
+
+// WithConfig configures Reputation.
+func WithConfig(cfg Config) Opt {
+	return func(r *Reputation) {
+		r.cfg = cfg
+	}
+}
+
+// WithTagger sets connection manager that is used to tag peers with their score,
+// so that peers with low score are trimmed first.
+func WithTagger(tagger Tagger) Opt {
+	return func(r *Reputation) {
+		r.tagger = tagger
+	}
+}
+
+// WithBanHandler sets the callback that is called when the peer is banned.
+func WithBanHandler(onBan func(peer.ID)) Opt {
+	return func(r *Reputation) {
+		r.onBan = onBan
+	}
+}
+
+func withClock(now func() time.Time) Opt {
+	return func(r *Reputation) {
+		r.now = now
+	}
+}
+
+type record struct {
+	score       float64
+	updated     time.Time
+	bannedUntil time.Time
+}
+
+func (r *record) decayed(now time.Time, halfLife time.Duration) float64 {
+	elapsed := now.Sub(r.updated)
+	if elapsed <= 0 || halfLife <= 0 {
+		return r.score
+	}
+	return r.score * math.Exp2(-float64(elapsed)/float64(halfLife))
+}
+
+// Reputation keeps scores of peers.
+type Reputation struct {
+	logger *zap.Logger
+	cfg    Config
+	tagger Tagger
+	onBan  func(peer.ID)
+	now    func() time.Time
+
+	mu        sync.Mutex
+	peers     map[peer.ID]*record
+	protected map[peer.ID]struct{}
+}
+
+// New creates Reputation.
+func New(opts ...Opt) *Reputation {
+	r := &Reputation{
+		logger:    zap.NewNop(),
+		cfg:       DefaultConfig(),
+		now:       time.Now,
+		peers:     map[peer.ID]*record{},
+		protected: map[peer.ID]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Protect exempts the peer from bans. Score of the protected peer is still tracked.
+func (r *Reputation) Protect(id peer.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.protected[id] = struct{}{}
+}
+
+// Record the event for the peer.
+func (r *Reputation) Record(id peer.ID, ev Event) {
+	r.mu.Lock()
+	now := r.now()
+	rec, exist := r.peers[id]
+	if !exist {
+		rec = &record{}
+		r.peers[id] = rec
+	}
+	rec.score = min(rec.decayed(now, r.cfg.HalfLife)+r.cfg.delta(ev), r.cfg.MaxScore)
+	rec.updated = now
+	score := rec.score
+	_, protected := r.protected[id]
+	ban := !protected && score <= r.cfg.BanThreshold && !rec.bannedUntil.After(now)
+	if ban {
+		rec.bannedUntil = now.Add(r.cfg.BanDuration)
+	}
+	r.mu.Unlock()
+
+	if r.tagger != nil {
+		r.tagger.TagPeer(id, Tag, int(score))
+	}
+	if ban {
+		r.logger.Info("banning peer",
+			zap.Stringer("peer", id),
+			zap.Stringer("event", ev),
+			zap.Float64("score", score),
+			zap.Duration("duration", r.cfg.BanDuration),
+		)
+		p2pmetrics.BannedPeers.Inc()
+		if r.onBan != nil {
+			r.onBan(id)
+		}
+	}
+}
+
+// Score returns the current score of the peer. Unknown peers have zero score.
+func (r *Reputation) Score(id peer.ID) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, exist := r.peers[id]
+	if !exist {
+		return 0
+	}
+	return rec.decayed(r.now(), r.cfg.HalfLife)
+}
+
+// Banned returns true if the peer is banned.
+func (r *Reputation) Banned(id peer.ID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, exist := r.peers[id]
+	if !exist {
+		return false
+	}
+	return rec.bannedUntil.After(r.now())
+}
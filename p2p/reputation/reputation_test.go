@@ -0,0 +1,175 @@
+package reputation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+type tags map[peer.ID]int
+
+func (t tags) TagPeer(id peer.ID, tag string, value int) {
+	if tag == Tag {
+		t[id] = value
+	}
+}
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func (c *clock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func testConfig() Config {
+	return Config{
+		InvalidDataPenalty:  20,
+		TimeoutPenalty:      2,
+		GossipRejectPenalty: 10,
+		UsefulReward:        1,
+		MaxScore:            10,
+		HalfLife:            time.Hour,
+		BanThreshold:        -50,
+		BanDuration:         time.Hour,
+		PersistInterval:     time.Minute,
+	}
+}
+
+func TestRecord(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		events []Event
+		score  float64
+	}{
+		{desc: "empty"},
+		{desc: "useful", events: []Event{Useful, Useful}, score: 2},
+		{
+			desc:   "capped",
+			events: []Event{Useful, Useful, Useful, Useful, Useful, Useful, Useful, Useful, Useful, Useful, Useful},
+			score:  10,
+		},
+		{desc: "timeout", events: []Event{Useful, Timeout}, score: -1},
+		{desc: "gossip reject", events: []Event{GossipReject}, score: -10},
+		{desc: "invalid data", events: []Event{InvalidData, Useful}, score: -19},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := &clock{now: time.Unix(0, 0)}
+			tagger := tags{}
+			r := New(WithConfig(testConfig()), WithTagger(tagger), withClock(c.Now))
+			for _, ev := range tc.events {
+				r.Record("a", ev)
+			}
+			require.Equal(t, tc.score, r.Score("a"))
+			require.Equal(t, int(tc.score), tagger["a"])
+			require.Zero(t, r.Score("b"))
+		})
+	}
+}
+
+func TestDecay(t *testing.T) {
+	c := &clock{now: time.Unix(0, 0)}
+	r := New(WithConfig(testConfig()), withClock(c.Now))
+	r.Record("a", GossipReject)
+	c.advance(time.Hour)
+	require.Equal(t, -5.0, r.Score("a"))
+	c.advance(time.Hour)
+	require.Equal(t, -2.5, r.Score("a"))
+	r.Record("a", Useful)
+	require.Equal(t, -1.5, r.Score("a"))
+}
+
+func TestBan(t *testing.T) {
+	c := &clock{now: time.Unix(0, 0)}
+	var banned []peer.ID
+	r := New(
+		WithConfig(testConfig()),
+		withClock(c.Now),
+		WithBanHandler(func(id peer.ID) { banned = append(banned, id) }),
+	)
+	r.Protect("protected")
+	for range 3 {
+		r.Record("a", InvalidData)
+		r.Record("protected", InvalidData)
+	}
+	require.True(t, r.Banned("a"))
+	require.False(t, r.Banned("protected"))
+	require.False(t, r.Banned("b"))
+	require.Equal(t, []peer.ID{"a"}, banned)
+
+	// doesn't extend the ban
+	r.Record("a", InvalidData)
+	require.Equal(t, []peer.ID{"a"}, banned)
+
+	c.advance(time.Hour)
+	require.False(t, r.Banned("a"))
+}
+
+func genPeer(tb testing.TB) peer.ID {
+	tb.Helper()
+	_, pub, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(tb, err)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(tb, err)
+	return id
+}
+
+func TestPersist(t *testing.T) {
+	dir := t.TempDir()
+	c := &clock{now: time.Unix(0, 0)}
+	r := New(WithConfig(testConfig()), withClock(c.Now))
+	good, bad, banned := genPeer(t), genPeer(t), genPeer(t)
+	r.Record(good, Useful)
+	r.Record(bad, GossipReject)
+	for range 3 {
+		r.Record(banned, InvalidData)
+	}
+	c.advance(10 * time.Minute)
+	r.Record(bad, GossipReject)
+	c.advance(10 * time.Hour)
+	require.NoError(t, r.Save(dir))
+
+	tagger := tags{}
+	restored := New(WithConfig(testConfig()), WithTagger(tagger), withClock(c.Now))
+	require.NoError(t, restored.Load(dir))
+	require.Equal(t, r.Score(bad), restored.Score(bad))
+	require.Equal(t, r.Score(banned), restored.Score(banned))
+	require.False(t, restored.Banned(banned))
+	require.Equal(t, int(r.Score(bad)), tagger[bad])
+	// negligible scores are not persisted
+	require.NotContains(t, restored.peers, good)
+
+	t.Run("banned", func(t *testing.T) {
+		dir := t.TempDir()
+		for range 3 {
+			r.Record(banned, InvalidData)
+		}
+		require.True(t, r.Banned(banned))
+		require.NoError(t, r.Save(dir))
+		restored := New(WithConfig(testConfig()), withClock(c.Now))
+		require.NoError(t, restored.Load(dir))
+		require.True(t, restored.Banned(banned))
+		c.advance(time.Hour)
+		require.False(t, restored.Banned(banned))
+	})
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, New().Load(t.TempDir()))
+	})
+	t.Run("broken crc", func(t *testing.T) {
+		f, err := os.OpenFile(filepath.Join(dir, File), os.O_WRONLY, 0o600)
+		require.NoError(t, err)
+		_, err = f.WriteAt([]byte{1, 1, 1}, 0)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		require.ErrorContains(t, New().Load(dir), "invalid checksum")
+	})
+}
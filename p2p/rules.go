@@ -0,0 +1,367 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/natefinch/atomic"
+	"go.uber.org/zap"
+)
+
+const (
+	rulesFile = "peer_rules.json"
+	// allowlistProtectTag protects allowlisted peers from being trimmed by connection manager.
+	allowlistProtectTag = "allowlist"
+	// rulesPruneInterval is how often expired rules are removed.
+	rulesPruneInterval = 10 * time.Second
+)
+
+// ErrRuleNotFound is returned when the removed rule doesn't exist.
+var ErrRuleNotFound = errors.New("p2p: peer rule not found")
+
+// RuleAction is the action of the peer rule.
+type RuleAction string
+
+const (
+	// RuleBlock refuses connections to and from the matching peers.
+	RuleBlock RuleAction = "block"
+	// RuleAllow accepts connections to and from the matching peers regardless of other rules and limits.
+	RuleAllow RuleAction = "allow"
+)
+
+// Rule blocks or allows a peer id or an address range until it expires.
+//
+// Rule is formatted as "<action> <peer id or CIDR> [<expiry>]", where expiry is either
+// RFC3339 time or duration from now. Rule without expiry never expires.
+type Rule struct {
+	Action  RuleAction
+	Target  string
+	Expires time.Time `json:",omitempty"`
+
+	peer  peer.ID
+	ipnet *net.IPNet
+}
+
+// NewRule validates the target of the rule. Rule with zero expires never expires.
+func NewRule(action RuleAction, target string, expires time.Time) (Rule, error) {
+	rule := Rule{Action: action, Target: target, Expires: expires}
+	if err := rule.parse(); err != nil {
+		return Rule{}, err
+	}
+	return rule, nil
+}
+
+// ParseRule parses the rule from the string, duration in expiry is added to now.
+func ParseRule(s string, now time.Time) (Rule, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 && len(fields) != 3 {
+		return Rule{}, fmt.Errorf("rule %q: expected <action> <target> [<expiry>]", s)
+	}
+	var expires time.Time
+	if len(fields) == 3 {
+		if d, err := time.ParseDuration(fields[2]); err == nil {
+			expires = now.Add(d)
+		} else if expires, err = time.Parse(time.RFC3339, fields[2]); err != nil {
+			return Rule{}, fmt.Errorf("rule %q: invalid expiry: %w", s, err)
+		}
+	}
+	rule, err := NewRule(RuleAction(fields[0]), fields[1], expires)
+	if err != nil {
+		return Rule{}, fmt.Errorf("rule %q: %w", s, err)
+	}
+	return rule, nil
+}
+
+func (r *Rule) parse() error {
+	switch r.Action {
+	case RuleBlock, RuleAllow:
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	if strings.Contains(r.Target, "/") {
+		_, ipnet, err := net.ParseCIDR(r.Target)
+		if err != nil {
+			return err
+		}
+		r.ipnet = ipnet
+		r.Target = ipnet.String()
+		return nil
+	}
+	pid, err := peer.Decode(r.Target)
+	if err != nil {
+		return err
+	}
+	r.peer = pid
+	r.Target = pid.String()
+	return nil
+}
+
+func (r Rule) String() string {
+	if r.Expires.IsZero() {
+		return fmt.Sprintf("%s %s", r.Action, r.Target)
+	}
+	return fmt.Sprintf("%s %s %s", r.Action, r.Target, r.Expires.UTC().Format(time.RFC3339))
+}
+
+func (r *Rule) expired(now time.Time) bool {
+	return !r.Expires.IsZero() && !r.Expires.After(now)
+}
+
+// rules is the list of peer rules that is persisted in the data directory.
+// Without data directory rules are kept only in memory.
+type rules struct {
+	dir string
+	now func() time.Time
+
+	mu    sync.RWMutex
+	rules []Rule
+}
+
+func loadRules(dir string) (*rules, error) {
+	r := &rules{dir: dir, now: time.Now}
+	if dir == "" {
+		return r, nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, rulesFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &r.rules); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", rulesFile, err)
+	}
+	for i := range r.rules {
+		if err := r.rules[i].parse(); err != nil {
+			return nil, fmt.Errorf("%s: %w", rulesFile, err)
+		}
+	}
+	return r, nil
+}
+
+// save the rules to the data directory. Rules are saved before they are applied in memory,
+// so that rules in memory are never ahead of the persisted rules.
+func (r *rules) save(rules []Rule) error {
+	if r.dir == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(rules, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := atomic.WriteFile(filepath.Join(r.dir, rulesFile), bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("save %s: %w", rulesFile, err)
+	}
+	return nil
+}
+
+// prune removes expired rules and returns them.
+func (r *rules) prune() ([]Rule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	var expired []Rule
+	for _, rule := range r.rules {
+		if rule.expired(now) {
+			expired = append(expired, rule)
+		}
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+	updated := slices.DeleteFunc(slices.Clone(r.rules), func(rule Rule) bool {
+		return rule.expired(now)
+	})
+	if err := r.save(updated); err != nil {
+		return nil, err
+	}
+	r.rules = updated
+	return expired, nil
+}
+
+// add the rule, replacing the rule with the same action and target.
+func (r *rules) add(rule Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updated := slices.DeleteFunc(slices.Clone(r.rules), func(other Rule) bool {
+		return other.Action == rule.Action && other.Target == rule.Target
+	})
+	updated = append(updated, rule)
+	if err := r.save(updated); err != nil {
+		return err
+	}
+	r.rules = updated
+	return nil
+}
+
+// remove the rule with the same action and target.
+func (r *rules) remove(rule Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	updated := slices.DeleteFunc(slices.Clone(r.rules), func(other Rule) bool {
+		return other.Action == rule.Action && other.Target == rule.Target
+	})
+	if len(updated) == len(r.rules) {
+		return fmt.Errorf("%w: %s", ErrRuleNotFound, rule)
+	}
+	if err := r.save(updated); err != nil {
+		return err
+	}
+	r.rules = updated
+	return nil
+}
+
+func (r *rules) list() []Rule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := r.now()
+	rst := make([]Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		if !rule.expired(now) {
+			rst = append(rst, rule)
+		}
+	}
+	return rst
+}
+
+// match returns actions of the rules that match either the peer or the ip.
+func (r *rules) match(pid peer.ID, ip net.IP) (allowed, blocked bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := r.now()
+	for i := range r.rules {
+		rule := &r.rules[i]
+		if rule.expired(now) {
+			continue
+		}
+		if (pid != "" && rule.peer == pid) || (ip != nil && rule.ipnet != nil && rule.ipnet.Contains(ip)) {
+			switch rule.Action {
+			case RuleAllow:
+				allowed = true
+			case RuleBlock:
+				blocked = true
+			}
+		}
+	}
+	return allowed, blocked
+}
+
+func ipFromMultiaddr(m multiaddr.Multiaddr) net.IP {
+	var ip net.IP
+	if m == nil {
+		return nil
+	}
+	multiaddr.ForEach(m, func(c multiaddr.Component) bool {
+		switch c.Protocol().Code {
+		case multiaddr.P_IP4, multiaddr.P_IP6:
+			ip = net.IP(c.RawValue())
+			return false
+		}
+		return true
+	})
+	return ip
+}
+
+// PeerRules returns peer rules that are not expired.
+func (fh *Host) PeerRules() []Rule {
+	return fh.rules.list()
+}
+
+// AddPeerRule adds the rule, replacing the rule with the same action and target.
+// Open connections that match the block rule are closed.
+func (fh *Host) AddPeerRule(rule Rule) error {
+	if err := rule.parse(); err != nil {
+		return err
+	}
+	if err := fh.rules.add(rule); err != nil {
+		return err
+	}
+	switch rule.Action {
+	case RuleAllow:
+		if rule.peer != "" {
+			fh.ConnManager().Protect(rule.peer, allowlistProtectTag)
+		}
+	case RuleBlock:
+		fh.closeBlocked()
+	}
+	return nil
+}
+
+// RemovePeerRule removes the rule with the same action and target.
+func (fh *Host) RemovePeerRule(rule Rule) error {
+	if err := rule.parse(); err != nil {
+		return err
+	}
+	if err := fh.rules.remove(rule); err != nil {
+		return err
+	}
+	if rule.Action == RuleAllow && rule.peer != "" {
+		fh.ConnManager().Unprotect(rule.peer, allowlistProtectTag)
+	}
+	// removing allow rule may leave connections that are blocked by other rules
+	fh.closeBlocked()
+	return nil
+}
+
+// pruneRules removes expired rules and unprotects peers that are no longer allowlisted.
+func (fh *Host) pruneRules() {
+	expired, err := fh.rules.prune()
+	if err != nil {
+		fh.logger.Zap().Warn("failed to prune expired peer rules", zap.Error(err))
+		return
+	}
+	for _, rule := range expired {
+		fh.logger.Zap().Info("peer rule expired", zap.Stringer("rule", rule))
+		if rule.Action == RuleAllow && rule.peer != "" {
+			fh.ConnManager().Unprotect(rule.peer, allowlistProtectTag)
+		}
+	}
+}
+
+func (fh *Host) pruneRulesLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fh.pruneRules()
+		}
+	}
+}
+
+func (fh *Host) closeBlocked() {
+	for _, conn := range fh.Network().Conns() {
+		if _, exist := fh.direct[conn.RemotePeer()]; exist {
+			continue
+		}
+		allowed, blocked := fh.rules.match(conn.RemotePeer(), ipFromMultiaddr(conn.RemoteMultiaddr()))
+		if !blocked || allowed {
+			continue
+		}
+		fh.logger.Zap().Info("closing connection blocked by peer rule",
+			zap.Stringer("peer", conn.RemotePeer()),
+			zap.Stringer("address", conn.RemoteMultiaddr()),
+		)
+		if err := conn.Close(); err != nil {
+			fh.logger.Zap().Debug("failed to close blocked connection",
+				zap.Stringer("peer", conn.RemotePeer()),
+				zap.Error(err),
+			)
+		}
+	}
+}
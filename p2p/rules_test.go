@@ -0,0 +1,249 @@
+package p2p
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ccmgr "github.com/libp2p/go-libp2p/core/connmgr"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+)
+
+type connAddrs struct {
+	remote multiaddr.Multiaddr
+}
+
+func (c connAddrs) LocalMultiaddr() multiaddr.Multiaddr {
+	return nil
+}
+
+func (c connAddrs) RemoteMultiaddr() multiaddr.Multiaddr {
+	return c.remote
+}
+
+const testPeer = "12D3KooWJuP7Pc1dnXE6pX9b6XmEUbJmgPQCXKDxLQdDw9Ww3ePe"
+
+func testPeerID(tb testing.TB) peer.ID {
+	tb.Helper()
+	pid, err := peer.Decode(testPeer)
+	require.NoError(tb, err)
+	return pid
+}
+
+func TestParseRule(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		desc   string
+		rule   string
+		expect string
+		err    bool
+	}{
+		{desc: "block peer", rule: "block " + testPeer, expect: "block " + testPeer},
+		{desc: "allow cidr", rule: "allow 10.1.2.3/8", expect: "allow 10.0.0.0/8"},
+		{desc: "ip6", rule: "block fe80::/10", expect: "block fe80::/10"},
+		{desc: "duration", rule: "block 1.2.3.0/24 1h", expect: "block 1.2.3.0/24 2024-01-01T01:00:00Z"},
+		{
+			desc:   "time",
+			rule:   "block 1.2.3.0/24 2024-01-02T00:00:00Z",
+			expect: "block 1.2.3.0/24 2024-01-02T00:00:00Z",
+		},
+		{desc: "unknown action", rule: "drop " + testPeer, err: true},
+		{desc: "invalid peer", rule: "block peer", err: true},
+		{desc: "invalid cidr", rule: "block 1.2.3/24", err: true},
+		{desc: "invalid expiry", rule: "block 1.2.3.0/24 tomorrow", err: true},
+		{desc: "missing target", rule: "block", err: true},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			rule, err := ParseRule(tc.rule, now)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, rule.String())
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	dir := t.TempDir()
+	r, err := loadRules(dir)
+	require.NoError(t, err)
+	now := time.Now()
+	r.now = func() time.Time { return now }
+
+	for _, s := range []string{"block " + testPeer, "allow 10.0.0.0/8", "block 10.1.0.0/16 1m", "block 1.2.3.0/24 1m"} {
+		rule, err := ParseRule(s, now)
+		require.NoError(t, err)
+		require.NoError(t, r.add(rule))
+	}
+	// replaces the rule with the same target
+	rule, err := ParseRule("block 1.2.3.0/24 1h", now)
+	require.NoError(t, err)
+	require.NoError(t, r.add(rule))
+	require.Len(t, r.list(), 4)
+
+	allowed, blocked := r.match(testPeerID(t), nil)
+	require.False(t, allowed)
+	require.True(t, blocked)
+	allowed, blocked = r.match("", []byte{10, 1, 1, 1})
+	require.True(t, allowed)
+	require.True(t, blocked)
+	allowed, blocked = r.match("", []byte{1, 2, 3, 4})
+	require.False(t, allowed)
+	require.True(t, blocked)
+
+	now = now.Add(2 * time.Minute)
+	allowed, blocked = r.match("", []byte{10, 1, 1, 1})
+	require.True(t, allowed)
+	require.False(t, blocked)
+	require.Len(t, r.list(), 3)
+
+	rule, err = ParseRule("allow 10.0.0.0/8", now)
+	require.NoError(t, err)
+	require.NoError(t, r.remove(rule))
+	require.ErrorIs(t, r.remove(rule), ErrRuleNotFound)
+
+	restored, err := loadRules(dir)
+	require.NoError(t, err)
+	restored.now = r.now
+	require.Equal(t, fmt.Sprint(r.list()), fmt.Sprint(restored.list()))
+	allowed, blocked = restored.match(testPeerID(t), nil)
+	require.False(t, allowed)
+	require.True(t, blocked)
+
+	expired, err := r.prune()
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	require.Equal(t, "block 10.1.0.0/16", fmt.Sprintf("%s %s", expired[0].Action, expired[0].Target))
+	restored, err = loadRules(dir)
+	require.NoError(t, err)
+	require.Len(t, restored.rules, 2)
+}
+
+func TestRulesSaveFirst(t *testing.T) {
+	r, err := loadRules(t.TempDir())
+	require.NoError(t, err)
+	rule, err := ParseRule("block "+testPeer, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, r.add(rule))
+
+	// rules are not updated in memory if they can't be saved
+	r.dir = filepath.Join(t.TempDir(), "missing")
+	other, err := ParseRule("allow 10.0.0.0/8", time.Now())
+	require.NoError(t, err)
+	require.Error(t, r.add(other))
+	require.Equal(t, []Rule{rule}, r.list())
+	require.Error(t, r.remove(rule))
+	require.Equal(t, []Rule{rule}, r.list())
+}
+
+func TestGaterRules(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DataDir = t.TempDir()
+	h, err := mocknet.New().GenPeer()
+	require.NoError(t, err)
+	g, err := newGater(cfg)
+	require.NoError(t, err)
+	g.updateHost(h)
+
+	public, err := multiaddr.NewMultiaddr("/ip4/95.217.200.84/tcp/8000")
+	require.NoError(t, err)
+	private, err := multiaddr.NewMultiaddr("/ip4/192.168.0.3/tcp/8000")
+	require.NoError(t, err)
+	require.True(t, g.InterceptPeerDial(testPeerID(t)))
+	require.True(t, g.InterceptAddrDial(testPeerID(t), public))
+	require.False(t, g.InterceptAddrDial(testPeerID(t), private))
+
+	for _, s := range []string{"block " + testPeer, "block 95.217.200.0/24", "allow 192.168.0.0/24"} {
+		rule, err := ParseRule(s, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, g.rules.add(rule))
+	}
+	require.False(t, g.InterceptPeerDial(testPeerID(t)))
+	require.False(t, g.InterceptSecured(network.DirInbound, testPeerID(t), nil))
+	require.False(t, g.InterceptAddrDial("", public))
+	require.False(t, g.InterceptAccept(connAddrs{remote: public}))
+	require.True(t, g.InterceptAddrDial("", private))
+	require.True(t, g.InterceptAccept(connAddrs{remote: private}))
+
+	// rules are loaded from data directory
+	g, err = newGater(cfg)
+	require.NoError(t, err)
+	g.updateHost(h)
+	require.False(t, g.InterceptPeerDial(testPeerID(t)))
+}
+
+// connMgrHost replaces null connection manager of the mocknet host.
+type connMgrHost struct {
+	host.Host
+	cm ccmgr.ConnManager
+}
+
+func (h connMgrHost) ConnManager() ccmgr.ConnManager {
+	return h.cm
+}
+
+func TestHostPeerRules(t *testing.T) {
+	mesh, err := mocknet.FullMeshConnected(3)
+	require.NoError(t, err)
+	cm, err := connmgr.NewConnManager(10, 20)
+	require.NoError(t, err)
+	t.Cleanup(func() { cm.Close() })
+	fh, err := Upgrade(connMgrHost{Host: mesh.Hosts()[0], cm: cm})
+	require.NoError(t, err)
+	blocked := mesh.Hosts()[1].ID()
+	require.NotEmpty(t, fh.Network().ConnsToPeer(blocked))
+
+	// host without the gater may reconnect, so disconnects are observed instead of open connections
+	disconnected := make(chan peer.ID, 10)
+	fh.Network().Notify(&network.NotifyBundle{
+		DisconnectedF: func(_ network.Network, c network.Conn) {
+			disconnected <- c.RemotePeer()
+		},
+	})
+	rule, err := ParseRule("block "+blocked.String(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, fh.AddPeerRule(rule))
+	require.Equal(t, []Rule{rule}, fh.PeerRules())
+	select {
+	case pid := <-disconnected:
+		require.Equal(t, blocked, pid)
+	case <-time.After(time.Second):
+		require.FailNow(t, "blocked peer wasn't disconnected")
+	}
+	require.NotEmpty(t, fh.Network().ConnsToPeer(mesh.Hosts()[2].ID()))
+
+	allow, err := ParseRule("allow "+blocked.String(), time.Now())
+	require.NoError(t, err)
+	require.NoError(t, fh.AddPeerRule(allow))
+	require.Len(t, fh.PeerRules(), 2)
+	require.True(t, fh.ConnManager().IsProtected(blocked, allowlistProtectTag))
+	require.NoError(t, fh.RemovePeerRule(allow))
+	require.False(t, fh.ConnManager().IsProtected(blocked, allowlistProtectTag))
+
+	// peer is unprotected when the allow rule expires
+	now := time.Now()
+	fh.rules.now = func() time.Time { return now }
+	allow, err = ParseRule("allow "+blocked.String()+" 1m", now)
+	require.NoError(t, err)
+	require.NoError(t, fh.AddPeerRule(allow))
+	require.True(t, fh.ConnManager().IsProtected(blocked, allowlistProtectTag))
+	fh.pruneRules()
+	require.True(t, fh.ConnManager().IsProtected(blocked, allowlistProtectTag))
+	now = now.Add(2 * time.Minute)
+	fh.pruneRules()
+	require.False(t, fh.ConnManager().IsProtected(blocked, allowlistProtectTag))
+	require.Equal(t, []Rule{rule}, fh.PeerRules())
+
+	require.NoError(t, fh.RemovePeerRule(rule))
+	require.Empty(t, fh.PeerRules())
+	require.ErrorIs(t, fh.RemovePeerRule(rule), ErrRuleNotFound)
+}
@@ -24,6 +24,13 @@ type deadlineAdjusterError struct {
 	hardTimeout  time.Duration
 }
 
+// IsStreamTimeout returns true if the error is caused by the peer not reading or writing the stream
+// before the stream deadline. Expiration of the context of the request is not a stream timeout.
+func IsStreamTimeout(err error) bool {
+	var derr *deadlineAdjusterError
+	return errors.As(err, &derr)
+}
+
 func (err *deadlineAdjusterError) Unwrap() error {
 	return err.innerErr
 }
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -105,6 +107,7 @@ func TestDeadlineAdjuster(t *testing.T) {
 	require.Equal(t, 1, n)
 	require.ErrorIs(t, err, yamux.ErrTimeout)
 	require.ErrorContains(t, err, "19 bytes read, 14 bytes written, timeout 10s, hard timeout 35s")
+	require.True(t, IsStreamTimeout(fmt.Errorf("wrapped: %w", err)))
 
 	n, err = dadj.Write([]byte("bbbcdef"))
 	require.Equal(t, 6, n)
@@ -125,4 +128,5 @@ func TestDeadlineAdjuster(t *testing.T) {
 	require.ErrorContains(t, err, "19 bytes read, 22 bytes written, timeout 10s, hard timeout 35s")
 
 	require.Equal(t, []int{10, 12, 14, 16, 18, 20, 35}, deadlines)
+	require.False(t, IsStreamTimeout(context.DeadlineExceeded))
 }
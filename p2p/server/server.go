@@ -13,6 +13,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/multiformats/go-multistream"
 	"github.com/multiformats/go-varint"
 	dto "github.com/prometheus/client_model/go"
 	"golang.org/x/sync/errgroup"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 )
 
 type DecayingTagSpec struct {
@@ -34,6 +36,8 @@ var (
 	ErrNotConnected = errors.New("peer is not connected")
 	// ErrPeerResponseFailed raised if peer responded with an error.
 	ErrPeerResponseFailed = errors.New("peer response failed")
+	// ErrProtocolNotSupported is returned when peer doesn't support any of the requested protocols.
+	ErrProtocolNotSupported = errors.New("protocol not supported by peer")
 )
 
 // Opt is a type to configure a server.
@@ -101,6 +105,13 @@ func WithRequestsPerInterval(n int, interval time.Duration) Opt {
 	}
 }
 
+// WithRecorder enables capture of requests and responses, both served and sent by the server.
+func WithRecorder(recorder *capture.Recorder) Opt {
+	return func(s *Server) {
+		s.recorder = recorder
+	}
+}
+
 func WithDecayingTag(tag DecayingTagSpec) Opt {
 	return func(s *Server) {
 		s.decayingTagSpec = &tag
@@ -159,7 +170,8 @@ type Server struct {
 	decayingTagSpec     *DecayingTagSpec
 	decayingTag         connmgr.DecayingTag
 
-	metrics *tracker // metrics can be nil
+	metrics  *tracker          // metrics can be nil
+	recorder *capture.Recorder // recorder can be nil
 
 	h Host
 }
@@ -298,8 +310,23 @@ func (s *Server) queueHandler(ctx context.Context, stream network.Stream) bool {
 		)
 		return false
 	}
+	var (
+		rw   io.ReadWriter = dadj
+		resp *capture.Buffer
+	)
+	if s.recorder != nil {
+		s.record(stream.Conn().RemotePeer(), capture.Inbound, capture.Request, "", buf, len(buf))
+		// only the beginning of the response is kept, it is truncated when recorded anyway
+		resp = s.recorder.NewBuffer()
+		rw = &recordingStream{Reader: dadj, Writer: io.MultiWriter(dadj, resp)}
+	}
 	start := time.Now()
-	if err = s.handler(log.WithNewRequestID(ctx), buf, dadj); err != nil {
+	err = s.handler(log.WithNewRequestID(ctx), buf, rw)
+	if s.recorder != nil {
+		s.record(stream.Conn().RemotePeer(), capture.Outbound, capture.Response, requestResult(err),
+			resp.Bytes(), resp.Size())
+	}
+	if err != nil {
 		s.logger.With().Debug("handler reported error",
 			log.String("protocol", s.protocol),
 			log.Stringer("remotePeer", stream.Conn().RemotePeer()),
@@ -356,7 +383,16 @@ func (s *Server) StreamRequest(
 	defer cancel()
 	stream, err := s.streamRequest(ctx, pid, req, extraProtocols...)
 	if err == nil {
+		var resp *capture.Buffer
+		if s.recorder != nil {
+			s.record(pid, capture.Outbound, capture.Request, "", req, len(req))
+			resp = s.recorder.NewBuffer()
+			stream = &recordingStream{Reader: io.TeeReader(stream, resp), Writer: stream, Closer: stream}
+		}
 		err = callback(ctx, stream)
+		if s.recorder != nil {
+			s.record(pid, capture.Inbound, capture.Response, requestResult(err), resp.Bytes(), resp.Size())
+		}
 		s.logger.WithContext(ctx).With().Debug("request execution time",
 			log.String("protocol", s.protocol),
 			log.Duration("duration", time.Since(start)),
@@ -397,6 +433,9 @@ func (s *Server) streamRequest(
 		pid,
 		protoIDs...,
 	)
+	if errors.Is(err, multistream.ErrNotSupported[protocol.ID]{}) {
+		return nil, fmt.Errorf("%w: %w", ErrProtocolNotSupported, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -424,6 +463,44 @@ func (s *Server) streamRequest(
 	return dadj, nil
 }
 
+func (s *Server) record(
+	pid peer.ID,
+	direction capture.Direction,
+	kind capture.Kind,
+	result string,
+	data []byte,
+	size int,
+) {
+	s.recorder.Record(capture.Record{
+		Peer:      string(pid),
+		Protocol:  s.protocol,
+		Direction: direction,
+		Kind:      kind,
+		Result:    result,
+		Size:      uint64(size),
+		Data:      data,
+	})
+}
+
+// requestResult is the outcome of the request, in terms of the client metrics.
+func requestResult(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, &ServerError{}):
+		return "server error"
+	default:
+		return "failed"
+	}
+}
+
+// recordingStream copies data that is read from or written to the stream.
+type recordingStream struct {
+	io.Reader
+	io.Writer
+	io.Closer
+}
+
 // NumAcceptedRequests returns the number of accepted requests for this server.
 // It is used for testing.
 func (s *Server) NumAcceptedRequests() int {
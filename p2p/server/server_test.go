@@ -3,6 +3,9 @@ package server
 import (
 	"context"
 	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -13,7 +16,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 )
 
 func TestServer(t *testing.T) {
@@ -84,6 +89,10 @@ func TestServer(t *testing.T) {
 		_, err := client.Request(ctx, mesh.Hosts()[2].ID(), request)
 		require.Error(t, err)
 	})
+	t.Run("NotSupported", func(t *testing.T) {
+		_, err := client.Request(ctx, mesh.Hosts()[3].ID(), request)
+		require.ErrorIs(t, err, ErrProtocolNotSupported)
+	})
 	t.Run("NotConnected", func(t *testing.T) {
 		_, err := client.Request(ctx, "unknown", request)
 		require.ErrorIs(t, err, ErrNotConnected)
@@ -157,3 +166,97 @@ func FuzzResponseConsistency(f *testing.F) {
 func FuzzResponseSafety(f *testing.F) {
 	tester.FuzzSafety[Response](f)
 }
+
+func TestServerCapture(t *testing.T) {
+	mesh, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	proto := "test"
+	request := []byte("test request")
+	handler := func(_ context.Context, msg []byte) ([]byte, error) {
+		return msg, nil
+	}
+	clientDir, srvDir := t.TempDir(), t.TempDir()
+	// client records are truncated
+	cfg := capture.DefaultConfig()
+	cfg.MaxDataSize = 4
+	clientRec, srvRec := capture.New(clientDir, capture.WithConfig(cfg)), capture.New(srvDir)
+	client := New(mesh.Hosts()[0], proto, WrapHandler(handler), WithRecorder(clientRec))
+	srv := New(mesh.Hosts()[1], proto, WrapHandler(handler), WithRecorder(srvRec))
+	ctx, cancel := context.WithCancel(context.Background())
+	var eg errgroup.Group
+	eg.Go(func() error {
+		return srv.Run(ctx)
+	})
+	t.Cleanup(func() {
+		cancel()
+		eg.Wait()
+	})
+	require.Eventually(t, func() bool {
+		return len(mesh.Hosts()[1].Mux().Protocols()) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	response, err := client.Request(ctx, mesh.Hosts()[1].ID(), request)
+	require.NoError(t, err)
+	require.Equal(t, request, response)
+	cancel()
+	require.NoError(t, eg.Wait())
+
+	read := func(rec *capture.Recorder, dir string) []capture.Record {
+		require.NoError(t, rec.Close())
+		f, err := os.Open(filepath.Join(dir, capture.File))
+		require.NoError(t, err)
+		defer f.Close()
+		rd := capture.NewReader(f)
+		var records []capture.Record
+		for {
+			rec, err := rd.Next()
+			if errors.Is(err, io.EOF) {
+				return records
+			}
+			require.NoError(t, err)
+			rec.Timestamp = 0
+			records = append(records, *rec)
+		}
+	}
+	encoded, err := codec.Encode(&Response{Data: request})
+	require.NoError(t, err)
+	clientID, srvID := mesh.Hosts()[0].ID(), mesh.Hosts()[1].ID()
+	require.Equal(t, []capture.Record{
+		{
+			Peer:      string(srvID),
+			Protocol:  proto,
+			Direction: capture.Outbound,
+			Kind:      capture.Request,
+			Size:      uint64(len(request)),
+			Data:      request[:4],
+		},
+		{
+			Peer:      string(srvID),
+			Protocol:  proto,
+			Direction: capture.Inbound,
+			Kind:      capture.Response,
+			Result:    "ok",
+			Size:      uint64(len(encoded)),
+			Data:      encoded[:4],
+		},
+	}, read(clientRec, clientDir))
+	require.Equal(t, []capture.Record{
+		{
+			Peer:      string(clientID),
+			Protocol:  proto,
+			Direction: capture.Inbound,
+			Kind:      capture.Request,
+			Size:      uint64(len(request)),
+			Data:      request,
+		},
+		{
+			Peer:      string(clientID),
+			Protocol:  proto,
+			Direction: capture.Outbound,
+			Kind:      capture.Response,
+			Result:    "ok",
+			Size:      uint64(len(encoded)),
+			Data:      encoded,
+		},
+	}, read(srvRec, srvDir))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"slices"
 	"sync"
 	"time"
@@ -21,10 +22,15 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/p2p/capture"
 	discovery "github.com/spacemeshos/go-spacemesh/p2p/dhtdiscovery"
 	"github.com/spacemeshos/go-spacemesh/p2p/pubsub"
+	"github.com/spacemeshos/go-spacemesh/p2p/reputation"
 )
 
+// captureDir is the directory inside DataDir with captured traffic.
+const captureDir = "capture"
+
 // Opt is for configuring Host.
 type Opt func(fh *Host)
 
@@ -75,6 +81,19 @@ func WithRelayCandidateChannel(relayCh chan<- peer.AddrInfo) Opt {
 	}
 }
 
+// WithReputation sets the reputation service for Host. By default it is created in Upgrade.
+func WithReputation(r *reputation.Reputation) Opt {
+	return func(fh *Host) {
+		fh.reputation = r
+	}
+}
+
+func withRules(r *rules) Opt {
+	return func(fh *Host) {
+		fh.rules = r
+	}
+}
+
 // Host is a conveniency wrapper for all p2p related functionality required to run
 // a full spacemesh node.
 type Host struct {
@@ -111,7 +130,28 @@ type Host struct {
 		value network.Reachability
 	}
 
-	ping *Ping
+	ping       *Ping
+	reputation *reputation.Reputation
+	// rules are enforced by the connection gater, see New.
+	rules *rules
+	// recorder is nil unless traffic capture is enabled.
+	recorder *capture.Recorder
+}
+
+func newReputation(logger log.Log, cfg Config, h host.Host) *reputation.Reputation {
+	return reputation.New(
+		reputation.WithLogger(logger.Zap()),
+		reputation.WithConfig(cfg.Reputation),
+		reputation.WithTagger(h.ConnManager()),
+		reputation.WithBanHandler(func(id peer.ID) {
+			if err := h.Network().ClosePeer(id); err != nil {
+				logger.With().Debug("failed to close banned peer",
+					log.String("peer", id.ShortString()),
+					log.Err(err),
+				)
+			}
+		}),
+	)
 }
 
 // Upgrade creates Host instance from host.Host.
@@ -136,10 +176,37 @@ func Upgrade(h host.Host, opts ...Opt) (*Host, error) {
 	if err != nil {
 		return nil, err
 	}
+	if fh.reputation == nil {
+		fh.reputation = newReputation(fh.logger, cfg, h)
+	}
+	if err := fh.reputation.Load(cfg.DataDir); err != nil {
+		fh.logger.With().Warning("failed to load peers reputation", log.Err(err))
+	}
 	for _, peer := range direct {
 		h.ConnManager().Protect(peer.ID, "direct")
+		fh.reputation.Protect(peer.ID)
 		// TBD: also protect ping
 	}
+	for _, peer := range bootnodes {
+		fh.reputation.Protect(peer.ID)
+	}
+	if fh.rules == nil {
+		fh.rules = &rules{now: time.Now}
+	}
+	for _, rule := range fh.rules.list() {
+		if rule.Action == RuleAllow && rule.peer != "" {
+			h.ConnManager().Protect(rule.peer, allowlistProtectTag)
+		}
+	}
+	if cfg.Capture.Enable {
+		if cfg.DataDir == "" {
+			return nil, errors.New("traffic capture requires data directory")
+		}
+		fh.recorder = capture.New(filepath.Join(cfg.DataDir, captureDir),
+			capture.WithLogger(fh.logger.Zap()),
+			capture.WithConfig(cfg.Capture),
+		)
+	}
 	if fh.cfg.DisablePubSub {
 		fh.PubSub = &pubsub.NullPubSub{}
 	} else {
@@ -152,6 +219,8 @@ func Upgrade(h host.Host, opts ...Opt) (*Host, error) {
 			QueueSize:             cfg.GossipQueueSize,
 			PeerOutboundQueueSize: cfg.GossipPeerOutboundQueueSize,
 			Throttle:              cfg.GossipValidationThrottle,
+			Reputation:            fh.reputation,
+			Recorder:              fh.recorder,
 		}); err != nil {
 			return nil, fmt.Errorf("failed to initialize pubsub: %w", err)
 		}
@@ -219,9 +288,13 @@ func Upgrade(h host.Host, opts ...Opt) (*Host, error) {
 			continue
 		}
 		peers = append(peers, peerID)
+		fh.reputation.Protect(peerID)
 	}
 	if len(peers) != 0 {
-		fh.ping = NewPing(fh.logger.Zap(), fh, peers, fh.discovery, WithPingInterval(fh.cfg.PingInterval))
+		fh.ping = NewPing(fh.logger.Zap(), fh, peers, fh.discovery,
+			WithPingInterval(fh.cfg.PingInterval),
+			WithPingReputation(fh.reputation),
+		)
 	}
 
 	fh.natTypeSub, err = fh.EventBus().Subscribe(new(event.EvtNATDeviceTypeChanged),
@@ -287,6 +360,7 @@ func (fh *Host) ConnectedPeerInfo(id peer.ID) *PeerInfo {
 		ID:          id,
 		Connections: connections,
 		Tags:        tags,
+		Reputation:  fh.reputation.Score(id),
 	}
 }
 
@@ -375,6 +449,16 @@ func (fh *Host) Ping() *Ping {
 	return fh.ping
 }
 
+// Reputation returns the service that keeps scores of peers.
+func (fh *Host) Reputation() *reputation.Reputation {
+	return fh.reputation
+}
+
+// Recorder returns the traffic recorder, it is nil if capture is not enabled.
+func (fh *Host) Recorder() *capture.Recorder {
+	return fh.recorder
+}
+
 func (fh *Host) Start() error {
 	fh.closed.Lock()
 	defer fh.closed.Unlock()
@@ -395,6 +479,14 @@ func (fh *Host) Start() error {
 			return nil
 		})
 	}
+	fh.eg.Go(func() error {
+		fh.reputation.Persist(fh.ctx, fh.cfg.DataDir, fh.cfg.Reputation.PersistInterval)
+		return nil
+	})
+	fh.eg.Go(func() error {
+		fh.pruneRulesLoop(fh.ctx, rulesPruneInterval)
+		return nil
+	})
 	fh.eg.Go(fh.trackNetEvents)
 	return nil
 }
@@ -415,6 +507,11 @@ func (fh *Host) Stop() error {
 	if err := fh.Host.Close(); err != nil {
 		return fmt.Errorf("failed to close libp2p host: %w", err)
 	}
+	if fh.recorder != nil {
+		if err := fh.recorder.Close(); err != nil {
+			return fmt.Errorf("failed to close traffic recorder: %w", err)
+		}
+	}
 	lp2plog.SetPrimaryCore(zapcore.NewNopCore())
 	return nil
 }
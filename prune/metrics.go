@@ -20,4 +20,18 @@ var (
 	certLatency      = pruneLatency.WithLabelValues("cert")
 	propTxLatency    = pruneLatency.WithLabelValues("proptxs")
 	activeSetLatency = pruneLatency.WithLabelValues("activeset")
+	ballotsLatency   = pruneLatency.WithLabelValues("ballots")
+	blocksLatency    = pruneLatency.WithLabelValues("blocks")
+	txsLatency       = pruneLatency.WithLabelValues("transactions")
+	rewardsLatency   = pruneLatency.WithLabelValues("rewards")
+	poetsLatency     = pruneLatency.WithLabelValues("poets")
+	atxBlobsLatency  = pruneLatency.WithLabelValues("atx_blobs")
+	beaconsLatency   = pruneLatency.WithLabelValues("beacons")
+
+	prunedRows = metrics.NewCounter(
+		"pruned_rows",
+		namespace,
+		"number of rows deleted by retention policies",
+		[]string{"table"},
+	)
 )
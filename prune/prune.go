@@ -2,18 +2,35 @@ package prune
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/activesets"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/signedmsgs"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
+	"github.com/spacemeshos/go-spacemesh/sql/rewards"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
 	"github.com/spacemeshos/go-spacemesh/timesync"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
+// keepLatestAtxs is the number of the latest atxs of every identity whose blobs are never pruned.
+// Matches the number of atxs included into a checkpoint.
+const keepLatestAtxs = 4
+
 type Opt func(*Pruner)
 
 func WithLogger(logger *zap.Logger) Opt {
@@ -22,12 +39,35 @@ func WithLogger(logger *zap.Logger) Opt {
 	}
 }
 
+// WithRetention enables pruning of tables according to the retention.
+func WithRetention(retention Retention) Opt {
+	return func(p *Pruner) {
+		p.retention = retention
+	}
+}
+
+// WithTortoiseConfig sets tortoise config, it is used to compute the window of layers
+// that must be kept to recover tortoise state.
+func WithTortoiseConfig(cfg tortoise.Config) Opt {
+	return func(p *Pruner) {
+		p.tortoise = cfg
+	}
+}
+
+// WithLocalDB enables pruning of records of signed messages in the local database.
+func WithLocalDB(db *localsql.Database) Opt {
+	return func(p *Pruner) {
+		p.localDB = db
+	}
+}
+
 func New(db *sql.Database, safeDist uint32, activesetEpoch types.EpochID, opts ...Opt) *Pruner {
 	p := &Pruner{
 		logger:         zap.NewNop(),
 		db:             db,
 		safeDist:       safeDist,
 		activesetEpoch: activesetEpoch,
+		tortoise:       tortoise.DefaultConfig(),
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -38,8 +78,14 @@ func New(db *sql.Database, safeDist uint32, activesetEpoch types.EpochID, opts .
 type Pruner struct {
 	logger         *zap.Logger
 	db             *sql.Database
+	localDB        *localsql.Database
 	safeDist       uint32
 	activesetEpoch types.EpochID
+	retention      Retention
+	tortoise       tortoise.Config
+
+	// poetsFrom is the first epoch that wasn't yet checked for poet proofs to prune.
+	poetsFrom types.EpochID
 }
 
 func Run(ctx context.Context, p *Pruner, clock *timesync.NodeClock, interval time.Duration) {
@@ -47,6 +93,7 @@ func Run(ctx context.Context, p *Pruner, clock *timesync.NodeClock, interval tim
 		zap.Uint32("dist", p.safeDist),
 		zap.Uint32("active set epoch", p.activesetEpoch.Uint32()),
 		zap.Duration("interval", interval),
+		zap.Any("retention", p.retention),
 	)
 	for {
 		select {
@@ -94,5 +141,182 @@ func (p *Pruner) Prune(current types.LayerID) error {
 		}
 		activeSetLatency.Observe(time.Since(start).Seconds())
 	}
+	if p.localDB != nil && current.GetEpoch() > 1 {
+		// nothing is signed for layers before the previous epoch
+		if err := signedmsgs.DeleteBefore(p.localDB, (current.GetEpoch() - 1).FirstLayer()); err != nil {
+			return err
+		}
+	}
+	if p.retention.Enabled() {
+		return p.pruneRetained(current)
+	}
+	return nil
+}
+
+// protected returns the first layer that must be kept to recover tortoise state.
+// Every layer is protected if the first layer of the window wasn't applied yet.
+func (p *Pruner) protected(current types.LayerID) (types.LayerID, error) {
+	applied, err := layers.GetLastApplied(p.db)
+	if err != nil {
+		return 0, fmt.Errorf("get last applied: %w", err)
+	}
+	size := p.tortoise.WindowSizeLayers(applied)
+	if applied <= size {
+		return 0, nil
+	}
+	// tortoise recovers from the first layer of the epoch of the window
+	// and needs aggregated hash of the layer before it, see tortoise.Recover
+	window := (applied - size).GetEpoch().FirstLayer()
+	if window > 0 {
+		window--
+	}
+	if oldest := current - types.LayerID(min(p.safeDist, current.Uint32())); oldest < window {
+		window = oldest
+	}
+	return window, nil
+}
+
+// horizon returns the first layer that is kept for the table with the retention.
+// Zero is returned if the table is kept forever.
+func (p *Pruner) horizon(
+	table string,
+	retention uint32,
+	current, protected types.LayerID,
+) types.LayerID {
+	if retention == 0 || retention >= current.Uint32() {
+		return 0
+	}
+	horizon := current - types.LayerID(retention)
+	if horizon > protected {
+		p.logger.Warn("retention is shorter than the tortoise window, keeping the window",
+			zap.String("table", table),
+			zap.Uint32("retention", retention),
+			zap.Uint32("horizon", protected.Uint32()),
+		)
+		return protected
+	}
+	return horizon
+}
+
+func (p *Pruner) pruneRetained(current types.LayerID) error {
+	protected, err := p.protected(current)
+	if err != nil {
+		return err
+	}
+	if horizon := p.horizon("ballots", p.retention.Ballots, current, protected); horizon > 0 {
+		if err := p.prune("ballots", ballotsLatency, func() (int, error) {
+			return ballots.DeleteBefore(p.db, horizon)
+		}); err != nil {
+			return err
+		}
+	}
+	if horizon := p.horizon("blocks", p.retention.Blocks, current, protected); horizon > 0 {
+		if err := p.prune("blocks", blocksLatency, func() (int, error) {
+			return blocks.DeleteBefore(p.db, horizon)
+		}); err != nil {
+			return err
+		}
+	}
+	if horizon := p.horizon("transactions", p.retention.Transactions, current, protected); horizon > 0 {
+		if err := p.prune("transactions", txsLatency, func() (int, error) {
+			var deleted int
+			err := p.db.WithTx(context.Background(), func(tx *sql.Tx) error {
+				var err error
+				deleted, err = transactions.DeleteAppliedBefore(tx, horizon)
+				return err
+			})
+			return deleted, err
+		}); err != nil {
+			return err
+		}
+	}
+	if horizon := p.horizon("rewards", p.retention.Rewards, current, protected); horizon > 0 {
+		if err := p.prune("rewards", rewardsLatency, func() (int, error) {
+			return rewards.DeleteBefore(p.db, horizon)
+		}); err != nil {
+			return err
+		}
+	}
+	// poet proofs are pruned before atx blobs, as references to them are decoded from blobs
+	if horizon := p.horizon("poets", p.retention.PoetProofs, current, protected); horizon > 0 {
+		if err := p.prune("poets", poetsLatency, func() (int, error) {
+			return p.prunePoets(horizon.GetEpoch())
+		}); err != nil {
+			return err
+		}
+	}
+	if horizon := p.horizon("atx_blobs", p.retention.ATXBlobs, current, protected); horizon > 0 {
+		if err := p.prune("atx_blobs", atxBlobsLatency, func() (int, error) {
+			return atxs.DeleteBlobsBefore(p.db, horizon.GetEpoch(), keepLatestAtxs)
+		}); err != nil {
+			return err
+		}
+	}
+	if horizon := p.horizon("beacons", p.retention.Beacons, current, protected); horizon > 0 {
+		// beacon of the first epoch after genesis is needed to recover tortoise after checkpoint
+		golden := types.GetEffectiveGenesis().Add(1).GetEpoch()
+		if err := p.prune("beacons", beaconsLatency, func() (int, error) {
+			return beacons.DeleteBefore(p.db, horizon.GetEpoch(), golden)
+		}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func (p *Pruner) prune(table string, latency prometheus.Observer, fn func() (int, error)) error {
+	start := time.Now()
+	deleted, err := fn()
+	if err != nil {
+		return fmt.Errorf("prune %s: %w", table, err)
+	}
+	latency.Observe(time.Since(start).Seconds())
+	prunedRows.WithLabelValues(table).Add(float64(deleted))
+	if deleted > 0 {
+		p.logger.Debug("pruned rows", zap.String("table", table), zap.Int("deleted", deleted))
+	}
+	return nil
+}
+
+// prunePoets deletes poet proofs referenced only by atxs published before the epoch.
+func (p *Pruner) prunePoets(before types.EpochID) (int, error) {
+	deleted := 0
+	for ; p.poetsFrom < before; p.poetsFrom++ {
+		prunable, kept, err := atxs.PrunableBlobs(p.db, p.poetsFrom, keepLatestAtxs)
+		if err != nil {
+			return deleted, err
+		}
+		keep := map[types.PoetProofRef]struct{}{}
+		for _, id := range kept {
+			ref, err := atxs.PoetProofRef(context.Background(), p.db, id)
+			switch {
+			case errors.Is(err, sql.ErrNotFound):
+			case err != nil:
+				return deleted, err
+			default:
+				keep[ref] = struct{}{}
+			}
+		}
+		var refs []types.PoetProofRef
+		for _, id := range prunable {
+			ref, err := atxs.PoetProofRef(context.Background(), p.db, id)
+			switch {
+			case errors.Is(err, sql.ErrNotFound):
+				// blob was pruned during previous runs
+				continue
+			case err != nil:
+				return deleted, err
+			}
+			if _, exists := keep[ref]; !exists {
+				keep[ref] = struct{}{}
+				refs = append(refs, ref)
+			}
+		}
+		n, err := poets.Delete(p.db, refs)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
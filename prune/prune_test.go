@@ -1,17 +1,32 @@
 package prune
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/spacemeshos/go-spacemesh/activation"
+	"github.com/spacemeshos/go-spacemesh/activation/wire"
+	"github.com/spacemeshos/go-spacemesh/codec"
 	"github.com/spacemeshos/go-spacemesh/common/types"
 	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/activesets"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
 	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/beacons"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
 	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/signedmsgs"
+	"github.com/spacemeshos/go-spacemesh/sql/poets"
+	"github.com/spacemeshos/go-spacemesh/sql/rewards"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
 )
 
 func TestPrune(t *testing.T) {
@@ -84,3 +99,181 @@ func TestPrune(t *testing.T) {
 		}
 	}
 }
+
+func addAtx(t *testing.T, db sql.Executor, sig *signing.EdSigner, epoch types.EpochID) types.ATXID {
+	ref := types.PoetProofRef{byte(epoch)}
+	atx := &types.ActivationTx{
+		InnerActivationTx: types.InnerActivationTx{
+			NIPostChallenge: types.NIPostChallenge{PublishEpoch: epoch, PrevATXID: types.RandomATXID()},
+			NumUnits:        1,
+		},
+	}
+	require.NoError(t, activation.SignAndFinalizeAtx(sig, atx))
+	// poet proof ref is decoded from the blob
+	wireAtx := wire.ActivationTxToWireV1(atx)
+	wireAtx.NIPost = &wire.NIPostV1{
+		Post:         &wire.PostV1{},
+		PostMetadata: &wire.PostMetadataV1{Challenge: ref[:]},
+	}
+	atx.AtxBlob.Blob = codec.MustEncode(wireAtx)
+	atx.SetEffectiveNumUnits(atx.NumUnits)
+	atx.SetReceived(time.Now())
+	vatx, err := atx.Verify(0, 1)
+	require.NoError(t, err)
+	require.NoError(t, atxs.Add(db, vatx))
+	if has, err := poets.Has(db, ref); err == nil && !has {
+		require.NoError(t, poets.Add(db, ref, []byte("proof"), []byte("poet"), epoch.String()))
+	}
+	return atx.ID()
+}
+
+func TestPruneSignedMessages(t *testing.T) {
+	types.SetLayersPerEpoch(3)
+
+	localDB := localsql.InMemory()
+	id := types.RandomNodeID()
+	for lid := types.LayerID(0); lid < 10; lid++ {
+		require.NoError(t, signedmsgs.Add(localDB, signedmsgs.Record{
+			Domain: signing.HARE,
+			ID:     id,
+			Layer:  lid,
+			Hash:   types.RandomHash(),
+		}))
+	}
+
+	pruner := New(sql.InMemory(), 5, 0, WithLocalDB(localDB))
+	// current layer 10 is in epoch 3, records of epoch 2 and later are kept
+	require.NoError(t, pruner.Prune(10))
+	records, err := signedmsgs.ByIdentity(localDB, id)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, types.LayerID(6), records[0].Layer)
+}
+
+func TestPruneRetention(t *testing.T) {
+	types.SetLayersPerEpoch(3)
+
+	db := sql.InMemory()
+	const (
+		current = types.LayerID(30)
+		applied = current - 1
+	)
+	for lid := types.LayerID(1); lid < current; lid++ {
+		blt := types.NewExistingBallot(types.BallotID{byte(lid)}, types.RandomEdSignature(), types.NodeID{1}, lid)
+		require.NoError(t, ballots.Add(db, &blt))
+		block := types.NewExistingBlock(types.BlockID{byte(lid)}, types.InnerBlock{LayerIndex: lid})
+		require.NoError(t, blocks.Add(db, block))
+		require.NoError(t, rewards.Add(db, &types.Reward{
+			Layer:     lid,
+			Coinbase:  types.Address{1},
+			SmesherID: types.NodeID{1},
+		}))
+	}
+	require.NoError(t, layers.SetApplied(db, applied, types.BlockID{byte(applied)}))
+	for epoch := types.EpochID(0); epoch <= current.GetEpoch(); epoch++ {
+		require.NoError(t, beacons.Add(db, epoch, types.Beacon{1}))
+	}
+	smesher, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	var ids []types.ATXID
+	for epoch := types.EpochID(1); epoch <= 8; epoch++ {
+		ids = append(ids, addAtx(t, db, smesher, epoch))
+	}
+	// identity that published a single atx, it shares the poet proof with atx of the first identity
+	other, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	otherID := addAtx(t, db, other, 2)
+
+	cfg := tortoise.DefaultConfig()
+	cfg.WindowSize = 6
+	// tortoise recovers from the first layer of the epoch of applied - window (21)
+	// and needs the layer before it
+	const protected = types.LayerID(20)
+
+	pruner := New(db, 3, current.GetEpoch(),
+		WithLogger(logtest.New(t).Zap()),
+		WithTortoiseConfig(cfg),
+		WithRetention(Retention{
+			Ballots:    5, // shorter than the tortoise window, horizon 25 is clamped to protected layer 20
+			Blocks:     15,
+			Rewards:    12,
+			PoetProofs: 12,
+			ATXBlobs:   15,
+			Beacons:    20,
+		}),
+	)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, pruner.Prune(current))
+	}
+
+	for lid := types.LayerID(1); lid < current; lid++ {
+		_, err := ballots.Get(db, types.BallotID{byte(lid)})
+		if lid < protected {
+			require.ErrorIs(t, err, sql.ErrNotFound, "ballot in %s", lid)
+		} else {
+			require.NoError(t, err, "ballot in %s", lid)
+		}
+		has, err := blocks.Has(db, types.BlockID{byte(lid)})
+		require.NoError(t, err)
+		require.Equal(t, lid >= current-15, has, "block in %s", lid)
+	}
+	got, err := rewards.ListByCoinbase(db, types.Address{1})
+	require.NoError(t, err)
+	require.Len(t, got, int(current-(current-12)))
+
+	// poets are pruned before epoch 6, blobs before epoch 5
+	for i, id := range ids {
+		epoch := types.EpochID(i + 1)
+		has, err := poets.Has(db, types.PoetProofRef{byte(epoch)})
+		require.NoError(t, err)
+		// latest 4 atxs of the identity are kept, proof from epoch 2 is referenced by the other identity
+		require.Equal(t, epoch >= 5 || epoch == 2, has, "poet proof in %s", epoch)
+
+		err = atxs.LoadBlob(context.Background(), db, id.Bytes(), &sql.Blob{})
+		if epoch < 5 {
+			require.ErrorIs(t, err, sql.ErrNotFound, "atx blob in %s", epoch)
+		} else {
+			require.NoError(t, err)
+		}
+		_, err = atxs.Get(db, id)
+		require.NoError(t, err)
+	}
+	require.NoError(t, atxs.LoadBlob(context.Background(), db, otherID.Bytes(), &sql.Blob{}))
+
+	golden := types.GetEffectiveGenesis().Add(1).GetEpoch()
+	for epoch := types.EpochID(0); epoch <= current.GetEpoch(); epoch++ {
+		_, err := beacons.Get(db, epoch)
+		if epoch < (current-20).GetEpoch() && epoch != golden {
+			require.ErrorIs(t, err, sql.ErrNotFound, "beacon in %s", epoch)
+		} else {
+			require.NoError(t, err, "beacon in %s", epoch)
+		}
+	}
+}
+
+func TestPruneRetentionWithinWindow(t *testing.T) {
+	types.SetLayersPerEpoch(3)
+
+	db := sql.InMemory()
+	const current = types.LayerID(10)
+	for lid := types.LayerID(1); lid < current; lid++ {
+		blt := types.NewExistingBallot(types.BallotID{byte(lid)}, types.RandomEdSignature(), types.NodeID{1}, lid)
+		require.NoError(t, ballots.Add(db, &blt))
+	}
+	require.NoError(t, layers.SetApplied(db, current-1, types.BlockID{1}))
+
+	pruner := New(db, 1, current.GetEpoch(), WithRetention(Retention{Ballots: 1}))
+	require.NoError(t, pruner.Prune(current))
+	for lid := types.LayerID(1); lid < current; lid++ {
+		_, err := ballots.Get(db, types.BallotID{byte(lid)})
+		require.NoError(t, err)
+	}
+}
+
+func TestRetentionValidate(t *testing.T) {
+	require.NoError(t, Retention{}.Validate())
+	require.NoError(t, Retention{PoetProofs: 10}.Validate())
+	require.NoError(t, Retention{ATXBlobs: 10}.Validate())
+	require.NoError(t, Retention{PoetProofs: 10, ATXBlobs: 10}.Validate())
+	require.Error(t, Retention{PoetProofs: 20, ATXBlobs: 10}.Validate())
+}
@@ -0,0 +1,39 @@
+package prune
+
+import (
+	"fmt"
+)
+
+// Retention configures how many of the most recent layers of data are kept in the database.
+// Zero keeps the data forever.
+//
+// Data needed to recover tortoise state and to generate or recover from a checkpoint is never pruned,
+// regardless of the configured retention.
+type Retention struct {
+	Ballots uint32 `mapstructure:"ballots"`
+	Blocks  uint32 `mapstructure:"blocks"`
+	// Transactions applies to applied transactions and their results.
+	Transactions uint32 `mapstructure:"transactions"`
+	Rewards      uint32 `mapstructure:"rewards"`
+	// PoetProofs applies to poet proofs referenced by atxs that were published before the horizon.
+	PoetProofs uint32 `mapstructure:"poet-proofs"`
+	// ATXBlobs applies to blobs of atxs, atx metadata is always kept.
+	ATXBlobs uint32 `mapstructure:"atx-blobs"`
+	Beacons  uint32 `mapstructure:"beacons"`
+}
+
+// Enabled returns true if at least one table has finite retention.
+func (r Retention) Enabled() bool {
+	return r != Retention{}
+}
+
+// Validate returns an error if retention can't be applied.
+func (r Retention) Validate() error {
+	// references to poet proofs are decoded from atx blobs,
+	// so blobs must outlive proofs that they reference.
+	if r.ATXBlobs != 0 && r.PoetProofs > r.ATXBlobs {
+		return fmt.Errorf("poet proofs retention (%d) must not be longer than atx blobs retention (%d)",
+			r.PoetProofs, r.ATXBlobs)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package signing
+
+import (
+	"errors"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+// ErrEquivocation is returned when the identity already signed a different message for the same slot.
+var ErrEquivocation = errors.New("refused to sign conflicting message")
+
+// Guard protects identities from signing two different messages for the same slot. Slot is
+// identified by the domain, layer and round of the message. Messages that are signed once per epoch
+// use the first layer of the epoch.
+type Guard interface {
+	// Check records the message for the slot. It returns ErrEquivocation if a different message
+	// was already recorded for the slot.
+	Check(d Domain, id types.NodeID, layer types.LayerID, round uint32, msg []byte) error
+}
+
+// SetGuard sets the guard that is checked by SignOnce. It must be set before the signer is used.
+func (es *EdSigner) SetGuard(g Guard) {
+	es.guard = g
+}
+
+// SignOnce signs the message unless the identity already signed a different message for the slot.
+// Signing the same message again is allowed. Without a guard it is the same as Sign.
+func (es *EdSigner) SignOnce(d Domain, layer types.LayerID, round uint32, m []byte) (types.EdSignature, error) {
+	if es.guard != nil {
+		if err := es.guard.Check(d, es.NodeID(), layer, round, m); err != nil {
+			return types.EmptyEdSignature, err
+		}
+	}
+	return es.Sign(d, m), nil
+}
@@ -0,0 +1,202 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/oasisprotocol/curve25519-voi/primitives/ed25519"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// PassphraseEnv is the environment variable that holds the passphrase of encrypted identity files.
+const PassphraseEnv = "SPACEMESH_IDENTITY_PASSPHRASE"
+
+const (
+	keystoreVersion = 1
+	kdfArgon2id     = "argon2id"
+	cipherXChaCha   = "xchacha20-poly1305"
+	saltSize        = 16
+
+	// upper bounds of kdf parameters accepted from the identity file, so that a crafted file
+	// can't make the node allocate arbitrary memory or spend arbitrary cpu time.
+	maxKDFTime     = 16
+	maxKDFMemory   = 1 << 20 // 1 GiB in KiB
+	maxKDFThreads  = 64
+	maxKDFSaltSize = 64
+)
+
+var (
+	// ErrPassphraseRequired is returned when an encrypted identity file is loaded without a passphrase.
+	ErrPassphraseRequired = errors.New("identity file is encrypted, passphrase is required")
+	// ErrInvalidPassphrase is returned when an encrypted identity file can't be decrypted with the passphrase.
+	ErrInvalidPassphrase = errors.New("invalid passphrase")
+)
+
+// kdfParams are parameters of argon2id used to derive the encryption key from the passphrase.
+type kdfParams struct {
+	Name    string `json:"name"`
+	Salt    []byte `json:"salt"`
+	Time    uint32 `json:"time"`
+	Memory  uint32 `json:"memory"` // in KiB
+	Threads uint8  `json:"threads"`
+}
+
+// defaultKDF follows the second recommended option of RFC 9106.
+var defaultKDF = kdfParams{
+	Name:    kdfArgon2id,
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+// encryptedKey is the format of the encrypted identity file.
+//
+// The public key is stored in plain text so that the identity can be recognized without the passphrase,
+// it is also used as additional data of the AEAD.
+type encryptedKey struct {
+	Version    int       `json:"version"`
+	PublicKey  []byte    `json:"publicKey"`
+	KDF        kdfParams `json:"kdf"`
+	Cipher     string    `json:"cipher"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+}
+
+func (k *encryptedKey) validate() error {
+	switch {
+	case k.Version != keystoreVersion:
+		return fmt.Errorf("unsupported keystore version %d", k.Version)
+	case k.KDF.Name != kdfArgon2id:
+		return fmt.Errorf("unsupported kdf %q", k.KDF.Name)
+	case k.Cipher != cipherXChaCha:
+		return fmt.Errorf("unsupported cipher %q", k.Cipher)
+	case len(k.PublicKey) != ed25519.PublicKeySize:
+		return fmt.Errorf("invalid public key size %d", len(k.PublicKey))
+	case len(k.Nonce) != chacha20poly1305.NonceSizeX:
+		return fmt.Errorf("invalid nonce size %d", len(k.Nonce))
+	case k.KDF.Time == 0 || k.KDF.Memory == 0 || k.KDF.Threads == 0:
+		return errors.New("invalid kdf parameters")
+	case k.KDF.Time > maxKDFTime || k.KDF.Memory > maxKDFMemory || k.KDF.Threads > maxKDFThreads:
+		return fmt.Errorf("kdf parameters exceed limits: time %d/%d, memory %d/%d KiB, threads %d/%d",
+			k.KDF.Time, maxKDFTime, k.KDF.Memory, maxKDFMemory, k.KDF.Threads, maxKDFThreads)
+	case len(k.KDF.Salt) < saltSize || len(k.KDF.Salt) > maxKDFSaltSize:
+		return fmt.Errorf("invalid salt size %d", len(k.KDF.Salt))
+	case len(k.Ciphertext) != PrivateKeySize+chacha20poly1305.Overhead:
+		return fmt.Errorf("invalid ciphertext size %d", len(k.Ciphertext))
+	}
+	return nil
+}
+
+func deriveKey(passphrase []byte, params *kdfParams) []byte {
+	return argon2.IDKey(passphrase, params.Salt, params.Time, params.Memory, params.Threads, chacha20poly1305.KeySize)
+}
+
+// IsEncryptedKey returns true if data is in the format of the encrypted identity file.
+func IsEncryptedKey(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+func parseEncryptedKey(data []byte) (*encryptedKey, error) {
+	var key encryptedKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("decode encrypted key: %w", err)
+	}
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// EncryptedKeyPublic returns the public key of the encrypted identity file without decrypting it.
+func EncryptedKeyPublic(data []byte) (*PublicKey, error) {
+	key, err := parseEncryptedKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewPublicKey(key.PublicKey), nil
+}
+
+// EncryptKey encrypts the private key with the key derived from the passphrase.
+func EncryptKey(priv PrivateKey, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("empty passphrase")
+	}
+	key := encryptedKey{
+		Version:   keystoreVersion,
+		PublicKey: Public(priv),
+		KDF:       defaultKDF,
+		Cipher:    cipherXChaCha,
+		Nonce:     make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	key.KDF.Salt = make([]byte, saltSize)
+	if _, err := rand.Read(key.KDF.Salt); err != nil {
+		return nil, fmt.Errorf("generate salt: %w", err)
+	}
+	if _, err := rand.Read(key.Nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	aead, err := chacha20poly1305.NewX(deriveKey(passphrase, &key.KDF))
+	if err != nil {
+		return nil, err
+	}
+	key.Ciphertext = aead.Seal(nil, key.Nonce, priv, key.PublicKey)
+	return json.MarshalIndent(&key, "", "  ")
+}
+
+// DecryptKey decrypts the private key encrypted with EncryptKey.
+func DecryptKey(data, passphrase []byte) (PrivateKey, error) {
+	key, err := parseEncryptedKey(data)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(deriveKey(passphrase, &key.KDF))
+	if err != nil {
+		return nil, err
+	}
+	priv, err := aead.Open(nil, key.Nonce, key.Ciphertext, key.PublicKey)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	if len(priv) != PrivateKeySize || !bytes.Equal(Public(priv), key.PublicKey) {
+		return nil, errors.New("private and public do not match")
+	}
+	return priv, nil
+}
+
+// LoadPassphrase reads the passphrase of encrypted identity files from the file,
+// or from the PassphraseEnv environment variable if the file is not set.
+// Trailing newlines are not part of the passphrase. Returns nil if the passphrase is not configured.
+func LoadPassphrase(file string) ([]byte, error) {
+	if file == "" {
+		if env := os.Getenv(PassphraseEnv); env != "" {
+			return []byte(env), nil
+		}
+		return nil, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read passphrase file: %w", err)
+	}
+	passphrase := bytes.TrimRight(data, "\r\n")
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
+
+// WithPassphrase sets the passphrase used to decrypt the identity file loaded with FromFile
+// and to encrypt the identity file written with ToFile.
+func WithPassphrase(passphrase []byte) EdSignerOptionFunc {
+	return func(opt *edSignerOption) error {
+		if len(passphrase) == 0 {
+			return errors.New("invalid option WithPassphrase: empty passphrase")
+		}
+		opt.passphrase = passphrase
+		return nil
+	}
+}
@@ -0,0 +1,152 @@
+package signing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystore_EncryptDecrypt(t *testing.T) {
+	signer, err := NewEdSigner()
+	require.NoError(t, err)
+	passphrase := []byte("correct horse battery staple")
+
+	data, err := EncryptKey(signer.PrivateKey(), passphrase)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(data))
+	require.NotContains(t, string(data), string(signer.PrivateKey()))
+
+	pub, err := EncryptedKeyPublic(data)
+	require.NoError(t, err)
+	require.Equal(t, signer.PublicKey(), pub)
+
+	priv, err := DecryptKey(data, passphrase)
+	require.NoError(t, err)
+	require.Equal(t, signer.PrivateKey(), priv)
+
+	_, err = DecryptKey(data, []byte("wrong"))
+	require.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	t.Run("tampered public key", func(t *testing.T) {
+		var key encryptedKey
+		require.NoError(t, json.Unmarshal(data, &key))
+		other, err := NewEdSigner()
+		require.NoError(t, err)
+		key.PublicKey = other.PublicKey().Bytes()
+		tampered, err := json.Marshal(&key)
+		require.NoError(t, err)
+		_, err = DecryptKey(tampered, passphrase)
+		require.ErrorIs(t, err, ErrInvalidPassphrase)
+	})
+	t.Run("unsupported kdf", func(t *testing.T) {
+		var key encryptedKey
+		require.NoError(t, json.Unmarshal(data, &key))
+		key.KDF.Name = "scrypt"
+		tampered, err := json.Marshal(&key)
+		require.NoError(t, err)
+		_, err = DecryptKey(tampered, passphrase)
+		require.ErrorContains(t, err, "unsupported kdf")
+	})
+	t.Run("kdf parameters exceed limits", func(t *testing.T) {
+		for _, tc := range []struct {
+			desc   string
+			modify func(*kdfParams)
+		}{
+			{"time", func(p *kdfParams) { p.Time = maxKDFTime + 1 }},
+			{"memory", func(p *kdfParams) { p.Memory = maxKDFMemory + 1 }},
+			{"threads", func(p *kdfParams) { p.Threads = maxKDFThreads + 1 }},
+			{"salt", func(p *kdfParams) { p.Salt = make([]byte, maxKDFSaltSize+1) }},
+		} {
+			t.Run(tc.desc, func(t *testing.T) {
+				var key encryptedKey
+				require.NoError(t, json.Unmarshal(data, &key))
+				tc.modify(&key.KDF)
+				tampered, err := json.Marshal(&key)
+				require.NoError(t, err)
+				_, err = DecryptKey(tampered, passphrase)
+				require.Error(t, err)
+				_, err = EncryptedKeyPublic(tampered)
+				require.Error(t, err)
+			})
+		}
+	})
+	t.Run("empty passphrase", func(t *testing.T) {
+		_, err := EncryptKey(signer.PrivateKey(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestEdSigner_Encrypted(t *testing.T) {
+	passphrase := []byte("passphrase")
+	path := filepath.Join(t.TempDir(), "identity.key")
+
+	signer, err := NewEdSigner(ToFile(path), WithPassphrase(passphrase))
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, IsEncryptedKey(data))
+
+	t.Run("passphrase after file", func(t *testing.T) {
+		loaded, err := NewEdSigner(FromFile(path), WithPassphrase(passphrase))
+		require.NoError(t, err)
+		require.Equal(t, signer.PrivateKey(), loaded.PrivateKey())
+		require.Equal(t, "identity.key", loaded.Name())
+	})
+	t.Run("passphrase before file", func(t *testing.T) {
+		loaded, err := NewEdSigner(WithPassphrase(passphrase), FromFile(path))
+		require.NoError(t, err)
+		require.Equal(t, signer.PrivateKey(), loaded.PrivateKey())
+	})
+	t.Run("missing passphrase", func(t *testing.T) {
+		_, err := NewEdSigner(FromFile(path))
+		require.ErrorIs(t, err, ErrPassphraseRequired)
+	})
+	t.Run("wrong passphrase", func(t *testing.T) {
+		_, err := NewEdSigner(FromFile(path), WithPassphrase([]byte("wrong")))
+		require.ErrorIs(t, err, ErrInvalidPassphrase)
+	})
+	t.Run("private key already set", func(t *testing.T) {
+		_, err := NewEdSigner(FromFile(path), WithPrivateKey(signer.PrivateKey()))
+		require.ErrorContains(t, err, "invalid option WithPrivateKey: private key already set")
+	})
+	t.Run("plain text file with passphrase", func(t *testing.T) {
+		plain := filepath.Join(t.TempDir(), "plain.key")
+		created, err := NewEdSigner(ToFile(plain))
+		require.NoError(t, err)
+		loaded, err := NewEdSigner(FromFile(plain), WithPassphrase(passphrase))
+		require.NoError(t, err)
+		require.Equal(t, created.PrivateKey(), loaded.PrivateKey())
+	})
+}
+
+func TestLoadPassphrase(t *testing.T) {
+	t.Run("not configured", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "")
+		passphrase, err := LoadPassphrase("")
+		require.NoError(t, err)
+		require.Nil(t, passphrase)
+	})
+	t.Run("environment", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "from env")
+		passphrase, err := LoadPassphrase("")
+		require.NoError(t, err)
+		require.Equal(t, []byte("from env"), passphrase)
+	})
+	t.Run("file takes precedence", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "from env")
+		path := filepath.Join(t.TempDir(), "passphrase")
+		require.NoError(t, os.WriteFile(path, []byte("from file\n"), 0o600))
+		passphrase, err := LoadPassphrase(path)
+		require.NoError(t, err)
+		require.Equal(t, []byte("from file"), passphrase)
+	})
+	t.Run("empty file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "passphrase")
+		require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+		_, err := LoadPassphrase(path)
+		require.Error(t, err)
+	})
+}
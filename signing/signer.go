@@ -27,6 +27,8 @@ const (
 
 	BEACON_FIRST_MSG    = 10
 	BEACON_FOLLOWUP_MSG = 11
+
+	CHECKPOINT = 12
 )
 
 // String returns the string representation of a domain.
@@ -46,15 +48,21 @@ func (d Domain) String() string {
 		return "BEACON_FIRST_MSG"
 	case BEACON_FOLLOWUP_MSG:
 		return "BEACON_FOLLOWUP_MSG"
+	case CHECKPOINT:
+		return "CHECKPOINT"
 	default:
 		return "UNKNOWN"
 	}
 }
 
 type edSignerOption struct {
-	priv   PrivateKey
-	file   string
-	prefix []byte
+	priv       PrivateKey
+	file       string
+	prefix     []byte
+	passphrase []byte
+	// encrypted is the content of the encrypted identity file, it is decrypted
+	// after all options are applied, so that WithPassphrase can be passed in any order.
+	encrypted []byte
 }
 
 // EdSignerOptionFunc modifies EdSigner.
@@ -96,6 +104,12 @@ func FromFile(path string) EdSignerOptionFunc {
 			return fmt.Errorf("failed to open identity file at %s: %w", path, err)
 		}
 
+		if IsEncryptedKey(data) {
+			opt.encrypted = data
+			opt.file = filepath.Base(path)
+			return nil
+		}
+
 		if n := hex.DecodedLen(len(data)); n != PrivateKeySize {
 			return fmt.Errorf("invalid key size %d/%d for %s", n, PrivateKeySize, filepath.Base(path))
 		}
@@ -121,7 +135,7 @@ func FromFile(path string) EdSignerOptionFunc {
 // WithPrivateKey sets the private key used by EdSigner.
 func WithPrivateKey(priv PrivateKey) EdSignerOptionFunc {
 	return func(opt *edSignerOption) error {
-		if opt.priv != nil {
+		if opt.priv != nil || opt.encrypted != nil {
 			return errors.New("invalid option WithPrivateKey: private key already set")
 		}
 
@@ -158,6 +172,7 @@ type EdSigner struct {
 	file string
 
 	prefix []byte
+	guard  Guard
 }
 
 // NewEdSigner returns an auto-generated ed signer.
@@ -170,6 +185,17 @@ func NewEdSigner(opts ...EdSignerOptionFunc) (*EdSigner, error) {
 		}
 	}
 
+	if cfg.encrypted != nil {
+		if cfg.passphrase == nil {
+			return nil, fmt.Errorf("load identity file %s: %w", cfg.file, ErrPassphraseRequired)
+		}
+		priv, err := DecryptKey(cfg.encrypted, cfg.passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt identity file %s: %w", cfg.file, err)
+		}
+		cfg.priv = priv
+	}
+
 	if cfg.priv == nil {
 		_, priv, err := ed25519.GenerateKey(nil)
 		if err != nil {
@@ -188,8 +214,16 @@ func NewEdSigner(opts ...EdSignerOptionFunc) (*EdSigner, error) {
 				return nil, fmt.Errorf("save identity file %s: %w", filepath.Base(cfg.file), fs.ErrExist)
 			}
 
-			dst := make([]byte, hex.EncodedLen(len(cfg.priv)))
-			hex.Encode(dst, cfg.priv)
+			var dst []byte
+			if cfg.passphrase != nil {
+				dst, err = EncryptKey(cfg.priv, cfg.passphrase)
+				if err != nil {
+					return nil, fmt.Errorf("encrypt identity file: %w", err)
+				}
+			} else {
+				dst = make([]byte, hex.EncodedLen(len(cfg.priv)))
+				hex.Encode(dst, cfg.priv)
+			}
 			err = os.WriteFile(cfg.file, dst, 0o600)
 			if err != nil {
 				return nil, fmt.Errorf("failed to write identity file: %w", err)
@@ -74,6 +74,66 @@ func Get(db sql.Executor, address types.Address, layer types.LayerID) (types.Acc
 	return account, nil
 }
 
+// History returns all recorded states of the account ordered by layer.
+func History(db sql.Executor, address types.Address) ([]*types.Account, error) {
+	var rst []*types.Account
+	_, err := db.Exec(
+		`select balance, next_nonce, layer_updated, template, state from accounts
+		where address = ?1 order by layer_updated asc;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, address.Bytes())
+		},
+		func(stmt *sql.Statement) bool {
+			account := types.Account{Address: address}
+			account.Balance = uint64(stmt.ColumnInt64(0))
+			account.NextNonce = uint64(stmt.ColumnInt64(1))
+			account.Layer = types.LayerID(uint32(stmt.ColumnInt64(2)))
+			if stmt.ColumnLen(3) > 0 {
+				account.TemplateAddress = &types.Address{}
+				stmt.ColumnBytes(3, account.TemplateAddress[:])
+				account.State = make([]byte, stmt.ColumnLen(4))
+				stmt.ColumnBytes(4, account.State)
+			}
+			rst = append(rst, &account)
+			return true
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load history for %v: %w", address, err)
+	}
+	return rst, nil
+}
+
+// InLayer returns accounts updated in the layer in the order they were updated.
+func InLayer(db sql.Executor, layer types.LayerID) ([]*types.Account, error) {
+	var rst []*types.Account
+	_, err := db.Exec(
+		"select address, balance, next_nonce, template, state from accounts where layer_updated = ?1 order by rowid;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(layer))
+		},
+		func(stmt *sql.Statement) bool {
+			account := types.Account{Layer: layer}
+			stmt.ColumnBytes(0, account.Address[:])
+			account.Balance = uint64(stmt.ColumnInt64(1))
+			account.NextNonce = uint64(stmt.ColumnInt64(2))
+			if stmt.ColumnLen(3) > 0 {
+				var template types.Address
+				stmt.ColumnBytes(3, template[:])
+				account.TemplateAddress = &template
+				account.State = make([]byte, stmt.ColumnLen(4))
+				stmt.ColumnBytes(4, account.State)
+			}
+			rst = append(rst, &account)
+			return true
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accounts in layer %v: %w", layer, err)
+	}
+	return rst, nil
+}
+
 // All returns all latest accounts.
 func All(db sql.Executor) ([]*types.Account, error) {
 	var rst []*types.Account
@@ -105,7 +165,21 @@ func All(db sql.Executor) ([]*types.Account, error) {
 
 func Snapshot(db sql.Executor, layer types.LayerID) ([]*types.Account, error) {
 	var rst []*types.Account
-	if rows, err := db.Exec(`
+	if err := IterateSnapshot(db, layer, func(account *types.Account) bool {
+		rst = append(rst, account)
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	if len(rst) == 0 {
+		return nil, sql.ErrNotFound
+	}
+	return rst, nil
+}
+
+// IterateSnapshot iterates over the latest state of accounts at the layer ordered by address.
+func IterateSnapshot(db sql.Executor, layer types.LayerID, fn func(*types.Account) bool) error {
+	if _, err := db.Exec(`
 			select address, balance, next_nonce, max(layer_updated), template, state from accounts 
 			where layer_updated <= ?1
 			group by address order by address asc;`,
@@ -125,14 +199,11 @@ func Snapshot(db sql.Executor, layer types.LayerID) ([]*types.Account, error) {
 				account.State = make([]byte, stmt.ColumnLen(5))
 				stmt.ColumnBytes(5, account.State)
 			}
-			rst = append(rst, &account)
-			return true
+			return fn(&account)
 		}); err != nil {
-		return nil, fmt.Errorf("failed to load all accounts %w", err)
-	} else if rows == 0 {
-		return nil, sql.ErrNotFound
+		return fmt.Errorf("failed to load all accounts %w", err)
 	}
-	return rst, nil
+	return nil
 }
 
 // Update account state at a certain layer.
@@ -59,6 +59,24 @@ func TestRevert(t *testing.T) {
 	require.Equal(t, seq[3], &latest)
 }
 
+func TestHistory(t *testing.T) {
+	address := types.Address{1, 1}
+	db := sql.InMemory()
+	history, err := History(db, address)
+	require.NoError(t, err)
+	require.Empty(t, history)
+
+	seq := genSeq(address, 5)
+	for _, update := range seq {
+		require.NoError(t, Update(db, update))
+	}
+	require.NoError(t, Update(db, &types.Account{Address: types.Address{2, 2}, Layer: 1}))
+
+	history, err = History(db, address)
+	require.NoError(t, err)
+	require.Equal(t, seq, history)
+}
+
 func TestAll(t *testing.T) {
 	db := sql.InMemory()
 	addresses := []types.Address{{1, 1}, {2, 2}, {3, 3}}
@@ -78,6 +96,27 @@ func TestAll(t *testing.T) {
 	}
 }
 
+func TestInLayer(t *testing.T) {
+	db := sql.InMemory()
+	addresses := []types.Address{{3, 3}, {1, 1}, {2, 2}}
+	for _, address := range addresses {
+		for _, update := range genSeq(address, 3) {
+			require.NoError(t, Update(db, update))
+		}
+	}
+
+	accounts, err := InLayer(db, 2)
+	require.NoError(t, err)
+	require.Len(t, accounts, len(addresses))
+	for i, address := range addresses {
+		require.Equal(t, &types.Account{Address: address, Layer: 2, Balance: 2}, accounts[i])
+	}
+
+	accounts, err = InLayer(db, 4)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
+
 func TestSnapshot(t *testing.T) {
 	db := sql.InMemory()
 
@@ -558,12 +558,11 @@ type CheckpointAtx struct {
 	Coinbase       types.Address
 }
 
-// LatestN returns the latest N ATXs per smesher.
-func LatestN(db sql.Executor, n int) ([]CheckpointAtx, error) {
-	var (
-		rst  []CheckpointAtx
-		ierr error
-	)
+// IterateForCheckpoint iterates over the latest N ATXs per smesher ordered by id.
+// ATXs of malicious identities are skipped. CommitmentATX is set to the commitment atx of the smesher,
+// it is left empty if the smesher doesn't have one.
+func IterateForCheckpoint(db sql.Executor, n int, fn func(*CheckpointAtx) bool) error {
+	var ierr error
 	enc := func(stmt *sql.Statement) {
 		stmt.BindInt64(1, int64(n))
 	}
@@ -578,30 +577,31 @@ func LatestN(db sql.Executor, n int) ([]CheckpointAtx, error) {
 		catx.Sequence = uint64(stmt.ColumnInt64(6))
 		stmt.ColumnBytes(7, catx.Coinbase[:])
 		if sql.IsNull(stmt, 8) {
-			ierr = errors.New("missing nonce")
+			ierr = fmt.Errorf("missing nonce for %s", catx.ID.ShortString())
 			return false
-		} else {
-			catx.VRFNonce = types.VRFPostIndex(stmt.ColumnInt64(8))
 		}
-		rst = append(rst, catx)
-		return true
+		catx.VRFNonce = types.VRFPostIndex(stmt.ColumnInt64(8))
+		if !sql.IsNull(stmt, 9) {
+			stmt.ColumnBytes(9, catx.CommitmentATX[:])
+		}
+		return fn(&catx)
 	}
 
-	if rows, err := db.Exec(`
-		select id, epoch, effective_num_units, base_tick_height, tick_count, pubkey, sequence, coinbase, nonce
+	if _, err := db.Exec(`
+		select id, epoch, effective_num_units, base_tick_height, tick_count, pubkey, sequence, coinbase, nonce,
+			(select commitment_atx from atxs c
+			where c.pubkey = a.pubkey and c.commitment_atx is not null
+			order by c.epoch desc limit 1)
 		from (
 			select row_number() over (partition by pubkey order by epoch desc) RowNum,
 			id, epoch, effective_num_units, base_tick_height, tick_count, pubkey, sequence, coinbase, nonce
 			from atxs
-		)
-		where RowNum <= ?1 order by pubkey;`, enc, dec); err != nil {
-		return nil, fmt.Errorf("latestN: %w", err)
-	} else if rows == 0 {
-		return nil, sql.ErrNotFound
-	} else if ierr != nil {
-		return nil, ierr
+		) a
+		where RowNum <= ?1 and pubkey not in (select pubkey from identities)
+		order by id;`, enc, dec); err != nil {
+		return fmt.Errorf("iterate for checkpoint: %w", err)
 	}
-	return rst, nil
+	return ierr
 }
 
 func AddCheckpointed(db sql.Executor, catx *CheckpointAtx) error {
@@ -811,3 +811,61 @@ func PoetProofRef(ctx context.Context, db sql.Executor, id types.ATXID) (types.P
 
 	return types.PoetProofRef(atx.NIPost.PostMetadata.Challenge), nil
 }
+
+// keptBlobsQuery selects ids of atxs whose blobs are never pruned:
+// the latest ?2 atxs of every identity and commitment atxs.
+const keptBlobsQuery = `
+	select id from (
+		select id, row_number() over (partition by pubkey order by epoch desc) RowNum from atxs
+	) where RowNum <= ?2
+	union
+	select commitment_atx from atxs where commitment_atx is not null`
+
+// DeleteBlobsBefore deletes blobs of atxs published before the epoch.
+//
+// Blobs of the latest atxs of every identity (up to keepLatest) and blobs of commitment atxs
+// are never deleted, as they are needed to generate a checkpoint and to recover from it.
+// Returns the number of deleted blobs.
+func DeleteBlobsBefore(db sql.Executor, epoch types.EpochID, keepLatest int) (int, error) {
+	rows, err := db.Exec(`
+		delete from atx_blobs where id in (
+			select id from atxs where epoch < ?1 and id not in (`+keptBlobsQuery+`
+			)
+		) returning 1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindInt64(2, int64(keepLatest))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete atx blobs before %s: %w", epoch, err)
+	}
+	return rows, nil
+}
+
+// PrunableBlobs splits atxs published in the epoch into atxs whose blobs will be deleted
+// by DeleteBlobsBefore and atxs whose blobs are kept.
+func PrunableBlobs(
+	db sql.Executor,
+	epoch types.EpochID,
+	keepLatest int,
+) (prunable, kept []types.ATXID, err error) {
+	if _, err := db.Exec(`
+		select id, id in (`+keptBlobsQuery+`
+		) from atxs where epoch = ?1;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindInt64(2, int64(keepLatest))
+		}, func(stmt *sql.Statement) bool {
+			var id types.ATXID
+			stmt.ColumnBytes(0, id[:])
+			if stmt.ColumnInt(1) == 1 {
+				kept = append(kept, id)
+			} else {
+				prunable = append(prunable, id)
+			}
+			return true
+		}); err != nil {
+		return nil, nil, fmt.Errorf("prunable blobs in %s: %w", epoch, err)
+	}
+	return prunable, kept, nil
+}
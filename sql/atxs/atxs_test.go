@@ -1,9 +1,11 @@
 package atxs_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"os"
+	"slices"
 	"testing"
 	"time"
 
@@ -150,103 +152,6 @@ func TestGetFirstIDByNodeID(t *testing.T) {
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
 
-func TestLatestN(t *testing.T) {
-	db := sql.InMemory()
-
-	sig1, err := signing.NewEdSigner()
-	require.NoError(t, err)
-	sig2, err := signing.NewEdSigner()
-	require.NoError(t, err)
-	sig3, err := signing.NewEdSigner()
-	require.NoError(t, err)
-
-	atx1, err := newAtx(sig1, withPublishEpoch(1), withSequence(0))
-	require.NoError(t, err)
-	atx2, err := newAtx(sig1, withPublishEpoch(2), withSequence(1))
-	require.NoError(t, err)
-	atx3, err := newAtx(sig2, withPublishEpoch(3), withSequence(1))
-	require.NoError(t, err)
-	atx4, err := newAtx(sig2, withPublishEpoch(4), withSequence(2))
-	require.NoError(t, err)
-	atx5, err := newAtx(sig2, withPublishEpoch(5), withSequence(3))
-	require.NoError(t, err)
-	atx6, err := newAtx(sig3, withPublishEpoch(1), withSequence(0))
-	require.NoError(t, err)
-
-	for _, atx := range []*types.VerifiedActivationTx{atx1, atx2, atx3, atx4, atx5, atx6} {
-		require.NoError(t, atxs.Add(db, atx))
-	}
-
-	for _, tc := range []struct {
-		desc     string
-		n        int
-		expected map[types.NodeID]map[types.ATXID]struct{}
-	}{
-		{
-			desc: "latest 3",
-			n:    3,
-			expected: map[types.NodeID]map[types.ATXID]struct{}{
-				sig1.NodeID(): {
-					atx1.ID(): struct{}{},
-					atx2.ID(): struct{}{},
-				},
-				sig2.NodeID(): {
-					atx3.ID(): struct{}{},
-					atx4.ID(): struct{}{},
-					atx5.ID(): struct{}{},
-				},
-				sig3.NodeID(): {
-					atx6.ID(): struct{}{},
-				},
-			},
-		},
-		{
-			desc: "latest 2",
-			n:    2,
-			expected: map[types.NodeID]map[types.ATXID]struct{}{
-				sig1.NodeID(): {
-					atx1.ID(): struct{}{},
-					atx2.ID(): struct{}{},
-				},
-				sig2.NodeID(): {
-					atx4.ID(): struct{}{},
-					atx5.ID(): struct{}{},
-				},
-				sig3.NodeID(): {
-					atx6.ID(): struct{}{},
-				},
-			},
-		},
-		{
-			desc: "latest 1",
-			n:    1,
-			expected: map[types.NodeID]map[types.ATXID]struct{}{
-				sig1.NodeID(): {
-					atx2.ID(): struct{}{},
-				},
-				sig2.NodeID(): {
-					atx5.ID(): struct{}{},
-				},
-				sig3.NodeID(): {
-					atx6.ID(): struct{}{},
-				},
-			},
-		},
-	} {
-		t.Run(tc.desc, func(t *testing.T) {
-			got, err := atxs.LatestN(db, tc.n)
-			require.NoError(t, err)
-			for _, catx := range got {
-				delete(tc.expected[catx.SmesherID], catx.ID)
-				if len(tc.expected[catx.SmesherID]) == 0 {
-					delete(tc.expected, catx.SmesherID)
-				}
-			}
-			require.Empty(t, tc.expected)
-		})
-	}
-}
-
 func TestGetByEpochAndNodeID(t *testing.T) {
 	db := sql.InMemory()
 
@@ -999,3 +904,103 @@ func TestLatest(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteBlobsBefore(t *testing.T) {
+	db := sql.InMemory()
+	ctx := context.Background()
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	var ids []types.ATXID
+	for epoch := types.EpochID(1); epoch <= 4; epoch++ {
+		atx, err := newAtx(sig, withPublishEpoch(epoch))
+		require.NoError(t, err)
+		require.NoError(t, atxs.Add(db, atx))
+		ids = append(ids, atx.ID())
+	}
+	// identity with a single old atx that is also used as a commitment atx
+	other, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	commitment, err := newAtx(other, withPublishEpoch(1))
+	require.NoError(t, err)
+	require.NoError(t, atxs.Add(db, commitment))
+	third, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	committed, err := newAtx(third, withPublishEpoch(4), func(atx *types.ActivationTx) {
+		atx.CommitmentATX = &ids[0]
+	})
+	require.NoError(t, err)
+	require.NoError(t, atxs.Add(db, committed))
+
+	for epoch := types.EpochID(1); epoch <= 4; epoch++ {
+		prunable, kept, err := atxs.PrunableBlobs(db, epoch, 1)
+		require.NoError(t, err)
+		switch epoch {
+		case 1:
+			require.Empty(t, prunable)
+			require.ElementsMatch(t, []types.ATXID{ids[0], commitment.ID()}, kept)
+		case 2, 3:
+			require.Equal(t, []types.ATXID{ids[epoch-1]}, prunable)
+			require.Empty(t, kept)
+		case 4:
+			require.Empty(t, prunable)
+			require.ElementsMatch(t, []types.ATXID{ids[3], committed.ID()}, kept)
+		}
+	}
+
+	deleted, err := atxs.DeleteBlobsBefore(db, 4, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	for i, id := range ids {
+		err := atxs.LoadBlob(ctx, db, id.Bytes(), &sql.Blob{})
+		switch i {
+		case 1, 2: // not the latest atx of the identity and not a commitment
+			require.ErrorIs(t, err, sql.ErrNotFound)
+		default:
+			require.NoError(t, err)
+		}
+	}
+	// the only atx of the identity is kept
+	require.NoError(t, atxs.LoadBlob(ctx, db, commitment.ID().Bytes(), &sql.Blob{}))
+
+	// metadata is kept
+	_, err = atxs.Get(db, ids[1])
+	require.NoError(t, err)
+}
+
+func TestIterateForCheckpoint(t *testing.T) {
+	db := sql.InMemory()
+
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	commitment := types.RandomATXID()
+	var ids []types.ATXID
+	for epoch := types.EpochID(1); epoch <= 3; epoch++ {
+		atx, err := newAtx(sig, withPublishEpoch(epoch), func(atx *types.ActivationTx) {
+			if epoch == 1 {
+				atx.CommitmentATX = &commitment
+			}
+		})
+		require.NoError(t, err)
+		require.NoError(t, atxs.Add(db, atx))
+		ids = append(ids, atx.ID())
+	}
+	malicious, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	atx, err := newAtx(malicious, withPublishEpoch(3))
+	require.NoError(t, err)
+	require.NoError(t, atxs.Add(db, atx))
+	require.NoError(t, identities.SetMalicious(db, malicious.NodeID(), []byte("bad"), time.Now()))
+
+	var got []types.ATXID
+	require.NoError(t, atxs.IterateForCheckpoint(db, 2, func(catx *atxs.CheckpointAtx) bool {
+		require.Equal(t, sig.NodeID(), catx.SmesherID)
+		require.Equal(t, commitment, catx.CommitmentATX)
+		got = append(got, catx.ID)
+		return true
+	}))
+	expected := []types.ATXID{ids[1], ids[2]}
+	slices.SortFunc(expected, func(a, b types.ATXID) int { return bytes.Compare(a[:], b[:]) })
+	require.Equal(t, expected, got)
+}
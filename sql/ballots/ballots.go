@@ -317,3 +317,16 @@ func AllFirstInEpoch(db sql.Executor, epoch types.EpochID) ([]*types.Ballot, err
 	}
 	return rst, nil
 }
+
+// DeleteBefore deletes ballots from layers before lid.
+// Returns the number of deleted ballots.
+func DeleteBefore(db sql.Executor, lid types.LayerID) (int, error) {
+	rows, err := db.Exec("delete from ballots where layer < ?1 returning 1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete ballots before %s: %w", lid, err)
+	}
+	return rows, nil
+}
@@ -360,3 +360,26 @@ func TestLoadBlob(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []int{len(blob1.Bytes), len(blob2.Bytes), -1}, sizes)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	for lid := types.LayerID(1); lid <= 5; lid++ {
+		ballot := types.NewExistingBallot(types.BallotID{byte(lid)}, types.EmptyEdSignature, types.NodeID{1}, lid)
+		require.NoError(t, Add(db, &ballot))
+	}
+	deleted, err := DeleteBefore(db, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	for lid := types.LayerID(1); lid <= 5; lid++ {
+		ids, err := IDsInLayer(db, lid)
+		require.NoError(t, err)
+		if lid < 3 {
+			require.Empty(t, ids)
+		} else {
+			require.Len(t, ids, 1)
+		}
+	}
+	deleted, err = DeleteBefore(db, 3)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+}
@@ -55,3 +55,17 @@ func Set(db sql.Executor, epoch types.EpochID, beacon types.Beacon) error {
 
 	return nil
 }
+
+// DeleteBefore deletes beacons for epochs before the epoch, beacon for the except epoch is kept.
+// Returns the number of deleted beacons.
+func DeleteBefore(db sql.Executor, epoch, except types.EpochID) (int, error) {
+	rows, err := db.Exec("delete from beacons where epoch < ?1 and epoch != ?2 returning 1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindInt64(2, int64(except))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete beacons before %s: %w", epoch, err)
+	}
+	return rows, nil
+}
@@ -69,3 +69,21 @@ func TestSet(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, fallbackBeacon, got)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	for epoch := types.EpochID(1); epoch <= 4; epoch++ {
+		require.NoError(t, Add(db, epoch, types.Beacon{byte(epoch)}))
+	}
+	deleted, err := DeleteBefore(db, 4, 2)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	for epoch := types.EpochID(1); epoch <= 4; epoch++ {
+		_, err := Get(db, epoch)
+		if epoch == 1 || epoch == 3 {
+			require.ErrorIs(t, err, sql.ErrNotFound)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
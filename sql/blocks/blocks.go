@@ -220,3 +220,16 @@ func ContextualValidity(db sql.Executor, lid types.LayerID) ([]types.BlockContex
 	}
 	return rst, nil
 }
+
+// DeleteBefore deletes blocks from layers before lid.
+// Returns the number of deleted blocks.
+func DeleteBefore(db sql.Executor, lid types.LayerID) (int, error) {
+	rows, err := db.Exec("delete from blocks where layer < ?1 returning 1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete blocks before %s: %w", lid, err)
+	}
+	return rows, nil
+}
@@ -313,3 +313,19 @@ func TestLoadBlob(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []int{len(blob1.Bytes), len(blob2.Bytes), -1}, sizes)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	for lid := types.LayerID(1); lid <= 5; lid++ {
+		block := types.NewExistingBlock(types.BlockID{byte(lid)}, types.InnerBlock{LayerIndex: lid})
+		require.NoError(t, Add(db, block))
+	}
+	deleted, err := DeleteBefore(db, 4)
+	require.NoError(t, err)
+	require.Equal(t, 3, deleted)
+	for lid := types.LayerID(1); lid <= 5; lid++ {
+		has, err := Has(db, types.BlockID{byte(lid)})
+		require.NoError(t, err)
+		require.Equal(t, lid >= 4, has)
+	}
+}
@@ -30,6 +30,8 @@ var (
 	ErrObjectExists = errors.New("database: object exists")
 	// ErrTooNew is returned if database version is newer than expected.
 	ErrTooNew = errors.New("database version is too new")
+	// ErrDataLoss is returned if reverting migrations would discard data and it wasn't allowed explicitly.
+	ErrDataLoss = errors.New("database: reverting migrations discards data")
 )
 
 const (
@@ -78,6 +80,7 @@ type conf struct {
 	flags         sqlite.OpenFlags
 	connections   int
 	skipMigration map[int]struct{}
+	noApply       bool
 	vacuumState   int
 	migrations    []Migration
 	enableLatency bool
@@ -138,6 +141,14 @@ func WithSkipMigrations(i ...int) Opt {
 	}
 }
 
+// WithoutApplyingMigrations keeps migrations for MigrateDown, but doesn't apply pending migrations
+// when the database is opened.
+func WithoutApplyingMigrations() Opt {
+	return func(c *conf) {
+		c.noApply = true
+	}
+}
+
 // WithVacuumState will execute vacuum if database version before the migration was less or equal to the provided value.
 func WithVacuumState(i int) Opt {
 	return func(c *conf) {
@@ -154,6 +165,14 @@ func WithLatencyMetering(enable bool) Opt {
 	}
 }
 
+// WithReadOnly opens database in read-only mode.
+// Migrations must be disabled with WithMigrations(nil), as they can't be applied to read-only database.
+func WithReadOnly() Opt {
+	return func(c *conf) {
+		c.flags = sqlite.SQLITE_OPEN_READONLY | sqlite.SQLITE_OPEN_URI | sqlite.SQLITE_OPEN_NOMUTEX
+	}
+}
+
 // WithQueryCache enables in-memory caching of results of some queries.
 func WithQueryCache(enable bool) Opt {
 	return func(c *conf) {
@@ -199,7 +218,7 @@ func Open(uri string, opts ...Opt) (*Database, error) {
 	if err != nil {
 		return nil, fmt.Errorf("open db %s: %w", uri, err)
 	}
-	db := &Database{pool: pool}
+	db := &Database{pool: pool, migrations: config.migrations, logger: config.logger}
 	if config.enableLatency {
 		db.latency = newQueryLatency()
 	}
@@ -222,25 +241,21 @@ func Open(uri string, opts ...Opt) (*Database, error) {
 			)
 			return nil, fmt.Errorf("%w: %d > %d", ErrTooNew, before, after)
 		}
-		config.logger.Info("running migrations",
-			zap.String("uri", uri),
-			zap.Int("current version", before),
-			zap.Int("target version", after),
-		)
-		for i, m := range config.migrations {
-			if m.Order() <= before {
+		if !config.noApply {
+			config.logger.Info("running migrations",
+				zap.String("uri", uri),
+				zap.Int("current version", before),
+				zap.Int("target version", after),
+			)
+		}
+		for _, m := range config.migrations {
+			if m.Order() <= before || config.noApply {
 				continue
 			}
 			if err := db.WithTx(context.Background(), func(tx *Tx) error {
 				if _, ok := config.skipMigration[m.Order()]; !ok {
+					// changes made by a failed migration are discarded together with the transaction
 					if err := m.Apply(tx); err != nil {
-						for j := i; j >= 0 && config.migrations[j].Order() > before; j-- {
-							if e := config.migrations[j].Rollback(); e != nil {
-								err = errors.Join(err, fmt.Errorf("rollback %s: %w", m.Name(), e))
-								break
-							}
-						}
-
 						return fmt.Errorf("apply %s: %w", m.Name(), err)
 					}
 				}
@@ -292,6 +307,9 @@ type Database struct {
 
 	latency    *prometheus.HistogramVec
 	queryCount atomic.Int64
+
+	migrations []Migration
+	logger     *zap.Logger
 }
 
 func (db *Database) getConn(ctx context.Context) *sqlite.Conn {
@@ -384,6 +402,74 @@ func (db *Database) Exec(query string, encoder Encoder, decoder Decoder) (int, e
 	return exec(conn, query, encoder, decoder)
 }
 
+// MigrateDown reverts migrations until the database schema is at the target version.
+//
+// All migrations are reverted in a single transaction, so the database is either
+// at the target version after the call or left unchanged. If any of the reverted
+// migrations discards data ErrDataLoss is returned, unless allowDataLoss is set.
+func (db *Database) MigrateDown(ctx context.Context, target int, allowDataLoss bool) error {
+	current, err := version(db)
+	if err != nil {
+		return err
+	}
+	if target < 0 || target > current {
+		return fmt.Errorf("target version %d is not in range [0, %d]", target, current)
+	}
+	var revert []Migration
+	for i := len(db.migrations) - 1; i >= 0; i-- {
+		m := db.migrations[i]
+		if m.Order() > current {
+			continue
+		}
+		if m.Order() <= target {
+			break
+		}
+		revert = append(revert, m)
+	}
+	if len(revert) == 0 || revert[0].Order() != current {
+		if current != target {
+			return fmt.Errorf("migration for current version %d is unknown", current)
+		}
+		return nil
+	}
+	var lossy []string
+	for _, m := range revert {
+		if m.Lossy() {
+			lossy = append(lossy, m.Name())
+		}
+	}
+	if len(lossy) > 0 && !allowDataLoss {
+		return fmt.Errorf("%w: %s", ErrDataLoss, strings.Join(lossy, ", "))
+	}
+	return db.WithTx(ctx, func(tx *Tx) error {
+		for i, m := range revert {
+			db.logger.Info("reverting migration",
+				zap.String("name", m.Name()),
+				zap.Int("order", m.Order()),
+				zap.Bool("lossy", m.Lossy()),
+			)
+			if err := m.Rollback(tx); err != nil {
+				return fmt.Errorf("rollback %s: %w", m.Name(), err)
+			}
+			previous := target
+			if i+1 < len(revert) {
+				previous = revert[i+1].Order()
+			}
+			if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d;", previous), nil, nil); err != nil {
+				return fmt.Errorf("update user_version to %d: %w", previous, err)
+			}
+		}
+		after, err := version(tx)
+		if err != nil {
+			return err
+		}
+		if after != target {
+			return fmt.Errorf("version after rollback %d doesn't match target %d", after, target)
+		}
+		return nil
+	})
+}
+
 // Close closes all pooled connections.
 func (db *Database) Close() error {
 	db.closeMux.Lock()
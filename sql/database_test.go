@@ -3,6 +3,7 @@ package sql
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -70,13 +71,15 @@ func Test_Migration_Rollback(t *testing.T) {
 	migration1.EXPECT().Apply(gomock.Any()).Return(nil)
 	migration2.EXPECT().Apply(gomock.Any()).Return(errors.New("migration 2 failed"))
 
-	migration2.EXPECT().Rollback().Return(nil)
-
 	dbFile := filepath.Join(t.TempDir(), "test.sql")
 	_, err := Open("file:"+dbFile,
 		WithMigrations([]Migration{migration1, migration2}),
 	)
 	require.ErrorContains(t, err, "migration 2 failed")
+
+	version, err := Version(dbFile)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
 }
 
 func Test_Migration_Rollback_Only_NewMigrations(t *testing.T) {
@@ -97,7 +100,6 @@ func Test_Migration_Rollback_Only_NewMigrations(t *testing.T) {
 	migration2.EXPECT().Name().Return("test").AnyTimes()
 	migration2.EXPECT().Order().Return(2).AnyTimes()
 	migration2.EXPECT().Apply(gomock.Any()).Return(errors.New("migration 2 failed"))
-	migration2.EXPECT().Rollback().Return(nil)
 
 	_, err = Open("file:"+dbFile,
 		WithMigrations([]Migration{migration1, migration2}),
@@ -155,6 +157,23 @@ func TestDatabaseVacuumState(t *testing.T) {
 	require.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestDatabaseReadOnly(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "test.sql")
+	db, err := Open("file:"+dbFile, WithMigrations(nil))
+	require.NoError(t, err)
+	_, err = db.Exec("create table t (id int)", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	db, err = Open("file:"+dbFile, WithReadOnly(), WithMigrations(nil))
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec("select count(*) from t", nil, nil)
+	require.NoError(t, err)
+	_, err = db.Exec("insert into t (id) values (1)", nil, nil)
+	require.Error(t, err)
+}
+
 func TestQueryCount(t *testing.T) {
 	db := InMemory()
 	require.Equal(t, 0, db.QueryCount())
@@ -191,3 +210,121 @@ func Test_Migration_FailsIfDatabaseTooNew(t *testing.T) {
 	)
 	require.ErrorIs(t, err, ErrTooNew)
 }
+
+func newTestMigration(ctrl *gomock.Controller, order int, lossy bool) *MockMigration {
+	m := NewMockMigration(ctrl)
+	m.EXPECT().Name().Return(fmt.Sprintf("test%d", order)).AnyTimes()
+	m.EXPECT().Order().Return(order).AnyTimes()
+	m.EXPECT().Lossy().Return(lossy).AnyTimes()
+	m.EXPECT().Apply(gomock.Any()).DoAndReturn(func(e Executor) error {
+		_, err := e.Exec(fmt.Sprintf("create table testing%d (id int)", order), nil, nil)
+		return err
+	}).AnyTimes()
+	m.EXPECT().Rollback(gomock.Any()).DoAndReturn(func(e Executor) error {
+		_, err := e.Exec(fmt.Sprintf("drop table testing%d", order), nil, nil)
+		return err
+	}).AnyTimes()
+	return m
+}
+
+func Test_MigrateDown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	migrations := []Migration{
+		newTestMigration(ctrl, 1, false),
+		newTestMigration(ctrl, 2, false),
+		newTestMigration(ctrl, 3, false),
+	}
+	dbFile := filepath.Join(t.TempDir(), "test.sql")
+	db, err := Open("file:"+dbFile, WithMigrations(migrations))
+	require.NoError(t, err)
+
+	require.NoError(t, db.MigrateDown(context.Background(), 1, false))
+	require.NoError(t, db.Close())
+
+	version, err := Version(dbFile)
+	require.NoError(t, err)
+	require.Equal(t, 1, version)
+
+	db, err = Open("file:"+dbFile, WithMigrations(migrations))
+	require.NoError(t, err)
+	_, err = db.Exec("select * from testing3", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
+
+func Test_MigrateDown_DataLoss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	migrations := []Migration{
+		newTestMigration(ctrl, 1, false),
+		newTestMigration(ctrl, 2, true),
+		newTestMigration(ctrl, 3, false),
+	}
+	dbFile := filepath.Join(t.TempDir(), "test.sql")
+	db, err := Open("file:"+dbFile, WithMigrations(migrations))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	require.NoError(t, db.MigrateDown(context.Background(), 2, false))
+	require.ErrorIs(t, db.MigrateDown(context.Background(), 1, false), ErrDataLoss)
+	current, err := version(db)
+	require.NoError(t, err)
+	require.Equal(t, 2, current)
+
+	require.NoError(t, db.MigrateDown(context.Background(), 1, true))
+	current, err = version(db)
+	require.NoError(t, err)
+	require.Equal(t, 1, current)
+}
+
+func Test_MigrateDown_Atomic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	failing := NewMockMigration(ctrl)
+	failing.EXPECT().Name().Return("failing").AnyTimes()
+	failing.EXPECT().Order().Return(2).AnyTimes()
+	failing.EXPECT().Lossy().Return(false).AnyTimes()
+	failing.EXPECT().Apply(gomock.Any()).Return(nil)
+	failing.EXPECT().Rollback(gomock.Any()).Return(errors.New("rollback failed"))
+	migrations := []Migration{
+		newTestMigration(ctrl, 1, false),
+		failing,
+		newTestMigration(ctrl, 3, false),
+	}
+	db := InMemory(WithMigrations(migrations))
+
+	require.ErrorContains(t, db.MigrateDown(context.Background(), 0, false), "rollback failed")
+	current, err := version(db)
+	require.NoError(t, err)
+	require.Equal(t, 3, current)
+	_, err = db.Exec("select * from testing3", nil, nil)
+	require.NoError(t, err)
+}
+
+func Test_MigrateDown_WithoutApplying(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	migrations := []Migration{
+		newTestMigration(ctrl, 1, false),
+		newTestMigration(ctrl, 2, false),
+	}
+	dbFile := filepath.Join(t.TempDir(), "test.sql")
+	db, err := Open("file:"+dbFile, WithMigrations(migrations))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	pending := newTestMigration(ctrl, 3, false)
+	db, err = Open("file:"+dbFile,
+		WithMigrations(append(migrations, pending)),
+		WithoutApplyingMigrations(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+	current, err := version(db)
+	require.NoError(t, err)
+	require.Equal(t, 2, current)
+
+	require.NoError(t, db.MigrateDown(context.Background(), 1, false))
+	current, err = version(db)
+	require.NoError(t, err)
+	require.Equal(t, 1, current)
+	_, err = db.Exec("select * from testing3", nil, nil)
+	require.Error(t, err)
+}
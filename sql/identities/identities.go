@@ -3,6 +3,7 @@ package identities
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/spacemeshos/go-spacemesh/codec"
@@ -11,11 +12,18 @@ import (
 	"github.com/spacemeshos/go-spacemesh/sql"
 )
 
+// CacheKindMaliciousIDs is the query cache kind of the malicious node IDs.
+const CacheKindMaliciousIDs sql.QueryCacheKind = "malicious-ids"
+
+// MaliciousCacheKey is the query cache key of the malicious node IDs. Data derived from them can be
+// cached under subkeys of it, subkeys are invalidated when an identity is recorded as malicious.
+var MaliciousCacheKey = sql.QueryCacheKey(CacheKindMaliciousIDs, "")
+
 // SetMalicious records identity as malicious.
 func SetMalicious(db sql.Executor, nodeID types.NodeID, proof []byte, received time.Time) error {
-	_, err := db.Exec(`insert into identities (pubkey, proof, received)
+	rows, err := db.Exec(`insert into identities (pubkey, proof, received)
 	values (?1, ?2, ?3)
-	on conflict do nothing;`,
+	on conflict do nothing returning pubkey;`,
 		func(stmt *sql.Statement) {
 			stmt.BindBytes(1, nodeID.Bytes())
 			stmt.BindBytes(2, proof)
@@ -25,6 +33,9 @@ func SetMalicious(db sql.Executor, nodeID types.NodeID, proof []byte, received t
 	if err != nil {
 		return fmt.Errorf("set malicious %v: %w", nodeID, err)
 	}
+	if rows != 0 {
+		sql.AppendToCachedSlice(db, MaliciousCacheKey, nodeID)
+	}
 	return nil
 }
 
@@ -108,18 +119,26 @@ func IterateMalicious(
 }
 
 // GetMalicious retrives malicious node IDs from the database.
-func GetMalicious(db sql.Executor) (nids []types.NodeID, err error) {
-	if err = IterateMalicious(db, func(total int, nid types.NodeID) error {
-		if nids == nil {
-			nids = make([]types.NodeID, 0, total)
-		}
-		nids = append(nids, nid)
-		return nil
-	}); err != nil {
+// The IDs are cached until an identity is recorded as malicious, the caller gets a copy.
+func GetMalicious(ctx context.Context, db sql.Executor) ([]types.NodeID, error) {
+	nids, err := sql.WithCachedValue(ctx, db, MaliciousCacheKey,
+		func(context.Context) (nids []types.NodeID, err error) {
+			if err = IterateMalicious(db, func(total int, nid types.NodeID) error {
+				if nids == nil {
+					nids = make([]types.NodeID, 0, total)
+				}
+				nids = append(nids, nid)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			if len(nids) != cap(nids) {
+				panic("BUG: bad malicious node ID count")
+			}
+			return nids, nil
+		})
+	if err != nil {
 		return nil, err
 	}
-	if len(nids) != cap(nids) {
-		panic("BUG: bad malicious node ID count")
-	}
-	return nids, nil
+	return slices.Clone(nids), nil
 }
@@ -57,7 +57,7 @@ func TestMalicious(t *testing.T) {
 
 func Test_GetMalicious(t *testing.T) {
 	db := sql.InMemory()
-	got, err := GetMalicious(db)
+	got, err := GetMalicious(context.Background(), db)
 	require.NoError(t, err)
 	require.Nil(t, got)
 
@@ -68,11 +68,48 @@ func Test_GetMalicious(t *testing.T) {
 		bad = append(bad, nid)
 		require.NoError(t, SetMalicious(db, nid, types.RandomBytes(11), time.Now().Local()))
 	}
-	got, err = GetMalicious(db)
+	got, err = GetMalicious(context.Background(), db)
 	require.NoError(t, err)
 	require.Equal(t, bad, got)
 }
 
+func Test_GetMaliciousCached(t *testing.T) {
+	db := sql.InMemory(sql.WithQueryCache(true))
+	got, err := GetMalicious(context.Background(), db)
+	require.NoError(t, err)
+	require.Empty(t, got)
+
+	subKey := sql.QueryCacheSubKey("test")
+	derived, err := sql.WithCachedSubKey(context.Background(), db, MaliciousCacheKey, subKey,
+		func(context.Context) (int, error) { return 1, nil })
+	require.NoError(t, err)
+	require.Equal(t, 1, derived)
+
+	nid := types.RandomNodeID()
+	require.NoError(t, SetMalicious(db, nid, types.RandomBytes(11), time.Now()))
+	got, err = GetMalicious(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, []types.NodeID{nid}, got)
+
+	// derived data is invalidated
+	derived, err = sql.WithCachedSubKey(context.Background(), db, MaliciousCacheKey, subKey,
+		func(context.Context) (int, error) { return 2, nil })
+	require.NoError(t, err)
+	require.Equal(t, 2, derived)
+
+	// already known identity is not appended again
+	require.NoError(t, SetMalicious(db, nid, types.RandomBytes(11), time.Now()))
+	got, err = GetMalicious(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, []types.NodeID{nid}, got)
+
+	// callers can't modify the cached ids
+	got[0] = types.RandomNodeID()
+	got, err = GetMalicious(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, []types.NodeID{nid}, got)
+}
+
 func TestLoadMalfeasanceBlob(t *testing.T) {
 	db := sql.InMemory()
 	ctx := context.Background()
@@ -5,7 +5,10 @@ package sql
 // Migration is interface for migrations provider.
 type Migration interface {
 	Apply(db Executor) error
-	Rollback() error
+	// Rollback reverts changes made by Apply, bringing the schema back to the previous version.
+	Rollback(db Executor) error
+	// Lossy is true if Rollback discards data that can't be restored by applying the migration again.
+	Lossy() bool
 	Name() string
 	Order() int
 }
@@ -0,0 +1,191 @@
+// Package beaconstate persists the state of the beacon protocol that is in progress,
+// so that the node can rejoin the protocol after restart.
+package beaconstate
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// SetState persists the encoded state of the protocol for the epoch, replacing the previous one.
+func SetState(db sql.Executor, epoch types.EpochID, round types.RoundID, state []byte) error {
+	if _, err := db.Exec(`insert into beacon_state (epoch, round, state) values (?1, ?2, ?3)
+		on conflict (epoch) do update set round = excluded.round, state = excluded.state;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindInt64(2, int64(round))
+			stmt.BindBytes(3, state)
+		}, nil); err != nil {
+		return fmt.Errorf("set beacon state for epoch %d: %w", epoch, err)
+	}
+	return nil
+}
+
+// GetState returns the round and the encoded state of the protocol for the epoch.
+// Returns sql.ErrNotFound if the state is not persisted.
+func GetState(db sql.Executor, epoch types.EpochID) (types.RoundID, []byte, error) {
+	var (
+		round types.RoundID
+		state []byte
+	)
+	rows, err := db.Exec("select round, state from beacon_state where epoch = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+		}, func(stmt *sql.Statement) bool {
+			round = types.RoundID(stmt.ColumnInt64(0))
+			state = make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, state)
+			return true
+		})
+	if err != nil {
+		return 0, nil, fmt.Errorf("get beacon state for epoch %d: %w", epoch, err)
+	} else if rows == 0 {
+		return 0, nil, fmt.Errorf("%w: beacon state for epoch %d", sql.ErrNotFound, epoch)
+	}
+	return round, state, nil
+}
+
+// AddFirstVotes persists encoded first round votes of the miner.
+// Votes are received only once per epoch, repeated votes are ignored.
+func AddFirstVotes(db sql.Executor, epoch types.EpochID, nodeID types.NodeID, votes []byte) error {
+	if _, err := db.Exec(`insert into beacon_first_votes (epoch, node_id, votes) values (?1, ?2, ?3)
+		on conflict do nothing;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindBytes(2, nodeID.Bytes())
+			stmt.BindBytes(3, votes)
+		}, nil); err != nil {
+		return fmt.Errorf("add first votes of %s in epoch %d: %w", nodeID.ShortString(), epoch, err)
+	}
+	return nil
+}
+
+// IterateFirstVotes calls fn for the first round votes of every miner in the epoch until it returns false.
+func IterateFirstVotes(
+	db sql.Executor,
+	epoch types.EpochID,
+	fn func(nodeID types.NodeID, votes []byte) bool,
+) error {
+	if _, err := db.Exec("select node_id, votes from beacon_first_votes where epoch = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+		}, func(stmt *sql.Statement) bool {
+			var id types.NodeID
+			stmt.ColumnBytes(0, id[:])
+			votes := make([]byte, stmt.ColumnLen(1))
+			stmt.ColumnBytes(1, votes)
+			return fn(id, votes)
+		}); err != nil {
+		return fmt.Errorf("iterate first votes in epoch %d: %w", epoch, err)
+	}
+	return nil
+}
+
+// AddFollowingVotes persists encoded votes of the miner in the following round.
+// Votes are received only once per round, repeated votes are ignored.
+func AddFollowingVotes(
+	db sql.Executor,
+	epoch types.EpochID,
+	nodeID types.NodeID,
+	round types.RoundID,
+	votes []byte,
+) error {
+	if _, err := db.Exec(`insert into beacon_following_votes (epoch, node_id, round, votes) values (?1, ?2, ?3, ?4)
+		on conflict do nothing;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindBytes(2, nodeID.Bytes())
+			stmt.BindInt64(3, int64(round))
+			stmt.BindBytes(4, votes)
+		}, nil); err != nil {
+		return fmt.Errorf("add following votes of %s in epoch %d round %d: %w",
+			nodeID.ShortString(), epoch, round, err)
+	}
+	return nil
+}
+
+// IterateFollowingVotes calls fn for the following votes of every miner in the epoch ordered by round
+// until it returns false.
+func IterateFollowingVotes(
+	db sql.Executor,
+	epoch types.EpochID,
+	fn func(nodeID types.NodeID, round types.RoundID, votes []byte) bool,
+) error {
+	if _, err := db.Exec(`select node_id, round, votes from beacon_following_votes
+		where epoch = ?1 order by round;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+		}, func(stmt *sql.Statement) bool {
+			var id types.NodeID
+			stmt.ColumnBytes(0, id[:])
+			votes := make([]byte, stmt.ColumnLen(2))
+			stmt.ColumnBytes(2, votes)
+			return fn(id, types.RoundID(stmt.ColumnInt64(1)), votes)
+		}); err != nil {
+		return fmt.Errorf("iterate following votes in epoch %d: %w", epoch, err)
+	}
+	return nil
+}
+
+// AddWeakCoinProposal persists encoded weak coin proposal of the miner in the round.
+func AddWeakCoinProposal(
+	db sql.Executor,
+	epoch types.EpochID,
+	round types.RoundID,
+	nodeID types.NodeID,
+	proposal []byte,
+) error {
+	if _, err := db.Exec(`insert into beacon_weak_coin_proposals (epoch, round, node_id, proposal)
+		values (?1, ?2, ?3, ?4) on conflict do nothing;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindInt64(2, int64(round))
+			stmt.BindBytes(3, nodeID.Bytes())
+			stmt.BindBytes(4, proposal)
+		}, nil); err != nil {
+		return fmt.Errorf("add weak coin proposal of %s in epoch %d round %d: %w",
+			nodeID.ShortString(), epoch, round, err)
+	}
+	return nil
+}
+
+// IterateWeakCoinProposals calls fn for the weak coin proposals in the round of the epoch until it returns false.
+func IterateWeakCoinProposals(
+	db sql.Executor,
+	epoch types.EpochID,
+	round types.RoundID,
+	fn func(proposal []byte) bool,
+) error {
+	if _, err := db.Exec(`select proposal from beacon_weak_coin_proposals
+		where epoch = ?1 and round = ?2;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+			stmt.BindInt64(2, int64(round))
+		}, func(stmt *sql.Statement) bool {
+			proposal := make([]byte, stmt.ColumnLen(0))
+			stmt.ColumnBytes(0, proposal)
+			return fn(proposal)
+		}); err != nil {
+		return fmt.Errorf("iterate weak coin proposals in epoch %d round %d: %w", epoch, round, err)
+	}
+	return nil
+}
+
+// DeleteBefore deletes the state of epochs before the given epoch.
+func DeleteBefore(db sql.Executor, epoch types.EpochID) error {
+	for _, query := range []string{
+		"delete from beacon_state where epoch < ?1;",
+		"delete from beacon_first_votes where epoch < ?1;",
+		"delete from beacon_following_votes where epoch < ?1;",
+		"delete from beacon_weak_coin_proposals where epoch < ?1;",
+	} {
+		if _, err := db.Exec(query, func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(epoch))
+		}, nil); err != nil {
+			return fmt.Errorf("delete beacon state before epoch %d: %w", epoch, err)
+		}
+	}
+	return nil
+}
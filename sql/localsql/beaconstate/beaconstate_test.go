@@ -0,0 +1,110 @@
+package beaconstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func TestState(t *testing.T) {
+	db := localsql.InMemory()
+
+	_, _, err := GetState(db, 2)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, SetState(db, 2, 0, []byte{1}))
+	require.NoError(t, SetState(db, 2, 3, []byte{2, 2}))
+	require.NoError(t, SetState(db, 3, 1, []byte{3}))
+
+	round, state, err := GetState(db, 2)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, round)
+	require.Equal(t, []byte{2, 2}, state)
+
+	require.NoError(t, DeleteBefore(db, 3))
+	_, _, err = GetState(db, 2)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	_, state, err = GetState(db, 3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, state)
+}
+
+func TestFirstVotes(t *testing.T) {
+	db := localsql.InMemory()
+	collect := func(epoch types.EpochID) map[types.NodeID][]byte {
+		rst := map[types.NodeID][]byte{}
+		require.NoError(t, IterateFirstVotes(db, epoch, func(id types.NodeID, votes []byte) bool {
+			rst[id] = votes
+			return true
+		}))
+		return rst
+	}
+
+	require.NoError(t, AddFirstVotes(db, 2, types.NodeID{1}, []byte{1}))
+	require.NoError(t, AddFirstVotes(db, 2, types.NodeID{1}, []byte{2}))
+	require.NoError(t, AddFirstVotes(db, 2, types.NodeID{2}, []byte{3}))
+	require.NoError(t, AddFirstVotes(db, 3, types.NodeID{1}, []byte{4}))
+
+	require.Equal(t, map[types.NodeID][]byte{{1}: {1}, {2}: {3}}, collect(2))
+
+	require.NoError(t, DeleteBefore(db, 3))
+	require.Empty(t, collect(2))
+	require.Equal(t, map[types.NodeID][]byte{{1}: {4}}, collect(3))
+}
+
+func TestFollowingVotes(t *testing.T) {
+	db := localsql.InMemory()
+	type votes struct {
+		id    types.NodeID
+		round types.RoundID
+		votes []byte
+	}
+	collect := func(epoch types.EpochID) []votes {
+		var rst []votes
+		require.NoError(t, IterateFollowingVotes(db, epoch, func(id types.NodeID, round types.RoundID, v []byte) bool {
+			rst = append(rst, votes{id, round, v})
+			return true
+		}))
+		return rst
+	}
+
+	require.NoError(t, AddFollowingVotes(db, 2, types.NodeID{1}, 2, []byte{1}))
+	require.NoError(t, AddFollowingVotes(db, 2, types.NodeID{1}, 2, []byte{2}))
+	require.NoError(t, AddFollowingVotes(db, 2, types.NodeID{1}, 1, []byte{3}))
+	require.NoError(t, AddFollowingVotes(db, 3, types.NodeID{2}, 1, []byte{4}))
+
+	require.Equal(t, []votes{{types.NodeID{1}, 1, []byte{3}}, {types.NodeID{1}, 2, []byte{1}}}, collect(2))
+
+	require.NoError(t, DeleteBefore(db, 3))
+	require.Empty(t, collect(2))
+	require.Equal(t, []votes{{types.NodeID{2}, 1, []byte{4}}}, collect(3))
+}
+
+func TestWeakCoinProposals(t *testing.T) {
+	db := localsql.InMemory()
+	collect := func(epoch types.EpochID, round types.RoundID) [][]byte {
+		var rst [][]byte
+		require.NoError(t, IterateWeakCoinProposals(db, epoch, round, func(proposal []byte) bool {
+			rst = append(rst, proposal)
+			return true
+		}))
+		return rst
+	}
+
+	require.NoError(t, AddWeakCoinProposal(db, 2, 3, types.NodeID{1}, []byte{1}))
+	require.NoError(t, AddWeakCoinProposal(db, 2, 3, types.NodeID{1}, []byte{2}))
+	require.NoError(t, AddWeakCoinProposal(db, 2, 2, types.NodeID{2}, []byte{3}))
+	require.NoError(t, AddWeakCoinProposal(db, 2, 1, types.NodeID{2}, []byte{4}))
+	require.NoError(t, AddWeakCoinProposal(db, 3, 1, types.NodeID{1}, []byte{5}))
+
+	require.Equal(t, [][]byte{{1}}, collect(2, 3))
+	require.Equal(t, [][]byte{{3}}, collect(2, 2))
+
+	require.NoError(t, DeleteBefore(db, 3))
+	require.Empty(t, collect(2, 1))
+	require.Equal(t, [][]byte{{5}}, collect(3, 1))
+}
@@ -0,0 +1,35 @@
+package mingas
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Set persists the minimal gas price accepted by the node.
+func Set(db sql.Executor, price uint64) error {
+	if _, err := db.Exec(`insert into min_gas (id, price) values (1, ?1)
+		on conflict (id) do update set price = excluded.price;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(price))
+		}, nil); err != nil {
+		return fmt.Errorf("set min gas %d: %w", price, err)
+	}
+	return nil
+}
+
+// Get returns the persisted minimal gas price or sql.ErrNotFound if it was never set.
+func Get(db sql.Executor) (uint64, error) {
+	var price uint64
+	rows, err := db.Exec("select price from min_gas where id = 1;", nil,
+		func(stmt *sql.Statement) bool {
+			price = uint64(stmt.ColumnInt64(0))
+			return true
+		})
+	if err != nil {
+		return 0, fmt.Errorf("get min gas: %w", err)
+	} else if rows == 0 {
+		return 0, fmt.Errorf("get min gas: %w", sql.ErrNotFound)
+	}
+	return price, nil
+}
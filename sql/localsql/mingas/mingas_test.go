@@ -0,0 +1,27 @@
+package mingas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func TestMinGas(t *testing.T) {
+	db := localsql.InMemory()
+
+	_, err := Get(db)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, Set(db, 10))
+	price, err := Get(db)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, price)
+
+	require.NoError(t, Set(db, 3))
+	price, err = Get(db)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, price)
+}
@@ -0,0 +1,209 @@
+// Package poetstats persists outcomes of requests to the PoET services, so that the node
+// can prefer reliable services and back off from the ones that keep failing.
+package poetstats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Stats of the requests to the PoET service made for the identity.
+type Stats struct {
+	// NodeID is empty if stats are aggregated over all identities.
+	NodeID  types.NodeID
+	Address string
+
+	SubmitSuccess uint32
+	SubmitFailure uint32
+	// SubmitLatency is the total duration of successful submits.
+	SubmitLatency time.Duration
+
+	ProofSuccess uint32
+	ProofFailure uint32
+	// ProofLatency is the total duration of successful proof queries.
+	ProofLatency time.Duration
+
+	// ConsecutiveFailures is the number of failed requests since the last successful one.
+	ConsecutiveFailures uint32
+	LastFailure         time.Time
+	LastSuccess         time.Time
+}
+
+// AvgSubmitLatency returns average latency of successful submits.
+func (s *Stats) AvgSubmitLatency() time.Duration {
+	if s.SubmitSuccess == 0 {
+		return 0
+	}
+	return s.SubmitLatency / time.Duration(s.SubmitSuccess)
+}
+
+// AvgProofLatency returns average latency of successful proof queries.
+func (s *Stats) AvgProofLatency() time.Duration {
+	if s.ProofSuccess == 0 {
+		return 0
+	}
+	return s.ProofLatency / time.Duration(s.ProofSuccess)
+}
+
+// Reliability is the share of successful requests, smoothed so that unknown service has reliability of 0.5.
+func (s *Stats) Reliability() float64 {
+	success := float64(s.SubmitSuccess + s.ProofSuccess)
+	total := success + float64(s.SubmitFailure+s.ProofFailure)
+	return (success + 1) / (total + 2)
+}
+
+// AddSubmit records the outcome of the submit to the PoET service for the identity.
+func AddSubmit(
+	db sql.Executor,
+	nodeID types.NodeID,
+	address string,
+	success bool,
+	latency time.Duration,
+	now time.Time,
+) error {
+	return add(db, "submit", nodeID, address, success, latency, now)
+}
+
+// AddProof records the outcome of the proof query to the PoET service for the identity.
+func AddProof(
+	db sql.Executor,
+	nodeID types.NodeID,
+	address string,
+	success bool,
+	latency time.Duration,
+	now time.Time,
+) error {
+	return add(db, "proof", nodeID, address, success, latency, now)
+}
+
+func add(
+	db sql.Executor,
+	kind string,
+	nodeID types.NodeID,
+	address string,
+	success bool,
+	latency time.Duration,
+	now time.Time,
+) error {
+	query := fmt.Sprintf(`insert into poet_stats (node_id, address, %[1]s_success, %[1]s_latency, last_success)
+		values (?1, ?2, 1, ?3, ?4)
+		on conflict (node_id, address) do update set %[1]s_success = %[1]s_success + 1,
+		%[1]s_latency = %[1]s_latency + excluded.%[1]s_latency, consecutive_failures = 0,
+		last_success = excluded.last_success;`, kind)
+	if !success {
+		query = fmt.Sprintf(`insert into poet_stats (node_id, address, %[1]s_failure, consecutive_failures, last_failure)
+			values (?1, ?2, 1, 1, ?4)
+			on conflict (node_id, address) do update set %[1]s_failure = %[1]s_failure + 1,
+			consecutive_failures = consecutive_failures + 1, last_failure = excluded.last_failure;`, kind)
+	}
+	if _, err := db.Exec(query, func(stmt *sql.Statement) {
+		stmt.BindBytes(1, nodeID.Bytes())
+		stmt.BindText(2, address)
+		stmt.BindInt64(3, latency.Nanoseconds())
+		stmt.BindInt64(4, now.UnixNano())
+	}, nil); err != nil {
+		return fmt.Errorf("add %s outcome for poet %s and %s: %w", kind, address, nodeID.ShortString(), err)
+	}
+	return nil
+}
+
+const columns = `node_id, address, submit_success, submit_failure, submit_latency,
+	proof_success, proof_failure, proof_latency, consecutive_failures, last_failure, last_success`
+
+func decode(stmt *sql.Statement) Stats {
+	stats := Stats{
+		Address:             stmt.ColumnText(1),
+		SubmitSuccess:       uint32(stmt.ColumnInt64(2)),
+		SubmitFailure:       uint32(stmt.ColumnInt64(3)),
+		SubmitLatency:       time.Duration(stmt.ColumnInt64(4)),
+		ProofSuccess:        uint32(stmt.ColumnInt64(5)),
+		ProofFailure:        uint32(stmt.ColumnInt64(6)),
+		ProofLatency:        time.Duration(stmt.ColumnInt64(7)),
+		ConsecutiveFailures: uint32(stmt.ColumnInt64(8)),
+	}
+	stmt.ColumnBytes(0, stats.NodeID[:])
+	if !sql.IsNull(stmt, 9) {
+		stats.LastFailure = time.Unix(0, stmt.ColumnInt64(9))
+	}
+	if !sql.IsNull(stmt, 10) {
+		stats.LastSuccess = time.Unix(0, stmt.ColumnInt64(10))
+	}
+	return stats
+}
+
+// aggregate stats of the PoET service over identities.
+// Consecutive failures are counted only for identities that failed after the last success of any identity.
+func aggregate(address string, stats []Stats) *Stats {
+	rst := &Stats{Address: address}
+	for _, s := range stats {
+		rst.SubmitSuccess += s.SubmitSuccess
+		rst.SubmitFailure += s.SubmitFailure
+		rst.SubmitLatency += s.SubmitLatency
+		rst.ProofSuccess += s.ProofSuccess
+		rst.ProofFailure += s.ProofFailure
+		rst.ProofLatency += s.ProofLatency
+		if s.LastFailure.After(rst.LastFailure) {
+			rst.LastFailure = s.LastFailure
+		}
+		if s.LastSuccess.After(rst.LastSuccess) {
+			rst.LastSuccess = s.LastSuccess
+		}
+	}
+	for _, s := range stats {
+		if s.LastFailure.After(rst.LastSuccess) {
+			rst.ConsecutiveFailures += s.ConsecutiveFailures
+		}
+	}
+	return rst
+}
+
+// Get returns stats of the PoET service aggregated over all identities or sql.ErrNotFound
+// if no requests were recorded. Only failures that happened after the last successful request
+// of any identity are counted as consecutive.
+func Get(db sql.Executor, address string) (*Stats, error) {
+	var stats []Stats
+	if _, err := db.Exec("select "+columns+" from poet_stats where address = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindText(1, address)
+		}, func(stmt *sql.Statement) bool {
+			stats = append(stats, decode(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("get stats for poet %s: %w", address, err)
+	}
+	if len(stats) == 0 {
+		return nil, fmt.Errorf("%w: stats for poet %s", sql.ErrNotFound, address)
+	}
+	return aggregate(address, stats), nil
+}
+
+// ByIdentity returns stats of every PoET service used by the identity ordered by address.
+func ByIdentity(db sql.Executor, nodeID types.NodeID) ([]Stats, error) {
+	var rst []Stats
+	if _, err := db.Exec("select "+columns+" from poet_stats where node_id = ?1 order by address;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, nodeID.Bytes())
+		}, func(stmt *sql.Statement) bool {
+			rst = append(rst, decode(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("get poet stats for %s: %w", nodeID.ShortString(), err)
+	}
+	return rst, nil
+}
+
+// All returns stats of every PoET service for every identity ordered by identity and address.
+func All(db sql.Executor) ([]Stats, error) {
+	var rst []Stats
+	if _, err := db.Exec("select "+columns+" from poet_stats order by node_id, address;", nil,
+		func(stmt *sql.Statement) bool {
+			rst = append(rst, decode(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("get poet stats: %w", err)
+	}
+	return rst, nil
+}
@@ -0,0 +1,130 @@
+package poetstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func TestStats(t *testing.T) {
+	db := localsql.InMemory()
+	now := time.Unix(100, 0)
+	id := types.RandomNodeID()
+
+	_, err := Get(db, "a")
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	require.NoError(t, AddSubmit(db, id, "a", true, time.Second, now))
+	require.NoError(t, AddSubmit(db, id, "a", true, 3*time.Second, now))
+	require.NoError(t, AddProof(db, id, "a", false, 0, now))
+	require.NoError(t, AddProof(db, id, "a", false, 0, now.Add(time.Minute)))
+	require.NoError(t, AddProof(db, id, "b", false, 0, now))
+
+	stats, err := Get(db, "a")
+	require.NoError(t, err)
+	require.Equal(t, &Stats{
+		Address:             "a",
+		SubmitSuccess:       2,
+		SubmitLatency:       4 * time.Second,
+		ProofFailure:        2,
+		ConsecutiveFailures: 2,
+		LastFailure:         now.Add(time.Minute),
+		LastSuccess:         now,
+	}, stats)
+	require.Equal(t, 2*time.Second, stats.AvgSubmitLatency())
+	require.Zero(t, stats.AvgProofLatency())
+	require.Equal(t, 0.5, stats.Reliability())
+
+	require.NoError(t, AddProof(db, id, "a", true, time.Minute, now))
+	stats, err = Get(db, "a")
+	require.NoError(t, err)
+	require.Zero(t, stats.ConsecutiveFailures)
+	require.Equal(t, now.Add(time.Minute), stats.LastFailure)
+	require.Equal(t, time.Minute, stats.AvgProofLatency())
+
+	all, err := ByIdentity(db, id)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	require.Equal(t, id, all[0].NodeID)
+	require.Equal(t, "a", all[0].Address)
+	require.Equal(t, "b", all[1].Address)
+	require.Equal(t, uint32(1), all[1].ConsecutiveFailures)
+	require.Less(t, all[1].Reliability(), all[0].Reliability())
+}
+
+func TestStatsByIdentity(t *testing.T) {
+	db := localsql.InMemory()
+	now := time.Unix(100, 0)
+	ids := []types.NodeID{{1}, {2}}
+
+	require.NoError(t, AddSubmit(db, ids[0], "a", true, time.Second, now))
+	require.NoError(t, AddProof(db, ids[0], "a", true, time.Second, now))
+	require.NoError(t, AddSubmit(db, ids[1], "a", true, 3*time.Second, now))
+	require.NoError(t, AddProof(db, ids[1], "a", false, 0, now))
+	require.NoError(t, AddProof(db, ids[1], "a", false, 0, now.Add(time.Minute)))
+
+	first, err := ByIdentity(db, ids[0])
+	require.NoError(t, err)
+	require.Equal(t, []Stats{{
+		NodeID:        ids[0],
+		Address:       "a",
+		SubmitSuccess: 1,
+		SubmitLatency: time.Second,
+		ProofSuccess:  1,
+		ProofLatency:  time.Second,
+		LastSuccess:   now,
+	}}, first)
+
+	second, err := ByIdentity(db, ids[1])
+	require.NoError(t, err)
+	require.Equal(t, []Stats{{
+		NodeID:              ids[1],
+		Address:             "a",
+		SubmitSuccess:       1,
+		SubmitLatency:       3 * time.Second,
+		ProofFailure:        2,
+		ConsecutiveFailures: 2,
+		LastFailure:         now.Add(time.Minute),
+		LastSuccess:         now,
+	}}, second)
+
+	stats, err := Get(db, "a")
+	require.NoError(t, err)
+	require.Equal(t, &Stats{
+		Address:             "a",
+		SubmitSuccess:       2,
+		SubmitLatency:       4 * time.Second,
+		ProofSuccess:        1,
+		ProofFailure:        2,
+		ProofLatency:        time.Second,
+		ConsecutiveFailures: 2,
+		LastFailure:         now.Add(time.Minute),
+		LastSuccess:         now,
+	}, stats)
+
+	none, err := ByIdentity(db, types.NodeID{3})
+	require.NoError(t, err)
+	require.Empty(t, none)
+
+	all, err := All(db)
+	require.NoError(t, err)
+	require.Equal(t, append(first, second...), all)
+
+	// success of any identity resets consecutive failures of the service
+	require.NoError(t, AddSubmit(db, ids[0], "a", true, time.Second, now.Add(2*time.Minute)))
+	stats, err = Get(db, "a")
+	require.NoError(t, err)
+	require.Zero(t, stats.ConsecutiveFailures)
+
+	// streak of the identity that failed since then is counted in full
+	require.NoError(t, AddProof(db, ids[1], "a", false, 0, now.Add(3*time.Minute)))
+	stats, err = Get(db, "a")
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), stats.ConsecutiveFailures)
+	require.Equal(t, now.Add(3*time.Minute), stats.LastFailure)
+}
@@ -0,0 +1,118 @@
+// Package signedmsgs persists hashes of messages signed by local identities, so that an identity never signs
+// two different messages for the same slot.
+package signedmsgs
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Record is a hash of the message signed by the identity for the slot.
+type Record struct {
+	Domain signing.Domain
+	ID     types.NodeID
+	Layer  types.LayerID
+	Round  uint32
+	Hash   types.Hash32
+}
+
+// Add inserts the record. It returns sql.ErrObjectExists if the slot already has a record.
+func Add(db sql.Executor, rec Record) error {
+	if _, err := db.Exec(`insert into signed_messages (domain, id, layer, round, hash)
+		values (?1, ?2, ?3, ?4, ?5);`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(rec.Domain))
+			stmt.BindBytes(2, rec.ID.Bytes())
+			stmt.BindInt64(3, int64(rec.Layer))
+			stmt.BindInt64(4, int64(rec.Round))
+			stmt.BindBytes(5, rec.Hash.Bytes())
+		}, nil); err != nil {
+		return fmt.Errorf("add %s %s %s/%d: %w", rec.Domain, rec.ID.ShortString(), rec.Layer, rec.Round, err)
+	}
+	return nil
+}
+
+// Get returns the hash of the message signed by the identity for the slot.
+// It returns sql.ErrNotFound if nothing was signed.
+func Get(
+	db sql.Executor,
+	domain signing.Domain,
+	id types.NodeID,
+	layer types.LayerID,
+	round uint32,
+) (types.Hash32, error) {
+	var hash types.Hash32
+	rows, err := db.Exec(`select hash from signed_messages
+		where domain = ?1 and id = ?2 and layer = ?3 and round = ?4;`,
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(domain))
+			stmt.BindBytes(2, id.Bytes())
+			stmt.BindInt64(3, int64(layer))
+			stmt.BindInt64(4, int64(round))
+		}, func(stmt *sql.Statement) bool {
+			stmt.ColumnBytes(0, hash[:])
+			return false
+		})
+	if err != nil {
+		return hash, fmt.Errorf("get %s %s %s/%d: %w", domain, id.ShortString(), layer, round, err)
+	}
+	if rows == 0 {
+		return hash, fmt.Errorf("get %s %s %s/%d: %w", domain, id.ShortString(), layer, round, sql.ErrNotFound)
+	}
+	return hash, nil
+}
+
+func decode(stmt *sql.Statement) Record {
+	rec := Record{
+		Domain: signing.Domain(stmt.ColumnInt64(0)),
+		Layer:  types.LayerID(stmt.ColumnInt64(2)),
+		Round:  uint32(stmt.ColumnInt64(3)),
+	}
+	stmt.ColumnBytes(1, rec.ID[:])
+	stmt.ColumnBytes(4, rec.Hash[:])
+	return rec
+}
+
+// ByIdentity returns all records of the identity ordered by slot.
+func ByIdentity(db sql.Executor, id types.NodeID) ([]Record, error) {
+	var rst []Record
+	if _, err := db.Exec(`select domain, id, layer, round, hash from signed_messages
+		where id = ?1 order by layer, domain, round;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+		}, func(stmt *sql.Statement) bool {
+			rst = append(rst, decode(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("records of %s: %w", id.ShortString(), err)
+	}
+	return rst, nil
+}
+
+// All returns records of every identity ordered by identity and slot.
+func All(db sql.Executor) ([]Record, error) {
+	var rst []Record
+	if _, err := db.Exec(`select domain, id, layer, round, hash from signed_messages
+		order by id, layer, domain, round;`, nil,
+		func(stmt *sql.Statement) bool {
+			rst = append(rst, decode(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("all records: %w", err)
+	}
+	return rst, nil
+}
+
+// DeleteBefore deletes records of every identity for layers before the given layer.
+func DeleteBefore(db sql.Executor, layer types.LayerID) error {
+	if _, err := db.Exec("delete from signed_messages where layer < ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(layer))
+		}, nil); err != nil {
+		return fmt.Errorf("delete before %s: %w", layer, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package signedmsgs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/signing"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func TestSignedMessages(t *testing.T) {
+	db := localsql.InMemory()
+	id := types.RandomNodeID()
+
+	_, err := Get(db, signing.HARE, id, 10, 1)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+
+	records := []Record{
+		{Domain: signing.HARE, ID: id, Layer: 10, Round: 1, Hash: types.RandomHash()},
+		{Domain: signing.HARE, ID: id, Layer: 10, Round: 2, Hash: types.RandomHash()},
+		{Domain: signing.BALLOT, ID: id, Layer: 10, Hash: types.RandomHash()},
+		{Domain: signing.ATX, ID: id, Layer: 20, Hash: types.RandomHash()},
+		{Domain: signing.ATX, ID: types.RandomNodeID(), Layer: 20, Hash: types.RandomHash()},
+	}
+	for _, rec := range records {
+		require.NoError(t, Add(db, rec))
+	}
+	require.ErrorIs(t, Add(db, records[0]), sql.ErrObjectExists)
+
+	hash, err := Get(db, signing.HARE, id, 10, 2)
+	require.NoError(t, err)
+	require.Equal(t, records[1].Hash, hash)
+
+	got, err := ByIdentity(db, id)
+	require.NoError(t, err)
+	require.Equal(t, []Record{records[2], records[0], records[1], records[3]}, got)
+
+	all, err := All(db)
+	require.NoError(t, err)
+	require.Len(t, all, len(records))
+
+	require.NoError(t, DeleteBefore(db, 11))
+	got, err = ByIdentity(db, id)
+	require.NoError(t, err)
+	require.Equal(t, []Record{records[3]}, got)
+}
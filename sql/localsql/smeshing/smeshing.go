@@ -0,0 +1,119 @@
+// Package smeshing persists per-identity smeshing settings: identities that were stopped individually,
+// coinbase of the identity and the epoch for which the identity is paused.
+package smeshing
+
+import (
+	"fmt"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+)
+
+// Identity is the smeshing settings of the identity.
+type Identity struct {
+	ID types.NodeID
+	// Stopped is true if the identity doesn't smesh while the node is smeshing.
+	Stopped bool
+	// Coinbase of the identity, empty if the coinbase of the node is used.
+	Coinbase types.Address
+	// PauseEpoch is the publish epoch of the ATX that the identity skips, zero if the identity is not paused.
+	PauseEpoch types.EpochID
+}
+
+// SetStopped persists whether the identity is stopped.
+func SetStopped(db sql.Executor, id types.NodeID, stopped bool) error {
+	if _, err := db.Exec(`insert into smeshing_identities (id, stopped) values (?1, ?2)
+		on conflict (id) do update set stopped = excluded.stopped;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+			stmt.BindBool(2, stopped)
+		}, nil); err != nil {
+		return fmt.Errorf("set stopped for %s: %w", id.ShortString(), err)
+	}
+	return nil
+}
+
+// SetCoinbase persists the coinbase of the identity. Empty address resets it to the coinbase of the node.
+func SetCoinbase(db sql.Executor, id types.NodeID, coinbase types.Address) error {
+	if _, err := db.Exec(`insert into smeshing_identities (id, coinbase) values (?1, ?2)
+		on conflict (id) do update set coinbase = excluded.coinbase;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+			if coinbase == (types.Address{}) {
+				stmt.BindNull(2)
+			} else {
+				stmt.BindBytes(2, coinbase.Bytes())
+			}
+		}, nil); err != nil {
+		return fmt.Errorf("set coinbase for %s: %w", id.ShortString(), err)
+	}
+	return nil
+}
+
+// Pause persists the epoch for which the identity is paused. Zero epoch resumes the identity.
+func Pause(db sql.Executor, id types.NodeID, epoch types.EpochID) error {
+	if _, err := db.Exec(`insert into smeshing_identities (id, pause_epoch) values (?1, ?2)
+		on conflict (id) do update set pause_epoch = excluded.pause_epoch;`,
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+			if epoch == 0 {
+				stmt.BindNull(2)
+			} else {
+				stmt.BindInt64(2, int64(epoch))
+			}
+		}, nil); err != nil {
+		return fmt.Errorf("pause %s: %w", id.ShortString(), err)
+	}
+	return nil
+}
+
+// Remove deletes the settings of the identity.
+func Remove(db sql.Executor, id types.NodeID) error {
+	if _, err := db.Exec("delete from smeshing_identities where id = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+		}, nil); err != nil {
+		return fmt.Errorf("remove %s: %w", id.ShortString(), err)
+	}
+	return nil
+}
+
+const columns = "id, stopped, coinbase, pause_epoch"
+
+func decode(stmt *sql.Statement) Identity {
+	var identity Identity
+	stmt.ColumnBytes(0, identity.ID[:])
+	identity.Stopped = stmt.ColumnInt(1) != 0
+	stmt.ColumnBytes(2, identity.Coinbase[:])
+	identity.PauseEpoch = types.EpochID(stmt.ColumnInt64(3))
+	return identity
+}
+
+// Get returns the settings of the identity. Identity that has no persisted settings is returned
+// with default settings.
+func Get(db sql.Executor, id types.NodeID) (*Identity, error) {
+	identity := Identity{ID: id}
+	if _, err := db.Exec("select "+columns+" from smeshing_identities where id = ?1;",
+		func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id.Bytes())
+		}, func(stmt *sql.Statement) bool {
+			identity = decode(stmt)
+			return false
+		}); err != nil {
+		return nil, fmt.Errorf("get %s: %w", id.ShortString(), err)
+	}
+	return &identity, nil
+}
+
+// All returns settings of every identity that has persisted settings.
+func All(db sql.Executor) ([]Identity, error) {
+	var rst []Identity
+	if _, err := db.Exec("select "+columns+" from smeshing_identities order by id;", nil,
+		func(stmt *sql.Statement) bool {
+			rst = append(rst, decode(stmt))
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("get all identities: %w", err)
+	}
+	return rst, nil
+}
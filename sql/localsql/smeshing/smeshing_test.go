@@ -0,0 +1,45 @@
+package smeshing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
+)
+
+func TestIdentities(t *testing.T) {
+	db := localsql.InMemory()
+	a, b := types.NodeID{1}, types.NodeID{2}
+	coinbase := types.GenerateAddress([]byte("coinbase"))
+
+	identity, err := Get(db, a)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{ID: a}, identity)
+
+	require.NoError(t, SetStopped(db, a, true))
+	require.NoError(t, SetCoinbase(db, a, coinbase))
+	require.NoError(t, Pause(db, a, 5))
+	require.NoError(t, Pause(db, b, 7))
+
+	identity, err = Get(db, a)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{ID: a, Stopped: true, Coinbase: coinbase, PauseEpoch: 5}, identity)
+
+	all, err := All(db)
+	require.NoError(t, err)
+	require.Equal(t, []Identity{*identity, {ID: b, PauseEpoch: 7}}, all)
+
+	require.NoError(t, SetStopped(db, a, false))
+	require.NoError(t, SetCoinbase(db, a, types.Address{}))
+	require.NoError(t, Pause(db, a, 0))
+	identity, err = Get(db, a)
+	require.NoError(t, err)
+	require.Equal(t, &Identity{ID: a}, identity)
+
+	require.NoError(t, Remove(db, b))
+	all, err = All(db)
+	require.NoError(t, err)
+	require.Equal(t, []Identity{{ID: a}}, all)
+}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"embed"
 	"fmt"
+	"io"
 	"io/fs"
 	"strconv"
 	"strings"
@@ -13,10 +14,18 @@ import (
 //go:embed migrations/**/*.sql
 var embedded embed.FS
 
+// lossyMarker is a comment that must be present in the down script if it discards data.
+const lossyMarker = "-- lossy:"
+
+// downSuffix is a suffix of the script that reverts migration with the same order.
+const downSuffix = ".down.sql"
+
 type sqlMigration struct {
 	order   int
 	name    string
 	content *bufio.Scanner
+	down    *bufio.Scanner
+	lossy   bool
 }
 
 func (m *sqlMigration) Apply(db Executor) error {
@@ -49,11 +58,22 @@ func (m *sqlMigration) Order() int {
 	return m.order
 }
 
-func (sqlMigration) Rollback() error {
-	// handled by the DB itself
+func (m *sqlMigration) Rollback(db Executor) error {
+	if m.down == nil {
+		return fmt.Errorf("migration %s doesn't have a down script", m.name)
+	}
+	for m.down.Scan() {
+		if _, err := db.Exec(m.down.Text(), nil, nil); err != nil {
+			return fmt.Errorf("exec %s: %w", m.down.Text(), err)
+		}
+	}
 	return nil
 }
 
+func (m *sqlMigration) Lossy() bool {
+	return m.lossy
+}
+
 func version(db Executor) (int, error) {
 	var current int
 	if _, err := db.Exec("PRAGMA user_version;", nil, func(stmt *Statement) bool {
@@ -75,6 +95,7 @@ func LocalMigrations() ([]Migration, error) {
 
 func sqlMigrations(dbname string) ([]Migration, error) {
 	var migrations []Migration
+	downs := make(map[int][]byte)
 	root := fmt.Sprintf("migrations/%s", dbname)
 	err := fs.WalkDir(embedded, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -91,23 +112,48 @@ func sqlMigrations(dbname string) ([]Migration, error) {
 		if err != nil {
 			return fmt.Errorf("invalid migration %s: %w", d.Name(), err)
 		}
+		if strings.HasSuffix(d.Name(), downSuffix) {
+			content, err := fs.ReadFile(embedded, path)
+			if err != nil {
+				return fmt.Errorf("read file %s: %w", path, err)
+			}
+			downs[order] = content
+			return nil
+		}
 		f, err := embedded.Open(path)
 		if err != nil {
 			return fmt.Errorf("read file %s: %w", path, err)
 		}
-		scanner := bufio.NewScanner(f)
-		scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-			if i := bytes.Index(data, []byte(";")); i >= 0 {
-				return i + 1, data[0 : i+1], nil
-			}
-			return 0, nil, nil
-		})
 		migrations = append(migrations, &sqlMigration{
 			order:   order,
 			name:    d.Name(),
-			content: scanner,
+			content: newStatementScanner(f),
 		})
 		return nil
 	})
-	return migrations, err
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range migrations {
+		m := m.(*sqlMigration)
+		content, exists := downs[m.order]
+		if !exists {
+			continue
+		}
+		m.down = newStatementScanner(bytes.NewReader(content))
+		m.lossy = bytes.Contains(content, []byte(lossyMarker))
+	}
+	return migrations, nil
+}
+
+// newStatementScanner returns a scanner that splits content into statements terminated by semicolon.
+func newStatementScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Split(func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if i := bytes.Index(data, []byte(";")); i >= 0 {
+			return i + 1, data[0 : i+1], nil
+		}
+		return 0, nil, nil
+	})
+	return scanner
 }
@@ -0,0 +1,3 @@
+-- lossy: removes all tables
+DROP TABLE nipost;
+DROP TABLE initial_post;
@@ -0,0 +1,19 @@
+-- lossy: initial_post.num_units, initial_post.vrf_nonce
+CREATE TABLE initial_post_old
+(
+    id            CHAR(32) PRIMARY KEY,
+    post_nonce    UNSIGNED INT NOT NULL,
+    post_indices  VARCHAR NOT NULL,
+    post_pow      UNSIGNED LONG INT NOT NULL,
+
+    commit_atx    CHAR(32)
+) WITHOUT ROWID;
+
+INSERT INTO initial_post_old (
+    id, post_nonce, post_indices, post_pow, commit_atx
+) SELECT
+    id, post_nonce, post_indices, post_pow, commit_atx
+FROM initial_post;
+
+DROP TABLE initial_post;
+ALTER TABLE initial_post_old RENAME TO initial_post;
@@ -0,0 +1,8 @@
+-- lossy: nipost, poet_registration, challenge.poet_proof_ref, challenge.poet_proof_membership
+DROP TABLE nipost;
+DROP TABLE poet_registration;
+
+ALTER TABLE challenge DROP COLUMN poet_proof_membership;
+ALTER TABLE challenge DROP COLUMN poet_proof_ref;
+
+ALTER TABLE challenge RENAME TO nipost;
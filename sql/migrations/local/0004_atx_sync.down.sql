@@ -0,0 +1,3 @@
+-- lossy: atx_sync_state, atx_sync_requests
+DROP TABLE atx_sync_requests;
+DROP TABLE atx_sync_state;
@@ -0,0 +1,3 @@
+-- lossy: atx_sync_requests.total, atx_sync_requests.downloaded
+ALTER TABLE atx_sync_requests DROP COLUMN downloaded;
+ALTER TABLE atx_sync_requests DROP COLUMN total;
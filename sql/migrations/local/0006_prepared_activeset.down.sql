@@ -0,0 +1,2 @@
+-- lossy: prepared_activeset
+DROP TABLE prepared_activeset;
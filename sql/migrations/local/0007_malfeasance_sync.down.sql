@@ -0,0 +1,2 @@
+-- lossy: malfeasance_sync_state
+DROP TABLE malfeasance_sync_state;
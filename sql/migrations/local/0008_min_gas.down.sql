@@ -0,0 +1,2 @@
+-- lossy: min_gas
+DROP TABLE min_gas;
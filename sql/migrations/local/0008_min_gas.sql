@@ -0,0 +1,5 @@
+CREATE TABLE min_gas
+(
+    id    INTEGER PRIMARY KEY CHECK (id = 1),
+    price UNSIGNED LONG INT NOT NULL
+);
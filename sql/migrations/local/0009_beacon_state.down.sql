@@ -0,0 +1,5 @@
+-- lossy: beacon_state, beacon_first_votes, beacon_following_votes, beacon_weak_coin_proposals
+DROP TABLE beacon_weak_coin_proposals;
+DROP TABLE beacon_following_votes;
+DROP TABLE beacon_first_votes;
+DROP TABLE beacon_state;
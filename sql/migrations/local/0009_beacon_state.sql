@@ -0,0 +1,32 @@
+CREATE TABLE beacon_state
+(
+    epoch UNSIGNED INT PRIMARY KEY,
+    round UNSIGNED INT NOT NULL,
+    state BLOB NOT NULL
+);
+
+CREATE TABLE beacon_first_votes
+(
+    epoch   UNSIGNED INT NOT NULL,
+    node_id CHAR(32) NOT NULL,
+    votes   BLOB NOT NULL,
+    PRIMARY KEY (epoch, node_id)
+) WITHOUT ROWID;
+
+CREATE TABLE beacon_following_votes
+(
+    epoch   UNSIGNED INT NOT NULL,
+    node_id CHAR(32) NOT NULL,
+    round   UNSIGNED INT NOT NULL,
+    votes   BLOB NOT NULL,
+    PRIMARY KEY (epoch, node_id, round)
+) WITHOUT ROWID;
+
+CREATE TABLE beacon_weak_coin_proposals
+(
+    epoch    UNSIGNED INT NOT NULL,
+    round    UNSIGNED INT NOT NULL,
+    node_id  CHAR(32) NOT NULL,
+    proposal BLOB NOT NULL,
+    PRIMARY KEY (epoch, round, node_id)
+) WITHOUT ROWID;
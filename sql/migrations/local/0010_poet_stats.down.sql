@@ -0,0 +1,2 @@
+-- lossy: poet_stats
+DROP TABLE poet_stats;
@@ -0,0 +1,15 @@
+CREATE TABLE poet_stats
+(
+    node_id              CHAR(32) NOT NULL,
+    address              TEXT NOT NULL,
+    submit_success       UNSIGNED INT NOT NULL DEFAULT 0,
+    submit_failure       UNSIGNED INT NOT NULL DEFAULT 0,
+    submit_latency       UNSIGNED LONG INT NOT NULL DEFAULT 0,
+    proof_success        UNSIGNED INT NOT NULL DEFAULT 0,
+    proof_failure        UNSIGNED INT NOT NULL DEFAULT 0,
+    proof_latency        UNSIGNED LONG INT NOT NULL DEFAULT 0,
+    consecutive_failures UNSIGNED INT NOT NULL DEFAULT 0,
+    last_failure         INT,
+    last_success         INT,
+    PRIMARY KEY (node_id, address)
+) WITHOUT ROWID;
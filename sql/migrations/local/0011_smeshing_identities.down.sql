@@ -0,0 +1,2 @@
+-- lossy: smeshing_identities
+DROP TABLE smeshing_identities;
@@ -0,0 +1,7 @@
+CREATE TABLE smeshing_identities
+(
+    id          CHAR(32) PRIMARY KEY,
+    stopped     BOOL NOT NULL DEFAULT FALSE,
+    coinbase    CHAR(24),
+    pause_epoch UNSIGNED INT
+) WITHOUT ROWID;
@@ -0,0 +1,3 @@
+-- lossy: signed_messages
+DROP INDEX signed_messages_by_layer;
+DROP TABLE signed_messages;
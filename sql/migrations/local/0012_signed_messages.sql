@@ -0,0 +1,10 @@
+CREATE TABLE signed_messages
+(
+    domain INT NOT NULL,
+    id     CHAR(32) NOT NULL,
+    layer  INT NOT NULL,
+    round  INT NOT NULL,
+    hash   CHAR(32) NOT NULL,
+    PRIMARY KEY (domain, id, layer, round)
+) WITHOUT ROWID;
+CREATE INDEX signed_messages_by_layer ON signed_messages (layer);
@@ -0,0 +1,17 @@
+-- lossy: removes all tables
+DROP TABLE recovery;
+DROP TABLE blocks;
+DROP TABLE ballots;
+DROP TABLE identities;
+DROP TABLE layers;
+DROP TABLE certificates;
+DROP TABLE rewards;
+DROP TABLE transactions;
+DROP TABLE transactions_results_addresses;
+DROP TABLE proposal_transactions;
+DROP TABLE block_transactions;
+DROP TABLE beacons;
+DROP TABLE atxs;
+DROP TABLE proposals;
+DROP TABLE poets;
+DROP TABLE accounts;
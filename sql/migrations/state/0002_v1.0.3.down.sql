@@ -0,0 +1,2 @@
+-- lossy: identities.received
+ALTER TABLE identities DROP COLUMN received;
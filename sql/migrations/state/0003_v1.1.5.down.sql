@@ -0,0 +1,2 @@
+-- lossy: activesets
+DROP TABLE activesets;
@@ -0,0 +1 @@
+DROP INDEX ballots_by_atx_by_layer;
@@ -0,0 +1 @@
+-- deleted proposals and certificates can't be restored, schema is unchanged
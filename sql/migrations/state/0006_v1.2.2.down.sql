@@ -0,0 +1 @@
+CREATE INDEX rewards_by_coinbase ON rewards (coinbase, layer);
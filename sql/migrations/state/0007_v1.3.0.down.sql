@@ -0,0 +1,3 @@
+-- lossy: activesets.epoch
+DROP INDEX activesets_by_epoch;
+ALTER TABLE activesets DROP COLUMN epoch;
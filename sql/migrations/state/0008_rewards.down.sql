@@ -0,0 +1,16 @@
+-- lossy: rewards.pubkey, rewards for the same coinbase in a layer are summed up
+DROP INDEX rewards_by_coinbase;
+DROP INDEX rewards_by_layer;
+ALTER TABLE rewards RENAME TO rewards_new;
+CREATE TABLE rewards
+(
+    coinbase     CHAR(24),
+    layer        INT NOT NULL,
+    total_reward UNSIGNED LONG INT,
+    layer_reward UNSIGNED LONG INT,
+    PRIMARY KEY (coinbase, layer)
+) WITHOUT ROWID;
+CREATE INDEX rewards_by_layer ON rewards (layer asc);
+INSERT INTO rewards (coinbase, layer, total_reward, layer_reward)
+  SELECT coinbase, layer, SUM(total_reward), SUM(layer_reward) FROM rewards_new GROUP BY coinbase, layer;
+DROP TABLE rewards_new;
@@ -0,0 +1 @@
+-- pruned activesets can't be restored, schema is unchanged
@@ -0,0 +1,99 @@
+DROP INDEX atxs_by_pubkey_by_epoch_desc;
+DROP INDEX atxs_by_epoch_by_pubkey;
+ALTER TABLE atxs RENAME TO atxs_new;
+CREATE TABLE atxs
+(
+    id                  CHAR(32) PRIMARY KEY,
+    epoch               INT NOT NULL,
+    effective_num_units INT NOT NULL,
+    commitment_atx      CHAR(32),
+    nonce               UNSIGNED LONG INT,
+    base_tick_height    UNSIGNED LONG INT,
+    tick_count          UNSIGNED LONG INT,
+    sequence            UNSIGNED LONG INT,
+    pubkey              CHAR(32),
+    coinbase            CHAR(24),
+    atx                 BLOB,
+    received            INT NOT NULL
+) WITHOUT ROWID;
+INSERT INTO atxs (id, epoch, effective_num_units, commitment_atx, nonce, base_tick_height, tick_count, sequence, pubkey, coinbase, atx, received)
+  SELECT id, epoch, effective_num_units, commitment_atx, nonce, base_tick_height, tick_count, sequence, pubkey, coinbase, atx, received
+  FROM atxs_new;
+CREATE INDEX atxs_by_pubkey_by_epoch_desc ON atxs (pubkey, epoch desc);
+CREATE INDEX atxs_by_epoch_by_pubkey ON atxs (epoch, pubkey);
+DROP TABLE atxs_new;
+
+DROP INDEX ballots_by_layer_by_pubkey;
+DROP INDEX ballots_by_atx_by_layer;
+ALTER TABLE ballots RENAME TO ballots_new;
+CREATE TABLE ballots
+(
+    id        CHAR(20) PRIMARY KEY,
+    atx       CHAR(32) NOT NULL,
+    layer     INT NOT NULL,
+    pubkey    VARCHAR,
+    ballot    BLOB
+) WITHOUT ROWID;
+INSERT INTO ballots (id, atx, layer, pubkey, ballot)
+  SELECT id, atx, layer, pubkey, ballot from ballots_new;
+CREATE INDEX ballots_by_layer_by_pubkey ON ballots (layer asc, pubkey);
+CREATE INDEX ballots_by_atx_by_layer ON ballots (atx, layer asc);
+DROP TABLE ballots_new;
+
+DROP INDEX blocks_by_layer;
+ALTER TABLE blocks RENAME TO blocks_new;
+CREATE TABLE blocks
+(
+    id       CHAR(20) PRIMARY KEY,
+    layer    INT NOT NULL,
+    validity SMALL INT,
+    block    BLOB
+) WITHOUT ROWID;
+INSERT INTO blocks (id, layer, validity, block)
+  SELECT id, layer, validity, block FROM blocks_new;
+CREATE INDEX blocks_by_layer ON blocks (layer, id asc);
+DROP TABLE blocks_new;
+
+DROP INDEX poets_by_service_id_by_round_id;
+ALTER TABLE poets RENAME TO poets_new;
+CREATE TABLE poets
+(
+    ref        VARCHAR PRIMARY KEY,
+    poet       BLOB,
+    service_id VARCHAR,
+    round_id   VARCHAR
+) WITHOUT ROWID;
+INSERT INTO poets (ref, poet, service_id, round_id)
+  SELECT ref, poet, service_id, round_id FROM poets_new;
+CREATE INDEX poets_by_service_id_by_round_id ON poets (service_id, round_id);
+DROP TABLE poets_new;
+
+ALTER TABLE certificates RENAME TO certificates_new;
+CREATE TABLE certificates
+(
+    layer INT NOT NULL,
+    block VARCHAR NOT NULL,
+    cert  BLOB,
+    valid bool NOT NULL,
+    PRIMARY KEY (layer, block)
+) WITHOUT ROWID;
+INSERT INTO certificates (layer, block, cert, valid)
+  SELECT layer, block, cert, valid FROM certificates_new;
+DROP TABLE certificates_new;
+
+DROP INDEX proposals_by_layer;
+ALTER TABLE proposals RENAME TO proposals_new;
+CREATE TABLE proposals
+(
+    id         CHAR(20) PRIMARY KEY,
+    ballot_id  CHAR(20),
+    layer      INT NOT NULL,
+    tx_ids     BLOB,
+    mesh_hash  CHAR(32),
+    signature  VARCHAR,
+    proposal   BLOB
+) WITHOUT ROWID;
+INSERT INTO proposals (id, ballot_id, layer, tx_ids, mesh_hash, signature, proposal)
+  SELECT id, ballot_id, layer, tx_ids, mesh_hash, signature, proposal FROM proposals_new;
+CREATE INDEX proposals_by_layer ON proposals (layer);
+DROP TABLE proposals_new;
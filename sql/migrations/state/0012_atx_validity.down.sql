@@ -0,0 +1,2 @@
+-- lossy: atxs.validity
+ALTER TABLE atxs DROP COLUMN validity;
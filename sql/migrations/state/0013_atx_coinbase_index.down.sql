@@ -0,0 +1 @@
+DROP INDEX atxs_by_coinbase;
@@ -0,0 +1,12 @@
+-- dropped proposals can't be restored, only the table is recreated
+CREATE TABLE proposals
+(
+    id         CHAR(20) PRIMARY KEY,
+    ballot_id  CHAR(20),
+    layer      INT NOT NULL,
+    tx_ids     BLOB,
+    mesh_hash  CHAR(32),
+    signature  VARCHAR,
+    proposal   BLOB
+);
+CREATE INDEX proposals_by_layer ON proposals (layer);
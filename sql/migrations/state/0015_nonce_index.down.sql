@@ -0,0 +1 @@
+DROP INDEX atxs_by_epoch_by_pubkey_nonce;
@@ -0,0 +1,39 @@
+-- lossy: atxs.prev_id
+DROP INDEX atxs_id;
+DROP INDEX atxs_by_pubkey_by_epoch_desc;
+DROP INDEX atxs_by_epoch_by_pubkey;
+DROP INDEX atxs_by_epoch_id;
+DROP INDEX atxs_by_coinbase;
+DROP INDEX atxs_by_epoch_by_pubkey_nonce;
+
+ALTER TABLE atxs RENAME TO atxs_new;
+
+CREATE TABLE atxs
+(
+    id                  CHAR(32) PRIMARY KEY,
+    epoch               INT NOT NULL,
+    effective_num_units INT NOT NULL,
+    commitment_atx      CHAR(32),
+    nonce               UNSIGNED LONG INT,
+    base_tick_height    UNSIGNED LONG INT,
+    tick_count          UNSIGNED LONG INT,
+    sequence            UNSIGNED LONG INT,
+    pubkey              CHAR(32),
+    coinbase            CHAR(24),
+    atx                 BLOB,
+    received            INT NOT NULL,
+    validity INTEGER DEFAULT false
+);
+
+INSERT INTO atxs (id, epoch, effective_num_units, commitment_atx, nonce, base_tick_height, tick_count, sequence, pubkey, coinbase, atx, received, validity)
+  SELECT a.id, a.epoch, a.effective_num_units, a.commitment_atx, a.nonce, a.base_tick_height, a.tick_count, a.sequence, a.pubkey, a.coinbase, b.atx, a.received, a.validity
+  FROM atxs_new a LEFT JOIN atx_blobs b ON a.id = b.id;
+
+CREATE INDEX atxs_by_pubkey_by_epoch_desc ON atxs (pubkey, epoch desc);
+CREATE INDEX atxs_by_epoch_by_pubkey ON atxs (epoch, pubkey);
+CREATE INDEX atxs_by_epoch_id on atxs (epoch, id);
+CREATE INDEX atxs_by_coinbase ON atxs (coinbase);
+CREATE INDEX atxs_by_epoch_by_pubkey_nonce ON atxs (pubkey, epoch desc, nonce) WHERE nonce IS NOT NULL;
+
+DROP TABLE atxs_new;
+DROP TABLE atx_blobs;
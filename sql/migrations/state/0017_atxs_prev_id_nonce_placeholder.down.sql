@@ -0,0 +1 @@
+-- placeholder for the code migration (sql/migrations/state_0017_migration.go), which is reverted by its Rollback
@@ -0,0 +1,2 @@
+-- lossy: atx_blobs.version
+ALTER TABLE atx_blobs DROP COLUMN version;
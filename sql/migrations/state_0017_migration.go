@@ -49,11 +49,53 @@ func (*migration0017) Order() int {
 	return 17
 }
 
-func (*migration0017) Rollback() error {
-	// handled by the DB itself
+// Rollback resets prev_id of all atxs, and nonces that are not present in atx blobs.
+// Both are recomputed from atx blobs when the migration is applied again.
+func (*migration0017) Rollback(db sql.Executor) error {
+	var (
+		derived []types.ATXID
+		ierr    error
+	)
+	dec := func(stmt *sql.Statement) bool {
+		var id types.ATXID
+		stmt.ColumnBytes(0, id[:])
+		var atx wire.ActivationTxV1
+		if _, err := codec.DecodeFrom(stmt.ColumnReader(1), &atx); err != nil {
+			ierr = fmt.Errorf("error decoding ATX %s: %w", id, err)
+			return false
+		}
+		if atx.VRFNonce == nil {
+			derived = append(derived, id)
+		}
+		return true
+	}
+	if _, err := db.Exec(`
+		select a.id, b.atx
+		from atxs a
+		inner join atx_blobs b on a.id = b.id
+		where a.nonce is not null and a.prev_id is not null`, nil, dec); err != nil {
+		return fmt.Errorf("error selecting ATXs: %w", err)
+	}
+	if ierr != nil {
+		return ierr
+	}
+	for _, id := range derived {
+		if _, err := db.Exec("update atxs set nonce = null where id = ?1", func(stmt *sql.Statement) {
+			stmt.BindBytes(1, id[:])
+		}, nil); err != nil {
+			return fmt.Errorf("error resetting nonce of ATX %s: %w", id, err)
+		}
+	}
+	if _, err := db.Exec("update atxs set prev_id = null", nil, nil); err != nil {
+		return fmt.Errorf("error resetting prev_id: %w", err)
+	}
 	return nil
 }
 
+func (*migration0017) Lossy() bool {
+	return false
+}
+
 func (m *migration0017) Apply(db sql.Executor) error {
 	maxEpoch, err := getMaxEpoch(db)
 	if err != nil {
@@ -111,3 +111,35 @@ func Test0017Migration(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func Test0017MigrationRollback(t *testing.T) {
+	db := sql.InMemory()
+	sig, err := signing.NewEdSigner()
+	require.NoError(t, err)
+	id1 := addAtx(t, db, sig, 1, 0, withNonce(42))
+	id2 := addAtx(t, db, sig, 2, 1, withPrevATXID(id1))
+	id3 := addAtx(t, db, sig, 3, 2, withNonce(111), withPrevATXID(id2))
+	m := New0017Migration(zaptest.NewLogger(t))
+	require.NoError(t, m.Apply(db))
+	nonce, err := atxs.NonceByID(db, id2)
+	require.NoError(t, err)
+	require.Equal(t, types.VRFPostIndex(42), nonce)
+
+	require.NoError(t, m.Rollback(db))
+	_, err = atxs.NonceByID(db, id2)
+	require.ErrorIs(t, err, sql.ErrNotFound)
+	for id, expected := range map[types.ATXID]types.VRFPostIndex{id1: 42, id3: 111} {
+		nonce, err := atxs.NonceByID(db, id)
+		require.NoError(t, err)
+		require.Equal(t, expected, nonce)
+	}
+	rows, err := db.Exec("select 1 from atxs where prev_id is not null", nil, nil)
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	// values are recomputed when the migration is applied again
+	require.NoError(t, New0017Migration(zaptest.NewLogger(t)).Apply(db))
+	nonce, err = atxs.NonceByID(db, id2)
+	require.NoError(t, err)
+	require.Equal(t, types.VRFPostIndex(42), nonce)
+}
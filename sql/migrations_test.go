@@ -1,6 +1,8 @@
 package sql
 
 import (
+	"context"
+	"fmt"
 	"slices"
 	"testing"
 
@@ -24,3 +26,71 @@ func Test_MigrationsAppliedOnce(t *testing.T) {
 	})
 	require.Equal(t, expectedVersion.Order(), version)
 }
+
+// schema returns columns and indexes of every table in the database.
+func schema(tb testing.TB, db Executor) map[string][]string {
+	tb.Helper()
+	var tables []string
+	_, err := db.Exec("select name from sqlite_master where type = 'table'", nil, func(stmt *Statement) bool {
+		tables = append(tables, stmt.ColumnText(0))
+		return true
+	})
+	require.NoError(tb, err)
+	rst := map[string][]string{}
+	for _, table := range tables {
+		_, err := db.Exec(fmt.Sprintf("pragma table_info(%q)", table), nil, func(stmt *Statement) bool {
+			rst[table] = append(rst[table], fmt.Sprintf("column %s %s notnull=%d default=%s pk=%d",
+				stmt.ColumnText(1), stmt.ColumnText(2), stmt.ColumnInt(3), stmt.ColumnText(4), stmt.ColumnInt(5)))
+			return true
+		})
+		require.NoError(tb, err)
+		_, err = db.Exec(fmt.Sprintf("pragma index_list(%q)", table), nil, func(stmt *Statement) bool {
+			rst[table] = append(rst[table], fmt.Sprintf("index %s unique=%d origin=%s partial=%d",
+				stmt.ColumnText(1), stmt.ColumnInt(2), stmt.ColumnText(3), stmt.ColumnInt(4)))
+			return true
+		})
+		require.NoError(tb, err)
+		slices.Sort(rst[table])
+	}
+	return rst
+}
+
+func testMigrationsRoundTrip(t *testing.T, load func() ([]Migration, error)) {
+	migrations, err := load()
+	require.NoError(t, err)
+	for _, m := range migrations {
+		previous := m.Order() - 1
+		t.Run(m.Name(), func(t *testing.T) {
+			all, err := load()
+			require.NoError(t, err)
+			expected := schema(t, InMemory(WithMigrations(all[:previous])))
+
+			all, err = load()
+			require.NoError(t, err)
+			db := InMemory(WithMigrations(all))
+			require.NoError(t, db.MigrateDown(context.Background(), previous, true))
+			v, err := version(db)
+			require.NoError(t, err)
+			require.Equal(t, previous, v)
+			require.Equal(t, expected, schema(t, db))
+		})
+	}
+}
+
+func Test_StateMigrationsRoundTrip(t *testing.T) {
+	testMigrationsRoundTrip(t, StateMigrations)
+}
+
+func Test_LocalMigrationsRoundTrip(t *testing.T) {
+	testMigrationsRoundTrip(t, LocalMigrations)
+}
+
+func Test_MigrationsHaveDownScripts(t *testing.T) {
+	for _, load := range []func() ([]Migration, error){StateMigrations, LocalMigrations} {
+		migrations, err := load()
+		require.NoError(t, err)
+		for _, m := range migrations {
+			require.NotNil(t, m.(*sqlMigration).down, "migration %s doesn't have a down script", m.Name())
+		}
+	}
+}
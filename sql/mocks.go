@@ -76,6 +76,44 @@ func (c *MockMigrationApplyCall) DoAndReturn(f func(Executor) error) *MockMigrat
 	return c
 }
 
+// Lossy mocks base method.
+func (m *MockMigration) Lossy() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lossy")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Lossy indicates an expected call of Lossy.
+func (mr *MockMigrationMockRecorder) Lossy() *MockMigrationLossyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lossy", reflect.TypeOf((*MockMigration)(nil).Lossy))
+	return &MockMigrationLossyCall{Call: call}
+}
+
+// MockMigrationLossyCall wrap *gomock.Call
+type MockMigrationLossyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockMigrationLossyCall) Return(arg0 bool) *MockMigrationLossyCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockMigrationLossyCall) Do(f func() bool) *MockMigrationLossyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockMigrationLossyCall) DoAndReturn(f func() bool) *MockMigrationLossyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Name mocks base method.
 func (m *MockMigration) Name() string {
 	m.ctrl.T.Helper()
@@ -153,17 +191,17 @@ func (c *MockMigrationOrderCall) DoAndReturn(f func() int) *MockMigrationOrderCa
 }
 
 // Rollback mocks base method.
-func (m *MockMigration) Rollback() error {
+func (m *MockMigration) Rollback(db Executor) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Rollback")
+	ret := m.ctrl.Call(m, "Rollback", db)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Rollback indicates an expected call of Rollback.
-func (mr *MockMigrationMockRecorder) Rollback() *MockMigrationRollbackCall {
+func (mr *MockMigrationMockRecorder) Rollback(db any) *MockMigrationRollbackCall {
 	mr.mock.ctrl.T.Helper()
-	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockMigration)(nil).Rollback))
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockMigration)(nil).Rollback), db)
 	return &MockMigrationRollbackCall{Call: call}
 }
 
@@ -179,13 +217,13 @@ func (c *MockMigrationRollbackCall) Return(arg0 error) *MockMigrationRollbackCal
 }
 
 // Do rewrite *gomock.Call.Do
-func (c *MockMigrationRollbackCall) Do(f func() error) *MockMigrationRollbackCall {
+func (c *MockMigrationRollbackCall) Do(f func(Executor) error) *MockMigrationRollbackCall {
 	c.Call = c.Call.Do(f)
 	return c
 }
 
 // DoAndReturn rewrite *gomock.Call.DoAndReturn
-func (c *MockMigrationRollbackCall) DoAndReturn(f func() error) *MockMigrationRollbackCall {
+func (c *MockMigrationRollbackCall) DoAndReturn(f func(Executor) error) *MockMigrationRollbackCall {
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
@@ -81,3 +81,20 @@ func GetRef(db sql.Executor, poetID []byte, roundID string) (ref types.PoetProof
 
 	return ref, nil
 }
+
+// Delete deletes poet proofs with the refs.
+// Returns the number of deleted proofs.
+func Delete(db sql.Executor, refs []types.PoetProofRef) (int, error) {
+	deleted := 0
+	for _, ref := range refs {
+		rows, err := db.Exec("delete from poets where ref = ?1 returning 1;",
+			func(stmt *sql.Statement) {
+				stmt.BindBytes(1, ref[:])
+			}, nil)
+		if err != nil {
+			return deleted, fmt.Errorf("delete poet %s: %w", types.Hash32(ref).ShortString(), err)
+		}
+		deleted += rows
+	}
+	return deleted, nil
+}
@@ -160,3 +160,19 @@ func TestGetRef(t *testing.T) {
 	_, err := GetRef(db, []byte("sid0"), "rid0")
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
+
+func TestDelete(t *testing.T) {
+	db := sql.InMemory()
+	refs := []types.PoetProofRef{{1}, {2}, {3}}
+	for i, ref := range refs {
+		require.NoError(t, Add(db, ref, []byte("proof"), []byte("sid"), string(rune('a'+i))))
+	}
+	deleted, err := Delete(db, []types.PoetProofRef{refs[0], refs[2], {4}})
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+	for i, ref := range refs {
+		has, err := Has(db, ref)
+		require.NoError(t, err)
+		require.Equal(t, i == 1, has)
+	}
+}
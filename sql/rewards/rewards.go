@@ -133,3 +133,16 @@ func IterateRewardsOps(
 	}
 	return derr
 }
+
+// DeleteBefore deletes rewards from layers before lid.
+// Returns the number of deleted rewards.
+func DeleteBefore(db sql.Executor, lid types.LayerID) (int, error) {
+	rows, err := db.Exec("delete from rewards where layer < ?1 returning 1;",
+		func(stmt *sql.Statement) {
+			stmt.BindInt64(1, int64(lid))
+		}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete rewards before %s: %w", lid, err)
+	}
+	return rows, nil
+}
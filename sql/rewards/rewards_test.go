@@ -335,3 +335,27 @@ func Test_0008Migration(t *testing.T) {
 	require.Equal(t, reward.Layer, rewards[0].Layer)
 	require.Equal(t, reward.SmesherID, rewards[0].SmesherID)
 }
+
+func TestDeleteBefore(t *testing.T) {
+	db := sql.InMemory()
+	coinbase := types.Address{1}
+	for lid := types.LayerID(1); lid <= 4; lid++ {
+		require.NoError(t, Add(db, &types.Reward{
+			Layer:       lid,
+			Coinbase:    coinbase,
+			SmesherID:   types.NodeID{1},
+			TotalReward: 10,
+			LayerReward: 9,
+		}))
+	}
+	deleted, err := DeleteBefore(db, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	got, err := ListByCoinbase(db, coinbase)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	for _, reward := range got {
+		require.GreaterOrEqual(t, reward.Layer, types.LayerID(3))
+	}
+}
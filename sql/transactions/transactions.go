@@ -71,6 +71,27 @@ func DeleteProposalTxsBefore(db sql.Executor, lid types.LayerID) error {
 	return nil
 }
 
+// DeleteAppliedBefore deletes transactions that were applied in layers before lid together
+// with their results and links to blocks. Pending transactions are not affected.
+// Returns the number of deleted transactions.
+func DeleteAppliedBefore(db sql.Executor, lid types.LayerID) (int, error) {
+	enc := func(stmt *sql.Statement) {
+		stmt.BindInt64(1, int64(lid))
+	}
+	if _, err := db.Exec(`delete from transactions_results_addresses
+		where tid in (select id from transactions where layer < ?1);`, enc, nil); err != nil {
+		return 0, fmt.Errorf("delete addresses mapping before %s: %w", lid, err)
+	}
+	if _, err := db.Exec("delete from block_transactions where layer < ?1;", enc, nil); err != nil {
+		return 0, fmt.Errorf("delete block txs before %s: %w", lid, err)
+	}
+	rows, err := db.Exec("delete from transactions where layer < ?1 returning 1;", enc, nil)
+	if err != nil {
+		return 0, fmt.Errorf("delete transactions before %s: %w", lid, err)
+	}
+	return rows, nil
+}
+
 // HasProposalTX returns true if the given transaction is included in the given proposal.
 func HasProposalTX(db sql.Executor, pid types.ProposalID, tid types.TransactionID) (bool, error) {
 	rows, err := db.Exec("select 1 from proposal_transactions where pid = ?1 and tid = ?2",
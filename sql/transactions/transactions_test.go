@@ -561,3 +561,39 @@ func TestTransactionInBlock(t *testing.T) {
 	_, _, err = transactions.TransactionInBlock(db, tid, lids[2])
 	require.ErrorIs(t, err, sql.ErrNotFound)
 }
+
+func TestDeleteAppliedBefore(t *testing.T) {
+	db := sql.InMemory()
+
+	rng := rand.New(rand.NewSource(1001))
+	var applied []types.TransactionID
+	for lid := types.LayerID(1); lid <= 4; lid++ {
+		signer, err := signing.NewEdSigner(signing.WithKeyFromRand(rng))
+		require.NoError(t, err)
+		tx := createTX(t, signer, types.Address{1}, uint64(lid), 191, 2)
+		require.NoError(t, transactions.Add(db, tx, time.Now()))
+		bid := types.RandomBlockID()
+		require.NoError(t, transactions.AddToBlock(db, tx.ID, lid, bid))
+		require.NoError(t, db.WithTx(context.Background(), func(dtx *sql.Tx) error {
+			return transactions.AddResult(dtx, tx.ID, &types.TransactionResult{Layer: lid, Block: bid})
+		}))
+		applied = append(applied, tx.ID)
+	}
+	signer, err := signing.NewEdSigner(signing.WithKeyFromRand(rng))
+	require.NoError(t, err)
+	pending := createTX(t, signer, types.Address{1}, 1, 191, 2)
+	require.NoError(t, transactions.Add(db, pending, time.Now()))
+
+	deleted, err := transactions.DeleteAppliedBefore(db, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, deleted)
+
+	for i, tid := range applied {
+		has, err := transactions.Has(db, tid)
+		require.NoError(t, err)
+		require.Equal(t, i >= 2, has)
+	}
+	has, err := transactions.Has(db, pending.ID)
+	require.NoError(t, err)
+	require.True(t, has)
+}
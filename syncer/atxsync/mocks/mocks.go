@@ -125,6 +125,45 @@ func (c *MockfetcherPeerEpochInfoCall) DoAndReturn(f func(context.Context, p2p.P
 	return c
 }
 
+// RangeSyncEpochAtxs mocks base method.
+func (m *Mockfetcher) RangeSyncEpochAtxs(arg0 context.Context, arg1 p2p.Peer, arg2 types.EpochID, arg3 []types.ATXID) ([]types.ATXID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RangeSyncEpochAtxs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]types.ATXID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RangeSyncEpochAtxs indicates an expected call of RangeSyncEpochAtxs.
+func (mr *MockfetcherMockRecorder) RangeSyncEpochAtxs(arg0, arg1, arg2, arg3 any) *MockfetcherRangeSyncEpochAtxsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeSyncEpochAtxs", reflect.TypeOf((*Mockfetcher)(nil).RangeSyncEpochAtxs), arg0, arg1, arg2, arg3)
+	return &MockfetcherRangeSyncEpochAtxsCall{Call: call}
+}
+
+// MockfetcherRangeSyncEpochAtxsCall wrap *gomock.Call
+type MockfetcherRangeSyncEpochAtxsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockfetcherRangeSyncEpochAtxsCall) Return(arg0 []types.ATXID, arg1 error) *MockfetcherRangeSyncEpochAtxsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockfetcherRangeSyncEpochAtxsCall) Do(f func(context.Context, p2p.Peer, types.EpochID, []types.ATXID) ([]types.ATXID, error)) *MockfetcherRangeSyncEpochAtxsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockfetcherRangeSyncEpochAtxsCall) DoAndReturn(f func(context.Context, p2p.Peer, types.EpochID, []types.ATXID) ([]types.ATXID, error)) *MockfetcherRangeSyncEpochAtxsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SelectBestShuffled mocks base method.
 func (m *Mockfetcher) SelectBestShuffled(arg0 int) []p2p.Peer {
 	m.ctrl.T.Helper()
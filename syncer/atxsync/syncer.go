@@ -26,6 +26,7 @@ import (
 type fetcher interface {
 	SelectBestShuffled(int) []p2p.Peer
 	PeerEpochInfo(context.Context, p2p.Peer, types.EpochID) (*fetch.EpochData, error)
+	RangeSyncEpochAtxs(context.Context, p2p.Peer, types.EpochID, []types.ATXID) ([]types.ATXID, error)
 	system.AtxFetcher
 }
 
@@ -172,8 +173,8 @@ func (s *Syncer) downloadEpochInfo(
 		}
 		// do not run it concurrently, epoch info is large and will continue to grow
 		for _, peer := range peers {
-			epochData, err := s.fetcher.PeerEpochInfo(ctx, peer, publish)
-			if err != nil || epochData == nil {
+			atxIDs, err := s.peerEpochAtxs(ctx, peer, publish)
+			if err != nil {
 				if errors.Is(err, context.Canceled) {
 					return nil
 				}
@@ -189,12 +190,12 @@ func (s *Syncer) downloadEpochInfo(
 				log.ZContext(ctx),
 				publish.Field().Zap(),
 				zap.String("peer", peer.String()),
-				zap.Int("atxs", len(epochData.AtxIDs)),
+				zap.Int("atxs", len(atxIDs)),
 			)
 			// adding hashes to fetcher is not useful as they overflow the cache and are not used
 			// so we switch to asking best peers immediately
-			update := make(map[types.ATXID]int, len(epochData.AtxIDs))
-			for _, atx := range epochData.AtxIDs {
+			update := make(map[types.ATXID]int, len(atxIDs))
+			for _, atx := range atxIDs {
 				update[atx] = 0
 			}
 			select {
@@ -208,6 +209,27 @@ func (s *Syncer) downloadEpochInfo(
 	}
 }
 
+// peerEpochAtxs returns atxs published in the epoch that the peer has and that might be missing locally.
+// Sets are reconciled with range sync if the peer supports it, otherwise the whole epoch info is downloaded.
+func (s *Syncer) peerEpochAtxs(ctx context.Context, peer p2p.Peer, publish types.EpochID) ([]types.ATXID, error) {
+	local, err := atxs.GetIDsByEpoch(ctx, s.db, publish)
+	if err != nil {
+		return nil, fmt.Errorf("get local atxs: %w", err)
+	}
+	atxIDs, err := s.fetcher.RangeSyncEpochAtxs(ctx, peer, publish, local)
+	if !errors.Is(err, fetch.ErrRangeSyncUnavailable) {
+		return atxIDs, err
+	}
+	epochData, err := s.fetcher.PeerEpochInfo(ctx, peer, publish)
+	switch {
+	case err != nil:
+		return nil, err
+	case epochData == nil:
+		return nil, errors.New("empty epoch info")
+	}
+	return epochData.AtxIDs, nil
+}
+
 func (s *Syncer) downloadAtxs(
 	ctx context.Context,
 	publish types.EpochID,
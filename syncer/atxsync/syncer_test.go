@@ -67,6 +67,13 @@ type tester struct {
 	fetcher *mocks.Mockfetcher
 }
 
+func (tester *tester) expectRangeSyncUnavailable() {
+	tester.fetcher.EXPECT().
+		RangeSyncEpochAtxs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fetch.ErrRangeSyncUnavailable).
+		AnyTimes()
+}
+
 func TestSyncer(t *testing.T) {
 	t.Run("sanity", func(t *testing.T) {
 		tester := newTester(t, Config{
@@ -78,6 +85,7 @@ func TestSyncer(t *testing.T) {
 
 		peers := []p2p.Peer{"a", "b", "c"}
 		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.EpochInfoPeers).Return(peers).AnyTimes()
+		tester.expectRangeSyncUnavailable()
 		publish := types.EpochID(1)
 		for _, p := range peers {
 			tester.fetcher.EXPECT().
@@ -105,6 +113,7 @@ func TestSyncer(t *testing.T) {
 		publish := types.EpochID(1)
 		now := time.Now()
 		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.EpochInfoPeers).Return([]p2p.Peer{"a"}).AnyTimes()
+		tester.expectRangeSyncUnavailable()
 		tester.fetcher.EXPECT().PeerEpochInfo(gomock.Any(), gomock.Any(), publish).Return(edata("1"), nil).AnyTimes()
 		tester.fetcher.EXPECT().GetAtxs(gomock.Any(), gomock.Any()).Return(errors.New("no atxs")).AnyTimes()
 		require.ErrorIs(t, tester.syncer.Download(ctx, publish, now), context.Canceled)
@@ -145,6 +154,7 @@ func TestSyncer(t *testing.T) {
 
 		peers := []p2p.Peer{"a"}
 		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.EpochInfoPeers).Return(peers).AnyTimes()
+		tester.expectRangeSyncUnavailable()
 		publish := types.EpochID(2)
 		tester.fetcher.EXPECT().PeerEpochInfo(gomock.Any(), peers[0], publish).Return(nil, errors.New("bad try"))
 		tester.fetcher.EXPECT().PeerEpochInfo(gomock.Any(), peers[0], publish).Return(edata("1", "2", "3"), nil)
@@ -171,6 +181,7 @@ func TestSyncer(t *testing.T) {
 
 		peers := []p2p.Peer{"a", "b"}
 		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.EpochInfoPeers).Return(peers).AnyTimes()
+		tester.expectRangeSyncUnavailable()
 		publish := types.EpochID(2)
 		good := edata("1", "2", "3")
 		bad := edata("4", "5", "6")
@@ -210,6 +221,28 @@ func TestSyncer(t *testing.T) {
 			require.NotContains(t, state, bad)
 		}
 	})
+	t.Run("range sync", func(t *testing.T) {
+		tester := newTester(t, DefaultConfig())
+		publish := types.EpochID(1)
+		require.NoError(t, atxs.Add(tester.db, atx(aid("1"))))
+		peers := []p2p.Peer{"a"}
+		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.EpochInfoPeers).Return(peers).AnyTimes()
+		tester.fetcher.EXPECT().
+			RangeSyncEpochAtxs(gomock.Any(), peers[0], publish, []types.ATXID{aid("1")}).
+			Return([]types.ATXID{aid("2"), aid("3")}, nil)
+		tester.fetcher.EXPECT().
+			GetAtxs(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, ids []types.ATXID, _ ...system.GetAtxOpt) error {
+				require.ElementsMatch(t, []types.ATXID{aid("2"), aid("3")}, ids)
+				for _, id := range ids {
+					require.NoError(t, atxs.Add(tester.db, atx(id)))
+				}
+				return nil
+			})
+
+		past := time.Now().Add(-time.Minute)
+		require.NoError(t, tester.syncer.Download(context.Background(), publish, past))
+	})
 	t.Run("terminate empty epoch", func(t *testing.T) {
 		cfg := DefaultConfig()
 		cfg.EpochInfoInterval = time.Millisecond
@@ -218,6 +251,7 @@ func TestSyncer(t *testing.T) {
 		now := time.Now()
 		peers := []p2p.Peer{"a"}
 		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.EpochInfoPeers).Return(peers).AnyTimes()
+		tester.expectRangeSyncUnavailable()
 		tester.fetcher.EXPECT().PeerEpochInfo(gomock.Any(), peers[0], publish).Return(edata(), nil).AnyTimes()
 		require.NoError(t, tester.syncer.Download(context.Background(), publish, now))
 	})
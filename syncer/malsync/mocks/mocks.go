@@ -118,6 +118,45 @@ func (c *MockfetcherGetMaliciousIDsCall) DoAndReturn(f func(context.Context, p2p
 	return c
 }
 
+// RangeSyncMaliciousIDs mocks base method.
+func (m *Mockfetcher) RangeSyncMaliciousIDs(arg0 context.Context, arg1 p2p.Peer, arg2 []types.NodeID) ([]types.NodeID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RangeSyncMaliciousIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]types.NodeID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RangeSyncMaliciousIDs indicates an expected call of RangeSyncMaliciousIDs.
+func (mr *MockfetcherMockRecorder) RangeSyncMaliciousIDs(arg0, arg1, arg2 any) *MockfetcherRangeSyncMaliciousIDsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RangeSyncMaliciousIDs", reflect.TypeOf((*Mockfetcher)(nil).RangeSyncMaliciousIDs), arg0, arg1, arg2)
+	return &MockfetcherRangeSyncMaliciousIDsCall{Call: call}
+}
+
+// MockfetcherRangeSyncMaliciousIDsCall wrap *gomock.Call
+type MockfetcherRangeSyncMaliciousIDsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockfetcherRangeSyncMaliciousIDsCall) Return(arg0 []types.NodeID, arg1 error) *MockfetcherRangeSyncMaliciousIDsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockfetcherRangeSyncMaliciousIDsCall) Do(f func(context.Context, p2p.Peer, []types.NodeID) ([]types.NodeID, error)) *MockfetcherRangeSyncMaliciousIDsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockfetcherRangeSyncMaliciousIDsCall) DoAndReturn(f func(context.Context, p2p.Peer, []types.NodeID) ([]types.NodeID, error)) *MockfetcherRangeSyncMaliciousIDsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SelectBestShuffled mocks base method.
 func (m *Mockfetcher) SelectBestShuffled(arg0 int) []p2p.Peer {
 	m.ctrl.T.Helper()
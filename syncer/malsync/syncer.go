@@ -28,6 +28,7 @@ import (
 type fetcher interface {
 	SelectBestShuffled(int) []p2p.Peer
 	GetMaliciousIDs(context.Context, p2p.Peer) ([]types.NodeID, error)
+	RangeSyncMaliciousIDs(context.Context, p2p.Peer, []types.NodeID) ([]types.NodeID, error)
 	system.MalfeasanceProofFetcher
 }
 
@@ -301,10 +302,14 @@ func (s *Syncer) downloadNodeIDs(ctx context.Context, initial bool, updates chan
 			continue
 		}
 
+		local, err := identities.GetMalicious(ctx, s.db)
+		if err != nil {
+			return fmt.Errorf("get local malicious IDs: %w", err)
+		}
 		var eg errgroup.Group
 		for _, peer := range peers {
 			eg.Go(func() error {
-				malIDs, err := s.fetcher.GetMaliciousIDs(ctx, peer)
+				malIDs, err := s.peerMaliciousIDs(ctx, peer, local)
 				if err != nil {
 					if errors.Is(err, context.Canceled) {
 						return ctx.Err()
@@ -341,6 +346,16 @@ func (s *Syncer) downloadNodeIDs(ctx context.Context, initial bool, updates chan
 	}
 }
 
+// peerMaliciousIDs returns IDs of malicious identities that the peer has and that might be missing locally.
+// Sets are reconciled with range sync if the peer supports it, otherwise all IDs are downloaded.
+func (s *Syncer) peerMaliciousIDs(ctx context.Context, peer p2p.Peer, local []types.NodeID) ([]types.NodeID, error) {
+	malIDs, err := s.fetcher.RangeSyncMaliciousIDs(ctx, peer, local)
+	if !errors.Is(err, fetch.ErrRangeSyncUnavailable) {
+		return malIDs, err
+	}
+	return s.fetcher.GetMaliciousIDs(ctx, peer)
+}
+
 func (s *Syncer) updateState() error {
 	if err := malsync.UpdateSyncState(s.localdb, s.clock.Now()); err != nil {
 		return fmt.Errorf("error updating malsync state: %w", err)
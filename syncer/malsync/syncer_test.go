@@ -190,6 +190,13 @@ func (tester *tester) expectGetMaliciousIDs() {
 	}
 }
 
+func (tester *tester) expectRangeSyncUnavailable() {
+	tester.fetcher.EXPECT().
+		RangeSyncMaliciousIDs(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, fetch.ErrRangeSyncUnavailable).
+		AnyTimes()
+}
+
 func (tester *tester) expectGetProofs(errMap map[types.NodeID]error) {
 	tester.fetcher.EXPECT().
 		GetMalfeasanceProofs(gomock.Any(), gomock.Any()).
@@ -223,6 +230,7 @@ func (tester *tester) expectPeers(peers []p2p.Peer) {
 func TestSyncer(t *testing.T) {
 	t.Run("EnsureInSync", func(t *testing.T) {
 		tester := newTester(t, DefaultConfig())
+		tester.expectRangeSyncUnavailable()
 		tester.expectPeers(tester.peers)
 		tester.expectGetMaliciousIDs()
 		tester.expectGetProofs(nil)
@@ -245,8 +253,27 @@ func TestSyncer(t *testing.T) {
 			tester.syncer.EnsureInSync(context.Background(), epochStart, epochEnd))
 		require.Zero(t, tester.peerErrCount.n)
 	})
+	t.Run("EnsureInSync with range sync", func(t *testing.T) {
+		tester := newTester(t, DefaultConfig())
+		tester.expectPeers(tester.peers)
+		proofData := codec.MustEncode(mproof(nid("1")))
+		require.NoError(t, identities.SetMalicious(tester.db, nid("1"), proofData, tester.clock.Now()))
+		for _, p := range tester.peers {
+			tester.fetcher.EXPECT().
+				RangeSyncMaliciousIDs(gomock.Any(), p, []types.NodeID{nid("1")}).
+				Return(malData("2"), nil)
+		}
+		tester.expectGetProofs(nil)
+		epochStart := tester.clock.Now().Truncate(time.Second)
+		epochEnd := epochStart.Add(10 * time.Minute)
+		require.NoError(t,
+			tester.syncer.EnsureInSync(context.Background(), epochStart, epochEnd))
+		require.ElementsMatch(t, []types.NodeID{nid("2")}, maps.Keys(tester.received))
+		require.Zero(t, tester.peerErrCount.n)
+	})
 	t.Run("EnsureInSync with no malfeasant identities", func(t *testing.T) {
 		tester := newTester(t, DefaultConfig())
+		tester.expectRangeSyncUnavailable()
 		tester.expectPeers(tester.peers)
 		for _, p := range tester.peers {
 			tester.fetcher.EXPECT().
@@ -261,6 +288,7 @@ func TestSyncer(t *testing.T) {
 	})
 	t.Run("interruptible", func(t *testing.T) {
 		tester := newTester(t, DefaultConfig())
+		tester.expectRangeSyncUnavailable()
 		ctx, cancel := context.WithCancel(context.Background())
 		cancel()
 		tester.expectPeers([]p2p.Peer{"a"})
@@ -274,6 +302,7 @@ func TestSyncer(t *testing.T) {
 	})
 	t.Run("retries on no peers", func(t *testing.T) {
 		tester := newTester(t, DefaultConfig())
+		tester.expectRangeSyncUnavailable()
 		ctx, cancel := context.WithCancel(context.Background())
 		ch := make(chan []p2p.Peer)
 		tester.fetcher.EXPECT().SelectBestShuffled(tester.cfg.MalfeasanceIDPeers).
@@ -302,6 +331,7 @@ func TestSyncer(t *testing.T) {
 		cfg := DefaultConfig()
 		cfg.MinSyncPeers = 2
 		tester := newTester(t, cfg)
+		tester.expectRangeSyncUnavailable()
 		tester.expectPeers(tester.peers)
 		tester.fetcher.EXPECT().
 			GetMaliciousIDs(gomock.Any(), tester.peers[0]).
@@ -335,6 +365,7 @@ func TestSyncer(t *testing.T) {
 		cfg := DefaultConfig()
 		cfg.RequestsLimit = 3
 		tester := newTester(t, cfg)
+		tester.expectRangeSyncUnavailable()
 		tester.expectPeers(tester.peers)
 		tester.expectGetMaliciousIDs()
 		tester.expectGetProofs(map[types.NodeID]error{
@@ -360,6 +391,7 @@ func TestSyncer(t *testing.T) {
 	})
 	t.Run("skip hashes after validation reject", func(t *testing.T) {
 		tester := newTester(t, DefaultConfig())
+		tester.expectRangeSyncUnavailable()
 		tester.expectPeers(tester.peers)
 		tester.expectGetMaliciousIDs()
 		tester.expectGetProofs(map[types.NodeID]error{
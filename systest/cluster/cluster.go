@@ -209,6 +209,23 @@ func New(cctx *testcontext.Context, opts ...Opt) *Cluster {
 	return cluster
 }
 
+// NewLocal creates Cluster with nodes that are not managed by kubernetes, such as nodes
+// of the devnet that run in the test process. Only the public api of the clients can be used.
+//
+// address is called with the index of the client every time the connection to the node
+// is established, so that the node may change its address after restart.
+func NewLocal(total int, address func(i int) (string, error)) *Cluster {
+	cluster := &Cluster{}
+	for i := range total {
+		cluster.clients = append(cluster.clients, &NodeClient{
+			Node:       Node{Name: fmt.Sprintf("local-%d", i)},
+			pubAddress: func() (string, error) { return address(i) },
+		})
+	}
+	cluster.smeshers = total
+	return cluster
+}
+
 // Cluster for managing state of the spacemesh cluster.
 type Cluster struct {
 	persisted         bool
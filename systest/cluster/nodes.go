@@ -140,6 +140,8 @@ func p2pEndpoint(n Node, ip, id string) string {
 type NodeClient struct {
 	session *testcontext.Context
 	Node
+	// pubAddress resolves the address of the public api for nodes that don't run in kubernetes.
+	pubAddress func() (string, error)
 
 	mu       sync.Mutex
 	pubConn  *grpc.ClientConn
@@ -147,10 +149,12 @@ type NodeClient struct {
 }
 
 func (n *NodeClient) Close() {
+	// reset functions acquire the lock
 	n.mu.Lock()
-	defer n.mu.Unlock()
-	n.resetPubConn(n.pubConn)
-	n.resetPrivConn(n.privConn)
+	pub, priv := n.pubConn, n.privConn
+	n.mu.Unlock()
+	n.resetPubConn(pub)
+	n.resetPrivConn(priv)
 }
 
 func (n *NodeClient) Resolve(ctx context.Context) (string, error) {
@@ -167,14 +171,11 @@ func (n *NodeClient) ensurePubConn() (*grpc.ClientConn, error) {
 	if n.pubConn != nil {
 		return n.pubConn, nil
 	}
-	pod, err := waitPod(n.session, n.Name)
+	address, err := n.resolvePubAddress()
 	if err != nil {
 		return nil, err
 	}
-	conn, err := grpc.NewClient(
-		fmt.Sprintf("%s:%d", pod.Status.PodIP, n.GRPC_PUB),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +183,17 @@ func (n *NodeClient) ensurePubConn() (*grpc.ClientConn, error) {
 	return n.pubConn, nil
 }
 
+func (n *NodeClient) resolvePubAddress() (string, error) {
+	if n.pubAddress != nil {
+		return n.pubAddress()
+	}
+	pod, err := waitPod(n.session, n.Name)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s:%d", pod.Status.PodIP, n.GRPC_PUB), nil
+}
+
 func (n *NodeClient) resetPubConn(conn *grpc.ClientConn) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
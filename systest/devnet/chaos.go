@@ -0,0 +1,86 @@
+package devnet
+
+import (
+	"fmt"
+	"time"
+)
+
+// Partition disconnects every node in a from every node in b, same as chaos.Partition2.
+// Partitioned nodes stay disconnected after restart until Heal is called.
+func (d *Devnet) Partition(a, b []int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, i := range a {
+		for _, j := range b {
+			if i == j {
+				return fmt.Errorf("node %d is on both sides of the partition", i)
+			}
+			d.blocked[pair(i, j)] = struct{}{}
+			d.unlink(d.nodes[i], d.nodes[j])
+		}
+	}
+	return nil
+}
+
+// Heal removes all partitions and connects running nodes that were partitioned.
+func (d *Devnet) Heal() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for p := range d.blocked {
+		delete(d.blocked, p)
+		a, b := d.nodes[p[0]], d.nodes[p[1]]
+		if a.app == nil || b.app == nil {
+			continue
+		}
+		if err := d.link(a, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Skew shifts the clock of the nodes by the offset relative to the shared clock, same as chaos.Timeskew.
+// Offset is not cumulative, zero offset removes the skew. It is preserved when node is restarted.
+func (d *Devnet) Skew(offset time.Duration, nodes ...int) {
+	for _, i := range nodes {
+		d.nodes[i].clock.offset.Store(int64(offset))
+	}
+}
+
+func (d *Devnet) isBlocked(a, b *instance) bool {
+	_, exists := d.blocked[pair(a.index, b.index)]
+	return exists
+}
+
+func (d *Devnet) link(a, b *instance) error {
+	if len(d.mn.LinksBetweenPeers(a.id, b.id)) == 0 {
+		if _, err := d.mn.LinkPeers(a.id, b.id); err != nil {
+			return fmt.Errorf("link %d and %d: %w", a.index, b.index, err)
+		}
+	}
+	if _, err := d.mn.ConnectPeers(a.id, b.id); err != nil {
+		return fmt.Errorf("connect %d and %d: %w", a.index, b.index, err)
+	}
+	return nil
+}
+
+func (d *Devnet) unlink(a, b *instance) {
+	if len(d.mn.LinksBetweenPeers(a.id, b.id)) == 0 {
+		return
+	}
+	// links are removed first so that nodes can't reconnect
+	d.mn.UnlinkPeers(a.id, b.id)
+	if a.app != nil {
+		d.mn.DisconnectPeers(a.id, b.id)
+	}
+	if b.app != nil {
+		d.mn.DisconnectPeers(b.id, a.id)
+	}
+}
+
+func pair(i, j int) [2]int {
+	if i > j {
+		i, j = j, i
+	}
+	return [2]int{i, j}
+}
@@ -0,0 +1,44 @@
+package devnet
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// advanceInterval is how often the shared clock is advanced when it follows the real time.
+const advanceInterval = 10 * time.Millisecond
+
+// skewedClock is a view on the shared clock that is shifted by the offset.
+// Only the wall time is shifted, durations of timers and tickers are not affected,
+// similarly to the time chaos injected into pods.
+type skewedClock struct {
+	clockwork.Clock
+	offset atomic.Int64
+}
+
+func (c *skewedClock) Now() time.Time {
+	return c.Clock.Now().Add(time.Duration(c.offset.Load()))
+}
+
+func (c *skewedClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// follow advances the fake clock with the real time until the context is canceled.
+func follow(ctx context.Context, clock clockwork.FakeClock) {
+	ticker := time.NewTicker(advanceInterval)
+	defer ticker.Stop()
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			clock.Advance(now.Sub(last))
+			last = now
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Package devnet runs a network of full nodes in a single process.
+//
+// Nodes are connected over the libp2p mock network and use a shared fake clock,
+// so that faults which systest injects with chaos-mesh (network partitions, time skew and
+// node failures) can be reproduced locally without kubernetes.
+//
+// Nodes of the devnet share process-wide state: the event reporter, the logger of libp2p
+// and the global parameters in the types package. Because of that the devnet must not run
+// in parallel with other tests that start nodes.
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/config"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/node"
+	"github.com/spacemeshos/go-spacemesh/p2p"
+	"github.com/spacemeshos/go-spacemesh/systest/cluster"
+)
+
+// DefaultConfig returns the config with short layers and epochs, suitable for running
+// several nodes in one process.
+func DefaultConfig() config.Config {
+	cfg := config.DefaultTestConfig()
+	cfg.Genesis.Accounts = nil
+	cfg.LayerDuration = 2 * time.Second
+	cfg.LayersPerEpoch = 4
+	cfg.Tortoise.Hdist = 4
+	cfg.Tortoise.Zdist = 2
+	cfg.Sync.Interval = time.Second
+	cfg.Sync.GossipDuration = time.Second
+	cfg.HARE3.PreroundDelay = 100 * time.Millisecond
+	cfg.HARE3.RoundDuration = 100 * time.Millisecond
+	cfg.POET.CycleGap = 2 * time.Second
+	cfg.POET.PhaseShift = 2 * time.Second
+
+	cfg.P2P.MinPeers = 0
+	cfg.P2P.DisableNatPort = true
+	cfg.P2P.DisableDHT = true
+	cfg.API.PublicListener = "127.0.0.1:0"
+	return cfg
+}
+
+// Opt configures the devnet.
+type Opt func(d *Devnet)
+
+// WithConfig sets the config that is used by every node.
+// Genesis time, poet servers and the node specific fields are overwritten by the devnet.
+func WithConfig(cfg config.Config) Opt {
+	return func(d *Devnet) {
+		d.config = cfg
+	}
+}
+
+// WithLog sets the logger for the nodes.
+func WithLog(logger log.Log) Opt {
+	return func(d *Devnet) {
+		d.logger = logger
+	}
+}
+
+// WithPoets sets the number of local poet servers. Default is 1.
+func WithPoets(n int) Opt {
+	return func(d *Devnet) {
+		d.poets = n
+	}
+}
+
+// WithMockPoets replaces poet servers with the in-memory poettest servers that are reached over grpc.
+func WithMockPoets() Opt {
+	return func(d *Devnet) {
+		d.mockPoets = true
+	}
+}
+
+// WithManualClock disables advancing of the shared clock with the real time.
+// The clock has to be advanced by the test with Clock().Advance.
+func WithManualClock() Opt {
+	return func(d *Devnet) {
+		d.manual = true
+	}
+}
+
+// instance is a member of the devnet. It keeps the data directory and the p2p identity
+// so that the node can be restarted.
+type instance struct {
+	index int
+	dir   string
+	key   crypto.PrivKey
+	id    peer.ID
+	addr  ma.Multiaddr
+	clock *skewedClock
+
+	app    *node.App
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+}
+
+// Devnet is a network of nodes running in the current process.
+type Devnet struct {
+	tb        testing.TB
+	logger    log.Log
+	config    config.Config
+	poets     int
+	mockPoets bool
+	manual    bool
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	eg      errgroup.Group
+	clock   clockwork.FakeClock
+	mn      mocknet.Mocknet
+	cluster *cluster.Cluster
+
+	mu      sync.Mutex
+	nodes   []*instance
+	blocked map[[2]int]struct{}
+}
+
+// New starts a devnet of the given size and connects all nodes to each other.
+// The devnet is stopped when the test finishes.
+func New(tb testing.TB, size int, opts ...Opt) *Devnet {
+	d := &Devnet{
+		tb:      tb,
+		config:  DefaultConfig(),
+		poets:   1,
+		blocked: map[[2]int]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.logger == (log.Log{}) {
+		d.logger = logtest.New(tb)
+	}
+	types.SetLayersPerEpoch(d.config.LayersPerEpoch)
+	types.SetNetworkHRP(d.config.NetworkHRP)
+
+	// genesis time is encoded with the precision of a second
+	genesis := time.Now().Truncate(time.Second)
+	d.config.Genesis.GenesisTime = genesis.Format(time.RFC3339)
+	d.clock = clockwork.NewFakeClockAt(genesis)
+	d.mn = mocknet.New()
+	d.ctx, d.cancel = context.WithCancel(context.Background())
+
+	var dirs []string
+	for range d.poets {
+		dirs = append(dirs, tb.TempDir())
+	}
+	for range size {
+		dirs = append(dirs, tb.TempDir())
+	}
+	// registered after temporary directories are created, so that nodes are stopped before
+	// directories are removed
+	tb.Cleanup(d.close)
+
+	if !d.manual {
+		d.eg.Go(func() error {
+			follow(d.ctx, d.clock)
+			return nil
+		})
+	}
+	d.config.PoetServers = nil
+	for _, dir := range dirs[:d.poets] {
+		if d.mockPoets {
+			srv, err := startMockPoet(dir, &d.config, d.logger.Zap())
+			require.NoError(tb, err)
+			d.eg.Go(func() error {
+				return srv.Run(d.ctx)
+			})
+			d.config.PoetServers = append(d.config.PoetServers, srv.GRPC())
+			continue
+		}
+		srv, endpoint, err := startPoet(d.ctx, dir, &d.config)
+		require.NoError(tb, err)
+		d.eg.Go(func() error {
+			return errors.Join(srv.Start(d.ctx), srv.Close())
+		})
+		d.config.PoetServers = append(d.config.PoetServers, endpoint)
+	}
+
+	for i, dir := range dirs[d.poets:] {
+		key, err := p2p.EnsureIdentity(filepath.Join(dir, "p2p"))
+		require.NoError(tb, err)
+		id, err := peer.IDFromPrivateKey(key)
+		require.NoError(tb, err)
+		n := &instance{
+			index: i,
+			dir:   dir,
+			key:   key,
+			id:    id,
+			addr:  mockAddr(id),
+			clock: &skewedClock{Clock: d.clock},
+		}
+		d.nodes = append(d.nodes, n)
+		require.NoError(tb, d.start(n), "start node %d", i)
+	}
+	d.cluster = cluster.NewLocal(size, d.pubAddress)
+	return d
+}
+
+// mockAddr returns the address in the same range that is used by the mocknet for generated peers.
+// The address is never dialed, connections between peers are made by the mocknet.
+func mockAddr(id peer.ID) ma.Multiaddr {
+	suffix := id
+	if len(id) > 8 {
+		suffix = id[len(id)-8:]
+	}
+	ip := net.ParseIP("100::")
+	copy(ip[net.IPv6len-len(suffix):], suffix)
+	return ma.StringCast(fmt.Sprintf("/ip6/%s/tcp/4242", ip))
+}
+
+// Total returns the number of nodes in the devnet, including stopped nodes.
+func (d *Devnet) Total() int {
+	return len(d.nodes)
+}
+
+// Cluster returns the devnet as the cluster, so that validations from the systest can run against it.
+// Requests to the stopped node fail, the client reconnects to the node after it is restarted.
+func (d *Devnet) Cluster() *cluster.Cluster {
+	return d.cluster
+}
+
+func (d *Devnet) pubAddress(i int) (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.nodes[i].app == nil {
+		return "", fmt.Errorf("node %d is stopped", i)
+	}
+	return d.nodes[i].app.GrpcPublicAddress(), nil
+}
+
+// App returns the app of the i-th node, or nil if the node is stopped.
+func (d *Devnet) App(i int) *node.App {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.nodes[i].app
+}
+
+// PeerID returns the p2p identity of the i-th node. It doesn't change on restart.
+func (d *Devnet) PeerID(i int) peer.ID {
+	return d.nodes[i].id
+}
+
+// Clock returns the clock shared by all nodes.
+func (d *Devnet) Clock() clockwork.FakeClock {
+	return d.clock
+}
+
+// Kill stops the i-th node. Data of the node is preserved and it can be started again with Restart.
+func (d *Devnet) Kill(i int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := d.nodes[i]
+	if n.app == nil {
+		return fmt.Errorf("node %d is not running", i)
+	}
+	d.stop(n)
+	for _, other := range d.nodes {
+		if other != n {
+			d.unlink(n, other)
+		}
+	}
+	return n.err
+}
+
+// Restart starts the i-th node after it was stopped with Kill.
+func (d *Devnet) Restart(i int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := d.nodes[i]
+	if n.app != nil {
+		return fmt.Errorf("node %d is running", i)
+	}
+	return d.start(n)
+}
+
+func (d *Devnet) start(n *instance) error {
+	h, err := d.mn.AddPeer(n.key, n.addr)
+	if err != nil {
+		return fmt.Errorf("add peer to mocknet: %w", err)
+	}
+	cfg := d.config
+	cfg.DataDirParent = n.dir
+	cfg.FileLock = filepath.Join(n.dir, "LOCK")
+	cfg.P2P.Listen = []ma.Multiaddr{n.addr}
+	cfg.SMESHING.Opts.DataDir = n.dir
+	cfg.SMESHING.CoinbaseAccount = types.GenerateAddress([]byte(strconv.Itoa(n.index))).String()
+
+	// the beacon is set for the first epoch after genesis, same as in the standalone mode,
+	// to save an epoch of the beacon protocol
+	beacon := types.Beacon{}
+	genesis := cfg.Genesis.GenesisID()
+	copy(beacon[:], genesis[:])
+	app := node.New(
+		node.WithConfig(&cfg),
+		node.WithLog(d.logger.Named(fmt.Sprintf("node-%d", n.index))),
+		node.WithClock(n.clock),
+		node.WithLibp2pHost(h),
+		node.WithBootstrapBeacon(types.GetEffectiveGenesis().GetEpoch()+1, beacon),
+	)
+	if err := app.Initialize(); err != nil {
+		return err
+	}
+	switch err := app.LoadIdentities(); {
+	case errors.Is(err, fs.ErrNotExist):
+		if err := app.NewIdentity(); err != nil {
+			return fmt.Errorf("create identity: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("load identities: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(d.ctx)
+	n.app, n.cancel, n.done, n.err = app, cancel, make(chan struct{}), nil
+	go func() {
+		defer close(n.done)
+		if err := app.Start(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			n.err = err
+		}
+	}()
+	select {
+	case <-app.Started():
+	case <-n.done:
+		d.stop(n)
+		return fmt.Errorf("start node %d: %w", n.index, n.err)
+	}
+	for _, other := range d.nodes {
+		if other == n || other.app == nil || d.isBlocked(n, other) {
+			continue
+		}
+		if err := d.link(n, other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Devnet) stop(n *instance) {
+	n.cancel()
+	<-n.done
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	n.app.Cleanup(ctx)
+	n.app = nil
+}
+
+func (d *Devnet) close() {
+	if d.cluster != nil {
+		d.cluster.CloseClients()
+	}
+	d.mu.Lock()
+	for _, n := range d.nodes {
+		if n.app != nil {
+			d.stop(n)
+		}
+	}
+	d.mu.Unlock()
+	d.cancel()
+	if err := d.eg.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+		d.tb.Errorf("devnet background: %v", err)
+	}
+	d.mn.Close()
+}
@@ -0,0 +1,96 @@
+package devnet
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/spacemeshos/api/release/go/spacemesh/v1"
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/systest/validation"
+)
+
+func currentLayer(tb testing.TB, d *Devnet, i int) uint32 {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	meshapi := pb.NewMeshServiceClient(d.Cluster().Client(i).PubConn())
+	resp, err := meshapi.CurrentLayer(ctx, &pb.CurrentLayerRequest{})
+	require.NoError(tb, err)
+	return resp.Layernum.Number
+}
+
+func eventually(tb testing.TB, v validation.Validation) {
+	require.Eventually(tb, func() bool {
+		return v(context.Background()) == nil
+	}, 30*time.Second, 100*time.Millisecond)
+}
+
+func TestDevnet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts several full nodes")
+	}
+	const size = 3
+	d := New(t, size)
+	require.Equal(t, size, d.Total())
+
+	eventually(t, validation.Sync(d.Cluster(), 0))
+	require.NoError(t, validation.Consensus(d.Cluster(), 0, 0)(context.Background()))
+
+	t.Run("partition", func(t *testing.T) {
+		require.NoError(t, d.Partition([]int{0}, []int{1, 2}))
+		require.Zero(t, d.App(0).Host().PeerCount())
+		require.EqualValues(t, 1, d.App(1).Host().PeerCount())
+
+		require.NoError(t, d.Heal())
+		for i := range size {
+			require.EqualValues(t, size-1, d.App(i).Host().PeerCount())
+		}
+	})
+	t.Run("skew", func(t *testing.T) {
+		layers := d.config.LayersPerEpoch
+		d.Skew(time.Duration(layers)*d.config.LayerDuration, 1)
+		require.Eventually(t, func() bool {
+			return currentLayer(t, d, 1) >= currentLayer(t, d, 0)+layers-1
+		}, 5*time.Second, 100*time.Millisecond)
+
+		d.Skew(0, 1)
+		require.LessOrEqual(t, currentLayer(t, d, 1), currentLayer(t, d, 0)+1)
+	})
+	t.Run("kill and restart", func(t *testing.T) {
+		id := d.App(2).Host().ID()
+		require.NoError(t, d.Kill(2))
+		require.Nil(t, d.App(2))
+		require.Error(t, validation.Sync(d.Cluster(), 0)(context.Background()))
+		require.Error(t, d.Kill(2))
+
+		require.NoError(t, d.Restart(2))
+		require.Equal(t, id, d.App(2).Host().ID())
+		require.Equal(t, d.PeerID(2), id)
+		require.EqualValues(t, size-1, d.App(2).Host().PeerCount())
+		eventually(t, validation.Sync(d.Cluster(), 0))
+	})
+}
+
+func TestDevnet_ManualClock(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts several full nodes")
+	}
+	d := New(t, 2, WithManualClock(), WithPoets(0))
+	start := currentLayer(t, d, 0)
+	d.Clock().Advance(3 * d.config.LayerDuration)
+	require.Eventually(t, func() bool {
+		return currentLayer(t, d, 0) == start+3 && currentLayer(t, d, 1) == start+3
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestDevnet_MockPoets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts several full nodes")
+	}
+	d := New(t, 2, WithMockPoets())
+	require.Len(t, d.config.PoetServers, 1)
+	require.True(t, strings.HasPrefix(d.config.PoetServers[0].Address, "grpc://"))
+	eventually(t, validation.Sync(d.Cluster(), 0))
+}
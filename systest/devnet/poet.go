@@ -0,0 +1,56 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spacemeshos/poet/server"
+	"go.uber.org/zap"
+
+	"github.com/spacemeshos/go-spacemesh/activation/poettest"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/config"
+)
+
+// startPoet starts a poet server that serves rounds aligned with epochs of the devnet.
+//
+// Poet rounds are scheduled with the real time, therefore they stay aligned with the devnet
+// only while the shared clock follows the real time.
+func startPoet(ctx context.Context, dir string, cfg *config.Config) (*server.Server, types.PoetServer, error) {
+	pcfg := server.DefaultConfig()
+	pcfg.PoetDir = dir
+	pcfg.RawRESTListener = "127.0.0.1:0"
+	pcfg.RawRPCListener = "127.0.0.1:0"
+	if err := pcfg.Genesis.UnmarshalFlag(cfg.Genesis.GenesisTime); err != nil {
+		return nil, types.PoetServer{}, fmt.Errorf("parse genesis time: %w", err)
+	}
+	pcfg.Round.EpochDuration = cfg.LayerDuration * time.Duration(cfg.LayersPerEpoch)
+	pcfg.Round.CycleGap = cfg.POET.CycleGap
+	pcfg.Round.PhaseShift = cfg.POET.PhaseShift
+	server.SetupConfig(pcfg)
+
+	srv, err := server.New(ctx, *pcfg)
+	if err != nil {
+		return nil, types.PoetServer{}, fmt.Errorf("init poet server: %w", err)
+	}
+	return srv, types.PoetServer{
+		Address: "http://" + srv.GrpcRestProxyAddr().String(),
+		Pubkey:  types.NewBase64Enc(srv.PublicKey()),
+	}, nil
+}
+
+// startMockPoet creates an in-memory poet server with the same rounds as startPoet.
+func startMockPoet(dir string, cfg *config.Config, logger *zap.Logger) (*poettest.Server, error) {
+	genesis, err := time.Parse(time.RFC3339, cfg.Genesis.GenesisTime)
+	if err != nil {
+		return nil, fmt.Errorf("parse genesis time: %w", err)
+	}
+	return poettest.New(poettest.Config{
+		Genesis:       genesis,
+		EpochDuration: cfg.LayerDuration * time.Duration(cfg.LayersPerEpoch),
+		PhaseShift:    cfg.POET.PhaseShift,
+		CycleGap:      cfg.POET.CycleGap,
+		Prover:        poettest.WorkProver(dir),
+	}, logger.Named("poet"))
+}
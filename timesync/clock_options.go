@@ -42,8 +42,8 @@ func (o *option) validate() error {
 
 type OptionFunc func(*option) error
 
-// withClock specifies which clock the NodeClock should use. Defaults to the real clock.
-func withClock(clock clockwork.Clock) OptionFunc {
+// WithClock specifies which clock the NodeClock should use. Defaults to the real clock.
+func WithClock(clock clockwork.Clock) OptionFunc {
 	return func(opts *option) error {
 		opts.clock = clock
 		return nil
@@ -136,6 +136,7 @@ func New(atxdata *atxsdata.Data, opts ...Opt) (*Tortoise, error) {
 	t.trtl = newTurtle(t.logger, t.cfg, atxdata)
 	if t.tracer != nil {
 		t.tracer.On(&ConfigTrace{
+			Version:                  traceVersion,
 			Hdist:                    t.cfg.Hdist,
 			Zdist:                    t.cfg.Zdist,
 			WindowSize:               t.cfg.WindowSize,
@@ -177,6 +178,9 @@ func (t *Tortoise) RecoverFrom(lid types.LayerID, opinion, prev types.Hash32) {
 	layer := t.trtl.layer(lid)
 	layer.opinion = opinion
 	layer.prevOpinion = &prev
+	if t.tracer != nil {
+		t.tracer.On(&RecoverFromTrace{Layer: lid, Opinion: opinion, Previous: prev})
+	}
 }
 
 // LatestComplete returns the latest verified layer.
@@ -411,7 +415,7 @@ func (t *Tortoise) OnRecoveredBallot(ballot *types.BallotTortoiseData) {
 			zap.Error(err))
 	}
 	if t.tracer != nil {
-		t.tracer.On(&BallotTrace{Ballot: ballot})
+		t.tracer.On(&RecoveredBallotTrace{Ballot: ballot})
 	}
 }
 
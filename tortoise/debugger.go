@@ -0,0 +1,358 @@
+package tortoise
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+)
+
+var eventNames = map[eventType]string{
+	traceStart:           "config",
+	traceWeakCoin:        "coin",
+	traceBeacon:          "beacon",
+	traceAtx:             "atx",
+	traceBallot:          "ballot",
+	traceDecode:          "decode",
+	traceStore:           "store",
+	traceEncode:          "encode",
+	traceTally:           "tally",
+	traceBlock:           "block",
+	traceHare:            "hare",
+	traceUpdates:         "updates",
+	traceApplied:         "applied",
+	traceMalfeasance:     "malfeasance",
+	traceRecoveredBlocks: "recovered",
+	traceRecoverFrom:     "recover-from",
+	traceRecoveredBallot: "recovered-ballot",
+}
+
+// TraceEventNames returns names of all events that can be recorded in the trace.
+func TraceEventNames() []string {
+	names := make([]string, 0, len(eventNames))
+	for _, name := range eventNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var errNotStarted = errors.New("tortoise is not started yet, config event wasn't executed")
+
+// TraceEvent describes the event executed by the debugger.
+type TraceEvent struct {
+	// Index of the event in the trace, starting from 0.
+	Index int
+	Name  string
+	// Layer is zero if the event doesn't refer to a layer.
+	Layer types.LayerID
+	// Ballot is empty if the event doesn't refer to a ballot.
+	Ballot types.BallotID
+	// Raw is the decoded event.
+	Raw any
+}
+
+// Debugger executes the trace event by event and allows to inspect the state
+// of the tortoise between events.
+type Debugger struct {
+	f      *os.File
+	dec    *json.Decoder
+	enum   eventEnum
+	runner *traceRunner
+	index  int
+}
+
+// NewDebugger opens the trace. Events are executed with Next.
+func NewDebugger(path string, opts ...Opt) (*Debugger, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Debugger{
+		f:    f,
+		dec:  json.NewDecoder(bufio.NewReaderSize(f, 1<<20)),
+		enum: newEventEnum(),
+		runner: &traceRunner{
+			opts:          opts,
+			pending:       map[types.BallotID]*DecodedBallot{},
+			assertOutputs: true,
+			assertErrors:  true,
+		},
+	}, nil
+}
+
+// Close closes the trace file.
+func (d *Debugger) Close() error {
+	return d.f.Close()
+}
+
+// Next executes the next event from the trace and returns its description.
+// Returns io.EOF if there are no more events.
+func (d *Debugger) Next() (*TraceEvent, error) {
+	ev, err := d.enum.Decode(d.dec)
+	if err != nil {
+		return nil, err
+	}
+	info := d.describe(ev)
+	d.index++
+	if err := ev.Run(d.runner); err != nil {
+		return info, fmt.Errorf("event %d (%s): %w", info.Index, info.Name, err)
+	}
+	return info, nil
+}
+
+func (d *Debugger) describe(ev traceEvent) *TraceEvent {
+	info := &TraceEvent{Index: d.index, Name: eventNames[ev.Type()], Raw: ev}
+	switch ev := ev.(type) {
+	case *WeakCoinTrace:
+		info.Layer = ev.Layer
+	case *BallotTrace:
+		info.Layer, info.Ballot = ev.Ballot.Layer, ev.Ballot.ID
+	case *RecoveredBallotTrace:
+		info.Layer, info.Ballot = ev.Ballot.Layer, ev.Ballot.ID
+	case *DecodeBallotTrace:
+		info.Layer, info.Ballot = ev.Ballot.Layer, ev.Ballot.ID
+	case *StoreBallotTrace:
+		info.Ballot = ev.ID
+		if pending, exist := d.runner.pending[ev.ID]; exist {
+			info.Layer = pending.Layer
+		}
+	case *EncodeVotesTrace:
+		info.Layer = ev.Layer
+	case *TallyTrace:
+		info.Layer = ev.Layer
+	case *BlockTrace:
+		info.Layer = ev.Header.LayerID
+	case *HareTrace:
+		info.Layer = ev.Layer
+	case *UpdatesTrace:
+		info.Layer = ev.To
+	case *AppliedTrace:
+		info.Layer = ev.Layer
+	case *RecoveredBlocksTrace:
+		info.Layer = ev.Layer
+	case *RecoverFromTrace:
+		info.Layer = ev.Layer
+	}
+	return info
+}
+
+// DebugState is a summary of the tortoise state.
+type DebugState struct {
+	Mode      Mode
+	Last      types.LayerID
+	Processed types.LayerID
+	Verified  types.LayerID
+	Evicted   types.LayerID
+}
+
+// State returns the summary of the tortoise state.
+func (d *Debugger) State() (*DebugState, error) {
+	t := d.runner.trt
+	if t == nil {
+		return nil, errNotStarted
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	mode := Verifying
+	if t.trtl.isFull {
+		mode = Full
+	}
+	return &DebugState{
+		Mode:      Mode(mode),
+		Last:      t.trtl.last,
+		Processed: t.trtl.processed,
+		Verified:  t.trtl.verified,
+		Evicted:   t.trtl.evicted,
+	}, nil
+}
+
+// DebugBlock is the state of the block in the tortoise.
+type DebugBlock struct {
+	ID       types.BlockID
+	Layer    types.LayerID
+	Height   uint64
+	Hare     string
+	Validity string
+	// Margin is the weight of votes counted by the full mode.
+	Margin float64
+	Data   bool
+}
+
+// DebugLayer is the state of the layer in the tortoise.
+type DebugLayer struct {
+	Layer          types.LayerID
+	HareTerminated bool
+	Coinflip       string
+	Opinion        types.Hash32
+	// Empty is the weight of votes for the empty layer counted by the full mode.
+	Empty float64
+	// GoodUncounted is the weight of good ballots that don't vote for this layer.
+	GoodUncounted   float64
+	ReferenceHeight uint64
+	Blocks          []DebugBlock
+}
+
+// Layer returns the state of the layer that is not evicted yet.
+func (d *Debugger) Layer(lid types.LayerID) (*DebugLayer, error) {
+	t := d.runner.trt
+	if t == nil {
+		return nil, errNotStarted
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !lid.After(t.trtl.evicted) {
+		return nil, fmt.Errorf("layer %d is evicted", lid)
+	}
+	if lid.After(t.trtl.last) {
+		return nil, fmt.Errorf("layer %d is after the last layer %d", lid, t.trtl.last)
+	}
+	layer := t.trtl.layer(lid)
+	rst := &DebugLayer{
+		Layer:           lid,
+		HareTerminated:  layer.hareTerminated,
+		Coinflip:        layer.coinflip.String(),
+		Opinion:         layer.opinion,
+		Empty:           layer.empty.Float(),
+		GoodUncounted:   layer.verifying.goodUncounted.Float(),
+		ReferenceHeight: layer.verifying.referenceHeight,
+	}
+	for _, block := range layer.blocks {
+		rst.Blocks = append(rst.Blocks, debugBlock(block))
+	}
+	return rst, nil
+}
+
+func debugBlock(block *blockInfo) DebugBlock {
+	return DebugBlock{
+		ID:       block.id,
+		Layer:    block.layer,
+		Height:   block.height,
+		Hare:     block.hare.String(),
+		Validity: block.validity.String(),
+		Margin:   block.margin.Float(),
+		Data:     block.data,
+	}
+}
+
+// DebugBallot is the state of the ballot in the tortoise.
+type DebugBallot struct {
+	ID        types.BallotID
+	Layer     types.LayerID
+	Base      types.BallotID
+	Smesher   types.NodeID
+	ATX       types.ATXID
+	Weight    float64
+	Height    uint64
+	Malicious bool
+	BadBeacon bool
+	Opinion   types.Hash32
+}
+
+// Ballot returns the state of the ballot that is not evicted yet.
+func (d *Debugger) Ballot(id types.BallotID) (*DebugBallot, error) {
+	t := d.runner.trt
+	if t == nil {
+		return nil, errNotStarted
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ballot, exist := t.trtl.ballotRefs[id]
+	if !exist {
+		return nil, fmt.Errorf("ballot %s is not known", id)
+	}
+	return &DebugBallot{
+		ID:        ballot.id,
+		Layer:     ballot.layer,
+		Base:      ballot.base.id,
+		Smesher:   ballot.reference.smesher,
+		ATX:       ballot.reference.atxid,
+		Weight:    ballot.weight.Float(),
+		Height:    ballot.reference.height,
+		Malicious: ballot.malicious,
+		BadBeacon: ballot.conditions.badBeacon,
+		Opinion:   ballot.opinion(),
+	}, nil
+}
+
+// DebugTally is the weight of votes for the block from ballots that are known to the tortoise.
+type DebugTally struct {
+	Block DebugBlock
+	// Support, Against and Abstain are recounted from all honest ballots that can vote for the block.
+	Support float64
+	Against float64
+	Abstain float64
+	Ballots int
+	// LocalThreshold and GlobalThreshold are compared with the margin to decide validity.
+	// GlobalThreshold is zero if there are no layers that can vote for the block.
+	LocalThreshold  float64
+	GlobalThreshold float64
+}
+
+// Tally recounts votes for the block that is not evicted yet.
+func (d *Debugger) Tally(id types.BlockID) (*DebugTally, error) {
+	t := d.runner.trt
+	if t == nil {
+		return nil, errNotStarted
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var block *blockInfo
+	for lid := t.trtl.evicted.Add(1); !lid.After(t.trtl.last) && block == nil; lid = lid.Add(1) {
+		for _, candidate := range t.trtl.layer(lid).blocks {
+			if candidate.id == id {
+				block = candidate
+				break
+			}
+		}
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s is not known", id)
+	}
+	rst := &DebugTally{
+		Block:          debugBlock(block),
+		LocalThreshold: t.trtl.localThreshold.Float(),
+	}
+	if t.trtl.hasVotingEpochs(block.layer) {
+		rst.GlobalThreshold = t.trtl.globalThreshold(t.cfg, block.layer).Float()
+	}
+	for lid := block.layer.Add(1); !lid.After(t.trtl.last); lid = lid.Add(1) {
+		for _, ballot := range t.trtl.ballots[lid] {
+			if ballot.malicious || block.height > ballot.reference.height {
+				continue
+			}
+			for lvote := ballot.votes.tail; lvote != nil; lvote = lvote.prev {
+				if lvote.lid != block.layer {
+					continue
+				}
+				rst.Ballots++
+				if lvote.vote == abstain {
+					rst.Abstain += ballot.weight.Float()
+				} else if lvote.getVote(block) == support {
+					rst.Support += ballot.weight.Float()
+				} else {
+					rst.Against += ballot.weight.Float()
+				}
+				break
+			}
+		}
+	}
+	return rst, nil
+}
+
+// hasVotingEpochs returns true if there are layers that can vote for the target and weights of their epochs are known.
+func (s *state) hasVotingEpochs(target types.LayerID) bool {
+	if !target.Before(s.last) {
+		return false
+	}
+	for epoch := target.Add(1).GetEpoch(); epoch <= s.last.GetEpoch(); epoch++ {
+		if _, exist := s.epochs[epoch]; !exist {
+			return false
+		}
+	}
+	return true
+}
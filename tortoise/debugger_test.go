@@ -0,0 +1,109 @@
+package tortoise
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestDebugger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tortoise.trace")
+	const size = 10
+	s := sim.New(
+		sim.WithLayerSize(size),
+	)
+	s.Setup()
+
+	ctx := context.Background()
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	trt := tortoiseFromSimState(t, s.GetState(0), WithConfig(cfg), WithTracer(WithOutput(path)))
+	var last types.LayerID
+	for i := 0; i < 10; i++ {
+		last = s.Next()
+		trt.TallyVotes(ctx, last)
+	}
+	expected := trt.LatestComplete()
+	require.NotZero(t, expected)
+
+	d, err := NewDebugger(path, WithLogger(logtest.New(t)))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, d.Close()) })
+
+	_, err = d.State()
+	require.ErrorIs(t, err, errNotStarted)
+
+	ev, err := d.Next()
+	require.NoError(t, err)
+	require.Equal(t, 0, ev.Index)
+	require.Equal(t, "config", ev.Name)
+
+	state, err := d.State()
+	require.NoError(t, err)
+	require.Equal(t, Mode(Verifying), state.Mode)
+	require.Zero(t, state.Verified)
+
+	var (
+		ballot types.BallotID
+		events = 1
+	)
+	for {
+		ev, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Equal(t, events, ev.Index)
+		events++
+		if ev.Name == "ballot" || ev.Name == "store" {
+			require.NotEqual(t, types.EmptyBallotID, ev.Ballot)
+			require.NotZero(t, ev.Layer)
+			ballot = ev.Ballot
+		}
+	}
+
+	state, err = d.State()
+	require.NoError(t, err)
+	require.Equal(t, expected, state.Verified)
+	require.Equal(t, last, state.Last)
+
+	layer, err := d.Layer(expected)
+	require.NoError(t, err)
+	require.True(t, layer.HareTerminated)
+	var block DebugBlock
+	for _, candidate := range layer.Blocks {
+		if candidate.Validity == "support" {
+			block = candidate
+		}
+	}
+	require.NotEqual(t, types.EmptyBlockID, block.ID)
+
+	_, err = d.Layer(last + 1)
+	require.Error(t, err)
+
+	info, err := d.Ballot(ballot)
+	require.NoError(t, err)
+	require.Equal(t, ballot, info.ID)
+	require.Positive(t, info.Weight)
+
+	_, err = d.Ballot(types.BallotID{1})
+	require.Error(t, err)
+
+	tally, err := d.Tally(block.ID)
+	require.NoError(t, err)
+	require.Equal(t, block, tally.Block)
+	require.Positive(t, tally.Ballots)
+	require.Greater(t, tally.Support, tally.GlobalThreshold)
+	require.Zero(t, tally.Against)
+
+	_, err = d.Tally(types.BlockID{1})
+	require.Error(t, err)
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"math/rand"
+
+	"github.com/spacemeshos/go-spacemesh/log"
+)
+
+// Config for the simulation of the cluster.
+type Config struct {
+	Seed     int64
+	Smeshers int
+	Layers   int
+	// Failure is a probability, in percents, that failable message will be dropped.
+	Failure int
+	// Failable messages, if empty every message can be dropped.
+	Failable []Message
+}
+
+// Run simulates cluster of smeshers with a single hare and beacon instance for the configured number of layers.
+// Monitors are notified about events and tested after every layer.
+func Run(logger log.Log, cfg Config, monitors ...Monitor) {
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	c := newCluster(logger, rng)
+	for i := 0; i < cfg.Smeshers; i++ {
+		c.addCore()
+	}
+	c.addHare().addBeacon()
+
+	r := newFailingRunner(c, &reliableMessenger{}, monitors, rng, [2]int{cfg.Failure, 100})
+	if len(cfg.Failable) > 0 {
+		r.failable(cfg.Failable...)
+	}
+	for i := 0; i < cfg.Layers; i++ {
+		r.next()
+		for _, monitor := range monitors {
+			monitor.Test()
+		}
+	}
+}
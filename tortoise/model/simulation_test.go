@@ -0,0 +1,40 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+type countingMonitor struct {
+	tests    int
+	verified types.LayerID
+}
+
+func (m *countingMonitor) OnEvent(event Event) {
+	if ev, ok := event.(EventVerified); ok {
+		m.verified = max(m.verified, ev.Verified)
+	}
+}
+
+func (m *countingMonitor) Test() {
+	m.tests++
+}
+
+func TestRun(t *testing.T) {
+	types.SetLayersPerEpoch(4)
+	const layers = 20
+	counter := &countingMonitor{}
+	Run(logtest.New(t), Config{
+		Seed:     1001,
+		Smeshers: 50,
+		Layers:   layers,
+		Failure:  5,
+		Failable: []Message{MessageBallot{}},
+	}, newVerifiedMonitor(t, types.GetEffectiveGenesis()), counter)
+	require.Equal(t, layers, counter.tests)
+	require.Greater(t, counter.verified, types.GetEffectiveGenesis())
+}
@@ -0,0 +1,265 @@
+package tortoise
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spacemeshos/go-spacemesh/atxsdata"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/atxs"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/certificates"
+	"github.com/spacemeshos/go-spacemesh/sql/identities"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+)
+
+// TraceExpectation is the outcome of the history that was used to record the trace.
+type TraceExpectation struct {
+	// Verified is the last layer verified at the end of the trace.
+	Verified types.LayerID   `json:"verified"`
+	Blocks   []ExpectedBlock `json:"blocks"`
+}
+
+// ExpectedBlock is the validity of the block stored in the database.
+type ExpectedBlock struct {
+	ID    types.BlockID `json:"id"`
+	Layer types.LayerID `json:"layer"`
+	Valid bool          `json:"valid"`
+}
+
+// ExpectationPath returns the path of the expectation for the trace.
+func ExpectationPath(trace string) string {
+	return strings.TrimSuffix(trace, ".json") + ".expected.json"
+}
+
+// WriteExpectation writes the expectation as json to the file.
+func WriteExpectation(path string, expected *TraceExpectation) error {
+	buf, err := json.MarshalIndent(expected, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf, 0o600)
+}
+
+// ReadExpectation reads the expectation written by WriteExpectation.
+func ReadExpectation(path string) (*TraceExpectation, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var expected TraceExpectation
+	if err := json.Unmarshal(buf, &expected); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return &expected, nil
+}
+
+// RecordTrace synthesises the trace from the history stored in the database for layers in [from, to].
+//
+// The trace consists of the same events that tortoise records WithTracer while the node follows the network:
+// atxs, beacons, ballots, blocks, hare outputs and weak coins. If from is not the first layer after genesis
+// it must be the first layer of the epoch, so that reference ballots are in the trace, and tortoise starts
+// from the aggregated hash of the layer.
+//
+// The returned expectation contains the validity of blocks stored in the database for the layers
+// verified in the trace. Error is returned if the tortoise diverges from the database.
+func RecordTrace(
+	ctx context.Context,
+	db sql.Executor,
+	path string,
+	from, to types.LayerID,
+	opts ...Opt,
+) (*TraceExpectation, error) {
+	genesis := types.GetEffectiveGenesis()
+	if !from.After(genesis) || from.After(to) {
+		return nil, fmt.Errorf("invalid range [%d, %d], effective genesis %d", from, to, genesis)
+	}
+	recovered := from != genesis.Add(1)
+	if recovered && !from.FirstInEpoch() {
+		return nil, fmt.Errorf("layer %d is not the first in epoch", from)
+	}
+
+	atxdata := atxsdata.New()
+	if err := atxs.IterateAtxsData(db, from.GetEpoch()-1, to.GetEpoch()-1,
+		func(
+			id types.ATXID,
+			node types.NodeID,
+			epoch types.EpochID,
+			coinbase types.Address,
+			weight,
+			base,
+			height uint64,
+			nonce *types.VRFPostIndex,
+			malicious bool,
+		) bool {
+			// nonce is not used by the tortoise
+			var vrf types.VRFPostIndex
+			if nonce != nil {
+				vrf = *nonce
+			}
+			atxdata.Add(epoch+1, node, coinbase, id, weight, base, height, vrf, malicious)
+			return true
+		}); err != nil {
+		return nil, fmt.Errorf("load atxs: %w", err)
+	}
+
+	trtl, err := New(atxdata, append(opts, WithTracer(WithOutput(path)))...)
+	if err != nil {
+		return nil, err
+	}
+	malicious, err := identities.GetMalicious(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("load malicious identities: %w", err)
+	}
+	for _, id := range malicious {
+		trtl.OnMalfeasance(id)
+	}
+	if recovered {
+		prev, err := layers.GetAggregatedHash(db, from-1)
+		if err != nil {
+			return nil, fmt.Errorf("get aggregated hash for layer %d: %w", from-1, err)
+		}
+		opinion, err := layers.GetAggregatedHash(db, from)
+		if err != nil {
+			return nil, fmt.Errorf("get aggregated hash for layer %d: %w", from, err)
+		}
+		trtl.RecoverFrom(from, opinion, prev)
+	}
+
+	for lid := from; !lid.After(to); lid = lid.Add(1) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		if err := recordLayer(ctx, trtl, db, atxdata, lid, lid == from,
+			recovered && lid.GetEpoch() == from.GetEpoch()); err != nil {
+			return nil, fmt.Errorf("record layer %d: %w", lid, err)
+		}
+	}
+
+	expected := &TraceExpectation{}
+	var diverged []error
+	for _, layer := range trtl.Updates() {
+		if !layer.Verified {
+			continue
+		}
+		expected.Verified = layer.Layer
+		for _, block := range layer.Blocks {
+			valid, err := blocks.IsValid(db, block.Header.ID)
+			if errors.Is(err, sql.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("get validity of block %s: %w", block.Header.ID, err)
+			}
+			if valid != block.Valid {
+				diverged = append(diverged, fmt.Errorf("block %s in layer %d: stored valid %t, tortoise valid %t",
+					block.Header.ID, layer.Layer, valid, block.Valid))
+			}
+			expected.Blocks = append(expected.Blocks, ExpectedBlock{
+				ID:    block.Header.ID,
+				Layer: layer.Layer,
+				Valid: valid,
+			})
+		}
+	}
+	if len(diverged) > 0 {
+		return nil, fmt.Errorf("tortoise diverged from the database: %w", errors.Join(diverged...))
+	}
+	return expected, nil
+}
+
+// recordLayer passes the layer from the database to the tortoise in the order it is received by the node.
+// Unlike RecoverLayer blocks are passed without validity stored in the database, so that it is computed
+// by the tortoise.
+func recordLayer(
+	ctx context.Context,
+	trtl *Tortoise,
+	db sql.Executor,
+	atxdata *atxsdata.Data,
+	lid types.LayerID,
+	first, recovered bool,
+) error {
+	if lid.FirstInEpoch() || first {
+		if err := recoverEpoch(lid.GetEpoch(), trtl, db, atxdata); err != nil {
+			return err
+		}
+	}
+	blocksrst, err := blocks.Layer(db, lid)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocksrst {
+		trtl.OnBlock(block.ToVote())
+	}
+	hare, err := certificates.GetHareOutput(db, lid)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return err
+	} else if err == nil {
+		trtl.OnHareOutput(lid, hare)
+	}
+	onBallot := trtl.OnBallot
+	if recovered {
+		// history of the ballot may precede the trace, opinion is recovered from the ballot
+		onBallot = trtl.OnRecoveredBallot
+	}
+	if err := recoverBallotsAndCoin(trtl, db, lid, onBallot); err != nil {
+		return err
+	}
+	trtl.TallyVotes(ctx, lid)
+	return nil
+}
+
+// CheckTrace executes the trace and compares the verified layer and validity of blocks with the expectation.
+func CheckTrace(path string, expected *TraceExpectation, opts ...Opt) error {
+	d, err := NewDebugger(path, opts...)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	var (
+		verified types.LayerID
+		validity = map[types.BlockID]bool{}
+	)
+	for {
+		ev, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		updates, ok := ev.Raw.(*UpdatesTrace)
+		if !ok {
+			continue
+		}
+		// results in the trace are equal to the results of the execution, otherwise Next fails
+		for _, layer := range updates.Results {
+			if layer.Verified {
+				verified = max(verified, layer.Layer)
+			}
+			for _, block := range layer.Blocks {
+				validity[block.Header.ID] = block.Valid
+			}
+		}
+	}
+	var diverged []error
+	if verified != expected.Verified {
+		diverged = append(diverged, fmt.Errorf("expected verified layer %d, got %d", expected.Verified, verified))
+	}
+	for _, block := range expected.Blocks {
+		valid, exist := validity[block.ID]
+		if !exist {
+			diverged = append(diverged, fmt.Errorf("block %s in layer %d: no result", block.ID, block.Layer))
+		} else if valid != block.Valid {
+			diverged = append(diverged, fmt.Errorf("block %s in layer %d: expected valid %t, got %t",
+				block.ID, block.Layer, block.Valid, valid))
+		}
+	}
+	return errors.Join(diverged...)
+}
@@ -0,0 +1,98 @@
+package tortoise
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+func TestRecordTrace(t *testing.T) {
+	ctx := context.Background()
+	const size = 10
+	s := sim.New(sim.WithLayerSize(size))
+	s.Setup()
+
+	cfg := defaultTestConfig()
+	cfg.LayerSize = size
+	state := s.GetState(0)
+	trt := tortoiseFromSimState(t, state, WithLogger(logtest.New(t)), WithConfig(cfg))
+	var last types.LayerID
+	for i := 0; i < 30; i++ {
+		last = s.Next()
+		trt.TallyVotes(ctx, last)
+	}
+	// persist results as the node does
+	for _, layer := range trt.Updates() {
+		require.NoError(t, layers.SetMeshHash(state.DB, layer.Layer, layer.Opinion))
+		if !layer.Verified {
+			continue
+		}
+		for _, block := range layer.Blocks {
+			require.NoError(t, blocks.UpdateValid(state.DB, block.Header.ID, block.Valid))
+		}
+	}
+	verified := trt.LatestComplete()
+	require.Greater(t, verified, types.GetEffectiveGenesis())
+
+	for _, tc := range []struct {
+		desc string
+		from types.LayerID
+	}{
+		{desc: "genesis", from: types.GetEffectiveGenesis() + 1},
+		{desc: "recover", from: (types.GetEffectiveGenesis() + 1).GetEpoch().Add(2).FirstLayer()},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "trace.json")
+			expected, err := RecordTrace(ctx, state.DB, path, tc.from, last,
+				WithLogger(logtest.New(t)), WithConfig(cfg))
+			require.NoError(t, err)
+			require.Equal(t, verified, expected.Verified)
+			require.NotEmpty(t, expected.Blocks)
+			for _, block := range expected.Blocks {
+				require.False(t, block.Layer.Before(tc.from))
+			}
+
+			require.NoError(t, WriteExpectation(ExpectationPath(path), expected))
+			read, err := ReadExpectation(ExpectationPath(path))
+			require.NoError(t, err)
+			require.Equal(t, expected, read)
+			require.NoError(t, CheckTrace(path, read, WithLogger(logtest.New(t))))
+
+			read.Blocks[0].Valid = !read.Blocks[0].Valid
+			require.ErrorContains(t, CheckTrace(path, read, WithLogger(logtest.New(t))), "expected valid")
+		})
+	}
+
+	t.Run("diverged", func(t *testing.T) {
+		rst, err := blocks.Layer(state.DB, verified)
+		require.NoError(t, err)
+		require.NotEmpty(t, rst)
+		id := rst[0].ID()
+		valid, err := blocks.IsValid(state.DB, id)
+		require.NoError(t, err)
+		require.NoError(t, blocks.UpdateValid(state.DB, id, !valid))
+		t.Cleanup(func() {
+			require.NoError(t, blocks.UpdateValid(state.DB, id, valid))
+		})
+		path := filepath.Join(t.TempDir(), "trace.json")
+		_, err = RecordTrace(ctx, state.DB, path, types.GetEffectiveGenesis()+1, last,
+			WithLogger(logtest.New(t)), WithConfig(cfg))
+		require.ErrorContains(t, err, "tortoise diverged from the database")
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "trace.json")
+		_, err := RecordTrace(ctx, state.DB, path, types.GetEffectiveGenesis()+2, last)
+		require.ErrorContains(t, err, "is not the first in epoch")
+		_, err = RecordTrace(ctx, state.DB, path, last, last-1)
+		require.ErrorContains(t, err, "invalid range")
+	})
+}
@@ -60,7 +60,7 @@ func Recover(
 		}
 	}
 
-	malicious, err := identities.GetMalicious(db)
+	malicious, err := identities.GetMalicious(ctx, db)
 	if err != nil {
 		return nil, fmt.Errorf("recover malicious %w", err)
 	}
@@ -183,7 +183,11 @@ func RecoverLayer(
 	trtl.OnRecoveredBlocks(lid, results, hareResult)
 	// tortoise votes according to the hare only within hdist (protocol parameter).
 	// also node is free to prune certificates outside hdist to minimize space usage.
+	return recoverBallotsAndCoin(trtl, db, lid, onBallot)
+}
 
+// recoverBallotsAndCoin loads ballots and the weak coin of the layer.
+func recoverBallotsAndCoin(trtl *Tortoise, db sql.Executor, lid types.LayerID, onBallot ballotFunc) error {
 	// NOTE(dshulyak) we loaded information about malicious identities earlier.
 	ballotsrst, err := ballots.LayerNoMalicious(db, lid)
 	if err != nil {
@@ -0,0 +1,419 @@
+package scenario
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/spacemeshos/go-spacemesh/atxsdata"
+	"github.com/spacemeshos/go-spacemesh/common/types"
+	"github.com/spacemeshos/go-spacemesh/log"
+	"github.com/spacemeshos/go-spacemesh/sql"
+	"github.com/spacemeshos/go-spacemesh/sql/ballots"
+	"github.com/spacemeshos/go-spacemesh/sql/blocks"
+	"github.com/spacemeshos/go-spacemesh/tortoise"
+	"github.com/spacemeshos/go-spacemesh/tortoise/model"
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+var messages = map[string]model.Message{
+	"atx":      model.MessageAtx{},
+	"ballot":   model.MessageBallot{},
+	"block":    model.MessageBlock{},
+	"coinflip": model.MessageCoinflip{},
+	"beacon":   model.MessageBeacon{},
+}
+
+// Divergence of the tortoise from the expected results.
+type Divergence struct {
+	// Step is the index of the expect step, -1 for model scenarios.
+	Step int
+	// Instance is the id of the tortoise instance.
+	Instance string
+	// Last is the last layer generated before the check.
+	Last    types.LayerID
+	Message string
+}
+
+func (d Divergence) String() string {
+	if d.Step < 0 {
+		return fmt.Sprintf("instance %s, last layer %d: %s", d.Instance, d.Last, d.Message)
+	}
+	return fmt.Sprintf("step %d, instance %s, last layer %d: %s", d.Step, d.Instance, d.Last, d.Message)
+}
+
+// Report of the executed scenario.
+type Report struct {
+	Name        string
+	Genesis     types.LayerID
+	Last        types.LayerID
+	Checks      int
+	Divergences []Divergence
+}
+
+// Failed is true if tortoise diverged from expectations at least once.
+func (r *Report) Failed() bool {
+	return len(r.Divergences) > 0
+}
+
+func (r *Report) diverged(step int, instance string, format string, args ...any) {
+	r.Divergences = append(r.Divergences, Divergence{
+		Step:     step,
+		Instance: instance,
+		Last:     r.Last,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Run executes the scenario and compares results of every tortoise instance with expectations.
+// Error is returned only if the scenario can't be executed.
+//
+// Run changes the global number of layers per epoch, therefore scenarios must not be executed concurrently.
+func Run(ctx context.Context, logger log.Log, sc *Scenario) (*Report, error) {
+	types.SetLayersPerEpoch(sc.LayersPerEpoch)
+	report := &Report{Name: sc.Name, Genesis: types.GetEffectiveGenesis()}
+	if sc.Model != nil {
+		runModel(logger, sc, report)
+		return report, nil
+	}
+	r, err := newSimRunner(logger, sc, report)
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range sc.Steps {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+		if err := r.step(ctx, i, step); err != nil {
+			return nil, fmt.Errorf("step %d: %w", i, err)
+		}
+	}
+	return report, nil
+}
+
+type instance struct {
+	trtl  *tortoise.Tortoise
+	state sim.State
+	next  types.LayerID
+}
+
+// tally passes layers up to lid from the database to the tortoise, as it is done during recovery,
+// and persists validity of blocks so that it is loaded with the next layers.
+func (i *instance) tally(ctx context.Context, lid types.LayerID) error {
+	for ; !i.next.After(lid); i.next = i.next.Add(1) {
+		if err := tortoise.RecoverLayer(ctx, i.trtl, i.state.DB, i.state.Atxdata, i.next, i.trtl.OnBallot); err != nil {
+			return fmt.Errorf("load layer %d: %w", i.next, err)
+		}
+		i.trtl.TallyVotes(ctx, i.next)
+	}
+	return i.persist()
+}
+
+func (i *instance) persist() error {
+	for _, layer := range i.trtl.Updates() {
+		for _, block := range layer.Blocks {
+			if block.Valid {
+				if err := blocks.SetValid(i.state.DB, block.Header.ID); err != nil {
+					return err
+				}
+			} else if block.Invalid {
+				if err := blocks.SetInvalid(i.state.DB, block.Header.ID); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type simRunner struct {
+	logger    log.Log
+	sc        *Scenario
+	report    *Report
+	gens      []*sim.Generator
+	instances []*instance
+	// owner is an index of the generator that produces layers for the instance.
+	owner   []int
+	splitAt types.LayerID
+}
+
+func newSimRunner(logger log.Log, sc *Scenario, report *Report) (*simRunner, error) {
+	g := sim.New(
+		sim.WithSeed(sc.Seed),
+		sim.WithLayerSize(sc.LayerSize),
+		sim.WithStates(sc.instances()),
+		sim.WithLogger(logger),
+	)
+	g.Setup(
+		sim.WithSetupMinerRange(sc.Setup.Miners[0], sc.Setup.Miners[1]),
+		sim.WithSetupUnitsRange(sc.Setup.Units[0], sc.Setup.Units[1]),
+		sim.WithSetupTicksRange(sc.Setup.Ticks[0], sc.Setup.Ticks[1]),
+	)
+	cfg := tortoise.DefaultConfig()
+	cfg.LayerSize = sc.LayerSize
+	cfg.MaxExceptions = int(sc.LayerSize) * 100
+	if sc.Tortoise.Hdist != 0 {
+		cfg.Hdist = sc.Tortoise.Hdist
+	}
+	if sc.Tortoise.Zdist != 0 {
+		cfg.Zdist = sc.Tortoise.Zdist
+	}
+	if sc.Tortoise.WindowSize != 0 {
+		cfg.WindowSize = sc.Tortoise.WindowSize
+	}
+	if sc.Tortoise.BadBeaconVoteDelayLayers != 0 {
+		cfg.BadBeaconVoteDelayLayers = sc.Tortoise.BadBeaconVoteDelayLayers
+	}
+	r := &simRunner{
+		logger: logger,
+		sc:     sc,
+		report: report,
+		gens:   []*sim.Generator{g},
+	}
+	for i := 0; i < sc.instances(); i++ {
+		state := g.GetState(i)
+		trtl, err := tortoise.New(state.Atxdata,
+			tortoise.WithLogger(logger.Named("tortoise-"+strconv.Itoa(i))),
+			tortoise.WithConfig(cfg),
+		)
+		if err != nil {
+			return nil, err
+		}
+		r.instances = append(r.instances, &instance{trtl: trtl, state: state, next: types.GetEffectiveGenesis()})
+		r.owner = append(r.owner, 0)
+	}
+	return r, nil
+}
+
+func (r *simRunner) step(ctx context.Context, i int, step Step) error {
+	switch {
+	case step.Layers > 0:
+		return r.layers(ctx, step)
+	case len(step.Split) > 0:
+		return r.split(step)
+	case step.Heal:
+		return r.heal(ctx)
+	case step.Expect != nil:
+		return r.expect(i, step.Expect)
+	}
+	return nil
+}
+
+func (r *simRunner) layers(ctx context.Context, step Step) error {
+	var opts []sim.NextOpt
+	if step.Blocks != nil {
+		opts = append(opts, sim.WithNumBlocks(*step.Blocks))
+	}
+	switch step.Hare {
+	case HareEmpty:
+		opts = append(opts, sim.WithEmptyHareOutput())
+	case HareNone:
+		opts = append(opts, sim.WithoutHareOutput())
+	}
+	if step.Coin != nil {
+		opts = append(opts, sim.WithCoin(*step.Coin))
+	}
+	if step.Reorder > 0 {
+		opts = append(opts, sim.WithNextReorder(step.Reorder))
+	}
+	if step.Size != nil {
+		opts = append(opts, sim.WithLayerSizeOverwrite(*step.Size))
+	}
+	for n := 0; n < step.Layers; n++ {
+		for j, g := range r.gens {
+			lid := g.Next(opts...)
+			r.report.Last = max(r.report.Last, lid)
+			for k, inst := range r.instances {
+				if r.owner[k] != j {
+					continue
+				}
+				if err := inst.tally(ctx, lid); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (r *simRunner) split(step Step) error {
+	parts, err := step.partitions()
+	if err != nil {
+		return err
+	}
+	r.gens = r.gens[0].Split(sim.WithPartitions(parts...))
+	r.splitAt = r.report.Last.Add(1)
+	// every partition is created with a single state, the rest of the states remain in the original partition
+	for k, inst := range r.instances {
+		r.owner[k] = 0
+		for j, g := range r.gens[1:] {
+			if g.GetState(0).DB == inst.state.DB {
+				r.owner[k] = j + 1
+			}
+		}
+	}
+	return nil
+}
+
+// heal merges partitions and delivers atxs, blocks and ballots produced since the split to every tortoise,
+// as the node would receive them after sync.
+func (r *simRunner) heal(ctx context.Context) error {
+	last := r.report.Last
+	// ballots that were already counted by the instance are not delivered again
+	known := make([]map[types.BallotID]struct{}, len(r.instances))
+	for k, inst := range r.instances {
+		known[k] = map[types.BallotID]struct{}{}
+		for lid := r.splitAt; !lid.After(last); lid = lid.Add(1) {
+			ids, err := ballots.IDsInLayer(inst.state.DB, lid)
+			if err != nil && !errors.Is(err, sql.ErrNotFound) {
+				return err
+			}
+			for _, id := range ids {
+				known[k][id] = struct{}{}
+			}
+		}
+	}
+	for _, other := range r.gens[1:] {
+		r.gens[0].Merge(other)
+	}
+	r.gens = r.gens[:1]
+	for k, inst := range r.instances {
+		r.owner[k] = 0
+		for epoch := r.splitAt.GetEpoch(); epoch <= last.GetEpoch()+1; epoch++ {
+			inst.state.Atxdata.IterateInEpoch(epoch, func(id types.ATXID, atx *atxsdata.ATX) {
+				inst.trtl.OnAtx(epoch, id, atx)
+			})
+		}
+		for lid := r.splitAt; !lid.After(last); lid = lid.Add(1) {
+			if err := deliver(inst, lid, known[k]); err != nil {
+				return fmt.Errorf("deliver layer %d: %w", lid, err)
+			}
+		}
+		inst.trtl.TallyVotes(ctx, last)
+		if err := inst.persist(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deliver(inst *instance, lid types.LayerID, known map[types.BallotID]struct{}) error {
+	blocksrst, err := blocks.Layer(inst.state.DB, lid)
+	if err != nil {
+		return err
+	}
+	for _, block := range blocksrst {
+		inst.trtl.OnBlock(block.ToVote())
+	}
+	ballotsrst, err := ballots.Layer(inst.state.DB, lid)
+	if err != nil {
+		return err
+	}
+	for _, ballot := range ballotsrst {
+		if _, exist := known[ballot.ID()]; !exist {
+			inst.trtl.OnBallot(ballot.ToTortoiseData())
+		}
+	}
+	return nil
+}
+
+func (r *simRunner) expect(i int, expect *Expect) error {
+	genesis := r.report.Genesis
+	for k, inst := range r.instances {
+		id := strconv.Itoa(k)
+		r.report.Checks++
+		verified := inst.trtl.LatestComplete()
+		switch {
+		case expect.Verified != nil:
+			if expected := genesis.Add(*expect.Verified); verified != expected {
+				r.report.diverged(i, id, "expected verified layer %d, got %d", expected, verified)
+			}
+		case expect.Lag != nil:
+			if expected := r.report.Last.Sub(min(*expect.Lag, r.report.Last.Uint32())); verified != expected {
+				r.report.diverged(i, id, "expected verified layer %d (lag %d), got %d", expected, *expect.Lag, verified)
+			}
+		}
+		for _, validity := range expect.Valid {
+			lid := genesis.Add(validity.Layer)
+			valid, err := countValid(inst.state.DB, lid)
+			if err != nil {
+				return err
+			}
+			if valid != validity.Blocks {
+				r.report.diverged(i, id, "expected %d valid blocks in layer %d, got %d", validity.Blocks, lid, valid)
+			}
+		}
+	}
+	return nil
+}
+
+func countValid(db sql.Executor, lid types.LayerID) (int, error) {
+	ids, err := blocks.IDsInLayer(db, lid)
+	if err != nil && !errors.Is(err, sql.ErrNotFound) {
+		return 0, err
+	}
+	valid := 0
+	for _, id := range ids {
+		ok, err := blocks.IsValid(db, id)
+		if err != nil && !errors.Is(err, sql.ErrNotFound) {
+			return 0, err
+		}
+		if ok {
+			valid++
+		}
+	}
+	return valid, nil
+}
+
+func runModel(logger log.Log, sc *Scenario, report *Report) {
+	cfg := model.Config{
+		Seed:     sc.Seed,
+		Smeshers: sc.Model.Smeshers,
+		Layers:   sc.Model.Layers,
+		Failure:  sc.Model.Failure,
+	}
+	for _, name := range sc.Model.Failable {
+		cfg.Failable = append(cfg.Failable, messages[name])
+	}
+	model.Run(logger, cfg, &lagMonitor{
+		lag:      sc.Model.Lag,
+		report:   report,
+		verified: map[string]types.LayerID{},
+	})
+}
+
+// lagMonitor checks that every core verified the layer with the expected lag after every layer.
+type lagMonitor struct {
+	lag      uint32
+	report   *Report
+	verified map[string]types.LayerID
+}
+
+func (m *lagMonitor) OnEvent(event model.Event) {
+	if ev, ok := event.(model.EventVerified); ok {
+		m.verified[ev.ID] = ev.Verified
+		m.report.Last = max(m.report.Last, ev.Layer)
+	}
+}
+
+func (m *lagMonitor) Test() {
+	if !m.report.Last.After(m.report.Genesis.Add(m.lag)) {
+		return
+	}
+	ids := make([]string, 0, len(m.verified))
+	for id := range m.verified {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	expected := m.report.Last.Sub(m.lag)
+	for _, id := range ids {
+		m.report.Checks++
+		if verified := m.verified[id]; verified != expected {
+			m.report.diverged(-1, id, "expected verified layer %d (lag %d), got %d", expected, m.lag, verified)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Package scenario implements declarative scenarios for the tortoise simulations.
+//
+// Scenario is a json document that describes either a sequence of steps for the layers generator
+// from tortoise/sim, or parameters of the cluster from tortoise/model. Layers in the scenario are
+// counted from the effective genesis, so that scenarios don't depend on the number of layers in epoch.
+//
+//	{
+//	  "name": "partition",
+//	  "layers_per_epoch": 4,
+//	  "layer_size": 10,
+//	  "setup": {"miners": [10, 10]},
+//	  "steps": [
+//	    {"layers": 8},
+//	    {"split": ["1/2"]},
+//	    {"layers": 4, "blocks": 1},
+//	    {"heal": true},
+//	    {"layers": 20},
+//	    {"expect": {"lag": 1}}
+//	  ]
+//	}
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spacemeshos/go-spacemesh/tortoise/sim"
+)
+
+const (
+	defaultLayersPerEpoch = 4
+	defaultLayerSize      = 10
+)
+
+// Hare outcomes for the layer.
+const (
+	// HareBlock is stored when hare output is the block from the layer, default.
+	HareBlock = "block"
+	// HareEmpty is stored when hare terminated with an empty output.
+	HareEmpty = "empty"
+	// HareNone is stored when hare didn't terminate.
+	HareNone = "none"
+)
+
+// Scenario describes the simulation and expected results.
+type Scenario struct {
+	Name           string `json:"name"`
+	Seed           int64  `json:"seed"`
+	LayersPerEpoch uint32 `json:"layers_per_epoch"`
+	LayerSize      uint32 `json:"layer_size"`
+
+	Tortoise Tortoise `json:"tortoise"`
+	Setup    Setup    `json:"setup"`
+	Steps    []Step   `json:"steps,omitempty"`
+	// Model runs the cluster from tortoise/model instead of steps.
+	Model *Model `json:"model,omitempty"`
+}
+
+// Tortoise overwrites parameters of the tortoise config, zero values are ignored.
+type Tortoise struct {
+	Hdist                    uint32 `json:"hdist"`
+	Zdist                    uint32 `json:"zdist"`
+	WindowSize               uint32 `json:"window_size"`
+	BadBeaconVoteDelayLayers uint32 `json:"bad_beacon_vote_delay"`
+}
+
+// Setup of the miners that produce atxs in every epoch. Ranges are inclusive [low, high].
+type Setup struct {
+	Miners [2]int `json:"miners"`
+	Units  [2]int `json:"units"`
+	Ticks  [2]int `json:"ticks"`
+}
+
+// Step is one of: layers, split, heal or expect.
+type Step struct {
+	// Layers is a number of layers to generate with the options below.
+	Layers int `json:"layers,omitempty"`
+	// Blocks in every generated layer, 5 by default.
+	Blocks *int `json:"blocks,omitempty"`
+	// Hare outcome, one of block, empty or none.
+	Hare string `json:"hare,omitempty"`
+	// Coin is a weak coin value, true by default.
+	Coin *bool `json:"coin,omitempty"`
+	// Reorder delays the every generated layer by the number of layers.
+	Reorder uint32 `json:"reorder,omitempty"`
+	// Size of the generated layers, layer size of the scenario by default.
+	Size *int `json:"size,omitempty"`
+
+	// Split network into partitions, each partition is a fraction of miners, formatted as "1/2".
+	// The rest of the miners remain in the original partition.
+	Split []string `json:"split,omitempty"`
+	// Heal merges partitions and delivers data produced during partition to every tortoise.
+	Heal bool `json:"heal,omitempty"`
+	// Expect is checked against every tortoise instance.
+	Expect *Expect `json:"expect,omitempty"`
+}
+
+// Expect is a checkpoint with expected results.
+type Expect struct {
+	// Verified is the expected verified layer, counted from effective genesis.
+	Verified *uint32 `json:"verified,omitempty"`
+	// Lag is the expected distance between the last generated and verified layer.
+	Lag *uint32 `json:"lag,omitempty"`
+	// Valid is the expected number of valid blocks in the layer.
+	Valid []Validity `json:"valid,omitempty"`
+}
+
+// Validity is the number of valid blocks in the layer, counted from effective genesis.
+type Validity struct {
+	Layer  uint32 `json:"layer"`
+	Blocks int    `json:"blocks"`
+}
+
+// Model is a cluster simulation with unreliable delivery of messages.
+type Model struct {
+	Smeshers int `json:"smeshers"`
+	Layers   int `json:"layers"`
+	// Failure is a probability, in percents, that failable message will be dropped.
+	Failure int `json:"failure"`
+	// Failable is a list of messages that can be dropped: atx, ballot, block, coinflip or beacon.
+	Failable []string `json:"failable,omitempty"`
+	// Lag is the expected distance between the last and verified layer after every layer.
+	Lag uint32 `json:"lag"`
+}
+
+// Load reads the scenario from the file.
+func Load(path string) (*Scenario, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sc, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(sc.Name) == 0 {
+		sc.Name = path
+	}
+	return sc, nil
+}
+
+// Parse decodes and validates the scenario. Unknown fields are rejected.
+func Parse(r io.Reader) (*Scenario, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var sc Scenario
+	if err := dec.Decode(&sc); err != nil {
+		return nil, fmt.Errorf("decode scenario: %w", err)
+	}
+	sc.defaults()
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (sc *Scenario) defaults() {
+	if sc.LayersPerEpoch == 0 {
+		sc.LayersPerEpoch = defaultLayersPerEpoch
+	}
+	if sc.LayerSize == 0 {
+		sc.LayerSize = defaultLayerSize
+	}
+	if sc.Setup.Miners == [2]int{} {
+		sc.Setup.Miners = [2]int{int(sc.LayerSize), int(sc.LayerSize)}
+	}
+	if sc.Setup.Units == [2]int{} {
+		sc.Setup.Units = [2]int{10, 10}
+	}
+	if sc.Setup.Ticks == [2]int{} {
+		sc.Setup.Ticks = [2]int{10, 10}
+	}
+}
+
+// Validate checks that the scenario can be executed.
+func (sc *Scenario) Validate() error {
+	if sc.Model != nil {
+		if len(sc.Steps) > 0 {
+			return errors.New("model and steps are mutually exclusive")
+		}
+		if sc.Model.Smeshers <= 0 || sc.Model.Layers <= 0 {
+			return errors.New("model requires positive number of smeshers and layers")
+		}
+		if sc.Model.Failure < 0 || sc.Model.Failure > 100 {
+			return fmt.Errorf("failure %d is not in [0, 100]", sc.Model.Failure)
+		}
+		for _, name := range sc.Model.Failable {
+			if _, exist := messages[name]; !exist {
+				return fmt.Errorf("unknown failable message %q", name)
+			}
+		}
+		return nil
+	}
+	if len(sc.Steps) == 0 {
+		return errors.New("scenario without steps")
+	}
+	for name, r := range map[string][2]int{"miners": sc.Setup.Miners, "units": sc.Setup.Units, "ticks": sc.Setup.Ticks} {
+		if r[0] <= 0 || r[0] > r[1] {
+			return fmt.Errorf("invalid %s range %v", name, r)
+		}
+	}
+	split := false
+	for i, step := range sc.Steps {
+		actions := 0
+		for _, set := range []bool{step.Layers > 0, len(step.Split) > 0, step.Heal, step.Expect != nil} {
+			if set {
+				actions++
+			}
+		}
+		if actions != 1 {
+			return fmt.Errorf("step %d: expected exactly one of layers, split, heal or expect", i)
+		}
+		switch {
+		case step.Layers > 0:
+			switch step.Hare {
+			case "", HareBlock, HareEmpty, HareNone:
+			default:
+				return fmt.Errorf("step %d: unknown hare outcome %q", i, step.Hare)
+			}
+			if step.Blocks != nil && *step.Blocks < 0 {
+				return fmt.Errorf("step %d: negative number of blocks", i)
+			}
+			if step.Blocks != nil && *step.Blocks == 0 && (step.Hare == "" || step.Hare == HareBlock) {
+				return fmt.Errorf("step %d: hare output requires at least one block", i)
+			}
+		case len(step.Split) > 0:
+			if split {
+				return fmt.Errorf("step %d: network is already split", i)
+			}
+			if _, err := step.partitions(); err != nil {
+				return fmt.Errorf("step %d: %w", i, err)
+			}
+			split = true
+		case step.Heal:
+			if !split {
+				return fmt.Errorf("step %d: network is not split", i)
+			}
+			split = false
+		case step.Expect != nil:
+			if step.Expect.Verified != nil && step.Expect.Lag != nil {
+				return fmt.Errorf("step %d: verified and lag are mutually exclusive", i)
+			}
+		}
+	}
+	return nil
+}
+
+// instances is a number of tortoise instances required by the scenario.
+//
+// Every partition takes a state from the generator by index after the previous partition took its state,
+// so the generator needs twice as many states as there are partitions.
+func (sc *Scenario) instances() int {
+	n := 1
+	for _, step := range sc.Steps {
+		n = max(n, 2*len(step.Split))
+	}
+	return n
+}
+
+func (s *Step) partitions() ([]sim.Fraction, error) {
+	var (
+		rst   []sim.Fraction
+		total float64
+	)
+	for _, part := range s.Split {
+		nominator, denominator, ok := strings.Cut(part, "/")
+		if !ok {
+			return nil, fmt.Errorf("partition %q is not a fraction", part)
+		}
+		n, err := strconv.Atoi(nominator)
+		if err != nil {
+			return nil, fmt.Errorf("parse partition %q: %w", part, err)
+		}
+		d, err := strconv.Atoi(denominator)
+		if err != nil {
+			return nil, fmt.Errorf("parse partition %q: %w", part, err)
+		}
+		if n <= 0 || d <= 0 || n >= d {
+			return nil, fmt.Errorf("partition %q must be in (0, 1)", part)
+		}
+		total += float64(n) / float64(d)
+		rst = append(rst, sim.Frac(n, d))
+	}
+	if total >= 1 {
+		return nil, fmt.Errorf("partitions %v leave no miners in the original partition", s.Split)
+	}
+	return rst, nil
+}
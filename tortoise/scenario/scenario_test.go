@@ -0,0 +1,67 @@
+package scenario
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/spacemeshos/go-spacemesh/log/logtest"
+)
+
+func TestScenarios(t *testing.T) {
+	entries, err := os.ReadDir("testdata")
+	require.NoError(t, err)
+	for _, entry := range entries {
+		t.Run(entry.Name(), func(t *testing.T) {
+			sc, err := Load(filepath.Join("testdata", entry.Name()))
+			require.NoError(t, err)
+			report, err := Run(context.Background(), logtest.New(t), sc)
+			require.NoError(t, err)
+			require.Positive(t, report.Checks)
+			require.False(t, report.Failed(), "%v", report.Divergences)
+		})
+	}
+}
+
+func TestDivergence(t *testing.T) {
+	sc, err := Parse(strings.NewReader(`{
+		"steps": [
+			{"layers": 6},
+			{"expect": {"verified": 2, "valid": [{"layer": 1, "blocks": 2}]}}
+		]
+	}`))
+	require.NoError(t, err)
+	report, err := Run(context.Background(), logtest.New(t), sc)
+	require.NoError(t, err)
+	require.True(t, report.Failed())
+	require.Len(t, report.Divergences, 2)
+	require.Equal(t, 1, report.Divergences[0].Step)
+	require.Contains(t, report.Divergences[0].String(), "expected verified layer")
+	require.Contains(t, report.Divergences[1].String(), "expected 2 valid blocks")
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		desc, scenario, err string
+	}{
+		{"unknown field", `{"steps": [{"layers": 1, "unknown": 1}]}`, "unknown field"},
+		{"no steps", `{}`, "scenario without steps"},
+		{"multiple actions", `{"steps": [{"layers": 1, "heal": true}]}`, "exactly one of"},
+		{"unknown hare", `{"steps": [{"layers": 1, "hare": "other"}]}`, "unknown hare outcome"},
+		{"heal without split", `{"steps": [{"heal": true}]}`, "network is not split"},
+		{"split twice", `{"steps": [{"split": ["1/2"]}, {"split": ["1/2"]}]}`, "already split"},
+		{"invalid fraction", `{"steps": [{"split": ["3/2"]}]}`, "must be in (0, 1)"},
+		{"no miners left", `{"steps": [{"split": ["1/2", "1/2"]}]}`, "leave no miners"},
+		{"model with steps", `{"model": {"smeshers": 1, "layers": 1}, "steps": [{"layers": 1}]}`, "mutually exclusive"},
+		{"unknown message", `{"model": {"smeshers": 1, "layers": 1, "failable": ["other"]}}`, "unknown failable"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tc.scenario))
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -1,13 +1,11 @@
 package tortoise
 
 import (
-	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -77,30 +75,18 @@ type traceRunner struct {
 }
 
 func RunTrace(path string, breakpoint func(), opts ...Opt) error {
-	f, err := os.Open(path)
+	d, err := NewDebugger(path, opts...)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-	dec := json.NewDecoder(bufio.NewReaderSize(f, 1<<20))
-	enum := newEventEnum()
-	runner := &traceRunner{
-		opts:          opts,
-		pending:       map[types.BallotID]*DecodedBallot{},
-		assertOutputs: true,
-		assertErrors:  true,
-	}
+	defer d.Close()
 	for {
-		ev, err := enum.Decode(dec)
-		if err != nil {
+		if _, err := d.Next(); err != nil {
 			if errors.Is(err, io.EOF) {
 				return nil
 			}
 			return err
 		}
-		if err := ev.Run(runner); err != nil {
-			return err
-		}
 		if breakpoint != nil {
 			breakpoint()
 		}
@@ -125,6 +111,8 @@ const (
 	traceApplied
 	traceMalfeasance
 	traceRecoveredBlocks
+	traceRecoverFrom
+	traceRecoveredBallot
 )
 
 type traceEvent interface {
@@ -133,7 +121,16 @@ type traceEvent interface {
 	Run(*traceRunner) error
 }
 
+// traceVersion is the version of the trace format written by the tracer.
+//
+// Versions:
+//   - 0: recovered ballots are traced as ballots.
+//   - 1: recovered ballots are traced with a separate event, tortoise starting from a recovered layer is traced.
+const traceVersion = 1
+
 type ConfigTrace struct {
+	// Version is the version of the trace format, it is zero in traces written before versioning.
+	Version                  uint32 `json:"version,omitempty"`
 	Hdist                    uint32 `json:"hdist"`
 	Zdist                    uint32 `json:"zdist"`
 	WindowSize               uint32 `json:"window"`
@@ -153,6 +150,9 @@ func (c *ConfigTrace) New() traceEvent {
 }
 
 func (c *ConfigTrace) Run(r *traceRunner) error {
+	if c.Version > traceVersion {
+		return fmt.Errorf("trace version %d is not supported, latest supported version %d", c.Version, traceVersion)
+	}
 	types.SetLayersPerEpoch(c.EpochSize)
 	types.SetEffectiveGenesis(c.EffectiveGenesis)
 	r.atxdata = atxsdata.New()
@@ -246,6 +246,23 @@ func (b *BallotTrace) Run(r *traceRunner) error {
 	return nil
 }
 
+type RecoveredBallotTrace struct {
+	Ballot *types.BallotTortoiseData `json:",inline"`
+}
+
+func (b *RecoveredBallotTrace) Type() eventType {
+	return traceRecoveredBallot
+}
+
+func (b *RecoveredBallotTrace) New() traceEvent {
+	return &RecoveredBallotTrace{}
+}
+
+func (b *RecoveredBallotTrace) Run(r *traceRunner) error {
+	r.trt.OnRecoveredBallot(b.Ballot)
+	return nil
+}
+
 type DecodeBallotTrace struct {
 	Ballot *types.BallotTortoiseData `json:",inline"`
 	Error  string                    `json:"e"`
@@ -489,6 +506,25 @@ func (r *RecoveredBlocksTrace) Run(tr *traceRunner) error {
 	return nil
 }
 
+type RecoverFromTrace struct {
+	Layer    types.LayerID `json:"layer"`
+	Opinion  types.Hash32  `json:"opinion"`
+	Previous types.Hash32  `json:"prev"`
+}
+
+func (r *RecoverFromTrace) Type() eventType {
+	return traceRecoverFrom
+}
+
+func (r *RecoverFromTrace) New() traceEvent {
+	return &RecoverFromTrace{}
+}
+
+func (r *RecoverFromTrace) Run(tr *traceRunner) error {
+	tr.trt.RecoverFrom(r.Layer, r.Opinion, r.Previous)
+	return nil
+}
+
 func assertErrors(err error, expect string) error {
 	msg := ""
 	if err != nil {
@@ -517,6 +553,8 @@ func newEventEnum() eventEnum {
 	enum.Register(&AppliedTrace{})
 	enum.Register(&MalfeasanceTrace{})
 	enum.Register(&RecoveredBlocksTrace{})
+	enum.Register(&RecoverFromTrace{})
+	enum.Register(&RecoveredBallotTrace{})
 	return enum
 }
 
@@ -537,8 +575,17 @@ func (e *eventEnum) Decode(dec *json.Decoder) (traceEvent, error) {
 	if ev == nil {
 		return nil, fmt.Errorf("type %d is not registered", event.Type)
 	}
+	data := []byte(event.Event)
+	// zap encodes the event as a json string or as an object depending on its version
+	if len(data) > 0 && data[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return nil, err
+		}
+		data = []byte(encoded)
+	}
 	obj := ev.New()
-	if err := json.Unmarshal(event.Event, obj); err != nil {
+	if err := json.Unmarshal(data, obj); err != nil {
 		return nil, err
 	}
 	return obj, nil
@@ -2,6 +2,7 @@ package tortoise
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"path/filepath"
@@ -78,15 +79,58 @@ func TestData(t *testing.T) {
 	}
 	require.NoError(t, err)
 	for _, entry := range entries {
-		if strings.HasSuffix(entry.Name(), ".md") {
+		if strings.HasSuffix(entry.Name(), ".md") || strings.HasSuffix(entry.Name(), ".expected.json") {
 			continue
 		}
 		t.Run(entry.Name(), func(t *testing.T) {
 			t.Parallel()
-			require.NoError(
-				t,
-				RunTrace(filepath.Join(data, entry.Name()), nil, WithLogger(logtest.New(t))),
-			)
+			path := filepath.Join(data, entry.Name())
+			expected, err := ReadExpectation(ExpectationPath(path))
+			if errors.Is(err, os.ErrNotExist) {
+				require.NoError(t, RunTrace(path, nil, WithLogger(logtest.New(t))))
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, CheckTrace(path, expected, WithLogger(logtest.New(t))))
+		})
+	}
+}
+
+func TestTraceVersion(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		desc    string
+		version uint32
+		err     string
+	}{
+		{desc: "unversioned", version: 0},
+		{desc: "current", version: traceVersion},
+		{desc: "newer", version: traceVersion + 1, err: "is not supported"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			t.Parallel()
+			cfg := defaultTestConfig()
+			event, err := json.Marshal(&ConfigTrace{
+				Version:          tc.version,
+				Hdist:            cfg.Hdist,
+				Zdist:            cfg.Zdist,
+				WindowSize:       cfg.WindowSize,
+				LayerSize:        cfg.LayerSize,
+				EpochSize:        types.GetLayersPerEpoch(),
+				EffectiveGenesis: types.GetEffectiveGenesis().Uint32(),
+			})
+			require.NoError(t, err)
+			line, err := json.Marshal(map[string]any{"t": traceStart, "o": string(event)})
+			require.NoError(t, err)
+			path := filepath.Join(t.TempDir(), "trace.json")
+			require.NoError(t, os.WriteFile(path, line, 0o600))
+
+			err = RunTrace(path, nil, WithLogger(logtest.New(t)))
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
 		})
 	}
 }
@@ -2,9 +2,11 @@ package txs
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
+	"sync/atomic"
 	"time"
 
 	"github.com/spacemeshos/go-spacemesh/common/types"
@@ -12,6 +14,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/log"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql/mingas"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
 	"github.com/spacemeshos/go-spacemesh/system"
 )
@@ -20,6 +23,9 @@ import (
 type CSConfig struct {
 	BlockGasLimit     uint64
 	NumTXsPerProposal int
+	// MinGasPrice is the default minimal gas price of transactions selected for proposals.
+	// It is overwritten by the value persisted in the local database.
+	MinGasPrice uint64
 }
 
 func defaultCSConfig() CSConfig {
@@ -46,15 +52,25 @@ func WithLogger(logger log.Log) ConservativeStateOpt {
 	}
 }
 
+// WithLocalDB defines the local database used to persist the minimal gas price.
+func WithLocalDB(db sql.Executor) ConservativeStateOpt {
+	return func(cs *ConservativeState) {
+		cs.localDB = db
+	}
+}
+
 // ConservativeState provides the conservative version of the VM state by taking into accounts of
 // nonce and balances for pending transactions in un-applied blocks and mempool.
 type ConservativeState struct {
 	vmState
 
-	logger log.Log
-	cfg    CSConfig
-	db     *sql.Database
-	cache  *Cache
+	logger  log.Log
+	cfg     CSConfig
+	db      *sql.Database
+	localDB sql.Executor
+	cache   *Cache
+
+	minGas atomic.Uint64
 }
 
 // NewConservativeState returns a ConservativeState.
@@ -69,9 +85,41 @@ func NewConservativeState(state vmState, db *sql.Database, opts ...ConservativeS
 		opt(cs)
 	}
 	cs.cache = NewCache(cs.getState, cs.logger)
+	cs.minGas.Store(cs.cfg.MinGasPrice)
+	if cs.localDB != nil {
+		price, err := mingas.Get(cs.localDB)
+		switch {
+		case err == nil:
+			cs.minGas.Store(price)
+		case !errors.Is(err, sql.ErrNotFound):
+			cs.logger.With().Error("failed to load min gas, using default",
+				log.Uint64("min_gas", cs.cfg.MinGasPrice),
+				log.Err(err),
+			)
+		}
+	}
+	minGasPrice.Set(float64(cs.minGas.Load()))
 	return cs
 }
 
+// MinGas returns the minimal gas price of transactions accepted from gossip and selected for proposals.
+func (cs *ConservativeState) MinGas() uint64 {
+	return cs.minGas.Load()
+}
+
+// SetMinGas updates the minimal gas price and persists it in the local database.
+func (cs *ConservativeState) SetMinGas(price uint64) error {
+	if cs.localDB != nil {
+		if err := mingas.Set(cs.localDB, price); err != nil {
+			return err
+		}
+	}
+	cs.minGas.Store(price)
+	minGasPrice.Set(float64(price))
+	cs.logger.With().Info("min gas updated", log.Uint64("min_gas", price))
+	return nil
+}
+
 func (cs *ConservativeState) getState(addr types.Address) (uint64, uint64) {
 	nonce, err := cs.vmState.GetNonce(addr)
 	if err != nil {
@@ -87,7 +135,7 @@ func (cs *ConservativeState) getState(addr types.Address) (uint64, uint64) {
 // SelectProposalTXs picks a specific number of random txs for miner to pack in a proposal.
 func (cs *ConservativeState) SelectProposalTXs(lid types.LayerID, numEligibility int) []types.TransactionID {
 	logger := cs.logger.WithFields(lid)
-	mi := newMempoolIterator(logger, cs.cache, cs.cfg.BlockGasLimit)
+	mi := newMempoolIterator(logger, cs.cache, cs.cfg.BlockGasLimit, cs.MinGas())
 	predictedBlock, byAddrAndNonce := mi.PopAll()
 	numTXs := numEligibility * cs.cfg.NumTXsPerProposal
 	return getProposalTXs(logger.WithFields(lid), numTXs, predictedBlock, byAddrAndNonce)
@@ -25,6 +25,7 @@ import (
 	"github.com/spacemeshos/go-spacemesh/signing"
 	"github.com/spacemeshos/go-spacemesh/sql"
 	"github.com/spacemeshos/go-spacemesh/sql/layers"
+	"github.com/spacemeshos/go-spacemesh/sql/localsql"
 	"github.com/spacemeshos/go-spacemesh/sql/transactions"
 	smocks "github.com/spacemeshos/go-spacemesh/system/mocks"
 )
@@ -248,6 +249,50 @@ func TestSelectProposalTXs_ExhaustMemPool(t *testing.T) {
 	require.Equal(t, got, got2)
 }
 
+func TestSelectProposalTXs_MinGas(t *testing.T) {
+	tcs := createConservativeState(t)
+	lid := types.LayerID(97)
+	expected := make([]types.TransactionID, 0, numTXsInProposal)
+	for i := 0; i < numTXsInProposal; i++ {
+		signer, err := signing.NewEdSigner()
+		require.NoError(t, err)
+		addr := types.GenerateAddress(signer.PublicKey().Bytes())
+		tcs.mvm.EXPECT().GetBalance(addr).Return(defaultBalance, nil).Times(1)
+		tcs.mvm.EXPECT().GetNonce(addr).Return(uint64(0), nil).Times(1)
+		fee := defaultFee
+		if i%2 == 0 {
+			fee = defaultFee + 1
+		}
+		tx := newTx(t, 0, defaultAmount, fee, signer)
+		require.NoError(t, tcs.AddToCache(context.Background(), tx, time.Now()))
+		if fee > defaultFee {
+			expected = append(expected, tx.ID)
+		}
+	}
+	require.Len(t, tcs.SelectProposalTXs(lid, 1), numTXsInProposal)
+
+	require.NoError(t, tcs.SetMinGas(defaultFee+1))
+	require.ElementsMatch(t, expected, tcs.SelectProposalTXs(lid, 1))
+}
+
+func TestMinGas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mvm := NewMockvmState(ctrl)
+	db := sql.InMemory()
+	localDB := localsql.InMemory()
+	cfg := defaultCSConfig()
+	cfg.MinGasPrice = 2
+
+	cs := NewConservativeState(mvm, db, WithCSConfig(cfg), WithLocalDB(localDB))
+	require.EqualValues(t, 2, cs.MinGas())
+	require.NoError(t, cs.SetMinGas(5))
+	require.EqualValues(t, 5, cs.MinGas())
+
+	// persisted value takes precedence over the config after restart
+	cs = NewConservativeState(mvm, db, WithCSConfig(cfg), WithLocalDB(localDB))
+	require.EqualValues(t, 5, cs.MinGas())
+}
+
 func TestSelectProposalTXs_SamePrincipal(t *testing.T) {
 	tcs := createConservativeState(t)
 	signer, err := signing.NewEdSigner()
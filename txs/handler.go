@@ -21,6 +21,7 @@ var (
 	errDuplicateTX = errors.New("tx already exists")
 	errParse       = errors.New("failed to parse tx")
 	errVerify      = errors.New("failed to verify tx")
+	errMinGas      = errors.New("gas price below min gas")
 )
 
 // TxHandler handles the transactions received via gossip or sync.
@@ -51,6 +52,8 @@ func updateMetrics(err error, counter *prometheus.CounterVec) {
 		counter.WithLabelValues(cantParse).Inc()
 	case errors.Is(err, errVerify):
 		counter.WithLabelValues(cantVerify).Inc()
+	case errors.Is(err, errMinGas):
+		counter.WithLabelValues(belowMinGas).Inc()
 	default:
 		counter.WithLabelValues(rejectedInternalErr).Inc()
 	}
@@ -62,10 +65,12 @@ func (th *TxHandler) HandleGossipTransaction(ctx context.Context, peer p2p.Peer,
 		return nil
 	}
 
-	err := th.VerifyAndCacheTx(ctx, msg)
+	// transactions below min gas are ignored and not propagated further,
+	// but peers are not penalized as min gas is a local policy
+	err := th.verifyAndCache(ctx, types.Hash32{}, th.state.MinGas(), msg)
 	updateMetrics(err, gossipTxCount)
 	if err != nil {
-		if !errors.Is(err, errDuplicateTX) {
+		if !errors.Is(err, errDuplicateTX) && !errors.Is(err, errMinGas) {
 			th.logger.WithContext(ctx).With().Warning("failed to handle tx", log.Err(err))
 		}
 		return err
@@ -80,7 +85,7 @@ func (th *TxHandler) HandleProposalTransaction(
 	_ p2p.Peer,
 	msg []byte,
 ) error {
-	err := th.verifyAndCache(ctx, expHash, msg)
+	err := th.verifyAndCache(ctx, expHash, 0, msg)
 	updateMetrics(err, proposalTxCount)
 	if errors.Is(err, errDuplicateTX) {
 		return nil
@@ -89,10 +94,10 @@ func (th *TxHandler) HandleProposalTransaction(
 }
 
 func (th *TxHandler) VerifyAndCacheTx(ctx context.Context, msg []byte) error {
-	return th.verifyAndCache(ctx, types.Hash32{}, msg)
+	return th.verifyAndCache(ctx, types.Hash32{}, 0, msg)
 }
 
-func (th *TxHandler) verifyAndCache(ctx context.Context, expHash types.Hash32, msg []byte) error {
+func (th *TxHandler) verifyAndCache(ctx context.Context, expHash types.Hash32, minGas uint64, msg []byte) error {
 	raw := types.NewRawTx(msg)
 	mtx, err := th.state.GetMeshTransaction(raw.ID)
 	if err != nil && !errors.Is(err, sql.ErrNotFound) {
@@ -117,6 +122,9 @@ func (th *TxHandler) verifyAndCache(ctx context.Context, expHash types.Hash32, m
 	if header.GasPrice == 0 || header.Fee() == 0 {
 		return fmt.Errorf("%w: zero gas price %s", errParse, raw.ID)
 	}
+	if header.GasPrice < minGas {
+		return fmt.Errorf("%w: %s gas price %d, min gas %d", errMinGas, raw.ID, header.GasPrice, minGas)
+	}
 	if !req.Verify() {
 		return fmt.Errorf("%w: %s", errVerify, raw.ID)
 	}
@@ -121,7 +121,7 @@ func Test_HandleBlock(t *testing.T) {
 
 func gossipExpectations(
 	t *testing.T,
-	fee uint64,
+	fee, minGas uint64,
 	hasErr, parseErr, addErr error,
 	has, verify, noHeader bool,
 ) (*TxHandler, *types.Transaction) {
@@ -132,6 +132,7 @@ func gossipExpectations(
 	id, err := peer.IDFromPublicKey(pub)
 	require.NoError(t, err)
 	th := NewTxHandler(cstate, id, logtest.New(t))
+	cstate.EXPECT().MinGas().Return(minGas).AnyTimes()
 
 	signer, err := signing.NewEdSigner()
 	require.NoError(t, err)
@@ -148,7 +149,7 @@ func gossipExpectations(
 		req := smocks.NewMockValidationRequest(ctrl)
 		req.EXPECT().Parse().Times(1).Return(tx.TxHeader, parseErr)
 		cstate.EXPECT().Validation(tx.RawTx).Times(1).Return(req)
-		if parseErr == nil && fee != 0 {
+		if parseErr == nil && fee != 0 && fee >= minGas {
 			req.EXPECT().Verify().Times(1).Return(verify)
 			if verify {
 				cstate.EXPECT().AddToCache(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
@@ -174,7 +175,7 @@ func isErr(err error) bool {
 func Test_HandleGossip(t *testing.T) {
 	for _, tc := range []struct {
 		desc                     string
-		fee                      uint64
+		fee, minGas              uint64
 		has                      bool
 		noHeader                 bool
 		hasErr, addErr, parseErr error
@@ -229,9 +230,22 @@ func Test_HandleGossip(t *testing.T) {
 			fee:    0,
 			expect: isErr,
 		},
+		{
+			desc:   "MinGas",
+			fee:    2,
+			minGas: 2,
+			verify: true,
+			expect: nilErr,
+		},
+		{
+			desc:   "BelowMinGas",
+			fee:    1,
+			minGas: 2,
+			expect: func(err error) bool { return errors.Is(err, errMinGas) },
+		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			th, tx := gossipExpectations(t, tc.fee,
+			th, tx := gossipExpectations(t, tc.fee, tc.minGas,
 				tc.hasErr, tc.parseErr, tc.addErr,
 				tc.has, tc.verify, tc.noHeader,
 			)
@@ -316,7 +330,7 @@ func Test_HandleProposal(t *testing.T) {
 		},
 	} {
 		t.Run(tc.desc, func(t *testing.T) {
-			th, tx := gossipExpectations(t, tc.fee,
+			th, tx := gossipExpectations(t, tc.fee, 0,
 				tc.hasErr, tc.parseErr, tc.addErr,
 				tc.has, tc.verify, tc.noHeader,
 			)
@@ -17,6 +17,7 @@ type conservativeState interface {
 	AddToCache(context.Context, *types.Transaction, time.Time) error
 	AddToDB(*types.Transaction) error
 	GetMeshTransaction(types.TransactionID) (*types.MeshTransaction, error)
+	MinGas() uint64
 }
 
 type vmState interface {
@@ -79,8 +79,10 @@ type mempoolIterator struct {
 }
 
 // newMempoolIterator builds and returns a mempoolIterator.
-func newMempoolIterator(logger log.Log, cs conStateCache, gasLimit uint64) *mempoolIterator {
+// Transactions with gas price below minGas are not returned by the iterator.
+func newMempoolIterator(logger log.Log, cs conStateCache, gasLimit, minGas uint64) *mempoolIterator {
 	txs := cs.GetMempool(logger)
+	filterMinGas(logger, txs, minGas)
 	mi := &mempoolIterator{
 		logger:       logger,
 		gasRemaining: gasLimit,
@@ -92,6 +94,34 @@ func newMempoolIterator(logger log.Log, cs conStateCache, gasLimit uint64) *memp
 	return mi
 }
 
+// filterMinGas drops transactions with gas price below minGas. As transactions of the same principal
+// have to be applied in nonce order, all transactions after the dropped one are dropped as well.
+func filterMinGas(logger log.Log, txs map[types.Address][]*NanoTX, minGas uint64) {
+	if minGas == 0 {
+		return
+	}
+	for addr, ntxs := range txs {
+		for i, ntx := range ntxs {
+			if ntx.GasPrice >= minGas {
+				continue
+			}
+			logger.With().Debug("tx gas price below min gas, removing remaining addr txs from mempool",
+				ntx.ID,
+				ntx.Principal,
+				log.Uint64("gas_price", ntx.GasPrice),
+				log.Uint64("min_gas", minGas),
+				log.Int("removed", len(ntxs)-i),
+			)
+			if i == 0 {
+				delete(txs, addr)
+			} else {
+				txs[addr] = ntxs[:i]
+			}
+			break
+		}
+	}
+}
+
 func (mi *mempoolIterator) buildPQ() {
 	i := 0
 	for addr, ntxs := range mi.txs {
@@ -84,7 +84,7 @@ func TestPopAll(t *testing.T) {
 	mockCache := NewMockconStateCache(ctrl)
 	mockCache.EXPECT().GetMempool(gomock.Any()).Return(mempool)
 	gasLimit := uint64(3)
-	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit)
+	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit, 0)
 	testPopAll(t, mi, expected[:gasLimit])
 	require.NotEmpty(t, mempool)
 }
@@ -98,7 +98,7 @@ func TestPopAll_SkipSomeGasTooHigh(t *testing.T) {
 	// make the 2nd one too expensive to pick, therefore invalidated all txs from addr0
 	orderedByFee[1].MaxGas = 10
 	expected := []*NanoTX{orderedByFee[0], orderedByFee[4], orderedByFee[5]}
-	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit)
+	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit, 0)
 	testPopAll(t, mi, expected)
 	require.NotEmpty(t, mempool)
 }
@@ -109,7 +109,19 @@ func TestPopAll_ExhaustMempool(t *testing.T) {
 	mockCache := NewMockconStateCache(ctrl)
 	mockCache.EXPECT().GetMempool(gomock.Any()).Return(mempool)
 	gasLimit := uint64(100)
-	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit)
+	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit, 0)
 	testPopAll(t, mi, expected)
 	require.Empty(t, mempool)
 }
+
+func TestPopAll_MinGas(t *testing.T) {
+	mempool, orderedByFee := makeMempool()
+	ctrl := gomock.NewController(t)
+	mockCache := NewMockconStateCache(ctrl)
+	mockCache.EXPECT().GetMempool(gomock.Any()).Return(mempool)
+	gasLimit := uint64(100)
+	// acct_1 is skipped entirely, acct_2 is skipped starting from (2, 1) as later nonces can't be applied
+	expected := []*NanoTX{orderedByFee[0], orderedByFee[1], orderedByFee[2], orderedByFee[3], orderedByFee[4]}
+	mi := newMempoolIterator(logtest.New(t), mockCache, gasLimit, 3)
+	testPopAll(t, mi, expected)
+}
@@ -16,6 +16,7 @@ const (
 	cantParse           = "parse"
 	cantVerify          = "verify"
 	rejectedBadNonce    = "badNonce"
+	belowMinGas         = "minGas"
 	rejectedInternalErr = "err"
 	RawFromDB           = "raw"
 	updated             = "updated"
@@ -60,6 +61,13 @@ var (
 	)
 )
 
+var minGasPrice = metrics.NewGauge(
+	"min_gas_price",
+	namespace,
+	"minimal gas price of transactions accepted from gossip and selected for proposals",
+	[]string{},
+).WithLabelValues()
+
 var (
 	cacheApplyDuration = metrics.NewHistogramWithBuckets(
 		"cache_apply_duration",
@@ -197,6 +197,44 @@ func (c *MockconservativeStateHasTxCall) DoAndReturn(f func(types.TransactionID)
 	return c
 }
 
+// MinGas mocks base method.
+func (m *MockconservativeState) MinGas() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MinGas")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// MinGas indicates an expected call of MinGas.
+func (mr *MockconservativeStateMockRecorder) MinGas() *MockconservativeStateMinGasCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MinGas", reflect.TypeOf((*MockconservativeState)(nil).MinGas))
+	return &MockconservativeStateMinGasCall{Call: call}
+}
+
+// MockconservativeStateMinGasCall wrap *gomock.Call
+type MockconservativeStateMinGasCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockconservativeStateMinGasCall) Return(arg0 uint64) *MockconservativeStateMinGasCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockconservativeStateMinGasCall) Do(f func() uint64) *MockconservativeStateMinGasCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockconservativeStateMinGasCall) DoAndReturn(f func() uint64) *MockconservativeStateMinGasCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Validation mocks base method.
 func (m *MockconservativeState) Validation(arg0 types.RawTx) system.ValidationRequest {
 	m.ctrl.T.Helper()